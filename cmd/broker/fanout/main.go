@@ -30,6 +30,7 @@ import (
 	"github.com/google/knative-gcp/pkg/utils/appcredentials"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"github.com/google/knative-gcp/pkg/utils/mainhelper"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
 
 	"go.uber.org/zap"
 )
@@ -54,6 +55,10 @@ type envConfig struct {
 
 	// Max to 10m.
 	TimeoutPerEvent time.Duration `envconfig:"TIMEOUT_PER_EVENT"`
+
+	// ReplyTokenPath is the path of the token sent with replies to the ingress, if the ingress
+	// authenticates its callers, e.g. a projected service account token.
+	ReplyTokenPath string `envconfig:"REPLY_TOKEN_PATH"`
 }
 
 func main() {
@@ -139,6 +144,9 @@ func buildHandlerOptions(env envConfig) []handler.Option {
 	if env.TimeoutPerEvent > 0 {
 		opts = append(opts, handler.WithTimeoutPerEvent(env.TimeoutPerEvent))
 	}
+	if env.ReplyTokenPath != "" {
+		opts = append(opts, handler.WithReplyTokenSource(oidc.NewFileTokenSource(env.ReplyTokenPath)))
+	}
 	opts = append(opts, handler.WithPubsubReceiveSettings(rs))
 	// The default CeClient is good?
 	return opts
@@ -17,12 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"github.com/google/knative-gcp/pkg/broker/ingress"
 	metadataClient "github.com/google/knative-gcp/pkg/gclient/metadata"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"github.com/google/knative-gcp/pkg/utils"
 	"github.com/google/knative-gcp/pkg/utils/appcredentials"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"github.com/google/knative-gcp/pkg/utils/mainhelper"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
 
 	"go.uber.org/zap"
 )
@@ -31,6 +33,12 @@ type envConfig struct {
 	PodName   string `envconfig:"POD_NAME" required:"true"`
 	Port      int    `envconfig:"PORT" default:"8080"`
 	ProjectID string `envconfig:"PROJECT_ID"`
+
+	// AuthIssuers is the list of OIDC issuers trusted to authenticate callers, e.g.
+	// "https://accounts.google.com". If it is empty, requests are not authenticated.
+	AuthIssuers []string `envconfig:"AUTH_ISSUERS"`
+	// AuthAudience is the audience the tokens of callers must be issued for.
+	AuthAudience string `envconfig:"AUTH_AUDIENCE"`
 }
 
 const (
@@ -39,10 +47,13 @@ const (
 )
 
 // main creates and starts an ingress handler using default options.
-// 1. It listens on port specified by "PORT" env var, or default 8080 if env var is not set
-// 2. It reads "PROJECT_ID" env var for pubsub project. If the env var is empty, it retrieves project ID from
-//    GCE metadata.
-// 3. It expects broker configmap mounted at "/var/run/cloud-run-events/broker/targets"
+//  1. It listens on port specified by "PORT" env var, or default 8080 if env var is not set
+//  2. It reads "PROJECT_ID" env var for pubsub project. If the env var is empty, it retrieves project ID from
+//     GCE metadata.
+//  3. It expects broker configmap mounted at "/var/run/cloud-run-events/broker/targets"
+//  4. If "AUTH_ISSUERS" env var is set, it requires callers to present a bearer token issued by one of
+//     the issuers for the audience in "AUTH_AUDIENCE", and checks them against the allowed identities
+//     of the broker.
 func main() {
 	appcredentials.MustExistOrUnsetEnv()
 
@@ -57,19 +68,31 @@ func main() {
 	}
 	logger.Desugar().Info("Starting ingress handler", zap.Any("envConfig", env), zap.Any("Project ID", projectID))
 
-	ingress, err := InitializeHandler(
+	var verifier ingress.TokenVerifier
+	if len(env.AuthIssuers) > 0 {
+		client, err := oidc.InClusterClient()
+		if err != nil {
+			logger.Desugar().Fatal("Failed to create OIDC client", zap.Error(err))
+		}
+		if verifier, err = oidc.NewVerifier(env.AuthIssuers, env.AuthAudience, client); err != nil {
+			logger.Desugar().Fatal("Failed to create token verifier", zap.Error(err))
+		}
+	}
+
+	handler, err := InitializeHandler(
 		ctx,
 		clients.Port(env.Port),
 		clients.ProjectID(projectID),
 		metrics.PodName(env.PodName),
 		metrics.ContainerName(component),
+		verifier,
 	)
 	if err != nil {
 		logger.Desugar().Fatal("Unable to create ingress handler: ", zap.Error(err))
 	}
 
-	logger.Desugar().Info("Starting ingress.", zap.Any("ingress", ingress))
-	if err := ingress.Start(ctx); err != nil {
+	logger.Desugar().Info("Starting ingress.", zap.Any("ingress", handler))
+	if err := handler.Start(ctx); err != nil {
 		logger.Desugar().Fatal("failed to start ingress: ", zap.Error(err))
 	}
 }
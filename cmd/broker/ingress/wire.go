@@ -34,6 +34,7 @@ func InitializeHandler(
 	projectID clients.ProjectID,
 	podName metrics.PodName,
 	containerName metrics.ContainerName,
+	verifier ingress.TokenVerifier,
 ) (*ingress.Handler, error) {
 	panic(wire.Build(
 		ingress.HandlerSet,
@@ -15,7 +15,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitializeHandler(ctx context.Context, port clients.Port, projectID clients.ProjectID, podName metrics.PodName, containerName metrics.ContainerName) (*ingress.Handler, error) {
+func InitializeHandler(ctx context.Context, port clients.Port, projectID clients.ProjectID, podName metrics.PodName, containerName metrics.ContainerName, verifier ingress.TokenVerifier) (*ingress.Handler, error) {
 	httpMessageReceiver := clients.NewHTTPMessageReceiver(port)
 	v := _wireValue
 	readonlyTargets, err := volume.NewTargetsFromFile(v...)
@@ -27,11 +27,14 @@ func InitializeHandler(ctx context.Context, port clients.Port, projectID clients
 		return nil, err
 	}
 	multiTopicDecoupleSink := ingress.NewMultiTopicDecoupleSink(ctx, readonlyTargets, client)
+	authorizer := ingress.NewAuthorizer(readonlyTargets, verifier)
+	rateLimiter := ingress.NewRateLimiter(readonlyTargets)
+	schemaValidator := ingress.NewSchemaValidator(readonlyTargets)
 	ingressReporter, err := metrics.NewIngressReporter(podName, containerName)
 	if err != nil {
 		return nil, err
 	}
-	handler := ingress.NewHandler(ctx, httpMessageReceiver, multiTopicDecoupleSink, ingressReporter)
+	handler := ingress.NewHandler(ctx, httpMessageReceiver, multiTopicDecoupleSink, authorizer, rateLimiter, schemaValidator, ingressReporter)
 	return handler, nil
 }
 
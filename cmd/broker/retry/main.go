@@ -32,6 +32,7 @@ import (
 	"github.com/google/knative-gcp/pkg/utils/appcredentials"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"github.com/google/knative-gcp/pkg/utils/mainhelper"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
 )
 
 const (
@@ -60,6 +61,10 @@ type envConfig struct {
 	// Max to 10m.
 	TimeoutPerEvent time.Duration `envconfig:"TIMEOUT_PER_EVENT"`
 
+	// ReplyTokenPath is the path of the token sent with replies to the ingress, if the ingress
+	// authenticates its callers, e.g. a projected service account token.
+	ReplyTokenPath string `envconfig:"REPLY_TOKEN_PATH"`
+
 	MinRetryBackoff time.Duration `envconfig:"MIN_RETRY_BACKOFF" default:"1s"`
 	MaxRetryBackoff time.Duration `envconfig:"MAX_RETRY_BACKOFF" default:"1m"`
 }
@@ -152,6 +157,9 @@ func buildHandlerOptions(env envConfig) []handler.Option {
 		MinBackoff: env.MinRetryBackoff,
 		MaxBackoff: env.MaxRetryBackoff,
 	}))
+	if env.ReplyTokenPath != "" {
+		opts = append(opts, handler.WithReplyTokenSource(oidc.NewFileTokenSource(env.ReplyTokenPath)))
+	}
 	opts = append(opts, handler.WithPubsubReceiveSettings(rs))
 	// The default CeClient is good?
 	return opts
@@ -29,6 +29,8 @@ import (
 	"github.com/google/knative-gcp/pkg/reconciler/deployment"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/firestore"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -59,6 +61,8 @@ func Controllers(
 	schedulerController scheduler.Constructor,
 	pubsubController pubsub.Constructor,
 	buildController build.Constructor,
+	firestoreController firestore.Constructor,
+	monitoringController monitoring.Constructor,
 	pullsubscriptionController staticpullsubscription.Constructor,
 	kedaPullsubscriptionController kedapullsubscription.Constructor,
 	topicController topic.Constructor,
@@ -70,6 +74,8 @@ func Controllers(
 		injection.ControllerConstructor(schedulerController),
 		injection.ControllerConstructor(pubsubController),
 		injection.ControllerConstructor(buildController),
+		injection.ControllerConstructor(firestoreController),
+		injection.ControllerConstructor(monitoringController),
 		injection.ControllerConstructor(pullsubscriptionController),
 		injection.ControllerConstructor(kedaPullsubscriptionController),
 		injection.ControllerConstructor(topicController),
@@ -21,6 +21,8 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/configs/gcpauth"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/firestore"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -44,6 +46,8 @@ func InitializeControllers(ctx context.Context) ([]injection.ControllerConstruct
 		scheduler.NewConstructor,
 		pubsub.NewConstructor,
 		build.NewConstructor,
+		firestore.NewConstructor,
+		monitoring.NewConstructor,
 		static.NewConstructor,
 		keda.NewConstructor,
 		topic.NewConstructor,
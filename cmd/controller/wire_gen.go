@@ -11,6 +11,8 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/configs/gcpauth"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs"
 	"github.com/google/knative-gcp/pkg/reconciler/events/build"
+	"github.com/google/knative-gcp/pkg/reconciler/events/firestore"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring"
 	"github.com/google/knative-gcp/pkg/reconciler/events/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler"
 	"github.com/google/knative-gcp/pkg/reconciler/events/storage"
@@ -44,10 +46,12 @@ func InitializeControllers(ctx context.Context) ([]injection.ControllerConstruct
 	schedulerConstructor := scheduler.NewConstructor(iamPolicyManager, storeSingleton)
 	pubsubConstructor := pubsub.NewConstructor(iamPolicyManager, storeSingleton)
 	buildConstructor := build.NewConstructor(iamPolicyManager, storeSingleton)
+	firestoreConstructor := firestore.NewConstructor(iamPolicyManager, storeSingleton)
+	monitoringConstructor := monitoring.NewConstructor(iamPolicyManager, storeSingleton)
 	staticConstructor := static.NewConstructor(iamPolicyManager, storeSingleton)
 	kedaConstructor := keda.NewConstructor(iamPolicyManager, storeSingleton)
 	topicConstructor := topic.NewConstructor(iamPolicyManager, storeSingleton)
 	channelConstructor := channel.NewConstructor(iamPolicyManager, storeSingleton)
-	v2 := Controllers(constructor, storageConstructor, schedulerConstructor, pubsubConstructor, buildConstructor, staticConstructor, kedaConstructor, topicConstructor, channelConstructor)
+	v2 := Controllers(constructor, storageConstructor, schedulerConstructor, pubsubConstructor, buildConstructor, firestoreConstructor, monitoringConstructor, staticConstructor, kedaConstructor, topicConstructor, channelConstructor)
 	return v2, nil
 }
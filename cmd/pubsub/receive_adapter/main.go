@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/eventing/pkg/tracing"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
@@ -66,6 +67,10 @@ type envConfig struct {
 	// Used for CE conversion.
 	AdapterType string `envconfig:"ADAPTER_TYPE"`
 
+	// Environment variable containing the JSON serialized options of the
+	// adapter. Their format depends on the type of adapter.
+	AdapterOptions string `envconfig:"ADAPTER_OPTIONS"`
+
 	// Topic is the environment variable containing the PubSub Topic being
 	// subscribed to's name. In the form that is unique within the project.
 	// E.g. 'laconia', not 'projects/my-gcp-project/topics/laconia'.
@@ -95,6 +100,26 @@ type envConfig struct {
 	// copied here as a JSON string.
 	TracingConfigJson string `envconfig:"K_TRACING_CONFIG" required:"true"`
 
+	// Environment variable containing the number of times a failed event is retried.
+	// Only set if the PullSubscription has a delivery spec, otherwise the messages of
+	// the failed events are redelivered until they expire.
+	Retry *int `envconfig:"RETRY"`
+
+	// Environment variable containing the backoff policy between retries, either
+	// "linear" or "exponential".
+	BackoffPolicy string `envconfig:"BACKOFF_POLICY"`
+
+	// Environment variable containing the backoff before the first retry.
+	BackoffDelay time.Duration `envconfig:"BACKOFF_DELAY"`
+
+	// Environment variable containing the URI where to send the events once their
+	// retries are exhausted.
+	DeadLetterSink string `envconfig:"DEAD_LETTER_SINK_URI"`
+
+	// Environment variable containing the ID of the subscription of the dead letter
+	// topic of the PullSubscription.
+	DeadLetterSubscription string `envconfig:"DEAD_LETTER_SUBSCRIPTION_ID"`
+
 	// Environment variable containing the namespace.
 	Namespace string `envconfig:"NAMESPACE" required:"true"`
 
@@ -163,20 +188,41 @@ func main() {
 		logger.Error("Failed to convert base64 extensions to map: %v", zap.Error(err))
 	}
 
+	converterType := converters.ConverterType(env.AdapterType)
+	filter, err := converters.NewFilter(converterType, env.AdapterOptions)
+	if err != nil {
+		logger.Fatal("Failed to create the filter from the adapter options", zap.Error(err))
+	}
+	mapper, err := converters.NewMapper(converterType, env.AdapterOptions)
+	if err != nil {
+		logger.Fatal("Failed to create the mapper from the adapter options", zap.Error(err))
+	}
+
 	logger.Info("Initializing adapter", zap.String("projectID", projectID), zap.String("topicID", env.Topic), zap.String("subscriptionID", env.Subscription))
 
 	args := &AdapterArgs{
 		TopicID:        env.Topic,
-		ConverterType:  converters.ConverterType(env.AdapterType),
+		ConverterType:  converterType,
 		SinkURI:        env.Sink,
 		TransformerURI: env.Transformer,
 		Extensions:     extensions,
+		Mapper:         mapper,
+		Filter:         filter,
+	}
+	if env.Retry != nil {
+		args.Delivery = &DeliveryArgs{
+			Retry:             *env.Retry,
+			Linear:            env.BackoffPolicy == string(eventingduckv1beta1.BackoffPolicyLinear),
+			BackoffDelay:      env.BackoffDelay,
+			DeadLetterSinkURI: env.DeadLetterSink,
+		}
 	}
 
 	adapter, err := InitializeAdapter(ctx,
 		clients.MaxConnsPerHost(maxConnectionsPerHost),
 		clients.ProjectID(projectID),
 		SubscriptionID(env.Subscription),
+		DeadLetterSubscriptionID(env.DeadLetterSubscription),
 		Namespace(env.Namespace),
 		Name(env.Name),
 		ResourceGroup(env.ResourceGroup),
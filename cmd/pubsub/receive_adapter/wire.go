@@ -32,6 +32,7 @@ func InitializeAdapter(
 	maxConnsPerHost clients.MaxConnsPerHost,
 	projectID clients.ProjectID,
 	subscriptionID adapter.SubscriptionID,
+	deadLetterSubscriptionID adapter.DeadLetterSubscriptionID,
 	namespace adapter.Namespace,
 	name adapter.Name,
 	resourceGroup adapter.ResourceGroup,
@@ -14,18 +14,19 @@ import (
 
 // Injectors from wire.go:
 
-func InitializeAdapter(ctx context.Context, maxConnsPerHost clients.MaxConnsPerHost, projectID clients.ProjectID, subscriptionID adapter.SubscriptionID, namespace adapter.Namespace, name adapter.Name, resourceGroup adapter.ResourceGroup, args *adapter.AdapterArgs) (*adapter.Adapter, error) {
+func InitializeAdapter(ctx context.Context, maxConnsPerHost clients.MaxConnsPerHost, projectID clients.ProjectID, subscriptionID adapter.SubscriptionID, deadLetterSubscriptionID adapter.DeadLetterSubscriptionID, namespace adapter.Namespace, name adapter.Name, resourceGroup adapter.ResourceGroup, args *adapter.AdapterArgs) (*adapter.Adapter, error) {
 	client, err := clients.NewPubsubClient(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
 	subscription := adapter.NewPubSubSubscription(ctx, client, subscriptionID)
+	deadLetterSubscription := adapter.NewPubSubDeadLetterSubscription(ctx, client, deadLetterSubscriptionID)
 	httpClient := clients.NewHTTPClient(ctx, maxConnsPerHost)
 	converter := converters.NewPubSubConverter()
 	statsReporter, err := adapter.NewStatsReporter(name, namespace, resourceGroup)
 	if err != nil {
 		return nil, err
 	}
-	adapterAdapter := adapter.NewAdapter(ctx, projectID, namespace, name, resourceGroup, subscription, httpClient, converter, statsReporter, args)
+	adapterAdapter := adapter.NewAdapter(ctx, projectID, namespace, name, resourceGroup, subscription, deadLetterSubscription, httpClient, converter, statsReporter, args)
 	return adapterAdapter, nil
 }
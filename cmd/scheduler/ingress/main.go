@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"go.uber.org/zap"
+
+	cloudschedulersourceinformer "github.com/google/knative-gcp/pkg/client/injection/informers/events/v1beta1/cloudschedulersource"
+	"github.com/google/knative-gcp/pkg/scheduler/ingress"
+	"github.com/google/knative-gcp/pkg/utils/clients"
+	"github.com/google/knative-gcp/pkg/utils/mainhelper"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
+)
+
+type envConfig struct {
+	Port            int `envconfig:"PORT" default:"8080"`
+	MaxConnsPerHost int `envconfig:"MAX_CONNS_PER_HOST" default:"100"`
+
+	// AuthIssuers is the list of OIDC issuers trusted to authenticate the Cloud Scheduler jobs.
+	AuthIssuers []string `envconfig:"AUTH_ISSUERS" default:"https://accounts.google.com"`
+	// AuthAudience is the audience the tokens of the jobs must be issued for, which is the
+	// URL of the ingress the jobs call.
+	AuthAudience string `envconfig:"AUTH_AUDIENCE" required:"true"`
+	// AllowedIdentities is the list of identities allowed to call the ingress, e.g. the email
+	// of the Google service account of the OIDC tokens of the jobs.
+	AllowedIdentities []string `envconfig:"ALLOWED_IDENTITIES" required:"true"`
+}
+
+const (
+	component = "scheduler-ingress"
+)
+
+// main creates and starts the shared scheduler ingress.
+//  1. It listens on port specified by "PORT" env var, or default 8080 if env var is not set
+//  2. It requires callers to present a bearer token issued by one of the issuers in "AUTH_ISSUERS"
+//     for the audience in "AUTH_AUDIENCE", of one of the identities in "ALLOWED_IDENTITIES".
+//  3. It sends the events of the CloudSchedulerSources with HTTP delivery to their sinks.
+func main() {
+	var env envConfig
+	ctx, res := mainhelper.Init(component, mainhelper.WithEnv(&env))
+	defer res.Cleanup()
+	logger := res.Logger
+
+	client, err := oidc.InClusterClient()
+	if err != nil {
+		logger.Desugar().Fatal("Failed to create OIDC client", zap.Error(err))
+	}
+	verifier, err := oidc.NewVerifier(env.AuthIssuers, env.AuthAudience, client)
+	if err != nil {
+		logger.Desugar().Fatal("Failed to create token verifier", zap.Error(err))
+	}
+
+	handler := ingress.NewHandler(ctx,
+		cloudschedulersourceinformer.Get(ctx).Lister(),
+		verifier,
+		env.AllowedIdentities,
+		clients.NewHTTPClient(ctx, clients.MaxConnsPerHost(env.MaxConnsPerHost)),
+	)
+
+	logger.Desugar().Info("Starting scheduler ingress", zap.Any("envConfig", env))
+	if err := clients.NewHTTPMessageReceiver(clients.Port(env.Port)).StartListen(ctx, handler); err != nil {
+		logger.Desugar().Fatal("Failed to start scheduler ingress", zap.Error(err))
+	}
+}
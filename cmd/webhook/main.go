@@ -20,6 +20,7 @@ import (
 	"context"
 	"log"
 
+	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	"github.com/google/knative-gcp/pkg/apis/configs/gcpauth"
 	configvalidation "github.com/google/knative-gcp/pkg/apis/configs/validation"
 	"github.com/google/knative-gcp/pkg/apis/events"
@@ -31,6 +32,7 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/messaging"
 	messagingv1alpha1 "github.com/google/knative-gcp/pkg/apis/messaging/v1alpha1"
 	messagingv1beta1 "github.com/google/knative-gcp/pkg/apis/messaging/v1beta1"
+	brokerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/eventing/pkg/logconfig"
 	"knative.dev/pkg/configmap"
@@ -56,20 +58,30 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	messagingv1beta1.SchemeGroupVersion.WithKind("Channel"):  &messagingv1beta1.Channel{},
 
 	// For group events.cloud.google.com.
-	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudStorageSource"):   &eventsv1alpha1.CloudStorageSource{},
-	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudSchedulerSource"): &eventsv1alpha1.CloudSchedulerSource{},
-	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudPubSubSource"):    &eventsv1alpha1.CloudPubSubSource{},
-	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudAuditLogsSource"): &eventsv1alpha1.CloudAuditLogsSource{},
-	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudBuildSource"):     &eventsv1alpha1.CloudBuildSource{},
-	eventsv1beta1.SchemeGroupVersion.WithKind("CloudStorageSource"):    &eventsv1beta1.CloudStorageSource{},
-	eventsv1beta1.SchemeGroupVersion.WithKind("CloudSchedulerSource"):  &eventsv1beta1.CloudSchedulerSource{},
-	eventsv1beta1.SchemeGroupVersion.WithKind("CloudPubSubSource"):     &eventsv1beta1.CloudPubSubSource{},
-	eventsv1beta1.SchemeGroupVersion.WithKind("CloudAuditLogsSource"):  &eventsv1beta1.CloudAuditLogsSource{},
-	eventsv1beta1.SchemeGroupVersion.WithKind("CloudBuildSource"):      &eventsv1beta1.CloudBuildSource{},
+	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudStorageSource"):        &eventsv1alpha1.CloudStorageSource{},
+	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudSchedulerSource"):      &eventsv1alpha1.CloudSchedulerSource{},
+	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudPubSubSource"):         &eventsv1alpha1.CloudPubSubSource{},
+	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudAuditLogsSource"):      &eventsv1alpha1.CloudAuditLogsSource{},
+	eventsv1alpha1.SchemeGroupVersion.WithKind("CloudBuildSource"):          &eventsv1alpha1.CloudBuildSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudStorageSource"):         &eventsv1beta1.CloudStorageSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudSchedulerSource"):       &eventsv1beta1.CloudSchedulerSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudPubSubSource"):          &eventsv1beta1.CloudPubSubSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudAuditLogsSource"):       &eventsv1beta1.CloudAuditLogsSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudBuildSource"):           &eventsv1beta1.CloudBuildSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudFirestoreSource"):       &eventsv1beta1.CloudFirestoreSource{},
+	eventsv1beta1.SchemeGroupVersion.WithKind("CloudMonitoringAlertSource"): &eventsv1beta1.CloudMonitoringAlertSource{},
+
+	// For group eventing.knative.dev.
+	// The eventing webhook runs the usual Broker and Trigger validations, these only validate
+	// the annotations of the Google Cloud Broker and skip the Brokers and Triggers of other
+	// broker classes.
+	brokerv1beta1.SchemeGroupVersion.WithKind("Broker"):  &brokerv1beta1.Broker{},
+	brokerv1beta1.SchemeGroupVersion.WithKind("Trigger"): &brokerv1beta1.Trigger{},
 
 	// For group internal.events.cloud.google.com.
 	inteventsv1alpha1.SchemeGroupVersion.WithKind("PullSubscription"): &inteventsv1alpha1.PullSubscription{},
 	inteventsv1alpha1.SchemeGroupVersion.WithKind("Topic"):            &inteventsv1alpha1.Topic{},
+	inteventsv1alpha1.SchemeGroupVersion.WithKind("BrokerCell"):       &inteventsv1alpha1.BrokerCell{},
 	inteventsv1beta1.SchemeGroupVersion.WithKind("PullSubscription"):  &inteventsv1beta1.PullSubscription{},
 	inteventsv1beta1.SchemeGroupVersion.WithKind("Topic"):             &inteventsv1beta1.Topic{},
 }
@@ -116,9 +128,15 @@ func newValidationConstructor(gcpas *gcpauth.StoreSingleton) validationControlle
 }
 
 func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher, gcpas *gcpauth.Store) *controller.Impl {
+	// Triggers are only validated if their Broker is a Google Cloud Broker.
+	brokerLister := brokerinformer.Get(ctx).Lister()
+	getBroker := func(namespace, name string) (*brokerv1beta1.Broker, error) {
+		return brokerLister.Brokers(namespace).Get(name)
+	}
+
 	// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 	ctxFunc := func(ctx context.Context) context.Context {
-		return gcpas.ToContext(ctx)
+		return brokerv1beta1.WithBrokerGetter(gcpas.ToContext(ctx), getBroker)
 	}
 
 	return validation.NewAdmissionController(ctx,
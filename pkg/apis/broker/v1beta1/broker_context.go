@@ -0,0 +1,39 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+)
+
+// BrokerGetter gets the Broker with the given namespace and name.
+type BrokerGetter func(namespace, name string) (*Broker, error)
+
+type brokerGetterKey struct{}
+
+// WithBrokerGetter returns a context with the BrokerGetter used to find the broker class of
+// a Trigger's Broker.
+func WithBrokerGetter(ctx context.Context, get BrokerGetter) context.Context {
+	return context.WithValue(ctx, brokerGetterKey{}, get)
+}
+
+func getBrokerGetter(ctx context.Context) BrokerGetter {
+	if get, ok := ctx.Value(brokerGetterKey{}).(BrokerGetter); ok {
+		return get
+	}
+	return nil
+}
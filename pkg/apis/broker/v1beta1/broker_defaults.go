@@ -22,6 +22,16 @@ import (
 
 // SetDefaults sets the default field values for a Broker.
 func (b *Broker) SetDefaults(ctx context.Context) {
-	// The Google Cloud Broker doesn't have any custom defaults. The
-	// eventing webhook will add the usual defaults.
+	if !b.IsGoogleCloudBroker() {
+		return
+	}
+	// The eventing webhook will add the usual defaults. The Google Cloud
+	// Broker only assigns its BrokerCell, so that the Brokers of a BrokerCell
+	// can be selected by label.
+	labels := b.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[BrokerCellLabelKey] = b.BrokerCellName()
+	b.SetLabels(labels)
 }
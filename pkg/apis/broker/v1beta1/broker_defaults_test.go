@@ -19,9 +19,57 @@ package v1beta1
 import (
 	"context"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 )
 
 func TestBroker_SetDefaults(t *testing.T) {
-	b := Broker{}
-	b.SetDefaults(context.TODO())
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		labels      map[string]string
+		want        map[string]string
+	}{{
+		name: "default brokercell",
+		want: map[string]string{BrokerCellLabelKey: DefaultBrokerCellName},
+	}, {
+		name:        "brokercell annotation",
+		annotations: map[string]string{BrokerCellAnnotation: "prod"},
+		labels:      map[string]string{"app": "shop"},
+		want:        map[string]string{"app": "shop", BrokerCellLabelKey: "prod"},
+	}, {
+		name:   "brokercell label",
+		labels: map[string]string{BrokerCellLabelKey: "batch"},
+		want:   map[string]string{BrokerCellLabelKey: "batch"},
+	}, {
+		name:        "annotation overrides label",
+		annotations: map[string]string{BrokerCellAnnotation: "prod"},
+		labels:      map[string]string{BrokerCellLabelKey: "batch"},
+		want:        map[string]string{BrokerCellLabelKey: "prod"},
+	}, {
+		name:        "other broker class",
+		annotations: map[string]string{eventingv1beta1.BrokerClassAnnotationKey: "MTChannelBasedBroker", BrokerCellAnnotation: "prod"},
+		labels:      map[string]string{"app": "shop"},
+		want:        map[string]string{"app": "shop"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := map[string]string{eventingv1beta1.BrokerClassAnnotationKey: BrokerClass}
+			for k, v := range test.annotations {
+				annotations[k] = v
+			}
+			b := Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+					Labels:      test.labels,
+				},
+			}
+			b.SetDefaults(context.TODO())
+			if diff := cmp.Diff(test.want, b.Labels); diff != "" {
+				t.Errorf("Labels (-want +got): %v", diff)
+			}
+		})
+	}
 }
@@ -37,6 +37,10 @@ const (
 	// BrokerConditionSubscription reports the status of the Broker's PubSub
 	// subscription. This condition is specific to the Google Cloud Broker.
 	BrokerConditionSubscription apis.ConditionType = "SubscriptionReady"
+	// BrokerConditionDataPlane reports the health of the fanout handlers of the Broker in the
+	// data plane pods of its BrokerCell. It doesn't affect the readiness of the Broker, as the
+	// data plane only starts the handlers of ready Brokers.
+	BrokerConditionDataPlane apis.ConditionType = "DataPlaneReady"
 )
 
 // GetCondition returns the condition currently associated with the given type, or nil.
@@ -105,3 +109,15 @@ func (bs *BrokerStatus) MarkSubscriptionUnknown(reason, format string, args ...i
 func (bs *BrokerStatus) MarkSubscriptionReady() {
 	brokerCondSet.Manage(bs).MarkTrue(BrokerConditionSubscription)
 }
+
+func (bs *BrokerStatus) MarkDataPlaneReady() {
+	brokerCondSet.Manage(bs).MarkTrue(BrokerConditionDataPlane)
+}
+
+func (bs *BrokerStatus) MarkDataPlaneFailed(reason, format string, args ...interface{}) {
+	brokerCondSet.Manage(bs).MarkFalse(BrokerConditionDataPlane, reason, format, args...)
+}
+
+func (bs *BrokerStatus) MarkDataPlaneUnknown(reason, format string, args ...interface{}) {
+	brokerCondSet.Manage(bs).MarkUnknown(BrokerConditionDataPlane, reason, format, args...)
+}
@@ -385,3 +385,20 @@ func TestBrokerConditionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestBrokerDataPlaneCondition(t *testing.T) {
+	bs := TestHelper.ReadyBrokerStatus()
+	bs.MarkDataPlaneFailed("HandlerStopped", "induced failure")
+	// The data plane doesn't affect the readiness of the broker.
+	if !bs.IsReady() {
+		t.Error("expected happy true, got false")
+	}
+	c := bs.GetCondition(BrokerConditionDataPlane)
+	if c.Status != corev1.ConditionFalse || c.Severity != apis.ConditionSeverityInfo {
+		t.Errorf("unexpected data plane condition: %+v", c)
+	}
+	bs.MarkDataPlaneReady()
+	if c := bs.GetCondition(BrokerConditionDataPlane); !c.IsTrue() {
+		t.Errorf("unexpected data plane condition: %+v", c)
+	}
+}
@@ -17,6 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -30,6 +36,54 @@ const (
 	// BrokerClass is the annotation value to use when creating a
 	// Google Cloud Broker object.
 	BrokerClass = "googlecloud"
+
+	// OrderingKeyAnnotation is the annotation key used to enable ordered delivery for the
+	// Broker. The value is the name of the CloudEvent attribute used as the ordering key, e.g.
+	// "partitionkey". Events with the same value of the attribute are delivered to each Trigger
+	// in the order they were received by the Broker. The annotation is immutable as the Pub/Sub
+	// subscriptions of the Broker and its Triggers can't be changed to ordered delivery after
+	// they are created.
+	OrderingKeyAnnotation = "broker.events.cloud.google.com/ordering-key"
+
+	// AllowedIdentitiesAnnotation is the annotation key used to restrict which callers can
+	// send events to the Broker when the ingress requires authentication. The value is a
+	// comma-separated list of identities, e.g.
+	// "system:serviceaccount:my-ns:my-sa,publisher@my-project.iam.gserviceaccount.com".
+	// An identity ending with "*" matches any identity with that prefix. If the annotation is
+	// not set, only the service accounts in the namespace of the Broker are allowed. An empty
+	// value denies all callers.
+	AllowedIdentitiesAnnotation = "broker.events.cloud.google.com/allowed-identities"
+
+	// RateLimitAnnotation is the annotation key used to limit the rate of events accepted by
+	// the Broker ingress. The value is the number of events per second, e.g. "100" or "0.5".
+	// Events over the limit are rejected with 429 Too Many Requests.
+	RateLimitAnnotation = "broker.events.cloud.google.com/rate-limit"
+	// RateLimitBurstAnnotation is the annotation key used to set the number of events the
+	// Broker accepts in a burst over the rate limit. It defaults to the rate limit rounded up.
+	RateLimitBurstAnnotation = "broker.events.cloud.google.com/rate-limit-burst"
+	// SourceRateLimitAnnotation is the annotation key used to limit the rate of events from
+	// each source accepted by the Broker ingress, in events per second.
+	SourceRateLimitAnnotation = "broker.events.cloud.google.com/source-rate-limit"
+	// SourceRateLimitBurstAnnotation is the annotation key used to set the number of events
+	// from each source the Broker accepts in a burst over the source rate limit.
+	SourceRateLimitBurstAnnotation = "broker.events.cloud.google.com/source-rate-limit-burst"
+
+	// EventSchemasAnnotation is the annotation key used to set the JSON Schemas the data of
+	// the events sent to the Broker must be valid against. The value is a JSON object, see
+	// EventSchemas.
+	EventSchemasAnnotation = "broker.events.cloud.google.com/event-schemas"
+
+	// BrokerCellAnnotation is the annotation key used to select the BrokerCell in the system
+	// namespace which runs the data plane of the Broker, e.g. "prod". Only the default BrokerCell
+	// is created if it doesn't exist, the others are created by the operator. The webhook assigns
+	// the selected BrokerCell to the BrokerCellLabelKey label, which can be set instead of the
+	// annotation.
+	BrokerCellAnnotation = "broker.events.cloud.google.com/brokercell"
+	// BrokerCellLabelKey is the label key of the BrokerCell assigned to the Broker.
+	BrokerCellLabelKey = "broker.events.cloud.google.com/brokercell"
+
+	// DefaultBrokerCellName is the name of the BrokerCell of the Brokers which don't select one.
+	DefaultBrokerCellName = "default"
 )
 
 // +genclient
@@ -107,6 +161,132 @@ type BrokerList struct {
 	Items []Broker `json:"items"`
 }
 
+// IsGoogleCloudBroker returns whether the broker class annotation of the Broker selects the
+// Google Cloud Broker. The webhook also receives the Brokers of other classes, which it must
+// leave alone.
+func (b *Broker) IsGoogleCloudBroker() bool {
+	return b.GetAnnotations()[eventingv1beta1.BrokerClassAnnotationKey] == BrokerClass
+}
+
+// OrderingKeyAttribute returns the name of the CloudEvent attribute used as the ordering key
+// of the Broker, or an empty string if the Broker doesn't deliver events in order.
+func (b *Broker) OrderingKeyAttribute() string {
+	return b.GetAnnotations()[OrderingKeyAnnotation]
+}
+
+// BrokerCellName returns the name of the BrokerCell of the Broker, selected by the
+// BrokerCellAnnotation or the BrokerCellLabelKey label, or DefaultBrokerCellName. The
+// BrokerCell is in the system namespace.
+func (b *Broker) BrokerCellName() string {
+	if name := b.GetAnnotations()[BrokerCellAnnotation]; name != "" {
+		return name
+	}
+	// Brokers created before the webhook assigned the label don't have it.
+	if name := b.GetLabels()[BrokerCellLabelKey]; name != "" {
+		return name
+	}
+	return DefaultBrokerCellName
+}
+
+// RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	// EventsPerSecond is the number of events allowed per second on average.
+	EventsPerSecond float64
+	// Burst is the maximum number of events allowed at once.
+	Burst int
+}
+
+// RateLimit returns the rate limit of all events sent to the Broker, or nil if the Broker
+// isn't rate limited.
+func (b *Broker) RateLimit() *RateLimit {
+	l, _ := parseRateLimit(b.GetAnnotations(), RateLimitAnnotation, RateLimitBurstAnnotation)
+	return l
+}
+
+// SourceRateLimit returns the rate limit of the events from each source sent to the Broker,
+// or nil if the sources aren't rate limited.
+func (b *Broker) SourceRateLimit() *RateLimit {
+	l, _ := parseRateLimit(b.GetAnnotations(), SourceRateLimitAnnotation, SourceRateLimitBurstAnnotation)
+	return l
+}
+
+func parseRateLimit(annotations map[string]string, rateKey, burstKey string) (*RateLimit, *apis.FieldError) {
+	r, hasRate := annotations[rateKey]
+	b, hasBurst := annotations[burstKey]
+	if !hasRate {
+		if hasBurst {
+			return nil, &apis.FieldError{
+				Message: fmt.Sprintf("burst requires the %s annotation", rateKey),
+				Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", burstKey)},
+			}
+		}
+		return nil, nil
+	}
+	eps, err := strconv.ParseFloat(r, 64)
+	if err != nil || !(eps > 0) || math.IsInf(eps, 1) {
+		return nil, &apis.FieldError{
+			Message: fmt.Sprintf("invalid rate limit %q, must be a positive number of events per second", r),
+			Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", rateKey)},
+		}
+	}
+	l := &RateLimit{EventsPerSecond: eps, Burst: int(math.Ceil(eps))}
+	if hasBurst {
+		if l.Burst, err = strconv.Atoi(b); err != nil || l.Burst <= 0 {
+			return nil, &apis.FieldError{
+				Message: fmt.Sprintf("invalid burst %q, must be a positive integer", b),
+				Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", burstKey)},
+			}
+		}
+	}
+	return l, nil
+}
+
+// EventSchemas are the JSON Schemas of the data of the events sent to a Broker. The data of an
+// event with a dataschema attribute in DataSchemas is validated against that schema, otherwise
+// the data of an event with a type in Types is validated against the schema of the type. The data
+// of other events isn't validated.
+// +k8s:deepcopy-gen=false
+type EventSchemas struct {
+	// Types are the schemas by event type.
+	// +optional
+	Types map[string]json.RawMessage `json:"types,omitempty"`
+	// DataSchemas are the schemas by dataschema URI.
+	// +optional
+	DataSchemas map[string]json.RawMessage `json:"dataSchemas,omitempty"`
+}
+
+// EventSchemas returns the schemas of the Broker from the EventSchemasAnnotation, or nil if the
+// annotation is not set.
+func (b *Broker) EventSchemas() (*EventSchemas, error) {
+	s, ok := b.GetAnnotations()[EventSchemasAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	schemas := &EventSchemas{}
+	if err := dec.Decode(schemas); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// AllowedIdentities returns the identities allowed to send events to the Broker when the
+// ingress requires authentication.
+func (b *Broker) AllowedIdentities() []string {
+	v, ok := b.GetAnnotations()[AllowedIdentitiesAnnotation]
+	if !ok {
+		return []string{fmt.Sprintf("system:serviceaccount:%s:*", b.Namespace)}
+	}
+	var ids []string
+	for _, id := range strings.Split(v, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // GetGroupVersionKind returns GroupVersionKind for Brokers
 func (b *Broker) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Broker")
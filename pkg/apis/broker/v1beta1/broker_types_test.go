@@ -17,9 +17,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/pkg/apis"
@@ -64,3 +66,132 @@ func TestBroker_GetStatus(t *testing.T) {
 		t.Errorf("GetStatus=%v, want=%v", got, want)
 	}
 }
+
+func TestBroker_AllowedIdentities(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{{
+		name: "default",
+		want: []string{"system:serviceaccount:ns:*"},
+	}, {
+		name:        "identities",
+		annotations: map[string]string{AllowedIdentitiesAnnotation: "system:serviceaccount:ns:sa, ,publisher@project.iam.gserviceaccount.com "},
+		want:        []string{"system:serviceaccount:ns:sa", "publisher@project.iam.gserviceaccount.com"},
+	}, {
+		name:        "empty",
+		annotations: map[string]string{AllowedIdentitiesAnnotation: ""},
+		want:        nil,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Annotations: test.annotations,
+				},
+			}
+			if diff := cmp.Diff(test.want, b.AllowedIdentities()); diff != "" {
+				t.Errorf("AllowedIdentities (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestBroker_BrokerCellName(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		labels      map[string]string
+		want        string
+	}{{
+		name: "default",
+		want: DefaultBrokerCellName,
+	}, {
+		name:        "annotation",
+		annotations: map[string]string{BrokerCellAnnotation: "prod"},
+		labels:      map[string]string{BrokerCellLabelKey: "batch"},
+		want:        "prod",
+	}, {
+		name:   "label",
+		labels: map[string]string{BrokerCellLabelKey: "batch"},
+		want:   "batch",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: test.annotations,
+					Labels:      test.labels,
+				},
+			}
+			if got := b.BrokerCellName(); got != test.want {
+				t.Errorf("BrokerCellName got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBroker_RateLimit(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		wantRateLimit   *RateLimit
+		wantSourceLimit *RateLimit
+	}{{
+		name: "no rate limits",
+	}, {
+		name: "default burst",
+		annotations: map[string]string{
+			RateLimitAnnotation:       "2.5",
+			SourceRateLimitAnnotation: "0.1",
+		},
+		wantRateLimit:   &RateLimit{EventsPerSecond: 2.5, Burst: 3},
+		wantSourceLimit: &RateLimit{EventsPerSecond: 0.1, Burst: 1},
+	}, {
+		name: "burst",
+		annotations: map[string]string{
+			RateLimitAnnotation:            "100",
+			RateLimitBurstAnnotation:       "1000",
+			SourceRateLimitAnnotation:      "10",
+			SourceRateLimitBurstAnnotation: "20",
+		},
+		wantRateLimit:   &RateLimit{EventsPerSecond: 100, Burst: 1000},
+		wantSourceLimit: &RateLimit{EventsPerSecond: 10, Burst: 20},
+	}, {
+		name:        "invalid rate limit",
+		annotations: map[string]string{RateLimitAnnotation: "-1"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			if diff := cmp.Diff(test.wantRateLimit, b.RateLimit()); diff != "" {
+				t.Errorf("RateLimit (-want +got): %v", diff)
+			}
+			if diff := cmp.Diff(test.wantSourceLimit, b.SourceRateLimit()); diff != "" {
+				t.Errorf("SourceRateLimit (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestBroker_EventSchemas(t *testing.T) {
+	b := &Broker{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		EventSchemasAnnotation: `{"types": {"com.example.order": {"required": ["id"]}}}`,
+	}}}
+	got, err := b.EventSchemas()
+	if err != nil {
+		t.Fatalf("EventSchemas failed: %v", err)
+	}
+	want := &EventSchemas{Types: map[string]json.RawMessage{
+		"com.example.order": json.RawMessage(`{"required": ["id"]}`),
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EventSchemas (-want +got): %v", diff)
+	}
+
+	if got, err := (&Broker{}).EventSchemas(); got != nil || err != nil {
+		t.Errorf("EventSchemas without the annotation got (%v, %v), want (nil, nil)", got, err)
+	}
+}
@@ -18,13 +18,121 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
+
+	"github.com/google/knative-gcp/pkg/broker/schema"
 )
 
 // Validate verifies that the Broker is valid.
 func (b *Broker) Validate(ctx context.Context) *apis.FieldError {
-	// The Google Cloud Broker doesn't have any custom validations. The
-	// eventing webhook will run the usual validations.
+	if !b.IsGoogleCloudBroker() {
+		return nil
+	}
+	// The eventing webhook will run the usual validations. The Google Cloud
+	// Broker only validates its own annotations.
+	var errs *apis.FieldError
+	if attr, ok := b.GetAnnotations()[OrderingKeyAnnotation]; ok && !validAttributeName.MatchString(attr) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid ordering key attribute %q, must be a valid CloudEvent attribute name", attr),
+			Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", OrderingKeyAnnotation)},
+		})
+	}
+	if v, ok := b.GetAnnotations()[AllowedIdentitiesAnnotation]; ok {
+		for _, id := range strings.Split(v, ",") {
+			id = strings.TrimSpace(id)
+			if i := strings.Index(id, "*"); i >= 0 && i != len(id)-1 {
+				errs = errs.Also(&apis.FieldError{
+					Message: fmt.Sprintf("invalid allowed identity %q, a wildcard is only allowed at the end", id),
+					Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", AllowedIdentitiesAnnotation)},
+				})
+			}
+		}
+	}
+	if _, err := parseRateLimit(b.GetAnnotations(), RateLimitAnnotation, RateLimitBurstAnnotation); err != nil {
+		errs = errs.Also(err)
+	}
+	if _, err := parseRateLimit(b.GetAnnotations(), SourceRateLimitAnnotation, SourceRateLimitBurstAnnotation); err != nil {
+		errs = errs.Also(err)
+	}
+	errs = errs.Also(validateEventSchemas(b))
+	errs = errs.Also(validateBrokerCell(b))
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*Broker); ok {
+			errs = errs.Also(b.CheckImmutableFields(ctx, original))
+		}
+	}
+	return errs
+}
+
+func validateEventSchemas(b *Broker) *apis.FieldError {
+	path := fmt.Sprintf("metadata.annotations[%s]", EventSchemasAnnotation)
+	schemas, err := b.EventSchemas()
+	if err != nil {
+		return &apis.FieldError{
+			Message: "invalid event schemas",
+			Paths:   []string{path},
+			Details: err.Error(),
+		}
+	}
+	if schemas == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	compile := func(kind string, raw map[string]json.RawMessage) {
+		for key, s := range raw {
+			if _, err := schema.Compile(s); err != nil {
+				errs = errs.Also(&apis.FieldError{
+					Message: fmt.Sprintf("invalid schema of %s %q", kind, key),
+					Paths:   []string{path},
+					Details: err.Error(),
+				})
+			}
+		}
+	}
+	compile("event type", schemas.Types)
+	compile("dataschema", schemas.DataSchemas)
+	return errs
+}
+
+// validateBrokerCell checks that the BrokerCell selected by the annotation or label of the
+// Broker is a valid name for the data plane services of the BrokerCell.
+func validateBrokerCell(b *Broker) *apis.FieldError {
+	var errs *apis.FieldError
+	check := func(name, path string) {
+		for _, msg := range validation.IsDNS1035Label(name) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid brokercell name %q", name),
+				Paths:   []string{path},
+				Details: msg,
+			})
+		}
+	}
+	if name, ok := b.GetAnnotations()[BrokerCellAnnotation]; ok {
+		check(name, fmt.Sprintf("metadata.annotations[%s]", BrokerCellAnnotation))
+	}
+	if name, ok := b.GetLabels()[BrokerCellLabelKey]; ok {
+		check(name, fmt.Sprintf("metadata.labels[%s]", BrokerCellLabelKey))
+	}
+	return errs
+}
+
+// CheckImmutableFields checks that the ordering key annotation of the Broker is not changed.
+func (b *Broker) CheckImmutableFields(ctx context.Context, original *Broker) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+	if diff := cmp.Diff(original.OrderingKeyAttribute(), b.OrderingKeyAttribute()); diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{fmt.Sprintf("metadata.annotations[%s]", OrderingKeyAnnotation)},
+			Details: diff,
+		}
+	}
 	return nil
 }
@@ -18,12 +18,295 @@ package v1beta1
 
 import (
 	"context"
+	"strings"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	"knative.dev/pkg/apis"
 )
 
+// withBrokerClass returns the annotations of a Google Cloud Broker with the given annotations.
+func withBrokerClass(annotations map[string]string) map[string]string {
+	a := map[string]string{eventingv1beta1.BrokerClassAnnotationKey: BrokerClass}
+	for k, v := range annotations {
+		a[k] = v
+	}
+	return a
+}
+
 func TestBroker_Validate(t *testing.T) {
-	b := Broker{}
+	b := Broker{ObjectMeta: metav1.ObjectMeta{Annotations: withBrokerClass(nil)}}
 	if err := b.Validate(context.TODO()); err != nil {
 		t.Errorf("expected nil, got %v", err)
 	}
 }
+
+func TestBroker_ValidateOtherBrokerClass(t *testing.T) {
+	b := Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				eventingv1beta1.BrokerClassAnnotationKey: "MTChannelBasedBroker",
+				OrderingKeyAnnotation:                    "partition-key",
+				BrokerCellAnnotation:                     "Prod_Cell",
+			},
+		},
+	}
+	if err := b.Validate(context.TODO()); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestBroker_ValidateOrderingKey(t *testing.T) {
+	brokerWithOrderingKey := func(attr string) *Broker {
+		return &Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: withBrokerClass(map[string]string{OrderingKeyAnnotation: attr}),
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		broker *Broker
+		want   string
+	}{{
+		name:   "valid ordering key",
+		ctx:    context.Background(),
+		broker: brokerWithOrderingKey("partitionkey"),
+	}, {
+		name:   "invalid ordering key",
+		ctx:    context.Background(),
+		broker: brokerWithOrderingKey("partition-key"),
+		want:   `invalid ordering key attribute "partition-key", must be a valid CloudEvent attribute name: metadata.annotations[broker.events.cloud.google.com/ordering-key]`,
+	}, {
+		name:   "empty ordering key",
+		ctx:    context.Background(),
+		broker: brokerWithOrderingKey(""),
+		want:   `invalid ordering key attribute "", must be a valid CloudEvent attribute name`,
+	}, {
+		name:   "unchanged ordering key",
+		ctx:    apis.WithinUpdate(context.Background(), brokerWithOrderingKey("partitionkey")),
+		broker: brokerWithOrderingKey("partitionkey"),
+	}, {
+		name:   "changed ordering key",
+		ctx:    apis.WithinUpdate(context.Background(), brokerWithOrderingKey("partitionkey")),
+		broker: brokerWithOrderingKey("key"),
+		want:   "Immutable fields changed (-old +new): metadata.annotations[broker.events.cloud.google.com/ordering-key]",
+	}, {
+		name:   "added ordering key",
+		ctx:    apis.WithinUpdate(context.Background(), &Broker{}),
+		broker: brokerWithOrderingKey("key"),
+		want:   "Immutable fields changed (-old +new): metadata.annotations[broker.events.cloud.google.com/ordering-key]",
+	}, {
+		name:   "removed ordering key",
+		ctx:    apis.WithinUpdate(context.Background(), brokerWithOrderingKey("key")),
+		broker: &Broker{ObjectMeta: metav1.ObjectMeta{Annotations: withBrokerClass(nil)}},
+		want:   "Immutable fields changed (-old +new): metadata.annotations[broker.events.cloud.google.com/ordering-key]",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.broker.Validate(test.ctx)
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBroker_ValidateAllowedIdentities(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{{
+		name:  "valid identities",
+		value: "system:serviceaccount:ns:sa, publisher@project.iam.gserviceaccount.com",
+	}, {
+		name:  "trailing wildcard",
+		value: "system:serviceaccount:ns:*",
+	}, {
+		name:  "empty",
+		value: "",
+	}, {
+		name:  "wildcard in the middle",
+		value: "system:serviceaccount:ns:sa,system:serviceaccount:*:sa",
+		want:  `invalid allowed identity "system:serviceaccount:*:sa", a wildcard is only allowed at the end: metadata.annotations[broker.events.cloud.google.com/allowed-identities]`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: withBrokerClass(map[string]string{AllowedIdentitiesAnnotation: test.value}),
+				},
+			}
+			err := b.Validate(context.Background())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBroker_ValidateBrokerCell(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		labels      map[string]string
+		want        string
+	}{{
+		name:        "valid annotation",
+		annotations: map[string]string{BrokerCellAnnotation: "prod"},
+	}, {
+		name:   "valid label",
+		labels: map[string]string{BrokerCellLabelKey: "batch-1"},
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{BrokerCellAnnotation: "Prod_Cell"},
+		want:        `invalid brokercell name "Prod_Cell": metadata.annotations[broker.events.cloud.google.com/brokercell]`,
+	}, {
+		name:   "label starting with a digit",
+		labels: map[string]string{BrokerCellLabelKey: "1st"},
+		want:   `invalid brokercell name "1st": metadata.labels[broker.events.cloud.google.com/brokercell]`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: withBrokerClass(test.annotations),
+					Labels:      test.labels,
+				},
+			}
+			err := b.Validate(context.Background())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBroker_ValidateRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{{
+		name: "rate limits",
+		annotations: map[string]string{
+			RateLimitAnnotation:            "100",
+			RateLimitBurstAnnotation:       "200",
+			SourceRateLimitAnnotation:      "0.5",
+			SourceRateLimitBurstAnnotation: "1",
+		},
+	}, {
+		name:        "invalid rate limit",
+		annotations: map[string]string{RateLimitAnnotation: "fast"},
+		want:        `invalid rate limit "fast", must be a positive number of events per second: metadata.annotations[broker.events.cloud.google.com/rate-limit]`,
+	}, {
+		name:        "zero rate limit",
+		annotations: map[string]string{SourceRateLimitAnnotation: "0"},
+		want:        `invalid rate limit "0", must be a positive number of events per second: metadata.annotations[broker.events.cloud.google.com/source-rate-limit]`,
+	}, {
+		name:        "NaN rate limit",
+		annotations: map[string]string{RateLimitAnnotation: "NaN"},
+		want:        `invalid rate limit "NaN", must be a positive number of events per second`,
+	}, {
+		name:        "invalid burst",
+		annotations: map[string]string{RateLimitAnnotation: "10", RateLimitBurstAnnotation: "-1"},
+		want:        `invalid burst "-1", must be a positive integer: metadata.annotations[broker.events.cloud.google.com/rate-limit-burst]`,
+	}, {
+		name:        "burst without rate limit",
+		annotations: map[string]string{SourceRateLimitBurstAnnotation: "10"},
+		want:        "burst requires the broker.events.cloud.google.com/source-rate-limit annotation: metadata.annotations[broker.events.cloud.google.com/source-rate-limit-burst]",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{ObjectMeta: metav1.ObjectMeta{Annotations: withBrokerClass(test.annotations)}}
+			err := b.Validate(context.Background())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBroker_ValidateEventSchemas(t *testing.T) {
+	tests := []struct {
+		name    string
+		schemas string
+		want    string
+	}{{
+		name:    "schemas",
+		schemas: `{"types": {"com.example.order": {"type": "object", "required": ["id"]}}, "dataSchemas": {"https://example.com/schemas/v1": true}}`,
+	}, {
+		name:    "invalid JSON",
+		schemas: `{"types": `,
+		want:    "invalid event schemas: metadata.annotations[broker.events.cloud.google.com/event-schemas]",
+	}, {
+		name:    "unknown field",
+		schemas: `{"schemas": {}}`,
+		want:    "invalid event schemas: metadata.annotations[broker.events.cloud.google.com/event-schemas]",
+	}, {
+		name:    "invalid type schema",
+		schemas: `{"types": {"com.example.order": {"type": "obj"}}}`,
+		want:    `invalid schema of event type "com.example.order": metadata.annotations[broker.events.cloud.google.com/event-schemas]`,
+	}, {
+		name:    "invalid dataschema schema",
+		schemas: `{"dataSchemas": {"https://example.com/schemas/v1": {"$ref": "#/definitions/missing"}}}`,
+		want:    `invalid schema of dataschema "https://example.com/schemas/v1": metadata.annotations[broker.events.cloud.google.com/event-schemas]`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &Broker{ObjectMeta: metav1.ObjectMeta{Annotations: withBrokerClass(map[string]string{EventSchemasAnnotation: test.schemas})}}
+			err := b.Validate(context.Background())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ var triggerCondSet = apis.NewLivingConditionSet(
 	eventingv1beta1.TriggerConditionBroker,
 	eventingv1beta1.TriggerConditionDependency,
 	eventingv1beta1.TriggerConditionSubscriberResolved,
+	TriggerConditionReplyResolved,
 	TriggerConditionTopic,
 	TriggerConditionSubscription,
 )
@@ -34,6 +35,13 @@ var triggerCondSet = apis.NewLivingConditionSet(
 const (
 	TriggerConditionTopic        apis.ConditionType = "TopicReady"
 	TriggerConditionSubscription apis.ConditionType = "SubscriptionReady"
+	// TriggerConditionReplyResolved is true if the reply destination of the Trigger, if any,
+	// is resolved.
+	TriggerConditionReplyResolved apis.ConditionType = "ReplyResolved"
+	// TriggerConditionDataPlane reports the health of the retry handlers of the Trigger in the
+	// data plane pods of the BrokerCell of its Broker. It doesn't affect the readiness of the
+	// Trigger, as the data plane only starts the handlers of ready Triggers.
+	TriggerConditionDataPlane apis.ConditionType = "DataPlaneReady"
 )
 
 // GetCondition returns the condition currently associated with the given type, or nil.
@@ -124,6 +132,40 @@ func (ts *TriggerStatus) MarkSubscriberResolvedUnknown(reason, messageFormat str
 	triggerCondSet.Manage(ts).MarkUnknown(eventingv1beta1.TriggerConditionSubscriberResolved, reason, messageFormat, messageA...)
 }
 
+// MarkReplyResolvedSucceeded marks the reply destination as resolved to the given URI. A nil URI
+// means the replies are not sent to a reply destination.
+func (ts *TriggerStatus) MarkReplyResolvedSucceeded(uri *apis.URL) {
+	if uri == nil {
+		delete(ts.Annotations, ReplyURIStatusAnnotation)
+		if len(ts.Annotations) == 0 {
+			ts.Annotations = nil
+		}
+	} else {
+		if ts.Annotations == nil {
+			ts.Annotations = make(map[string]string, 1)
+		}
+		ts.Annotations[ReplyURIStatusAnnotation] = uri.String()
+	}
+	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionReplyResolved)
+}
+
+func (ts *TriggerStatus) MarkReplyResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	delete(ts.Annotations, ReplyURIStatusAnnotation)
+	if len(ts.Annotations) == 0 {
+		ts.Annotations = nil
+	}
+	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionReplyResolved, reason, messageFormat, messageA...)
+}
+
+// ReplyURI returns the resolved URI of the reply destination, or nil if it isn't resolved.
+func (ts *TriggerStatus) ReplyURI() *apis.URL {
+	uri, err := apis.ParseURL(ts.Annotations[ReplyURIStatusAnnotation])
+	if err != nil {
+		return nil
+	}
+	return uri
+}
+
 func (ts *TriggerStatus) MarkDependencySucceeded() {
 	triggerCondSet.Manage(ts).MarkTrue(eventingv1beta1.TriggerConditionDependency)
 }
@@ -159,3 +201,15 @@ func (ts *TriggerStatus) PropagateDependencyStatus(ks *duckv1.KResource) {
 		ts.MarkDependencyUnknown("DependencyUnknown", "The status of Dependency is invalid: %v", kc.Status)
 	}
 }
+
+func (ts *TriggerStatus) MarkDataPlaneReady() {
+	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionDataPlane)
+}
+
+func (ts *TriggerStatus) MarkDataPlaneFailed(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionDataPlane, reason, messageFormat, messageA...)
+}
+
+func (ts *TriggerStatus) MarkDataPlaneUnknown(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkUnknown(TriggerConditionDataPlane, reason, messageFormat, messageA...)
+}
@@ -153,6 +153,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 					}, {
 						Type:   eventingv1beta1.TriggerConditionReady,
 						Status: corev1.ConditionUnknown,
+					}, {
+						Type:   TriggerConditionReplyResolved,
+						Status: corev1.ConditionUnknown,
 					}, {
 						Type:   eventingv1beta1.TriggerConditionSubscriberResolved,
 						Status: corev1.ConditionUnknown,
@@ -190,6 +193,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 					}, {
 						Type:   eventingv1beta1.TriggerConditionReady,
 						Status: corev1.ConditionUnknown,
+					}, {
+						Type:   TriggerConditionReplyResolved,
+						Status: corev1.ConditionUnknown,
 					}, {
 						Type:   eventingv1beta1.TriggerConditionSubscriberResolved,
 						Status: corev1.ConditionUnknown,
@@ -227,6 +233,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 					}, {
 						Type:   eventingv1beta1.TriggerConditionReady,
 						Status: corev1.ConditionUnknown,
+					}, {
+						Type:   TriggerConditionReplyResolved,
+						Status: corev1.ConditionUnknown,
 					}, {
 						Type:   eventingv1beta1.TriggerConditionSubscriberResolved,
 						Status: corev1.ConditionUnknown,
@@ -263,6 +272,7 @@ func TestTriggerConditionStatus(t *testing.T) {
 		topicStatus              corev1.ConditionStatus
 		subscriptionStatus       corev1.ConditionStatus
 		subscriberResolvedStatus corev1.ConditionStatus
+		replyResolvedFailed      bool
 		dependencyStatus         *duckv1.KResource
 		wantConditionStatus      corev1.ConditionStatus
 	}{{
@@ -345,6 +355,15 @@ func TestTriggerConditionStatus(t *testing.T) {
 		subscriberResolvedStatus: corev1.ConditionUnknown,
 		dependencyStatus:         TestHelper.ReadyDependencyStatus(),
 		wantConditionStatus:      corev1.ConditionUnknown,
+	}, {
+		name:                     "failed to resolve reply",
+		brokerStatus:             TestHelper.ReadyBrokerStatus(),
+		subscriptionStatus:       corev1.ConditionTrue,
+		topicStatus:              corev1.ConditionTrue,
+		subscriberResolvedStatus: corev1.ConditionTrue,
+		replyResolvedFailed:      true,
+		dependencyStatus:         TestHelper.ReadyDependencyStatus(),
+		wantConditionStatus:      corev1.ConditionFalse,
 	}, {
 		name:                     "dependency unconfigured",
 		brokerStatus:             TestHelper.ReadyBrokerStatus(),
@@ -405,6 +424,11 @@ func TestTriggerConditionStatus(t *testing.T) {
 			} else {
 				ts.MarkSubscriberResolvedUnknown("Status of Subscriber URI is unknown", "induced failure")
 			}
+			if test.replyResolvedFailed {
+				ts.MarkReplyResolvedFailed("Unable to get the reply URI", "reply destination not found")
+			} else {
+				ts.MarkReplyResolvedSucceeded(nil)
+			}
 			if test.dependencyStatus == nil {
 				ts.MarkDependencySucceeded()
 			} else {
@@ -428,3 +452,43 @@ func TestTriggerConditionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestTriggerReplyURI(t *testing.T) {
+	ts := &TriggerStatus{}
+	if got := ts.ReplyURI(); got != nil {
+		t.Errorf("ReplyURI of a new status got %v, want nil", got)
+	}
+	uri := apis.HTTP("other-broker.example.com")
+	ts.MarkReplyResolvedSucceeded(uri)
+	if got := ts.ReplyURI(); got.String() != uri.String() {
+		t.Errorf("ReplyURI got %v, want %v", got, uri)
+	}
+	if got := ts.GetCondition(TriggerConditionReplyResolved); got == nil || got.Status != corev1.ConditionTrue {
+		t.Errorf("ReplyResolved condition got %v, want true", got)
+	}
+	ts.MarkReplyResolvedFailed("Unable to get the reply URI", "reply destination not found")
+	if got := ts.ReplyURI(); got != nil {
+		t.Errorf("ReplyURI after the resolution failed got %v, want nil", got)
+	}
+	if ts.Annotations != nil {
+		t.Errorf("status annotations got %v, want nil", ts.Annotations)
+	}
+}
+
+func TestTriggerDataPlaneCondition(t *testing.T) {
+	ts := &TriggerStatus{}
+	ts.InitializeConditions()
+	ts.MarkDataPlaneFailed("HandlerStopped", "induced failure")
+	// The data plane doesn't affect the readiness of the trigger.
+	if got := ts.GetTopLevelCondition(); got.Status != corev1.ConditionUnknown {
+		t.Errorf("unexpected readiness: want %v, got %v", corev1.ConditionUnknown, got.Status)
+	}
+	c := ts.GetCondition(TriggerConditionDataPlane)
+	if c.Status != corev1.ConditionFalse || c.Severity != apis.ConditionSeverityInfo {
+		t.Errorf("unexpected data plane condition: %+v", c)
+	}
+	ts.MarkDataPlaneUnknown("DataPlaneNotProbed", "induced unknown")
+	if c := ts.GetCondition(TriggerConditionDataPlane); c.Status != corev1.ConditionUnknown {
+		t.Errorf("unexpected data plane condition: %+v", c)
+	}
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -33,6 +37,26 @@ const (
 	// InjectionAnnotation is the annotation key used to enable knative eventing injection for a namespace and automatically create a default broker.
 	// This will be used when the client creates a trigger paired with default broker and the default broker doesn't exist in the namespace
 	InjectionAnnotation = "knative-eventing-injection"
+	// FiltersAnnotation is the annotation key used to specify advanced filters for the Trigger, in
+	// addition to spec.filter. The value is a JSON list of TriggerFilters and an event is delivered
+	// only if it passes all of them.
+	FiltersAnnotation = "trigger.events.cloud.google.com/filters"
+	// DeliveryAnnotation is the annotation key used to specify the delivery spec of the Trigger. The value is
+	// a JSON delivery spec, e.g. {"retry": 5, "backoffPolicy": "linear", "backoffDelay": "PT10S"}, and the
+	// fields that are set override the delivery spec of the Broker.
+	DeliveryAnnotation = "trigger.events.cloud.google.com/delivery"
+	// ReplyAnnotation is the annotation key used to specify where the replies of the Trigger's
+	// subscriber are sent. The value is a JSON ReplySpec, e.g. {"drop": true} or
+	// {"destination": {"ref": {"apiVersion": "eventing.knative.dev/v1beta1", "kind": "Broker", "name": "other"}}}.
+	// By default, replies are sent to the Trigger's Broker.
+	ReplyAnnotation = "trigger.events.cloud.google.com/reply"
+	// ReplyURIStatusAnnotation is the status annotation key used to record the resolved URI of the
+	// reply destination of the Trigger.
+	ReplyURIStatusAnnotation = "trigger.events.cloud.google.com/reply-uri"
+	// TransportAnnotation is the annotation key used to specify the options of the connections to the
+	// Trigger's subscriber. The value is a JSON TransportSpec, e.g. {"h2c": true} or
+	// {"tls": {"caBundle": "-----BEGIN CERTIFICATE-----...", "clientCertificateSecret": "mesh"}}.
+	TransportAnnotation = "trigger.events.cloud.google.com/transport"
 )
 
 // +genclient
@@ -94,6 +118,96 @@ type TriggerStatus struct {
 	//SubscriptionID string `json:"subscriptionId,omitempty"`
 }
 
+// TriggerFilter is an advanced filter on the CloudEvent attributes. An event passes the filter only
+// if it matches every field that is set.
+type TriggerFilter struct {
+	// Exact requires the attributes to exactly equal the given values.
+	// +optional
+	Exact map[string]string `json:"exact,omitempty"`
+
+	// Prefix requires the attributes to start with the given values.
+	// +optional
+	Prefix map[string]string `json:"prefix,omitempty"`
+
+	// Suffix requires the attributes to end with the given values.
+	// +optional
+	Suffix map[string]string `json:"suffix,omitempty"`
+
+	// Exists requires the attributes to be present.
+	// +optional
+	Exists []string `json:"exists,omitempty"`
+
+	// Not requires the filter not to match.
+	// +optional
+	Not *TriggerFilter `json:"not,omitempty"`
+
+	// Any requires at least one of the filters to match.
+	// +optional
+	Any []TriggerFilter `json:"any,omitempty"`
+
+	// All requires all of the filters to match.
+	// +optional
+	All []TriggerFilter `json:"all,omitempty"`
+
+	// Expression requires the expression over the attributes to evaluate to true, e.g.
+	// "type LIKE 'com.example.%' AND priority > 3".
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+// ReplySpec specifies where the replies of a Trigger's subscriber are sent. Exactly one of the
+// fields must be set.
+type ReplySpec struct {
+	// Destination is where the replies are sent, e.g. another Broker or an addressable.
+	// +optional
+	Destination *duckv1.Destination `json:"destination,omitempty"`
+
+	// Drop drops the replies.
+	// +optional
+	Drop bool `json:"drop,omitempty"`
+}
+
+// TransportSpec specifies the options of the connections to a Trigger's subscriber. Subscribers
+// without a TransportSpec are delivered to over connections shared by all such subscribers.
+type TransportSpec struct {
+	// H2C delivers events with HTTP/2 over cleartext (h2c), e.g. to gRPC servers. It can't be used
+	// with TLS or the idle connection options, as HTTP/2 multiplexes requests on a single
+	// connection per host.
+	// +optional
+	H2C bool `json:"h2c,omitempty"`
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept to the subscriber.
+	// +optional
+	MaxIdleConnsPerHost *int32 `json:"maxIdleConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is how long an idle connection is kept before it's closed, as an ISO 8601
+	// duration, e.g. "PT90S".
+	// +optional
+	IdleConnTimeout *string `json:"idleConnTimeout,omitempty"`
+
+	// KeepAlive is the interval between TCP keep-alive probes of the connections, as an ISO 8601
+	// duration.
+	// +optional
+	KeepAlive *string `json:"keepAlive,omitempty"`
+
+	// TLS is the TLS options of the connections to the subscriber.
+	// +optional
+	TLS *TransportTLS `json:"tls,omitempty"`
+}
+
+// TransportTLS specifies the TLS options of the connections to a Trigger's subscriber.
+type TransportTLS struct {
+	// CABundle is the PEM-encoded CA certificates trusted to verify the certificate of the
+	// subscriber. If empty, the system roots are trusted.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// ClientCertificateSecret is the name of the Secret of type kubernetes.io/tls in the namespace
+	// of the Trigger with the client certificate presented to the subscriber for mutual TLS.
+	// +optional
+	ClientCertificateSecret string `json:"clientCertificateSecret,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TriggerList is a collection of Triggers.
@@ -114,6 +228,70 @@ func (t *Trigger) GetUntypedSpec() interface{} {
 	return t.Spec
 }
 
+// Filters returns the advanced filters of the Trigger from the FiltersAnnotation, or nil if the
+// annotation is not set.
+func (t *Trigger) Filters() ([]TriggerFilter, error) {
+	s, ok := t.Annotations[FiltersAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	var filters []TriggerFilter
+	if err := dec.Decode(&filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// DeliverySpec returns the delivery spec of the Trigger from the DeliveryAnnotation, or nil if the
+// annotation is not set.
+func (t *Trigger) DeliverySpec() (*eventingduckv1beta1.DeliverySpec, error) {
+	s, ok := t.Annotations[DeliveryAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	ds := &eventingduckv1beta1.DeliverySpec{}
+	if err := dec.Decode(ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// ReplySpec returns the reply spec of the Trigger from the ReplyAnnotation, or nil if the annotation
+// is not set.
+func (t *Trigger) ReplySpec() (*ReplySpec, error) {
+	s, ok := t.Annotations[ReplyAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	rs := &ReplySpec{}
+	if err := dec.Decode(rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// TransportSpec returns the transport spec of the Trigger from the TransportAnnotation, or nil if
+// the annotation is not set.
+func (t *Trigger) TransportSpec() (*TransportSpec, error) {
+	s, ok := t.Annotations[TransportAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	ts := &TransportSpec{}
+	if err := dec.Decode(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
 func (*Trigger) GetConditionSet() apis.ConditionSet {
 	return triggerCondSet
@@ -20,9 +20,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestTrigger_GetGroupVersionKind(t *testing.T) {
@@ -64,3 +67,47 @@ func TestTrigger_GetStatus(t *testing.T) {
 		t.Errorf("GetStatus=%v, want=%v", got, want)
 	}
 }
+
+func TestTrigger_ReplySpec(t *testing.T) {
+	tr := &Trigger{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		ReplyAnnotation: `{"destination":{"ref":{"apiVersion":"eventing.knative.dev/v1beta1","kind":"Broker","name":"other"}}}`,
+	}}}
+	got, err := tr.ReplySpec()
+	if err != nil {
+		t.Fatalf("ReplySpec failed: %v", err)
+	}
+	want := &ReplySpec{Destination: &duckv1.Destination{Ref: &duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1beta1",
+		Kind:       "Broker",
+		Name:       "other",
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReplySpec (-want +got): %v", diff)
+	}
+
+	if got, err := (&Trigger{}).ReplySpec(); got != nil || err != nil {
+		t.Errorf("ReplySpec without the annotation got (%v, %v), want (nil, nil)", got, err)
+	}
+}
+
+func TestTrigger_TransportSpec(t *testing.T) {
+	tr := &Trigger{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		TransportAnnotation: `{"maxIdleConnsPerHost":10,"idleConnTimeout":"PT90S","tls":{"clientCertificateSecret":"mesh"}}`,
+	}}}
+	got, err := tr.TransportSpec()
+	if err != nil {
+		t.Fatalf("TransportSpec failed: %v", err)
+	}
+	want := &TransportSpec{
+		MaxIdleConnsPerHost: ptr.Int32(10),
+		IdleConnTimeout:     ptr.String("PT90S"),
+		TLS:                 &TransportTLS{ClientCertificateSecret: "mesh"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TransportSpec (-want +got): %v", diff)
+	}
+
+	if got, err := (&Trigger{}).TransportSpec(); got != nil || err != nil {
+		t.Errorf("TransportSpec without the annotation got (%v, %v), want (nil, nil)", got, err)
+	}
+}
@@ -18,13 +18,258 @@ package v1beta1
 
 import (
 	"context"
+	"crypto/x509"
+	"regexp"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
+
+	"github.com/google/knative-gcp/pkg/broker/expr"
+	"github.com/google/knative-gcp/pkg/utils"
 )
 
+// Only allow lowercase alphanumeric, consistent with the CloudEvents attribute naming rules.
+var validAttributeName = regexp.MustCompile(`^[a-z0-9]+$`)
+
 // Validate the Trigger.
 func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
-	// The Google Cloud Broker doesn't have any custom validations. The
-	// eventing webhook will run the usual validations.
+	if !t.hasGoogleCloudBroker(ctx) {
+		return nil
+	}
+	// The eventing webhook will run the usual validations. The Google Cloud
+	// Broker only validates its own annotations.
+	errs := t.validateFilters(ctx).ViaKey(FiltersAnnotation)
+	errs = errs.Also(t.validateDelivery(ctx).ViaKey(DeliveryAnnotation))
+	errs = errs.Also(t.validateReply(ctx).ViaKey(ReplyAnnotation))
+	errs = errs.Also(t.validateTransport(ctx).ViaKey(TransportAnnotation))
+	return errs.ViaField("metadata", "annotations")
+}
+
+// hasGoogleCloudBroker returns whether the Trigger may belong to a Google Cloud Broker. The
+// webhook also receives the Triggers of other broker classes, which it must leave alone. A
+// Trigger whose Broker can't be found, e.g. because it is created before the Broker, is
+// validated, as the annotations are specific to the Google Cloud Broker anyway.
+func (t *Trigger) hasGoogleCloudBroker(ctx context.Context) bool {
+	get := getBrokerGetter(ctx)
+	if get == nil {
+		return true
+	}
+	b, err := get(t.Namespace, t.Spec.Broker)
+	if err != nil {
+		return true
+	}
+	return b.IsGoogleCloudBroker()
+}
+
+func (t *Trigger) validateFilters(ctx context.Context) *apis.FieldError {
+	filters, err := t.Filters()
+	if err != nil {
+		return &apis.FieldError{
+			Message: "invalid filters",
+			Paths:   []string{apis.CurrentField},
+			Details: err.Error(),
+		}
+	}
+	var errs *apis.FieldError
+	for i, f := range filters {
+		errs = errs.Also(f.Validate(ctx).ViaIndex(i))
+	}
+	return errs
+}
+
+func (t *Trigger) validateDelivery(ctx context.Context) *apis.FieldError {
+	ds, err := t.DeliverySpec()
+	if err != nil {
+		return &apis.FieldError{
+			Message: "invalid delivery spec",
+			Paths:   []string{apis.CurrentField},
+			Details: err.Error(),
+		}
+	}
+	if ds == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if ds.DeadLetterSink != nil {
+		errs = errs.Also(ds.DeadLetterSink.Validate(ctx).ViaField("deadLetterSink"))
+	}
+	if ds.Retry != nil && *ds.Retry < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*ds.Retry, "retry"))
+	}
+	if ds.BackoffPolicy != nil {
+		switch *ds.BackoffPolicy {
+		case eventingduckv1beta1.BackoffPolicyExponential, eventingduckv1beta1.BackoffPolicyLinear:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffPolicy, "backoffPolicy"))
+		}
+	}
+	if ds.BackoffDelay != nil {
+		// Unlike the eventing webhook, the backoff delay is validated as an ISO 8601 duration.
+		if _, err := utils.ParseISO8601Duration(*ds.BackoffDelay); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffDelay, "backoffDelay"))
+		}
+	}
+	return errs
+}
+
+func (t *Trigger) validateReply(ctx context.Context) *apis.FieldError {
+	rs, err := t.ReplySpec()
+	if err != nil {
+		return &apis.FieldError{
+			Message: "invalid reply spec",
+			Paths:   []string{apis.CurrentField},
+			Details: err.Error(),
+		}
+	}
+	if rs == nil {
+		return nil
+	}
+	switch {
+	case rs.Destination != nil && rs.Drop:
+		return apis.ErrMultipleOneOf("destination", "drop")
+	case rs.Destination != nil:
+		return rs.Destination.Validate(ctx).ViaField("destination")
+	case !rs.Drop:
+		return apis.ErrMissingOneOf("destination", "drop")
+	}
 	return nil
 }
+
+func (t *Trigger) validateTransport(ctx context.Context) *apis.FieldError {
+	ts, err := t.TransportSpec()
+	if err != nil {
+		return &apis.FieldError{
+			Message: "invalid transport spec",
+			Paths:   []string{apis.CurrentField},
+			Details: err.Error(),
+		}
+	}
+	if ts == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if ts.MaxIdleConnsPerHost != nil && *ts.MaxIdleConnsPerHost < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*ts.MaxIdleConnsPerHost, "maxIdleConnsPerHost"))
+	}
+	errs = errs.Also(validateDuration(ts.IdleConnTimeout, "idleConnTimeout"))
+	errs = errs.Also(validateDuration(ts.KeepAlive, "keepAlive"))
+	if ts.H2C {
+		// HTTP/2 multiplexes requests on a single connection per host.
+		var conflicts []string
+		if ts.MaxIdleConnsPerHost != nil {
+			conflicts = append(conflicts, "maxIdleConnsPerHost")
+		}
+		if ts.IdleConnTimeout != nil {
+			conflicts = append(conflicts, "idleConnTimeout")
+		}
+		if ts.TLS != nil {
+			conflicts = append(conflicts, "tls")
+		}
+		if len(conflicts) > 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: "fields can't be used with h2c",
+				Paths:   conflicts,
+			})
+		}
+	}
+	if ts.TLS != nil {
+		errs = errs.Also(ts.TLS.Validate(ctx).ViaField("tls"))
+	}
+	return errs
+}
+
+// Validate verifies that the TransportTLS is valid.
+func (tls *TransportTLS) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if tls.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(tls.CABundle)) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "no PEM-encoded certificate found",
+			Paths:   []string{"caBundle"},
+		})
+	}
+	if tls.ClientCertificateSecret != "" {
+		if msgs := validation.IsDNS1123Subdomain(tls.ClientCertificateSecret); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(tls.ClientCertificateSecret, "clientCertificateSecret"))
+		}
+	}
+	return errs
+}
+
+func validateDuration(d *string, field string) *apis.FieldError {
+	if d == nil {
+		return nil
+	}
+	if _, err := utils.ParseISO8601Duration(*d); err != nil {
+		return apis.ErrInvalidValue(*d, field)
+	}
+	return nil
+}
+
+// Validate verifies that the TriggerFilter is valid.
+func (f *TriggerFilter) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	empty := true
+	if f.Exact != nil {
+		empty = false
+		errs = errs.Also(validateAttributes(f.Exact, false).ViaField("exact"))
+	}
+	if f.Prefix != nil {
+		empty = false
+		errs = errs.Also(validateAttributes(f.Prefix, true).ViaField("prefix"))
+	}
+	if f.Suffix != nil {
+		empty = false
+		errs = errs.Also(validateAttributes(f.Suffix, true).ViaField("suffix"))
+	}
+	if f.Exists != nil {
+		empty = false
+		for i, name := range f.Exists {
+			if !validAttributeName.MatchString(name) {
+				errs = errs.Also(apis.ErrInvalidArrayValue(name, "exists", i))
+			}
+		}
+	}
+	if f.Not != nil {
+		empty = false
+		errs = errs.Also(f.Not.Validate(ctx).ViaField("not"))
+	}
+	if f.Any != nil {
+		empty = false
+		for i, sub := range f.Any {
+			errs = errs.Also(sub.Validate(ctx).ViaFieldIndex("any", i))
+		}
+	}
+	if f.All != nil {
+		empty = false
+		for i, sub := range f.All {
+			errs = errs.Also(sub.Validate(ctx).ViaFieldIndex("all", i))
+		}
+	}
+	if f.Expression != "" {
+		empty = false
+		if _, err := expr.Parse(f.Expression); err != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "invalid expression",
+				Paths:   []string{"expression"},
+				Details: err.Error(),
+			})
+		}
+	}
+	if empty {
+		errs = errs.Also(apis.ErrGeneric("expected at least one filter", apis.CurrentField))
+	}
+	return errs
+}
+
+func validateAttributes(attrs map[string]string, nonEmpty bool) *apis.FieldError {
+	var errs *apis.FieldError
+	for name, value := range attrs {
+		if !validAttributeName.MatchString(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, apis.CurrentField, "Attribute name must consist of lowercase letters and digits"))
+		} else if nonEmpty && value == "" {
+			errs = errs.Also(apis.ErrInvalidValue(value, apis.CurrentField).ViaKey(name))
+		}
+	}
+	return errs
+}
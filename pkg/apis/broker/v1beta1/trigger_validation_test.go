@@ -18,12 +18,345 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 )
 
+// testCABundle is a self-signed certificate for example.com.
+const testCABundle = `-----BEGIN CERTIFICATE-----
+MIIBhDCCASmgAwIBAgIUS5fyrLiQLLJjjagPareRpe/jTHYwCgYIKoZIzj0EAwIw
+FjEUMBIGA1UEAwwLZXhhbXBsZS5jb20wIBcNMjYxMDE3MDE1MzQwWhgPMjEyNjA5
+MjMwMTUzNDBaMBYxFDASBgNVBAMMC2V4YW1wbGUuY29tMFkwEwYHKoZIzj0CAQYI
+KoZIzj0DAQcDQgAEiqeEIpVjIOObyn3hVGyNUR3/YBnHMwhAqSSK8w3jyIh+fMyd
+h7GJCwV73nwIYyPZasi1SFFCUeB5wvclR7juOqNTMFEwHQYDVR0OBBYEFEAe/y6K
+j8XelIw6MrecHetPqrL2MB8GA1UdIwQYMBaAFEAe/y6Kj8XelIw6MrecHetPqrL2
+MA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSQAwRgIhAOUiYPAxXIcRMLw5
+fxIn3dedryp6tZxQjj0/n9xxshT/AiEA9bKmIOOqVShWVcLGes7MXonlSQFY+8Qc
+n60i3+ZzCQI=
+-----END CERTIFICATE-----
+`
+
 func TestTrigger_Validate(t *testing.T) {
 	trig := Trigger{}
 	if err := trig.Validate(context.TODO()); err != nil {
 		t.Errorf("expected nil, got %v", err)
 	}
 }
+
+func TestTrigger_ValidateBrokerClass(t *testing.T) {
+	brokers := map[string]*Broker{
+		"gcp": {ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{eventingv1beta1.BrokerClassAnnotationKey: BrokerClass},
+		}},
+		"mt": {ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{eventingv1beta1.BrokerClassAnnotationKey: "MTChannelBasedBroker"},
+		}},
+	}
+	ctx := WithBrokerGetter(context.Background(), func(namespace, name string) (*Broker, error) {
+		if b, ok := brokers[name]; ok {
+			return b, nil
+		}
+		return nil, apierrs.NewNotFound(Resource("brokers"), name)
+	})
+	tests := []struct {
+		name    string
+		broker  string
+		wantErr bool
+	}{{
+		name:    "google cloud broker",
+		broker:  "gcp",
+		wantErr: true,
+	}, {
+		name:   "other broker class",
+		broker: "mt",
+	}, {
+		name:    "broker not found",
+		broker:  "missing",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Annotations: map[string]string{FiltersAnnotation: "not json"},
+				},
+				Spec: eventingv1beta1.TriggerSpec{Broker: test.broker},
+			}
+			err := trig.Validate(ctx)
+			if test.wantErr != (err != nil) {
+				t.Errorf("unexpected error, want error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTrigger_ValidateFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters string
+		want    string
+	}{{
+		name:    "valid filters",
+		filters: `[{"exact":{"source":"a"}},{"prefix":{"type":"com.example."}},{"suffix":{"subject":".png"}},{"exists":["myext"]}]`,
+	}, {
+		name:    "valid nested filters",
+		filters: `[{"not":{"any":[{"prefix":{"type":"a"}},{"all":[{"suffix":{"type":"b"}},{"expression":"myext > 3"}]}]}}]`,
+	}, {
+		name:    "no filters",
+		filters: `[]`,
+	}, {
+		name:    "invalid json",
+		filters: `{"prefix":{"type":"a"}}`,
+		want:    "invalid filters: metadata.annotations.[trigger.events.cloud.google.com/filters]",
+	}, {
+		name:    "unknown field",
+		filters: `[{"startsWith":{"type":"a"}}]`,
+		want:    "invalid filters: metadata.annotations.[trigger.events.cloud.google.com/filters]",
+	}, {
+		name:    "empty filter",
+		filters: `[{"prefix":{"type":"a"}},{}]`,
+		want:    "expected at least one filter: metadata.annotations.[trigger.events.cloud.google.com/filters][1]",
+	}, {
+		name:    "invalid attribute name",
+		filters: `[{"prefix":{"Type":"a"}}]`,
+		want:    "invalid key name \"Type\": metadata.annotations.[trigger.events.cloud.google.com/filters][0].prefix",
+	}, {
+		name:    "empty prefix",
+		filters: `[{"prefix":{"type":""}}]`,
+		want:    "invalid value: : metadata.annotations.[trigger.events.cloud.google.com/filters][0].prefix[type]",
+	}, {
+		name:    "invalid exists",
+		filters: `[{"exists":["my-ext"]}]`,
+		want:    "invalid value: my-ext: metadata.annotations.[trigger.events.cloud.google.com/filters][0].exists[0]",
+	}, {
+		name:    "invalid nested expression",
+		filters: `[{"any":[{"prefix":{"type":"a"}},{"expression":"type LIKE"}]}]`,
+		want:    "invalid expression: metadata.annotations.[trigger.events.cloud.google.com/filters][0].any[1].expression",
+	}, {
+		name:    "invalid not",
+		filters: `[{"not":{}}]`,
+		want:    "expected at least one filter: metadata.annotations.[trigger.events.cloud.google.com/filters][0].not",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{FiltersAnnotation: test.filters},
+				},
+			}
+			err := trig.Validate(context.TODO())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			// Only compare the message and the path, the details come from the parsers.
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrigger_ValidateDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery string
+		want     string
+	}{{
+		name:     "valid delivery spec",
+		delivery: `{"retry":5,"backoffPolicy":"linear","backoffDelay":"PT0.5S"}`,
+	}, {
+		name:     "valid dead letter sink",
+		delivery: `{"deadLetterSink":{"uri":"http://example.com"}}`,
+	}, {
+		name:     "empty delivery spec",
+		delivery: `{}`,
+	}, {
+		name:     "invalid json",
+		delivery: `[]`,
+		want:     "invalid delivery spec: metadata.annotations.[trigger.events.cloud.google.com/delivery]",
+	}, {
+		name:     "unknown field",
+		delivery: `{"retries":5}`,
+		want:     "invalid delivery spec: metadata.annotations.[trigger.events.cloud.google.com/delivery]",
+	}, {
+		name:     "negative retry",
+		delivery: `{"retry":-1}`,
+		want:     "invalid value: -1: metadata.annotations.[trigger.events.cloud.google.com/delivery].retry",
+	}, {
+		name:     "invalid backoff policy",
+		delivery: `{"backoffPolicy":"random"}`,
+		want:     "invalid value: random: metadata.annotations.[trigger.events.cloud.google.com/delivery].backoffPolicy",
+	}, {
+		name:     "invalid backoff delay",
+		delivery: `{"backoffDelay":"10s"}`,
+		want:     "invalid value: 10s: metadata.annotations.[trigger.events.cloud.google.com/delivery].backoffDelay",
+	}, {
+		name:     "invalid dead letter sink",
+		delivery: `{"deadLetterSink":{}}`,
+		want:     "expected at least one, got none: metadata.annotations.[trigger.events.cloud.google.com/delivery].deadLetterSink.ref",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{DeliveryAnnotation: test.delivery},
+				},
+			}
+			err := trig.Validate(context.TODO())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrigger_ValidateReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		want  string
+	}{{
+		name:  "broker destination",
+		reply: `{"destination":{"ref":{"apiVersion":"eventing.knative.dev/v1beta1","kind":"Broker","name":"other","namespace":"ns"}}}`,
+	}, {
+		name:  "uri destination",
+		reply: `{"destination":{"uri":"http://example.com"}}`,
+	}, {
+		name:  "drop",
+		reply: `{"drop":true}`,
+	}, {
+		name:  "invalid json",
+		reply: `[]`,
+		want:  "invalid reply spec: metadata.annotations.[trigger.events.cloud.google.com/reply]",
+	}, {
+		name:  "unknown field",
+		reply: `{"uri":"http://example.com"}`,
+		want:  "invalid reply spec: metadata.annotations.[trigger.events.cloud.google.com/reply]",
+	}, {
+		name:  "empty reply spec",
+		reply: `{}`,
+		want:  "expected exactly one, got neither: metadata.annotations.[trigger.events.cloud.google.com/reply].destination, metadata.annotations.[trigger.events.cloud.google.com/reply].drop",
+	}, {
+		name:  "destination and drop",
+		reply: `{"destination":{"uri":"http://example.com"},"drop":true}`,
+		want:  "expected exactly one, got both: metadata.annotations.[trigger.events.cloud.google.com/reply].destination, metadata.annotations.[trigger.events.cloud.google.com/reply].drop",
+	}, {
+		name:  "invalid destination",
+		reply: `{"destination":{}}`,
+		want:  "expected at least one, got none: metadata.annotations.[trigger.events.cloud.google.com/reply].destination.ref",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ReplyAnnotation: test.reply},
+				},
+			}
+			err := trig.Validate(context.TODO())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTrigger_ValidateTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport string
+		want      string
+	}{{
+		name:      "h2c",
+		transport: `{"h2c":true,"keepAlive":"PT30S"}`,
+	}, {
+		name:      "connection pooling",
+		transport: `{"maxIdleConnsPerHost":10,"idleConnTimeout":"PT90S","keepAlive":"PT15S"}`,
+	}, {
+		name:      "tls",
+		transport: fmt.Sprintf(`{"tls":{"caBundle":%q,"clientCertificateSecret":"mesh"}}`, testCABundle),
+	}, {
+		name:      "invalid json",
+		transport: `[]`,
+		want:      "invalid transport spec: metadata.annotations.[trigger.events.cloud.google.com/transport]",
+	}, {
+		name:      "unknown field",
+		transport: `{"http2":true}`,
+		want:      "invalid transport spec: metadata.annotations.[trigger.events.cloud.google.com/transport]",
+	}, {
+		name:      "negative max idle connections",
+		transport: `{"maxIdleConnsPerHost":-1}`,
+		want:      "invalid value: -1: metadata.annotations.[trigger.events.cloud.google.com/transport].maxIdleConnsPerHost",
+	}, {
+		name:      "invalid idle connection timeout",
+		transport: `{"idleConnTimeout":"90s"}`,
+		want:      "invalid value: 90s: metadata.annotations.[trigger.events.cloud.google.com/transport].idleConnTimeout",
+	}, {
+		name:      "invalid keep alive",
+		transport: `{"keepAlive":"PT"}`,
+		want:      "invalid value: PT: metadata.annotations.[trigger.events.cloud.google.com/transport].keepAlive",
+	}, {
+		name:      "h2c with connection pooling and tls",
+		transport: `{"h2c":true,"maxIdleConnsPerHost":10,"tls":{}}`,
+		want:      "fields can't be used with h2c: metadata.annotations.[trigger.events.cloud.google.com/transport].maxIdleConnsPerHost, metadata.annotations.[trigger.events.cloud.google.com/transport].tls",
+	}, {
+		name:      "invalid ca bundle",
+		transport: `{"tls":{"caBundle":"not a certificate"}}`,
+		want:      "no PEM-encoded certificate found: metadata.annotations.[trigger.events.cloud.google.com/transport].tls.caBundle",
+	}, {
+		name:      "invalid client certificate",
+		transport: `{"tls":{"clientCertificateSecret":"mesh/cert"}}`,
+		want:      "invalid value: mesh/cert: metadata.annotations.[trigger.events.cloud.google.com/transport].tls.clientCertificateSecret",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trig := Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{TransportAnnotation: test.transport},
+				},
+			}
+			err := trig.Validate(context.TODO())
+			if test.want == "" {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", test.want)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.want) {
+				t.Errorf("unexpected error, want prefix %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -35,6 +35,13 @@ func (source *CloudAuditLogsSource) ConvertTo(_ context.Context, to apis.Convert
 		sink.Spec.ServiceName = source.Spec.ServiceName
 		sink.Spec.MethodName = source.Spec.MethodName
 		sink.Spec.ResourceName = source.Spec.ResourceName
+		sink.Spec.MethodNames = source.Spec.MethodNames
+		sink.Spec.ResourceNamePrefix = source.Spec.ResourceNamePrefix
+		sink.Spec.PrincipalEmails = source.Spec.PrincipalEmails
+		sink.Spec.Severity = source.Spec.Severity
+		sink.Spec.AdvancedFilter = source.Spec.AdvancedFilter
+		sink.Spec.Scope = source.Spec.Scope
+		sink.Spec.ScopeID = source.Spec.ScopeID
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.StackdriverSink = source.Status.StackdriverSink
 		return nil
@@ -54,6 +61,13 @@ func (sink *CloudAuditLogsSource) ConvertFrom(_ context.Context, from apis.Conve
 		sink.Spec.ServiceName = source.Spec.ServiceName
 		sink.Spec.MethodName = source.Spec.MethodName
 		sink.Spec.ResourceName = source.Spec.ResourceName
+		sink.Spec.MethodNames = source.Spec.MethodNames
+		sink.Spec.ResourceNamePrefix = source.Spec.ResourceNamePrefix
+		sink.Spec.PrincipalEmails = source.Spec.PrincipalEmails
+		sink.Spec.Severity = source.Spec.Severity
+		sink.Spec.AdvancedFilter = source.Spec.AdvancedFilter
+		sink.Spec.Scope = source.Spec.Scope
+		sink.Spec.ScopeID = source.Spec.ScopeID
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.StackdriverSink = source.Status.StackdriverSink
 		return nil
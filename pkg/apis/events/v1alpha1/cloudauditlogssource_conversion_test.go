@@ -150,10 +150,17 @@ var (
 	completeCloudAuditLogsSource = &CloudAuditLogsSource{
 		ObjectMeta: completeObjectMeta,
 		Spec: CloudAuditLogsSourceSpec{
-			PubSubSpec:   completePubSubSpec,
-			ServiceName:  "serviceName",
-			MethodName:   "methodName",
-			ResourceName: "resourceName",
+			PubSubSpec:         completePubSubSpec,
+			ServiceName:        "serviceName",
+			MethodName:         "methodName",
+			MethodNames:        []string{"methodName1", "methodName2"},
+			ResourceName:       "resourceName",
+			ResourceNamePrefix: "resourceNamePrefix",
+			PrincipalEmails:    []string{"principal@example.com"},
+			Severity:           "NOTICE",
+			AdvancedFilter:     "advancedFilter",
+			Scope:              "scope",
+			ScopeID:            "scopeId",
 		},
 		Status: CloudAuditLogsSourceStatus{
 			PubSubStatus:    completePubSubStatus,
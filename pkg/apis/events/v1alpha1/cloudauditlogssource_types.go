@@ -90,14 +90,49 @@ type CloudAuditLogsSourceSpec struct {
 	// The GCP service providing audit logs. Required.
 	ServiceName string `json:"serviceName"`
 	// The name of the service method or operation. For API calls,
-	// this should be the name of the API method. Required.
-	MethodName string `json:"methodName"`
+	// this should be the name of the API method. Either MethodName or
+	// MethodNames is required.
+	MethodName string `json:"methodName,omitempty"`
+	// The names of the service methods or operations, any of which
+	// is matched.
+	MethodNames []string `json:"methodNames,omitempty"`
 	// The resource or collection that is the target of the
 	// operation. The name is a scheme-less URI, not including the
 	// API service name.
 	ResourceName string `json:"resourceName,omitempty"`
+	// The prefix of the names of the resources or collections that
+	// are the target of the operation. Can't be set with ResourceName.
+	ResourceNamePrefix string `json:"resourceNamePrefix,omitempty"`
+	// The emails of the principals making the operation, any of
+	// which is matched.
+	PrincipalEmails []string `json:"principalEmails,omitempty"`
+	// The minimum severity of the audit logs, e.g. NOTICE or ERROR.
+	Severity string `json:"severity,omitempty"`
+	// An advanced Cloud Logging filter which the audit logs must
+	// also match. See https://cloud.google.com/logging/docs/view/advanced-queries.
+	AdvancedFilter string `json:"advancedFilter,omitempty"`
+
+	// Scope of the Cloud Logging sink which exports the audit logs. One of
+	// project (the default), folder or organization. The folder and
+	// organization scopes export the audit logs of all the projects in the
+	// folder or organization.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+	// ScopeID is the numeric ID of the folder or organization. Required for
+	// the folder and organization scopes.
+	// +optional
+	ScopeID string `json:"scopeId,omitempty"`
 }
 
+const (
+	// CloudAuditLogsSourceScopeProject exports the audit logs of the project of the source.
+	CloudAuditLogsSourceScopeProject = "project"
+	// CloudAuditLogsSourceScopeFolder exports the audit logs of the projects in a folder.
+	CloudAuditLogsSourceScopeFolder = "folder"
+	// CloudAuditLogsSourceScopeOrganization exports the audit logs of the projects in an organization.
+	CloudAuditLogsSourceScopeOrganization = "organization"
+)
+
 type CloudAuditLogsSourceStatus struct {
 	duckv1alpha1.PubSubStatus `json:",inline"`
 
@@ -32,6 +32,10 @@ func (source *CloudBuildSource) ConvertTo(_ context.Context, to apis.Convertible
 	case *v1beta1.CloudBuildSource:
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec.PubSubSpec = convert.ToV1beta1PubSubSpec(source.Spec.PubSubSpec)
+		sink.Spec.BuildStatuses = source.Spec.BuildStatuses
+		sink.Spec.TriggerIDs = source.Spec.TriggerIDs
+		sink.Spec.TriggerNames = source.Spec.TriggerNames
+		sink.Spec.Tags = source.Spec.Tags
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		return nil
 	default:
@@ -47,6 +51,10 @@ func (sink *CloudBuildSource) ConvertFrom(_ context.Context, from apis.Convertib
 	case *v1beta1.CloudBuildSource:
 		sink.ObjectMeta = source.ObjectMeta
 		sink.Spec.PubSubSpec = convert.FromV1beta1PubSubSpec(source.Spec.PubSubSpec)
+		sink.Spec.BuildStatuses = source.Spec.BuildStatuses
+		sink.Spec.TriggerIDs = source.Spec.TriggerIDs
+		sink.Spec.TriggerNames = source.Spec.TriggerNames
+		sink.Spec.Tags = source.Spec.Tags
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		return nil
 	default:
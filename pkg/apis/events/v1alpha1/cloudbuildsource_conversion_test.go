@@ -37,7 +37,11 @@ var (
 	completeCloudBuildSource = &CloudBuildSource{
 		ObjectMeta: completeObjectMeta,
 		Spec: CloudBuildSourceSpec{
-			PubSubSpec: completePubSubSpec,
+			PubSubSpec:    completePubSubSpec,
+			BuildStatuses: []string{"FAILURE", "TIMEOUT"},
+			TriggerIDs:    []string{"triggerId"},
+			TriggerNames:  []string{"triggerName"},
+			Tags:          []string{"tag"},
 		},
 		Status: CloudBuildSourceStatus{
 			PubSubStatus: completePubSubStatus,
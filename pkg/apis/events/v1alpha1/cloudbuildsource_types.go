@@ -64,6 +64,27 @@ type CloudBuildSourceSpec struct {
 	// It is optional. Defaults to 'cloud-builds' and the topic must be 'cloud-builds'
 	// +optional
 	Topic *string `json:"topic,omitempty"`
+
+	// BuildStatuses limits the events sent to the sink to builds with one of
+	// these statuses, e.g. FAILURE and TIMEOUT. If unspecified, the events of
+	// builds with any status are sent.
+	// +optional
+	BuildStatuses []string `json:"buildStatuses,omitempty"`
+
+	// TriggerIDs limits the events sent to the sink to builds started by one of
+	// the build triggers with these IDs, or with the names in TriggerNames.
+	// +optional
+	TriggerIDs []string `json:"triggerIds,omitempty"`
+
+	// TriggerNames limits the events sent to the sink to builds started by one of
+	// the build triggers with these names, or with the IDs in TriggerIDs.
+	// +optional
+	TriggerNames []string `json:"triggerNames,omitempty"`
+
+	// Tags limits the events sent to the sink to builds with at least one of
+	// these tags.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
 }
 
 const (
@@ -75,6 +96,19 @@ const (
 	CloudBuildSourceBuildStatus = "status"
 )
 
+// The statuses of Cloud Build builds.
+const (
+	CloudBuildSourceBuildStatusUnknown       = "STATUS_UNKNOWN"
+	CloudBuildSourceBuildStatusQueued        = "QUEUED"
+	CloudBuildSourceBuildStatusWorking       = "WORKING"
+	CloudBuildSourceBuildStatusSuccess       = "SUCCESS"
+	CloudBuildSourceBuildStatusFailure       = "FAILURE"
+	CloudBuildSourceBuildStatusInternalError = "INTERNAL_ERROR"
+	CloudBuildSourceBuildStatusTimeout       = "TIMEOUT"
+	CloudBuildSourceBuildStatusCancelled     = "CANCELLED"
+	CloudBuildSourceBuildStatusExpired       = "EXPIRED"
+)
+
 // CloudBuildSourceEventSource returns the Cloud Build CloudEvent source value.
 func CloudBuildSourceEventSource(googleCloudProject, buildId string) string {
 	return fmt.Sprintf("//cloudbuild.googleapis.com/projects/%s/builds/%s", googleCloudProject, buildId)
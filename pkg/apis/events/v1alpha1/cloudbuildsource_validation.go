@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
@@ -27,6 +28,19 @@ import (
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
 )
 
+// buildStatuses are the statuses of Cloud Build builds.
+var buildStatuses = sets.NewString(
+	CloudBuildSourceBuildStatusUnknown,
+	CloudBuildSourceBuildStatusQueued,
+	CloudBuildSourceBuildStatusWorking,
+	CloudBuildSourceBuildStatusSuccess,
+	CloudBuildSourceBuildStatusFailure,
+	CloudBuildSourceBuildStatusInternalError,
+	CloudBuildSourceBuildStatusTimeout,
+	CloudBuildSourceBuildStatusCancelled,
+	CloudBuildSourceBuildStatusExpired,
+)
+
 func (current *CloudBuildSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := current.Spec.Validate(ctx).ViaField("spec")
 	return duckv1alpha1.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
@@ -46,6 +60,27 @@ func (current *CloudBuildSourceSpec) Validate(ctx context.Context) *apis.FieldEr
 		errs = errs.Also(err.ViaField("sink"))
 	}
 
+	for i, status := range current.BuildStatuses {
+		if !buildStatuses.Has(status) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(status, "buildStatuses", i))
+		}
+	}
+	for i, id := range current.TriggerIDs {
+		if id == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(id, "triggerIds", i))
+		}
+	}
+	for i, name := range current.TriggerNames {
+		if name == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(name, "triggerNames", i))
+		}
+	}
+	for i, tag := range current.Tags {
+		if tag == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(tag, "tags", i))
+		}
+	}
+
 	if err := duckv1alpha1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
@@ -62,7 +97,7 @@ func (current *CloudBuildSource) CheckImmutableFields(ctx context.Context, origi
 	// Modification of Topic, Secret and Project are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudBuildSourceSpec{},
-			"Sink", "CloudEventOverrides")); diff != "" {
+			"Sink", "CloudEventOverrides", "BuildStatuses", "TriggerIDs", "TriggerNames", "Tags")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
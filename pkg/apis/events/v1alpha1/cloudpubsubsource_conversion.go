@@ -36,6 +36,7 @@ func (source *CloudPubSubSource) ConvertTo(_ context.Context, to apis.Convertibl
 		sink.Spec.AckDeadline = source.Spec.AckDeadline
 		sink.Spec.RetainAckedMessages = source.Spec.RetainAckedMessages
 		sink.Spec.RetentionDuration = source.Spec.RetentionDuration
+		sink.Spec.EventMapping = (*v1beta1.CloudPubSubSourceEventMapping)(source.Spec.EventMapping)
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		return nil
 	default:
@@ -55,6 +56,7 @@ func (sink *CloudPubSubSource) ConvertFrom(_ context.Context, from apis.Converti
 		sink.Spec.AckDeadline = source.Spec.AckDeadline
 		sink.Spec.RetainAckedMessages = source.Spec.RetainAckedMessages
 		sink.Spec.RetentionDuration = source.Spec.RetentionDuration
+		sink.Spec.EventMapping = (*CloudPubSubSourceEventMapping)(source.Spec.EventMapping)
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		return nil
 	default:
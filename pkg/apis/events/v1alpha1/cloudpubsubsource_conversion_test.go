@@ -45,6 +45,14 @@ var (
 			AckDeadline:         &ackDeadline,
 			RetainAckedMessages: true,
 			RetentionDuration:   &retentionDuration,
+			EventMapping: &CloudPubSubSourceEventMapping{
+				Type:       "${attributes.type}",
+				Source:     "//example.com/${attributes.app}",
+				Subject:    "${data.subject}",
+				ID:         "${attributes.id}",
+				Extensions: map[string]string{"tenant": "${data.tenant}"},
+				UnwrapData: true,
+			},
 		},
 		Status: CloudPubSubSourceStatus{
 			PubSubStatus: completePubSubStatus,
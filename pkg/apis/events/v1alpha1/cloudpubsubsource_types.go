@@ -87,6 +87,49 @@ type CloudPubSubSourceSpec struct {
 	// shorter than 10 minutes. Defaults to 7 days ('7d').
 	// +optional
 	RetentionDuration *string `json:"retentionDuration,omitempty"`
+
+	// EventMapping configures how the Pub/Sub messages are mapped to
+	// CloudEvents. If not set, the events have the type
+	// com.google.cloud.pubsub.topic.publish and the Pub/Sub push envelope of
+	// the message as data.
+	// +optional
+	EventMapping *CloudPubSubSourceEventMapping `json:"eventMapping,omitempty"`
+}
+
+// CloudPubSubSourceEventMapping maps the attributes and data of Pub/Sub
+// messages to CloudEvent attributes.
+//
+// The attributes are templates, in which ${attributes.<name>} is replaced by
+// the value of the message attribute <name>, and ${data.<path>} by the value
+// at the dot-separated <path> of the JSON message data. If a referenced value
+// is missing, the attribute keeps its default value, and an extension or the
+// subject is not set.
+type CloudPubSubSourceEventMapping struct {
+	// Type is the template of the type of the events.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source is the template of the source of the events.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Subject is the template of the subject of the events.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// ID is the template of the ID of the events.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Extensions are the templates of the extensions of the events, keyed by
+	// extension name.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// UnwrapData sets the data of the events to the data of the messages,
+	// instead of their Pub/Sub push envelope.
+	// +optional
+	UnwrapData bool `json:"unwrapData,omitempty"`
 }
 
 // GetAckDeadline parses AckDeadline and returns the default if an error occurs.
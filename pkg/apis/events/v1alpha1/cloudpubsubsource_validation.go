@@ -18,6 +18,9 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -39,6 +42,32 @@ const (
 	maxAckDeadline = 10 * time.Minute // 10 minutes.
 )
 
+var (
+	// eventMappingReferenceRegexp matches the references of event mapping templates.
+	eventMappingReferenceRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+	// eventMappingValueRegexp matches the values which can be referenced by event
+	// mapping templates.
+	eventMappingValueRegexp = regexp.MustCompile(`^(attributes\.[^${}]+|data(\.[^.${}]+)+)$`)
+
+	// extensionNameRegexp matches the valid CloudEvent extension names.
+	extensionNameRegexp = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+	// reservedEventAttributes are the CloudEvent attributes which can't be used as
+	// extension names.
+	reservedEventAttributes = map[string]bool{
+		"id":              true,
+		"source":          true,
+		"specversion":     true,
+		"type":            true,
+		"datacontenttype": true,
+		"dataschema":      true,
+		"subject":         true,
+		"time":            true,
+		"data":            true,
+	}
+)
+
 func (current *CloudPubSubSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := current.Spec.Validate(ctx).ViaField("spec")
 	return duckv1alpha1.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
@@ -77,6 +106,10 @@ func (current *CloudPubSubSourceSpec) Validate(ctx context.Context) *apis.FieldE
 		}
 	}
 
+	if current.EventMapping != nil {
+		errs = errs.Also(current.EventMapping.Validate(ctx).ViaField("eventMapping"))
+	}
+
 	if err := duckv1alpha1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
@@ -84,6 +117,51 @@ func (current *CloudPubSubSourceSpec) Validate(ctx context.Context) *apis.FieldE
 	return errs
 }
 
+func (current *CloudPubSubSourceEventMapping) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for field, template := range map[string]string{
+		"type":    current.Type,
+		"source":  current.Source,
+		"subject": current.Subject,
+		"id":      current.ID,
+	} {
+		errs = errs.Also(validateEventMappingTemplate(template, field))
+	}
+	for name, template := range current.Extensions {
+		if !extensionNameRegexp.MatchString(name) || reservedEventAttributes[name] {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "extensions",
+				"extension names must consist of 1 to 20 lowercase letters or digits, and must not be CloudEvent attributes"))
+		} else if template == "" {
+			errs = errs.Also(apis.ErrMissingField(apis.CurrentField).ViaKey(name).ViaField("extensions"))
+		} else {
+			errs = errs.Also(validateEventMappingTemplate(template, apis.CurrentField).ViaKey(name).ViaField("extensions"))
+		}
+	}
+	return errs
+}
+
+// validateEventMappingTemplate checks that the references of the template are
+// to message attributes or paths of the message data, and are closed.
+func validateEventMappingTemplate(template, field string) *apis.FieldError {
+	for _, match := range eventMappingReferenceRegexp.FindAllStringSubmatch(template, -1) {
+		if !eventMappingValueRegexp.MatchString(match[1]) {
+			return &apis.FieldError{
+				Message: "invalid value: " + template,
+				Paths:   []string{field},
+				Details: fmt.Sprintf("%q must reference ${attributes.<name>} or ${data.<path>}", match[0]),
+			}
+		}
+	}
+	if strings.Contains(eventMappingReferenceRegexp.ReplaceAllString(template, ""), "${") {
+		return &apis.FieldError{
+			Message: "invalid value: " + template,
+			Paths:   []string{field},
+			Details: "the template has an unclosed reference",
+		}
+	}
+	return nil
+}
+
 func (current *CloudPubSubSource) CheckImmutableFields(ctx context.Context, original *CloudPubSubSource) *apis.FieldError {
 	if original == nil {
 		return nil
@@ -93,7 +171,7 @@ func (current *CloudPubSubSource) CheckImmutableFields(ctx context.Context, orig
 	// Modification of Topic, Secret, ServiceAccount, and Project are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudPubSubSourceSpec{},
-			"Sink", "AckDeadline", "RetainAckedMessages", "RetentionDuration", "CloudEventOverrides")); diff != "" {
+			"Sink", "AckDeadline", "RetainAckedMessages", "RetentionDuration", "CloudEventOverrides", "EventMapping")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -198,6 +198,39 @@ func TestCloudPubSubSourceCheckValidationFields(t *testing.T) {
 			}(),
 			error: false,
 		},
+		"event mapping": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type:       "com.example.${attributes.eventType}",
+					Subject:    "orders/${data.order.id}",
+					Extensions: map[string]string{"tenant": "${data.tenant}"},
+					UnwrapData: true,
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad event mapping, unknown reference": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type: "${headers.type}",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, extension name": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Extensions: map[string]string{"Tenant-ID": "${data.tenant}"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
 		"invalid k8s service account": {
 			spec: func() CloudPubSubSourceSpec {
 				obj := pubSubSourceSpec.DeepCopy()
@@ -458,6 +491,18 @@ func TestCloudPubSubSourceCheckImmutableFields(t *testing.T) {
 			updated: pubSubSourceSpec,
 			allowed: true,
 		},
+		"EventMapping changed": {
+			orig: &pubSubSourceSpec,
+			updated: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type:       "${attributes.eventType}",
+					UnwrapData: true,
+				}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"not spec": {
 			orig:    []string{"wrong"},
 			updated: pubSubSourceSpec,
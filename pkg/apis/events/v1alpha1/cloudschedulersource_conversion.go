@@ -35,6 +35,19 @@ func (source *CloudSchedulerSource) ConvertTo(_ context.Context, to apis.Convert
 		sink.Spec.Location = source.Spec.Location
 		sink.Spec.Schedule = source.Spec.Schedule
 		sink.Spec.Data = source.Spec.Data
+		sink.Spec.TimeZone = source.Spec.TimeZone
+		if rc := source.Spec.RetryConfig; rc != nil {
+			sink.Spec.RetryConfig = &v1beta1.SchedulerRetryConfig{
+				RetryCount:         rc.RetryCount,
+				MaxRetryDuration:   rc.MaxRetryDuration,
+				MinBackoffDuration: rc.MinBackoffDuration,
+				MaxBackoffDuration: rc.MaxBackoffDuration,
+				MaxDoublings:       rc.MaxDoublings,
+			}
+		}
+		sink.Spec.AttemptDeadline = source.Spec.AttemptDeadline
+		sink.Spec.Paused = source.Spec.Paused
+		sink.Spec.Delivery = v1beta1.SchedulerDelivery(source.Spec.Delivery)
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.JobName = source.Status.JobName
 		return nil
@@ -54,6 +67,19 @@ func (sink *CloudSchedulerSource) ConvertFrom(_ context.Context, from apis.Conve
 		sink.Spec.Location = source.Spec.Location
 		sink.Spec.Schedule = source.Spec.Schedule
 		sink.Spec.Data = source.Spec.Data
+		sink.Spec.TimeZone = source.Spec.TimeZone
+		if rc := source.Spec.RetryConfig; rc != nil {
+			sink.Spec.RetryConfig = &SchedulerRetryConfig{
+				RetryCount:         rc.RetryCount,
+				MaxRetryDuration:   rc.MaxRetryDuration,
+				MinBackoffDuration: rc.MinBackoffDuration,
+				MaxBackoffDuration: rc.MaxBackoffDuration,
+				MaxDoublings:       rc.MaxDoublings,
+			}
+		}
+		sink.Spec.AttemptDeadline = source.Spec.AttemptDeadline
+		sink.Spec.Paused = source.Spec.Paused
+		sink.Spec.Delivery = SchedulerDelivery(source.Spec.Delivery)
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.JobName = source.Status.JobName
 		return nil
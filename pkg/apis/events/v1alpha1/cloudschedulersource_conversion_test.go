@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 // These variables are used to create a 'complete' version of CloudSchedulerSource where every field is
@@ -41,6 +42,17 @@ var (
 			Location:   "location",
 			Schedule:   "schedule",
 			Data:       "data",
+			TimeZone:   "America/New_York",
+			RetryConfig: &SchedulerRetryConfig{
+				RetryCount:         3,
+				MaxRetryDuration:   ptr.String("PT1H"),
+				MinBackoffDuration: ptr.String("PT10S"),
+				MaxBackoffDuration: ptr.String("PT5M"),
+				MaxDoublings:       ptr.Int32(2),
+			},
+			AttemptDeadline: ptr.String("PT5M"),
+			Paused:          true,
+			Delivery:        SchedulerDeliveryHTTP,
 		},
 		Status: CloudSchedulerSourceStatus{
 			PubSubStatus: completePubSubStatus,
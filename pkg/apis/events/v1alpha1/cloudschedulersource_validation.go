@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,15 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
+	"github.com/google/knative-gcp/pkg/utils"
+)
+
+const (
+	// The bounds of the attempt deadline of a Cloud Scheduler job.
+	minAttemptDeadline = 15 * time.Second
+	maxAttemptDeadline = 30 * time.Minute
+	// maxRetryCount is the maximum number of retries of a Cloud Scheduler job.
+	maxRetryCount = 5
 )
 
 func (current *CloudSchedulerSource) Validate(ctx context.Context) *apis.FieldError {
@@ -59,6 +69,28 @@ func (current *CloudSchedulerSourceSpec) Validate(ctx context.Context) *apis.Fie
 		errs = errs.Also(apis.ErrMissingField("data"))
 	}
 
+	if current.TimeZone != "" {
+		if _, err := time.LoadLocation(current.TimeZone); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(current.TimeZone, "timeZone"))
+		}
+	}
+
+	if current.RetryConfig != nil {
+		errs = errs.Also(current.RetryConfig.Validate(ctx).ViaField("retryConfig"))
+	}
+
+	if current.AttemptDeadline != nil {
+		if d, err := utils.ParseISO8601Duration(*current.AttemptDeadline); err != nil || d < minAttemptDeadline || d > maxAttemptDeadline {
+			errs = errs.Also(apis.ErrInvalidValue(*current.AttemptDeadline, "attemptDeadline"))
+		}
+	}
+
+	switch current.Delivery {
+	case "", SchedulerDeliveryPubSub, SchedulerDeliveryHTTP:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.Delivery, "delivery"))
+	}
+
 	if err := duckv1alpha1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
@@ -66,15 +98,52 @@ func (current *CloudSchedulerSourceSpec) Validate(ctx context.Context) *apis.Fie
 	return errs
 }
 
+// Validate verifies that the SchedulerRetryConfig is valid.
+func (rc *SchedulerRetryConfig) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if rc.RetryCount < 0 || rc.RetryCount > maxRetryCount {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(rc.RetryCount, 0, maxRetryCount, "retryCount"))
+	}
+	if rc.MaxDoublings != nil && *rc.MaxDoublings < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*rc.MaxDoublings, "maxDoublings"))
+	}
+	durations := make(map[string]time.Duration)
+	for field, d := range map[string]*string{
+		"maxRetryDuration":   rc.MaxRetryDuration,
+		"minBackoffDuration": rc.MinBackoffDuration,
+		"maxBackoffDuration": rc.MaxBackoffDuration,
+	} {
+		if d == nil {
+			continue
+		}
+		v, err := utils.ParseISO8601Duration(*d)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*d, field))
+			continue
+		}
+		durations[field] = v
+	}
+	minBackoff, minOK := durations["minBackoffDuration"]
+	maxBackoff, maxOK := durations["maxBackoffDuration"]
+	if minOK && maxOK && minBackoff > maxBackoff {
+		errs = errs.Also(&apis.FieldError{
+			Message: "minBackoffDuration must not be greater than maxBackoffDuration",
+			Paths:   []string{"minBackoffDuration", "maxBackoffDuration"},
+		})
+	}
+	return errs
+}
+
 func (current *CloudSchedulerSource) CheckImmutableFields(ctx context.Context, original *CloudSchedulerSource) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 	var errs *apis.FieldError
-	// Modification of Location, Schedule, Data, Secret, ServiceAccount, Project are not allowed. Everything else is mutable.
+	// Modification of Location, Secret, ServiceAccount, Project are not allowed. Everything else is mutable,
+	// as the job is updated in place.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudSchedulerSourceSpec{},
-			"Sink", "CloudEventOverrides")); diff != "" {
+			"Sink", "CloudEventOverrides", "Schedule", "Data", "TimeZone", "RetryConfig", "AttemptDeadline", "Paused")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -28,6 +28,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -389,7 +390,7 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       schedulerWithSecret.Data,
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Data changed": {
 			orig: &schedulerWithSecret,
@@ -399,6 +400,31 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       "some-other-data",
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
+			allowed: true,
+		},
+		"Job options changed": {
+			orig: &schedulerWithSecret,
+			updated: CloudSchedulerSourceSpec{
+				Location:        schedulerWithSecret.Location,
+				Schedule:        schedulerWithSecret.Schedule,
+				Data:            schedulerWithSecret.Data,
+				TimeZone:        "America/New_York",
+				RetryConfig:     &SchedulerRetryConfig{RetryCount: 3},
+				AttemptDeadline: ptr.String("PT5M"),
+				Paused:          true,
+				PubSubSpec:      schedulerWithSecret.PubSubSpec,
+			},
+			allowed: true,
+		},
+		"Delivery changed": {
+			orig: &schedulerWithSecret,
+			updated: CloudSchedulerSourceSpec{
+				Location:   schedulerWithSecret.Location,
+				Schedule:   schedulerWithSecret.Schedule,
+				Data:       schedulerWithSecret.Data,
+				Delivery:   SchedulerDeliveryHTTP,
+				PubSubSpec: schedulerWithSecret.PubSubSpec,
+			},
 			allowed: false,
 		},
 		"Secret.Name changed": {
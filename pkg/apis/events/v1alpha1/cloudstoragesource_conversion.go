@@ -37,6 +37,20 @@ func (source *CloudStorageSource) ConvertTo(_ context.Context, to apis.Convertib
 		sink.Spec.EventTypes = source.Spec.EventTypes
 		sink.Spec.ObjectNamePrefix = source.Spec.ObjectNamePrefix
 		sink.Spec.PayloadFormat = source.Spec.PayloadFormat
+		sink.Spec.CustomAttributes = source.Spec.CustomAttributes
+		sink.Spec.ObjectNameSuffix = source.Spec.ObjectNameSuffix
+		if f := source.Spec.ObjectNameFilter; f != nil {
+			sink.Spec.ObjectNameFilter = &v1beta1.ObjectNameFilter{
+				Include: make([]v1beta1.ObjectNamePattern, len(f.Include)),
+				Exclude: make([]v1beta1.ObjectNamePattern, len(f.Exclude)),
+			}
+			for i, p := range f.Include {
+				sink.Spec.ObjectNameFilter.Include[i] = v1beta1.ObjectNamePattern(p)
+			}
+			for i, p := range f.Exclude {
+				sink.Spec.ObjectNameFilter.Exclude[i] = v1beta1.ObjectNamePattern(p)
+			}
+		}
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.NotificationID = source.Status.NotificationID
 		return nil
@@ -58,6 +72,20 @@ func (sink *CloudStorageSource) ConvertFrom(_ context.Context, from apis.Convert
 		sink.Spec.EventTypes = source.Spec.EventTypes
 		sink.Spec.ObjectNamePrefix = source.Spec.ObjectNamePrefix
 		sink.Spec.PayloadFormat = source.Spec.PayloadFormat
+		sink.Spec.CustomAttributes = source.Spec.CustomAttributes
+		sink.Spec.ObjectNameSuffix = source.Spec.ObjectNameSuffix
+		if f := source.Spec.ObjectNameFilter; f != nil {
+			sink.Spec.ObjectNameFilter = &ObjectNameFilter{
+				Include: make([]ObjectNamePattern, len(f.Include)),
+				Exclude: make([]ObjectNamePattern, len(f.Exclude)),
+			}
+			for i, p := range f.Include {
+				sink.Spec.ObjectNameFilter.Include[i] = ObjectNamePattern(p)
+			}
+			for i, p := range f.Exclude {
+				sink.Spec.ObjectNameFilter.Exclude[i] = ObjectNamePattern(p)
+			}
+		}
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.NotificationID = source.Status.NotificationID
 		return nil
@@ -42,6 +42,12 @@ var (
 			EventTypes:       []string{"event", "types"},
 			ObjectNamePrefix: "objectNamePrefix",
 			PayloadFormat:    "payloadFormat",
+			CustomAttributes: map[string]string{"team": "storage"},
+			ObjectNameSuffix: ".jpg",
+			ObjectNameFilter: &ObjectNameFilter{
+				Include: []ObjectNamePattern{{Glob: "images/**"}},
+				Exclude: []ObjectNamePattern{{Regex: "^images/tmp/"}},
+			},
 		},
 		Status: CloudStorageSourceStatus{
 			PubSubStatus:   completePubSubStatus,
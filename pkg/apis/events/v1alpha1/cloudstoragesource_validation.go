@@ -18,17 +18,25 @@ package v1alpha1
 
 import (
 	"context"
+	"regexp"
 
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
+var (
+	cloudEventContextAttributes = sets.NewString("id", "source", "specversion", "type", "datacontenttype",
+		"dataschema", "subject", "time", "data")
+)
+
 func (current *CloudStorageSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := current.Spec.Validate(ctx).ViaField("spec")
 	return duckv1alpha1.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
@@ -49,6 +57,23 @@ func (current *CloudStorageSourceSpec) Validate(ctx context.Context) *apis.Field
 		errs = errs.Also(apis.ErrMissingField("bucket"))
 	}
 
+	switch current.PayloadFormat {
+	case "", CloudStorageSourcePayloadFormatJSON, CloudStorageSourcePayloadFormatNone:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.PayloadFormat, "payloadFormat"))
+	}
+
+	for key := range current.CustomAttributes {
+		if !v1beta1.CustomAttributeKeyRegexp.MatchString(key) || cloudEventContextAttributes.Has(key) {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, "customAttributes",
+				"custom attribute keys must consist of lowercase letters and digits, and must not be a CloudEvent context attribute"))
+		}
+	}
+
+	if current.ObjectNameFilter != nil {
+		errs = errs.Also(current.ObjectNameFilter.Validate(ctx).ViaField("objectNameFilter"))
+	}
+
 	if err := duckv1alpha1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
@@ -56,15 +81,41 @@ func (current *CloudStorageSourceSpec) Validate(ctx context.Context) *apis.Field
 	return errs
 }
 
+func (f *ObjectNameFilter) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, p := range f.Include {
+		errs = errs.Also(p.Validate(ctx).ViaFieldIndex("include", i))
+	}
+	for i, p := range f.Exclude {
+		errs = errs.Also(p.Validate(ctx).ViaFieldIndex("exclude", i))
+	}
+	return errs
+}
+
+func (p *ObjectNamePattern) Validate(ctx context.Context) *apis.FieldError {
+	switch {
+	case p.Glob == "" && p.Regex == "":
+		return apis.ErrMissingOneOf("glob", "regex")
+	case p.Glob != "" && p.Regex != "":
+		return apis.ErrMultipleOneOf("glob", "regex")
+	case p.Regex != "":
+		if _, err := regexp.Compile(p.Regex); err != nil {
+			return apis.ErrInvalidValue(p.Regex, "regex")
+		}
+	}
+	return nil
+}
+
 func (current *CloudStorageSource) CheckImmutableFields(ctx context.Context, original *CloudStorageSource) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 	var errs *apis.FieldError
-	// Modification of EventType, Secret, ServiceAccount, Project, Bucket, ObjectNamePrefix and PayloadFormat are not allowed. Everything else is mutable.
+	// Modification of Secret, Project and Bucket are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudStorageSourceSpec{},
-			"Sink", "CloudEventOverrides", "ServiceAccountName")); diff != "" {
+			"Sink", "CloudEventOverrides", "ServiceAccountName", "EventTypes", "ObjectNamePrefix", "PayloadFormat", "CustomAttributes",
+			"ObjectNameSuffix", "ObjectNameFilter")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
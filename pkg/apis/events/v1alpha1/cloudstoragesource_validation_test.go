@@ -20,7 +20,6 @@ import (
 	"context"
 	"testing"
 
-	cloudevents "github.com/cloudevents/sdk-go"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
@@ -79,7 +78,7 @@ var (
 		Bucket:           "my-test-bucket",
 		EventTypes:       []string{CloudStorageSourceFinalize, CloudStorageSourceDelete},
 		ObjectNamePrefix: "test-prefix",
-		PayloadFormat:    cloudevents.ApplicationJSON,
+		PayloadFormat:    CloudStorageSourcePayloadFormatJSON,
 		PubSubSpec: duckv1alpha1.PubSubSpec{
 			SourceSpec: duckv1.SourceSpec{
 				Sink: duckv1.Destination{
@@ -386,7 +385,7 @@ func TestCheckImmutableFields(t *testing.T) {
 				PayloadFormat:    storageSourceSpec.PayloadFormat,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"ObjectNamePrefix changed": {
 			orig: &storageSourceSpec,
@@ -397,7 +396,7 @@ func TestCheckImmutableFields(t *testing.T) {
 				PayloadFormat:    storageSourceSpec.PayloadFormat,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"PayloadFormat changed": {
 			orig: &storageSourceSpec,
@@ -405,10 +404,22 @@ func TestCheckImmutableFields(t *testing.T) {
 				Bucket:           storageSourceSpec.Bucket,
 				EventTypes:       storageSourceSpec.EventTypes,
 				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
-				PayloadFormat:    "some-other-format",
+				PayloadFormat:    CloudStorageSourcePayloadFormatNone,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
+		},
+		"CustomAttributes changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Bucket:           storageSourceSpec.Bucket,
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				PayloadFormat:    storageSourceSpec.PayloadFormat,
+				CustomAttributes: map[string]string{"team": "storage"},
+				PubSubSpec:       storageSourceSpec.PubSubSpec,
+			},
+			allowed: true,
 		},
 		"Secret.Name changed": {
 			orig: &storageSourceSpec,
@@ -89,6 +89,16 @@ func (in *CloudAuditLogsSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudAuditLogsSourceSpec) DeepCopyInto(out *CloudAuditLogsSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.MethodNames != nil {
+		in, out := &in.MethodNames, &out.MethodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrincipalEmails != nil {
+		in, out := &in.PrincipalEmails, &out.PrincipalEmails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -189,6 +199,26 @@ func (in *CloudBuildSourceSpec) DeepCopyInto(out *CloudBuildSourceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BuildStatuses != nil {
+		in, out := &in.BuildStatuses, &out.BuildStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerIDs != nil {
+		in, out := &in.TriggerIDs, &out.TriggerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerNames != nil {
+		in, out := &in.TriggerNames, &out.TriggerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -247,6 +277,29 @@ func (in *CloudPubSubSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPubSubSourceEventMapping) DeepCopyInto(out *CloudPubSubSourceEventMapping) {
+	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPubSubSourceEventMapping.
+func (in *CloudPubSubSourceEventMapping) DeepCopy() *CloudPubSubSourceEventMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPubSubSourceEventMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudPubSubSourceList) DeepCopyInto(out *CloudPubSubSourceList) {
 	*out = *in
@@ -294,6 +347,11 @@ func (in *CloudPubSubSourceSpec) DeepCopyInto(out *CloudPubSubSourceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EventMapping != nil {
+		in, out := &in.EventMapping, &out.EventMapping
+		*out = new(CloudPubSubSourceEventMapping)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -389,6 +447,16 @@ func (in *CloudSchedulerSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudSchedulerSourceSpec) DeepCopyInto(out *CloudSchedulerSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.RetryConfig != nil {
+		in, out := &in.RetryConfig, &out.RetryConfig
+		*out = new(SchedulerRetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AttemptDeadline != nil {
+		in, out := &in.AttemptDeadline, &out.AttemptDeadline
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -489,6 +557,18 @@ func (in *CloudStorageSourceSpec) DeepCopyInto(out *CloudStorageSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CustomAttributes != nil {
+		in, out := &in.CustomAttributes, &out.CustomAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ObjectNameFilter != nil {
+		in, out := &in.ObjectNameFilter, &out.ObjectNameFilter
+		*out = new(ObjectNameFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -518,3 +598,81 @@ func (in *CloudStorageSourceStatus) DeepCopy() *CloudStorageSourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectNameFilter) DeepCopyInto(out *ObjectNameFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]ObjectNamePattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]ObjectNamePattern, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectNameFilter.
+func (in *ObjectNameFilter) DeepCopy() *ObjectNameFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectNameFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectNamePattern) DeepCopyInto(out *ObjectNamePattern) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectNamePattern.
+func (in *ObjectNamePattern) DeepCopy() *ObjectNamePattern {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectNamePattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerRetryConfig) DeepCopyInto(out *SchedulerRetryConfig) {
+	*out = *in
+	if in.MaxRetryDuration != nil {
+		in, out := &in.MaxRetryDuration, &out.MaxRetryDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinBackoffDuration != nil {
+		in, out := &in.MinBackoffDuration, &out.MinBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackoffDuration != nil {
+		in, out := &in.MaxBackoffDuration, &out.MaxBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDoublings != nil {
+		in, out := &in.MaxDoublings, &out.MaxDoublings
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerRetryConfig.
+func (in *SchedulerRetryConfig) DeepCopy() *SchedulerRetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerRetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
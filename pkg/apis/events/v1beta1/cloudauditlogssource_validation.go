@@ -18,15 +18,28 @@ package v1beta1
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// maxAdvancedFilterLength is the maximum length of the filter of a Cloud Logging sink.
+const maxAdvancedFilterLength = 20000
+
+// scopeIDRegexp matches the numeric IDs of folders and organizations.
+var scopeIDRegexp = regexp.MustCompile(`^[0-9]+$`)
+
+// logSeverities are the severities of Cloud Logging log entries.
+var logSeverities = sets.NewString("DEFAULT", "DEBUG", "INFO", "NOTICE", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY")
+
 func (current *CloudAuditLogsSource) Validate(ctx context.Context) *apis.FieldError {
 	return current.Spec.Validate(ctx).ViaField("spec")
 }
@@ -45,9 +58,56 @@ func (current *CloudAuditLogsSourceSpec) Validate(ctx context.Context) *apis.Fie
 	if current.ServiceName == "" {
 		errs = errs.Also(apis.ErrMissingField("serviceName"))
 	}
-	// MethodName [required]
-	if current.MethodName == "" {
-		errs = errs.Also(apis.ErrMissingField("methodName"))
+	// MethodName or MethodNames [required]
+	switch {
+	case current.MethodName == "" && len(current.MethodNames) == 0:
+		errs = errs.Also(apis.ErrMissingOneOf("methodName", "methodNames"))
+	case current.MethodName != "" && len(current.MethodNames) > 0:
+		errs = errs.Also(apis.ErrMultipleOneOf("methodName", "methodNames"))
+	}
+	for i, name := range current.MethodNames {
+		if name == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(name, "methodNames", i))
+		}
+	}
+
+	if current.ResourceName != "" && current.ResourceNamePrefix != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("resourceName", "resourceNamePrefix"))
+	}
+
+	for i, email := range current.PrincipalEmails {
+		if email == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(email, "principalEmails", i))
+		}
+	}
+
+	if current.Severity != "" && !logSeverities.Has(current.Severity) {
+		errs = errs.Also(apis.ErrInvalidValue(current.Severity, "severity"))
+	}
+
+	switch current.Scope {
+	case "", CloudAuditLogsSourceScopeProject:
+		if current.ScopeID != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("scopeId"))
+		}
+	case CloudAuditLogsSourceScopeFolder, CloudAuditLogsSourceScopeOrganization:
+		if current.ScopeID == "" {
+			errs = errs.Also(apis.ErrMissingField("scopeId"))
+		} else if !scopeIDRegexp.MatchString(current.ScopeID) {
+			errs = errs.Also(apis.ErrInvalidValue(current.ScopeID, "scopeId"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.Scope, "scope"))
+	}
+
+	if current.AdvancedFilter != "" {
+		if err := validateAdvancedFilter(current.AdvancedFilter); err != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "invalid value: " + current.AdvancedFilter,
+				Paths:   []string{"advancedFilter"},
+				Details: err.Error(),
+			})
+		}
 	}
 
 	if err := duckv1beta1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
@@ -57,6 +117,41 @@ func (current *CloudAuditLogsSourceSpec) Validate(ctx context.Context) *apis.Fie
 	return errs
 }
 
+// validateAdvancedFilter checks that the filter is within the length limit of
+// Cloud Logging sink filters, and that its quotes and parentheses are balanced,
+// so that it can be safely joined with the other conditions of the source.
+// The rest of the syntax is checked by Cloud Logging when the sink is created.
+func validateAdvancedFilter(filter string) error {
+	if len(filter) > maxAdvancedFilterLength {
+		return fmt.Errorf("the filter must be at most %d characters long", maxAdvancedFilterLength)
+	}
+	depth := 0
+	quoted := false
+	for i := 0; i < len(filter); i++ {
+		switch c := filter[i]; {
+		case quoted && c == '\\':
+			// Skip the escaped character.
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth < 0 {
+				return errors.New("unbalanced parentheses")
+			}
+		}
+	}
+	if quoted {
+		return errors.New("unterminated string")
+	}
+	if depth != 0 {
+		return errors.New("unbalanced parentheses")
+	}
+	return nil
+}
+
 func (current *CloudAuditLogsSource) CheckImmutableFields(ctx context.Context, original *CloudAuditLogsSource) *apis.FieldError {
 	if original == nil {
 		return nil
@@ -18,11 +18,15 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	gcpauthtesthelper "github.com/google/knative-gcp/pkg/apis/configs/gcpauth/testhelper"
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -141,6 +145,138 @@ func TestCloudAuditLogsSourceValidationFields(t *testing.T) {
 	}
 }
 
+func TestCloudAuditLogsSourceFilterValidation(t *testing.T) {
+	withFilter := func(f func(*CloudAuditLogsSourceSpec)) *CloudAuditLogsSourceSpec {
+		spec := auditLogsSourceSpec.DeepCopy()
+		spec.MethodName = ""
+		spec.ResourceName = ""
+		spec.MethodNames = []string{"SetIamPolicy", "storage.setIamPermissions"}
+		f(spec)
+		return spec
+	}
+	testCases := []struct {
+		name string
+		spec *CloudAuditLogsSourceSpec
+		want *apis.FieldError
+	}{{
+		name: "all filters",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.ResourceNamePrefix = "projects/_/buckets/prod-"
+			spec.PrincipalEmails = []string{"alice@example.com"}
+			spec.Severity = "NOTICE"
+			spec.AdvancedFilter = `protoPayload.status.code!=0 AND (labels.env="prod" OR labels.env="(staging")`
+		}),
+	}, {
+		name: "no method name",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.MethodNames = nil
+		}),
+		want: apis.ErrMissingOneOf("methodName", "methodNames"),
+	}, {
+		name: "method name and method names",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.MethodName = "SetIamPolicy"
+		}),
+		want: apis.ErrMultipleOneOf("methodName", "methodNames"),
+	}, {
+		name: "empty method name",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.MethodNames = []string{"SetIamPolicy", ""}
+		}),
+		want: apis.ErrInvalidArrayValue("", "methodNames", 1),
+	}, {
+		name: "resource name and prefix",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.ResourceName = "projects/_/buckets/prod"
+			spec.ResourceNamePrefix = "projects/_/buckets/prod-"
+		}),
+		want: apis.ErrMultipleOneOf("resourceName", "resourceNamePrefix"),
+	}, {
+		name: "empty principal email",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.PrincipalEmails = []string{""}
+		}),
+		want: apis.ErrInvalidArrayValue("", "principalEmails", 0),
+	}, {
+		name: "invalid severity",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Severity = "notice"
+		}),
+		want: apis.ErrInvalidValue("notice", "severity"),
+	}, {
+		name: "organization scope",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Scope = CloudAuditLogsSourceScopeOrganization
+			spec.ScopeID = "123456789"
+		}),
+	}, {
+		name: "folder scope without id",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Scope = CloudAuditLogsSourceScopeFolder
+		}),
+		want: apis.ErrMissingField("scopeId"),
+	}, {
+		name: "invalid scope id",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Scope = CloudAuditLogsSourceScopeFolder
+			spec.ScopeID = "folders/123"
+		}),
+		want: apis.ErrInvalidValue("folders/123", "scopeId"),
+	}, {
+		name: "project scope with id",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Scope = CloudAuditLogsSourceScopeProject
+			spec.ScopeID = "123"
+		}),
+		want: apis.ErrDisallowedFields("scopeId"),
+	}, {
+		name: "invalid scope",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.Scope = "billingAccount"
+			spec.ScopeID = "123"
+		}),
+		want: apis.ErrInvalidValue("billingAccount", "scope"),
+	}, {
+		name: "unbalanced parentheses",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.AdvancedFilter = `severity>=ERROR) OR (labels.env="prod"`
+		}),
+		want: &apis.FieldError{
+			Message: `invalid value: severity>=ERROR) OR (labels.env="prod"`,
+			Paths:   []string{"advancedFilter"},
+			Details: "unbalanced parentheses",
+		},
+	}, {
+		name: "unterminated string",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.AdvancedFilter = `labels.env="prod\"`
+		}),
+		want: &apis.FieldError{
+			Message: `invalid value: labels.env="prod\"`,
+			Paths:   []string{"advancedFilter"},
+			Details: "unterminated string",
+		},
+	}, {
+		name: "too long filter",
+		spec: withFilter(func(spec *CloudAuditLogsSourceSpec) {
+			spec.AdvancedFilter = strings.Repeat("a", maxAdvancedFilterLength+1)
+		}),
+		want: &apis.FieldError{
+			Message: "invalid value: " + strings.Repeat("a", maxAdvancedFilterLength+1),
+			Paths:   []string{"advancedFilter"},
+			Details: fmt.Sprintf("the filter must be at most %d characters long", maxAdvancedFilterLength),
+		},
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.spec.Validate(context.TODO())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate CloudAuditLogsSourceSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
 func TestCloudAuditLogsSourceCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
 		orig    interface{}
@@ -14,6 +14,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"fmt"
 
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
@@ -41,12 +42,13 @@ type CloudBuildSource struct {
 }
 
 var (
-	_ kmeta.OwnerRefable           = (*CloudBuildSource)(nil)
-	_ resourcesemantics.GenericCRD = (*CloudBuildSource)(nil)
-	_ kngcpduck.PubSubable         = (*CloudBuildSource)(nil)
-	_ kngcpduck.Identifiable       = (*CloudBuildSource)(nil)
-	_                              = duck.VerifyType(&CloudBuildSource{}, &duckv1.Conditions{})
-	_ duckv1.KRShaped              = (*CloudBuildSource)(nil)
+	_ kmeta.OwnerRefable            = (*CloudBuildSource)(nil)
+	_ resourcesemantics.GenericCRD  = (*CloudBuildSource)(nil)
+	_ kngcpduck.PubSubable          = (*CloudBuildSource)(nil)
+	_ kngcpduck.Identifiable        = (*CloudBuildSource)(nil)
+	_ kngcpduck.AdapterConfigurable = (*CloudBuildSource)(nil)
+	_                               = duck.VerifyType(&CloudBuildSource{}, &duckv1.Conditions{})
+	_ duckv1.KRShaped               = (*CloudBuildSource)(nil)
 )
 
 // CloudBuildSourceSpec defines the desired state of the CloudBuildSource.
@@ -54,6 +56,27 @@ type CloudBuildSourceSpec struct {
 	// This brings in the PubSub based Source Specs. Includes:
 	// Sink, CloudEventOverrides, Secret, and Project
 	duckv1beta1.PubSubSpec `json:",inline"`
+
+	// BuildStatuses limits the events sent to the sink to builds with one of
+	// these statuses, e.g. FAILURE and TIMEOUT. If unspecified, the events of
+	// builds with any status are sent.
+	// +optional
+	BuildStatuses []string `json:"buildStatuses,omitempty"`
+
+	// TriggerIDs limits the events sent to the sink to builds started by one of
+	// the build triggers with these IDs, or with the names in TriggerNames.
+	// +optional
+	TriggerIDs []string `json:"triggerIds,omitempty"`
+
+	// TriggerNames limits the events sent to the sink to builds started by one of
+	// the build triggers with these names, or with the IDs in TriggerIDs.
+	// +optional
+	TriggerNames []string `json:"triggerNames,omitempty"`
+
+	// Tags limits the events sent to the sink to builds with at least one of
+	// these tags.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
 }
 
 const (
@@ -63,6 +86,24 @@ const (
 	CloudBuildSourceBuildId = "buildId"
 	// CloudBuildSourceBuildStatus is the Pub/Sub message attribute key with the CloudBuildSource's build status.
 	CloudBuildSourceBuildStatus = "status"
+	// CloudBuildSourceBuildStatusExtension is the CloudEvent extension with the build status.
+	CloudBuildSourceBuildStatusExtension = "buildstatus"
+	// CloudBuildSourceTriggerIdExtension is the CloudEvent extension with the ID of the
+	// build trigger which started the build, if any.
+	CloudBuildSourceTriggerIdExtension = "triggerid"
+)
+
+// The statuses of Cloud Build builds.
+const (
+	CloudBuildSourceBuildStatusUnknown       = "STATUS_UNKNOWN"
+	CloudBuildSourceBuildStatusQueued        = "QUEUED"
+	CloudBuildSourceBuildStatusWorking       = "WORKING"
+	CloudBuildSourceBuildStatusSuccess       = "SUCCESS"
+	CloudBuildSourceBuildStatusFailure       = "FAILURE"
+	CloudBuildSourceBuildStatusInternalError = "INTERNAL_ERROR"
+	CloudBuildSourceBuildStatusTimeout       = "TIMEOUT"
+	CloudBuildSourceBuildStatusCancelled     = "CANCELLED"
+	CloudBuildSourceBuildStatusExpired       = "EXPIRED"
 )
 
 // CloudBuildSourceEventSource returns the Cloud Build CloudEvent source value.
@@ -120,6 +161,34 @@ func (bs *CloudBuildSource) PubSubStatus() *duckv1beta1.PubSubStatus {
 	return &bs.Status.PubSubStatus
 }
 
+// CloudBuildSourceAdapterOptions are the options of the receive adapter of a
+// CloudBuildSource, which filters the events sent to the sink.
+type CloudBuildSourceAdapterOptions struct {
+	BuildStatuses []string `json:"buildStatuses,omitempty"`
+	TriggerIDs    []string `json:"triggerIds,omitempty"`
+	TriggerNames  []string `json:"triggerNames,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// Methods for adapterConfigurable interface.
+
+// AdapterOptions returns the JSON serialized CloudBuildSourceAdapterOptions, or
+// the empty string if the events are not filtered.
+func (bs *CloudBuildSource) AdapterOptions() string {
+	opts := CloudBuildSourceAdapterOptions{
+		BuildStatuses: bs.Spec.BuildStatuses,
+		TriggerIDs:    bs.Spec.TriggerIDs,
+		TriggerNames:  bs.Spec.TriggerNames,
+		Tags:          bs.Spec.Tags,
+	}
+	if len(opts.BuildStatuses) == 0 && len(opts.TriggerIDs) == 0 && len(opts.TriggerNames) == 0 && len(opts.Tags) == 0 {
+		return ""
+	}
+	// Marshaling can't fail, as the options only consist of strings.
+	b, _ := json.Marshal(opts)
+	return string(b)
+}
+
 // ConditionSet returns the apis.ConditionSet of the embedding object
 func (bs *CloudBuildSource) ConditionSet() *apis.ConditionSet {
 	return &buildCondSet
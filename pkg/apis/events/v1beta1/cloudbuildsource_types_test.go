@@ -117,3 +117,33 @@ func TestCloudBuildSource_GetStatus(t *testing.T) {
 		t.Errorf("GetStatus=%v, want=%v", got, want)
 	}
 }
+
+func TestCloudBuildSourceAdapterOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec CloudBuildSourceSpec
+		want string
+	}{{
+		name: "no filter",
+	}, {
+		name: "statuses",
+		spec: CloudBuildSourceSpec{BuildStatuses: []string{"FAILURE", "TIMEOUT"}},
+		want: `{"buildStatuses":["FAILURE","TIMEOUT"]}`,
+	}, {
+		name: "triggers and tags",
+		spec: CloudBuildSourceSpec{
+			TriggerIDs:   []string{"trigger-id"},
+			TriggerNames: []string{"trigger-name"},
+			Tags:         []string{"prod"},
+		},
+		want: `{"triggerIds":["trigger-id"],"triggerNames":["trigger-name"],"tags":["prod"]}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &CloudBuildSource{Spec: tc.spec}
+			if got := s.AdapterOptions(); got != tc.want {
+				t.Errorf("AdapterOptions got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
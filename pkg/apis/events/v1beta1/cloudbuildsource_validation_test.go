@@ -173,6 +173,49 @@ func TestCloudBuildSourceCheckValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"valid filters": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildStatuses = []string{"FAILURE", "TIMEOUT"}
+				obj.TriggerIDs = []string{"trigger-id"}
+				obj.TriggerNames = []string{"trigger-name"}
+				obj.Tags = []string{"prod"}
+				return *obj
+			}(),
+			error: false,
+		},
+		"invalid build status": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildStatuses = []string{"FAILURE", "FAILED"}
+				return *obj
+			}(),
+			error: true,
+		},
+		"empty trigger id": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.TriggerIDs = []string{""}
+				return *obj
+			}(),
+			error: true,
+		},
+		"empty trigger name": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.TriggerNames = []string{"trigger-name", ""}
+				return *obj
+			}(),
+			error: true,
+		},
+		"empty tag": {
+			spec: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.Tags = []string{""}
+				return *obj
+			}(),
+			error: true,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -379,6 +422,18 @@ func TestCloudBuildSourceCheckImmutableFields(t *testing.T) {
 			},
 			allowed: true,
 		},
+		"filters changed": {
+			orig: &buildSourceSpec,
+			updated: func() CloudBuildSourceSpec {
+				obj := buildSourceSpec.DeepCopy()
+				obj.BuildStatuses = []string{"FAILURE"}
+				obj.TriggerIDs = []string{"trigger-id"}
+				obj.TriggerNames = []string{"trigger-name"}
+				obj.Tags = []string{"prod"}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"no change": {
 			orig:    &buildSourceSpec,
 			updated: buildSourceSpec,
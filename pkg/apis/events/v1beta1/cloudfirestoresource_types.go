@@ -0,0 +1,185 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	kngcpduck "github.com/google/knative-gcp/pkg/duck/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudFirestoreSource is a specification for a Cloud Firestore document change event source.
+type CloudFirestoreSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudFirestoreSourceSpec   `json:"spec"`
+	Status CloudFirestoreSourceStatus `json:"status"`
+}
+
+// Verify that CloudFirestoreSource matches various duck types.
+var (
+	_ apis.Convertible              = (*CloudFirestoreSource)(nil)
+	_ apis.Defaultable              = (*CloudFirestoreSource)(nil)
+	_ apis.Validatable              = (*CloudFirestoreSource)(nil)
+	_ runtime.Object                = (*CloudFirestoreSource)(nil)
+	_ kmeta.OwnerRefable            = (*CloudFirestoreSource)(nil)
+	_ resourcesemantics.GenericCRD  = (*CloudFirestoreSource)(nil)
+	_ kngcpduck.Identifiable        = (*CloudFirestoreSource)(nil)
+	_ kngcpduck.PubSubable          = (*CloudFirestoreSource)(nil)
+	_ kngcpduck.AdapterConfigurable = (*CloudFirestoreSource)(nil)
+	_ duckv1.KRShaped               = (*CloudFirestoreSource)(nil)
+)
+
+// CloudFirestoreSourceSpec is the spec for a CloudFirestoreSource resource.
+type CloudFirestoreSourceSpec struct {
+	// This brings in the PubSub based Source Specs. Includes:
+	// Sink, CloudEventOverrides, Secret, and Project
+	duckv1beta1.PubSubSpec `json:",inline"`
+
+	// Database is the ID of the Firestore database. Defaults to "(default)".
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Document is the path pattern of the documents whose changes are sent
+	// to the sink, e.g. "users/{userId}". A segment of "*" or "{name}"
+	// matches a single segment of the document path, and a last segment of
+	// "**" or "{name=**}" matches any number of segments.
+	Document string `json:"document"`
+
+	// EventTypes to send to the sink. If unspecified, then all the event types are sent.
+	// +optional
+	EventTypes []string `json:"eventTypes,omitempty"`
+}
+
+const (
+	// CloudFirestoreSourceDefaultDatabase is the ID of the default Firestore database.
+	CloudFirestoreSourceDefaultDatabase = "(default)"
+
+	// CloudFirestoreSourceCreate is the event type of the creation of a document.
+	CloudFirestoreSourceCreate = "com.google.cloud.firestore.document.create"
+	// CloudFirestoreSourceUpdate is the event type of the update of an existing document.
+	CloudFirestoreSourceUpdate = "com.google.cloud.firestore.document.update"
+	// CloudFirestoreSourceDelete is the event type of the deletion of a document.
+	CloudFirestoreSourceDelete = "com.google.cloud.firestore.document.delete"
+	// CloudFirestoreSourceWrite is the event type of a write which either created
+	// or updated a document, as it did not require the document to exist or not.
+	CloudFirestoreSourceWrite = "com.google.cloud.firestore.document.write"
+)
+
+// CloudFirestoreSourceEventSource returns the Cloud Firestore CloudEvent source value.
+func CloudFirestoreSourceEventSource(googleCloudProject, database string) string {
+	return fmt.Sprintf("//firestore.googleapis.com/projects/%s/databases/%s", googleCloudProject, database)
+}
+
+var firestoreSourceCondSet = apis.NewLivingConditionSet(
+	duckv1beta1.PullSubscriptionReady,
+	duckv1beta1.TopicReady,
+	SinkReady,
+)
+
+// CloudFirestoreSourceStatus is the status for a CloudFirestoreSource resource.
+type CloudFirestoreSourceStatus struct {
+	duckv1beta1.PubSubStatus `json:",inline"`
+
+	// ID of the Stackdriver sink used to publish the audit logs of the document writes.
+	StackdriverSink string `json:"stackdriverSink,omitempty"`
+}
+
+func (*CloudFirestoreSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("CloudFirestoreSource")
+}
+
+// Methods for identifiable interface.
+// IdentitySpec returns the IdentitySpec portion of the Spec.
+func (s *CloudFirestoreSource) IdentitySpec() *duckv1beta1.IdentitySpec {
+	return &s.Spec.IdentitySpec
+}
+
+// IdentityStatus returns the IdentityStatus portion of the Status.
+func (s *CloudFirestoreSource) IdentityStatus() *duckv1beta1.IdentityStatus {
+	return &s.Status.IdentityStatus
+}
+
+// ConditionSet returns the apis.ConditionSet of the embedding object
+func (*CloudFirestoreSource) ConditionSet() *apis.ConditionSet {
+	return &firestoreSourceCondSet
+}
+
+// Methods for pubsubable interface.
+
+// PubSubSpec returns the PubSubSpec portion of the Spec.
+func (s *CloudFirestoreSource) PubSubSpec() *duckv1beta1.PubSubSpec {
+	return &s.Spec.PubSubSpec
+}
+
+// PubSubStatus returns the PubSubStatus portion of the Status.
+func (s *CloudFirestoreSource) PubSubStatus() *duckv1beta1.PubSubStatus {
+	return &s.Status.PubSubStatus
+}
+
+// CloudFirestoreSourceAdapterOptions are the options of the receive adapter of a
+// CloudFirestoreSource, which filters the events sent to the sink.
+type CloudFirestoreSourceAdapterOptions struct {
+	Document   string   `json:"document,omitempty"`
+	EventTypes []string `json:"eventTypes,omitempty"`
+}
+
+// Methods for adapterConfigurable interface.
+
+// AdapterOptions returns the JSON serialized CloudFirestoreSourceAdapterOptions.
+func (s *CloudFirestoreSource) AdapterOptions() string {
+	// Marshaling can't fail, as the options only consist of strings.
+	b, _ := json.Marshal(CloudFirestoreSourceAdapterOptions{
+		Document:   s.Spec.Document,
+		EventTypes: s.Spec.EventTypes,
+	})
+	return string(b)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudFirestoreSourceList is a list of CloudFirestoreSource resources.
+type CloudFirestoreSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CloudFirestoreSource `json:"items"`
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*CloudFirestoreSource) GetConditionSet() apis.ConditionSet {
+	return firestoreSourceCondSet
+}
+
+// GetStatus retrieves the status of the CloudFirestoreSource. Implements the KRShaped interface.
+func (s *CloudFirestoreSource) GetStatus() *duckv1.Status {
+	return &s.Status.Status
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+func TestCloudFirestoreSourceGetGroupVersionKind(t *testing.T) {
+	want := schema.GroupVersionKind{
+		Group:   "events.cloud.google.com",
+		Version: "v1beta1",
+		Kind:    "CloudFirestoreSource",
+	}
+
+	s := &CloudFirestoreSource{}
+	got := s.GetGroupVersionKind()
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestCloudFirestoreSourceConditionSet(t *testing.T) {
+	want := []apis.Condition{{
+		Type: SinkReady,
+	}, {
+		Type: duckv1beta1.TopicReady,
+	}, {
+		Type: duckv1beta1.PullSubscriptionReady,
+	}, {
+		Type: apis.ConditionReady,
+	}}
+	s := &CloudFirestoreSource{}
+
+	s.ConditionSet().Manage(&s.Status).InitializeConditions()
+	var got []apis.Condition = s.Status.GetConditions()
+
+	compareConditionTypes := cmp.Transformer("ConditionType", func(c apis.Condition) apis.ConditionType {
+		return c.Type
+	})
+	sortConditionTypes := cmpopts.SortSlices(func(a, b apis.Condition) bool {
+		return a.Type < b.Type
+	})
+	if diff := cmp.Diff(want, got, sortConditionTypes, compareConditionTypes); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestCloudFirestoreSourceEventSource(t *testing.T) {
+	want := "//firestore.googleapis.com/projects/PROJECT/databases/(default)"
+
+	got := CloudFirestoreSourceEventSource("PROJECT", "(default)")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestCloudFirestoreSourceIdentitySpec(t *testing.T) {
+	s := &CloudFirestoreSource{
+		Spec: CloudFirestoreSourceSpec{
+			PubSubSpec: duckv1beta1.PubSubSpec{
+				IdentitySpec: duckv1beta1.IdentitySpec{
+					ServiceAccountName: "test",
+				},
+			},
+		},
+	}
+	want := "test"
+	got := s.IdentitySpec().ServiceAccountName
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestCloudFirestoreSourceIdentityStatus(t *testing.T) {
+	s := &CloudFirestoreSource{}
+	want := &duckv1beta1.IdentityStatus{}
+	got := s.IdentityStatus()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed to get expected (-want, +got) = %v", diff)
+	}
+}
+
+func TestCloudFirestoreSourceAdapterOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec CloudFirestoreSourceSpec
+		want string
+	}{{
+		name: "document",
+		spec: CloudFirestoreSourceSpec{Document: "users/{userId}"},
+		want: `{"document":"users/{userId}"}`,
+	}, {
+		name: "event types",
+		spec: CloudFirestoreSourceSpec{
+			Document:   "users/*",
+			EventTypes: []string{CloudFirestoreSourceCreate, CloudFirestoreSourceDelete},
+		},
+		want: `{"document":"users/*","eventTypes":["com.google.cloud.firestore.document.create","com.google.cloud.firestore.document.delete"]}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &CloudFirestoreSource{Spec: tc.spec}
+			if got := s.AdapterOptions(); got != tc.want {
+				t.Errorf("AdapterOptions got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCloudFirestoreSource_GetConditionSet(t *testing.T) {
+	s := &CloudFirestoreSource{}
+
+	if got, want := s.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestCloudFirestoreSource_GetStatus(t *testing.T) {
+	s := &CloudFirestoreSource{
+		Status: CloudFirestoreSourceStatus{},
+	}
+	if got, want := s.GetStatus(), &s.Status.Status; got != want {
+		t.Errorf("GetStatus=%v, want=%v", got, want)
+	}
+}
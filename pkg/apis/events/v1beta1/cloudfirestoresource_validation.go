@@ -0,0 +1,137 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+)
+
+var (
+	// firestoreDatabaseRegexp matches the IDs of Firestore databases.
+	firestoreDatabaseRegexp = regexp.MustCompile(`^(\(default\)|[a-z][a-z0-9-]{2,62})$`)
+
+	// firestoreWildcardRegexp matches the wildcard segments of document path patterns.
+	firestoreWildcardRegexp = regexp.MustCompile(`^(\*|\*\*|{[a-zA-Z_][a-zA-Z0-9_]*}|{[a-zA-Z_][a-zA-Z0-9_]*=\*\*})$`)
+
+	firestoreEventTypes = sets.NewString(
+		CloudFirestoreSourceCreate,
+		CloudFirestoreSourceUpdate,
+		CloudFirestoreSourceDelete,
+		CloudFirestoreSourceWrite,
+	)
+)
+
+func (current *CloudFirestoreSource) Validate(ctx context.Context) *apis.FieldError {
+	errs := current.Spec.Validate(ctx).ViaField("spec")
+	return duckv1beta1.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
+}
+
+func (current *CloudFirestoreSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	// Sink [required]
+	if equality.Semantic.DeepEqual(current.Sink, duckv1.Destination{}) {
+		errs = errs.Also(apis.ErrMissingField("sink"))
+	} else if err := current.Sink.Validate(ctx); err != nil {
+		errs = errs.Also(err.ViaField("sink"))
+	}
+
+	if current.Database != "" && !firestoreDatabaseRegexp.MatchString(current.Database) {
+		errs = errs.Also(apis.ErrInvalidValue(current.Database, "database"))
+	}
+
+	// Document [required]
+	if current.Document == "" {
+		errs = errs.Also(apis.ErrMissingField("document"))
+	} else if err := validateDocumentPattern(current.Document); err != nil {
+		errs = errs.Also(&apis.FieldError{
+			Message: "invalid value: " + current.Document,
+			Paths:   []string{"document"},
+			Details: err.Error(),
+		})
+	}
+
+	for i, eventType := range current.EventTypes {
+		if !firestoreEventTypes.Has(eventType) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(eventType, "eventTypes", i))
+		}
+	}
+
+	if err := duckv1beta1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
+		errs = errs.Also(err)
+	}
+
+	return errs
+}
+
+// validateDocumentPattern checks that the pattern is a path of non-empty
+// segments which matches documents, not collections. Wildcards must be whole
+// segments, and the multi-segment wildcard can only be the last segment.
+func validateDocumentPattern(pattern string) error {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			return errors.New("the document path has an empty segment")
+		case firestoreWildcardRegexp.MatchString(segment):
+			if strings.HasSuffix(segment, "**") || strings.HasSuffix(segment, "**}") {
+				if i != len(segments)-1 {
+					return errors.New("the multi-segment wildcard must be the last segment")
+				}
+				return nil
+			}
+		case strings.ContainsAny(segment, "*{}"):
+			return errors.New("wildcards must be whole segments")
+		}
+	}
+	if len(segments)%2 != 0 {
+		return errors.New("the document path must have an even number of segments")
+	}
+	return nil
+}
+
+func (current *CloudFirestoreSource) CheckImmutableFields(ctx context.Context, original *CloudFirestoreSource) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	// Modification of Database, Topic, Secret and Project are not allowed. Everything else is mutable.
+	if diff := cmp.Diff(original.Spec, current.Spec,
+		cmpopts.IgnoreFields(CloudFirestoreSourceSpec{},
+			"Sink", "CloudEventOverrides", "Document", "EventTypes")); diff != "" {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		})
+	}
+	// Modification of non-empty cluster name annotation is not allowed.
+	return duckv1beta1.CheckImmutableClusterNameAnnotation(&current.ObjectMeta, &original.ObjectMeta, errs)
+}
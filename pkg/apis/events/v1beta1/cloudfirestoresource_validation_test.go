@@ -0,0 +1,229 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	metadatatesting "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var firestoreSourceSpec = CloudFirestoreSourceSpec{
+	Database: CloudFirestoreSourceDefaultDatabase,
+	Document: "users/{userId}",
+	PubSubSpec: duckv1beta1.PubSubSpec{
+		Secret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: "secret-name",
+			},
+			Key: "secret-key",
+		},
+		SourceSpec: duckv1.SourceSpec{
+			Sink: duckv1.Destination{
+				Ref: &duckv1.KReference{
+					APIVersion: "foo",
+					Kind:       "bar",
+					Namespace:  "baz",
+					Name:       "qux",
+				},
+			},
+		},
+		Project: "my-eventing-project",
+	},
+}
+
+func TestCloudFirestoreSourceValidationFields(t *testing.T) {
+	withSpec := func(f func(*CloudFirestoreSourceSpec)) CloudFirestoreSourceSpec {
+		spec := firestoreSourceSpec.DeepCopy()
+		f(spec)
+		return *spec
+	}
+	testCases := map[string]struct {
+		spec CloudFirestoreSourceSpec
+		want *apis.FieldError
+	}{
+		"ok": {
+			spec: firestoreSourceSpec,
+		},
+		"ok, wildcards and event types": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Database = "my-database"
+				spec.Document = "users/*/orders/{orderId}/items/{path=**}"
+				spec.EventTypes = []string{CloudFirestoreSourceCreate, CloudFirestoreSourceWrite}
+			}),
+		},
+		"ok, multi-segment wildcard": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "**"
+			}),
+		},
+		"missing sink": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Sink = duckv1.Destination{}
+			}),
+			want: apis.ErrMissingField("sink"),
+		},
+		"missing document": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = ""
+			}),
+			want: apis.ErrMissingField("document"),
+		},
+		"invalid database": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Database = "My_Database"
+			}),
+			want: apis.ErrInvalidValue("My_Database", "database"),
+		},
+		"collection document": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "users/{userId}/orders"
+			}),
+			want: &apis.FieldError{
+				Message: "invalid value: users/{userId}/orders",
+				Paths:   []string{"document"},
+				Details: "the document path must have an even number of segments",
+			},
+		},
+		"empty segment": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "/users/{userId}"
+			}),
+			want: &apis.FieldError{
+				Message: "invalid value: /users/{userId}",
+				Paths:   []string{"document"},
+				Details: "the document path has an empty segment",
+			},
+		},
+		"partial wildcard": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "users/user-*"
+			}),
+			want: &apis.FieldError{
+				Message: "invalid value: users/user-*",
+				Paths:   []string{"document"},
+				Details: "wildcards must be whole segments",
+			},
+		},
+		"multi-segment wildcard not last": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "users/**/orders/{orderId}"
+			}),
+			want: &apis.FieldError{
+				Message: "invalid value: users/**/orders/{orderId}",
+				Paths:   []string{"document"},
+				Details: "the multi-segment wildcard must be the last segment",
+			},
+		},
+		"invalid event type": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.EventTypes = []string{CloudFirestoreSourceCreate, "com.google.cloud.firestore.document.read"}
+			}),
+			want: apis.ErrInvalidArrayValue("com.google.cloud.firestore.document.read", "eventTypes", 1),
+		},
+		"secret and service account": {
+			spec: withSpec(func(spec *CloudFirestoreSourceSpec) {
+				spec.ServiceAccountName = validServiceAccountName
+			}),
+			want: &apis.FieldError{
+				Message: "Can't have spec.serviceAccountName and spec.secret at the same time",
+				Paths:   []string{""},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := tc.spec.Validate(context.TODO())
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Errorf("unexpected error (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestCloudFirestoreSourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		orig    *CloudFirestoreSourceSpec
+		updated func(*CloudFirestoreSourceSpec)
+		allowed bool
+	}{
+		"nil orig": {
+			updated: func(*CloudFirestoreSourceSpec) {},
+			allowed: true,
+		},
+		"no change": {
+			orig:    &firestoreSourceSpec,
+			updated: func(*CloudFirestoreSourceSpec) {},
+			allowed: true,
+		},
+		"Sink changed": {
+			orig: &firestoreSourceSpec,
+			updated: func(spec *CloudFirestoreSourceSpec) {
+				spec.Sink.Ref.Name = "some-other-name"
+			},
+			allowed: true,
+		},
+		"Document and EventTypes changed": {
+			orig: &firestoreSourceSpec,
+			updated: func(spec *CloudFirestoreSourceSpec) {
+				spec.Document = "orders/{orderId}"
+				spec.EventTypes = []string{CloudFirestoreSourceDelete}
+			},
+			allowed: true,
+		},
+		"Database changed": {
+			orig: &firestoreSourceSpec,
+			updated: func(spec *CloudFirestoreSourceSpec) {
+				spec.Database = "some-other-database"
+			},
+			allowed: false,
+		},
+		"Project changed": {
+			orig: &firestoreSourceSpec,
+			updated: func(spec *CloudFirestoreSourceSpec) {
+				spec.Project = "some-other-project"
+			},
+			allowed: false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Annotations: map[string]string{
+					duckv1beta1.ClusterNameAnnotation: metadatatesting.FakeClusterName,
+				},
+			}
+			var orig *CloudFirestoreSource
+			if tc.orig != nil {
+				orig = &CloudFirestoreSource{ObjectMeta: objectMeta, Spec: *tc.orig}
+			}
+			updated := &CloudFirestoreSource{ObjectMeta: objectMeta, Spec: *firestoreSourceSpec.DeepCopy()}
+			tc.updated(&updated.Spec)
+			err := updated.CheckImmutableFields(context.TODO(), orig)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestCloudMonitoringAlertSourceStatusIsReady(t *testing.T) {
+	tests := []struct {
+		name                string
+		s                   *CloudMonitoringAlertSourceStatus
+		wantConditionStatus corev1.ConditionStatus
+		want                bool
+	}{{
+		name: "uninitialized",
+		s:    &CloudMonitoringAlertSourceStatus{},
+		want: false,
+	}, {
+		name: "initialized",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSourceStatus{}
+			s.InitializeConditions()
+			return s
+		}(),
+		wantConditionStatus: corev1.ConditionUnknown,
+		want:                false,
+	}, {
+		name: "the status of topic is false",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelReady("projects/test-project/notificationChannels/12345")
+			s.Status.MarkTopicFailed(s.ConditionSet(), "test", "the status of topic is false")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+		want:                false,
+	}, {
+		name: "the status of pullsubscription is unknown",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelReady("projects/test-project/notificationChannels/12345")
+			s.Status.MarkPullSubscriptionUnknown(s.ConditionSet(), "test", "the status of pullsubscription is unknown")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionUnknown,
+		want:                false,
+	}, {
+		name: "notification channel is not ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelNotReady("test", "the status of notification channel is false")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+		want:                false,
+	}, {
+		name: "ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSource{}
+			s.Status.InitializeConditions()
+			s.Status.MarkTopicReady(s.ConditionSet())
+			s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+			s.Status.MarkNotificationChannelReady("projects/test-project/notificationChannels/12345")
+			return &s.Status
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantConditionStatus != "" {
+				gotConditionStatus := test.s.GetTopLevelCondition().Status
+				if gotConditionStatus != test.wantConditionStatus {
+					t.Errorf("unexpected condition status: want %v, got %v", test.wantConditionStatus, gotConditionStatus)
+				}
+			}
+			got := test.s.IsReady()
+			if got != test.want {
+				t.Errorf("unexpected readiness: want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCloudMonitoringAlertSourceStatusGetCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         *CloudMonitoringAlertSourceStatus
+		condQuery apis.ConditionType
+		want      *apis.Condition
+	}{{
+		name:      "uninitialized",
+		s:         &CloudMonitoringAlertSourceStatus{},
+		condQuery: apis.ConditionReady,
+		want:      nil,
+	}, {
+		name: "initialized",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSourceStatus{}
+			s.InitializeConditions()
+			return s
+		}(),
+		condQuery: apis.ConditionReady,
+		want: &apis.Condition{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionUnknown,
+		},
+	}, {
+		name: "notification channel not ready",
+		s: func() *CloudMonitoringAlertSourceStatus {
+			s := &CloudMonitoringAlertSourceStatus{}
+			s.InitializeConditions()
+			s.MarkNotificationChannelNotReady("NotReady", "test message")
+			return s
+		}(),
+		condQuery: NotificationChannelReady,
+		want: &apis.Condition{
+			Type:    NotificationChannelReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "NotReady",
+			Message: "test message",
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.s.GetCondition(test.condQuery)
+			ignoreTime := cmpopts.IgnoreFields(apis.Condition{},
+				"LastTransitionTime", "Severity")
+			if diff := cmp.Diff(test.want, got, ignoreTime); diff != "" {
+				t.Errorf("unexpected condition (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestCloudMonitoringAlertSourceStatusMarkNotificationChannelReady(t *testing.T) {
+	s := &CloudMonitoringAlertSourceStatus{}
+	s.InitializeConditions()
+	s.MarkNotificationChannelReady("projects/test-project/notificationChannels/12345")
+	if got, want := s.NotificationChannel, "projects/test-project/notificationChannels/12345"; got != want {
+		t.Errorf("NotificationChannel got %q, want %q", got, want)
+	}
+	if got := s.GetCondition(NotificationChannelReady).Status; got != corev1.ConditionTrue {
+		t.Errorf("NotificationChannelReady condition status got %v, want %v", got, corev1.ConditionTrue)
+	}
+}
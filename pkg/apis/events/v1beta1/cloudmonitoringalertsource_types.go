@@ -0,0 +1,172 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	kngcpduck "github.com/google/knative-gcp/pkg/duck/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudMonitoringAlertSource is a specification for a Cloud Monitoring alerting incident event source.
+type CloudMonitoringAlertSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudMonitoringAlertSourceSpec   `json:"spec"`
+	Status CloudMonitoringAlertSourceStatus `json:"status"`
+}
+
+// Verify that CloudMonitoringAlertSource matches various duck types.
+var (
+	_ apis.Convertible             = (*CloudMonitoringAlertSource)(nil)
+	_ apis.Defaultable             = (*CloudMonitoringAlertSource)(nil)
+	_ apis.Validatable             = (*CloudMonitoringAlertSource)(nil)
+	_ runtime.Object               = (*CloudMonitoringAlertSource)(nil)
+	_ kmeta.OwnerRefable           = (*CloudMonitoringAlertSource)(nil)
+	_ resourcesemantics.GenericCRD = (*CloudMonitoringAlertSource)(nil)
+	_ kngcpduck.Identifiable       = (*CloudMonitoringAlertSource)(nil)
+	_ kngcpduck.PubSubable         = (*CloudMonitoringAlertSource)(nil)
+	_ duckv1.KRShaped              = (*CloudMonitoringAlertSource)(nil)
+)
+
+// CloudMonitoringAlertSourceSpec is the spec for a CloudMonitoringAlertSource resource.
+type CloudMonitoringAlertSourceSpec struct {
+	// This brings in the PubSub based Source Specs. Includes:
+	// Sink, CloudEventOverrides, Secret, and Project
+	duckv1beta1.PubSubSpec `json:",inline"`
+
+	// AlertPolicies are the IDs of the alert policies of the project whose
+	// incidents are sent to the sink.
+	AlertPolicies []string `json:"alertPolicies"`
+}
+
+const (
+	// CloudMonitoringAlertSourceIncidentOpened is the event type of the opening of an incident.
+	CloudMonitoringAlertSourceIncidentOpened = "com.google.cloud.monitoring.incident.opened"
+
+	// CloudMonitoringAlertSourceIncidentClosed is the event type of the closing of an incident.
+	CloudMonitoringAlertSourceIncidentClosed = "com.google.cloud.monitoring.incident.closed"
+
+	// CloudMonitoringAlertSourcePolicyExtension is the CloudEvent extension of
+	// the name of the alert policy of an incident.
+	CloudMonitoringAlertSourcePolicyExtension = "policy"
+
+	// CloudMonitoringAlertSourceConditionExtension is the CloudEvent extension
+	// of the name of the condition of the alert policy which opened an incident.
+	CloudMonitoringAlertSourceConditionExtension = "condition"
+
+	// CloudMonitoringAlertSourceResourceExtension is the CloudEvent extension of
+	// the name of the monitored resource of an incident.
+	CloudMonitoringAlertSourceResourceExtension = "resource"
+)
+
+// CloudMonitoringAlertSourceEventSource returns the Cloud Monitoring CloudEvent source value.
+func CloudMonitoringAlertSourceEventSource(googleCloudProject string) string {
+	return fmt.Sprintf("//monitoring.googleapis.com/projects/%s", googleCloudProject)
+}
+
+const (
+	// NotificationChannelReady has status True when the notification channel of
+	// a CloudMonitoringAlertSource has been created and added to its alert policies.
+	NotificationChannelReady apis.ConditionType = "NotificationChannelReady"
+)
+
+var monitoringAlertSourceCondSet = apis.NewLivingConditionSet(
+	duckv1beta1.PullSubscriptionReady,
+	duckv1beta1.TopicReady,
+	NotificationChannelReady,
+)
+
+// CloudMonitoringAlertSourceStatus is the status for a CloudMonitoringAlertSource resource.
+type CloudMonitoringAlertSourceStatus struct {
+	duckv1beta1.PubSubStatus `json:",inline"`
+
+	// NotificationChannel is the name of the notification channel which
+	// publishes the incidents to the topic.
+	// +optional
+	NotificationChannel string `json:"notificationChannel,omitempty"`
+
+	// AlertPolicies are the IDs of the alert policies the notification channel
+	// has been added to.
+	// +optional
+	AlertPolicies []string `json:"alertPolicies,omitempty"`
+}
+
+func (*CloudMonitoringAlertSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("CloudMonitoringAlertSource")
+}
+
+// Methods for identifiable interface.
+// IdentitySpec returns the IdentitySpec portion of the Spec.
+func (s *CloudMonitoringAlertSource) IdentitySpec() *duckv1beta1.IdentitySpec {
+	return &s.Spec.IdentitySpec
+}
+
+// IdentityStatus returns the IdentityStatus portion of the Status.
+func (s *CloudMonitoringAlertSource) IdentityStatus() *duckv1beta1.IdentityStatus {
+	return &s.Status.IdentityStatus
+}
+
+// ConditionSet returns the apis.ConditionSet of the embedding object
+func (*CloudMonitoringAlertSource) ConditionSet() *apis.ConditionSet {
+	return &monitoringAlertSourceCondSet
+}
+
+// Methods for pubsubable interface.
+
+// PubSubSpec returns the PubSubSpec portion of the Spec.
+func (s *CloudMonitoringAlertSource) PubSubSpec() *duckv1beta1.PubSubSpec {
+	return &s.Spec.PubSubSpec
+}
+
+// PubSubStatus returns the PubSubStatus portion of the Status.
+func (s *CloudMonitoringAlertSource) PubSubStatus() *duckv1beta1.PubSubStatus {
+	return &s.Status.PubSubStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudMonitoringAlertSourceList is a list of CloudMonitoringAlertSource resources.
+type CloudMonitoringAlertSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CloudMonitoringAlertSource `json:"items"`
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*CloudMonitoringAlertSource) GetConditionSet() apis.ConditionSet {
+	return monitoringAlertSourceCondSet
+}
+
+// GetStatus retrieves the status of the CloudMonitoringAlertSource. Implements the KRShaped interface.
+func (s *CloudMonitoringAlertSource) GetStatus() *duckv1.Status {
+	return &s.Status.Status
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	metadatatesting "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var monitoringAlertSourceSpec = CloudMonitoringAlertSourceSpec{
+	AlertPolicies: []string{"12345", "67890"},
+	PubSubSpec: duckv1beta1.PubSubSpec{
+		Secret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: "secret-name",
+			},
+			Key: "secret-key",
+		},
+		SourceSpec: duckv1.SourceSpec{
+			Sink: duckv1.Destination{
+				Ref: &duckv1.KReference{
+					APIVersion: "foo",
+					Kind:       "bar",
+					Namespace:  "baz",
+					Name:       "qux",
+				},
+			},
+		},
+		Project: "my-eventing-project",
+	},
+}
+
+func TestCloudMonitoringAlertSourceValidationFields(t *testing.T) {
+	withSpec := func(f func(*CloudMonitoringAlertSourceSpec)) CloudMonitoringAlertSourceSpec {
+		spec := monitoringAlertSourceSpec.DeepCopy()
+		f(spec)
+		return *spec
+	}
+	testCases := map[string]struct {
+		spec CloudMonitoringAlertSourceSpec
+		want *apis.FieldError
+	}{
+		"ok": {
+			spec: monitoringAlertSourceSpec,
+		},
+		"missing sink": {
+			spec: withSpec(func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.Sink = duckv1.Destination{}
+			}),
+			want: apis.ErrMissingField("sink"),
+		},
+		"missing alert policies": {
+			spec: withSpec(func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.AlertPolicies = nil
+			}),
+			want: apis.ErrMissingField("alertPolicies"),
+		},
+		"alert policy name": {
+			spec: withSpec(func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.AlertPolicies = []string{"12345", "projects/my-eventing-project/alertPolicies/67890"}
+			}),
+			want: apis.ErrInvalidArrayValue("projects/my-eventing-project/alertPolicies/67890", "alertPolicies", 1),
+		},
+		"duplicate alert policy": {
+			spec: withSpec(func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.AlertPolicies = []string{"12345", "12345"}
+			}),
+			want: apis.ErrInvalidArrayValue("12345", "alertPolicies", 1),
+		},
+		"secret and service account": {
+			spec: withSpec(func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.ServiceAccountName = validServiceAccountName
+			}),
+			want: &apis.FieldError{
+				Message: "Can't have spec.serviceAccountName and spec.secret at the same time",
+				Paths:   []string{""},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := tc.spec.Validate(context.TODO())
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Errorf("unexpected error (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestCloudMonitoringAlertSourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		orig    *CloudMonitoringAlertSourceSpec
+		updated func(*CloudMonitoringAlertSourceSpec)
+		allowed bool
+	}{
+		"nil orig": {
+			updated: func(*CloudMonitoringAlertSourceSpec) {},
+			allowed: true,
+		},
+		"no change": {
+			orig:    &monitoringAlertSourceSpec,
+			updated: func(*CloudMonitoringAlertSourceSpec) {},
+			allowed: true,
+		},
+		"Sink changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.Sink.Ref.Name = "some-other-name"
+			},
+			allowed: true,
+		},
+		"AlertPolicies changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.AlertPolicies = []string{"67890"}
+			},
+			allowed: true,
+		},
+		"Secret changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.Secret.Name = "some-other-secret"
+			},
+			allowed: false,
+		},
+		"Project changed": {
+			orig: &monitoringAlertSourceSpec,
+			updated: func(spec *CloudMonitoringAlertSourceSpec) {
+				spec.Project = "some-other-project"
+			},
+			allowed: false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Annotations: map[string]string{
+					duckv1beta1.ClusterNameAnnotation: metadatatesting.FakeClusterName,
+				},
+			}
+			var orig *CloudMonitoringAlertSource
+			if tc.orig != nil {
+				orig = &CloudMonitoringAlertSource{ObjectMeta: objectMeta, Spec: *tc.orig}
+			}
+			updated := &CloudMonitoringAlertSource{ObjectMeta: objectMeta, Spec: *monitoringAlertSourceSpec.DeepCopy()}
+			tc.updated(&updated.Spec)
+			err := updated.CheckImmutableFields(context.TODO(), orig)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -47,15 +48,16 @@ type CloudPubSubSource struct {
 
 // Verify that CloudPubSubSource matches various duck types.
 var (
-	_ apis.Convertible             = (*CloudPubSubSource)(nil)
-	_ apis.Defaultable             = (*CloudPubSubSource)(nil)
-	_ apis.Validatable             = (*CloudPubSubSource)(nil)
-	_ runtime.Object               = (*CloudPubSubSource)(nil)
-	_ kmeta.OwnerRefable           = (*CloudPubSubSource)(nil)
-	_ resourcesemantics.GenericCRD = (*CloudPubSubSource)(nil)
-	_ kngcpduck.Identifiable       = (*CloudPubSubSource)(nil)
-	_ kngcpduck.PubSubable         = (*CloudPubSubSource)(nil)
-	_ duckv1.KRShaped              = (*CloudPubSubSource)(nil)
+	_ apis.Convertible              = (*CloudPubSubSource)(nil)
+	_ apis.Defaultable              = (*CloudPubSubSource)(nil)
+	_ apis.Validatable              = (*CloudPubSubSource)(nil)
+	_ runtime.Object                = (*CloudPubSubSource)(nil)
+	_ kmeta.OwnerRefable            = (*CloudPubSubSource)(nil)
+	_ resourcesemantics.GenericCRD  = (*CloudPubSubSource)(nil)
+	_ kngcpduck.Identifiable        = (*CloudPubSubSource)(nil)
+	_ kngcpduck.PubSubable          = (*CloudPubSubSource)(nil)
+	_ kngcpduck.AdapterConfigurable = (*CloudPubSubSource)(nil)
+	_ duckv1.KRShaped               = (*CloudPubSubSource)(nil)
 )
 
 // CloudPubSubSourceSpec defines the desired state of the CloudPubSubSource.
@@ -88,6 +90,49 @@ type CloudPubSubSourceSpec struct {
 	// shorter than 10 minutes. Defaults to 7 days ('7d').
 	// +optional
 	RetentionDuration *string `json:"retentionDuration,omitempty"`
+
+	// EventMapping configures how the Pub/Sub messages are mapped to
+	// CloudEvents. If not set, the events have the type
+	// com.google.cloud.pubsub.topic.publish and the Pub/Sub push envelope of
+	// the message as data.
+	// +optional
+	EventMapping *CloudPubSubSourceEventMapping `json:"eventMapping,omitempty"`
+}
+
+// CloudPubSubSourceEventMapping maps the attributes and data of Pub/Sub
+// messages to CloudEvent attributes.
+//
+// The attributes are templates, in which ${attributes.<name>} is replaced by
+// the value of the message attribute <name>, and ${data.<path>} by the value
+// at the dot-separated <path> of the JSON message data. If a referenced value
+// is missing, the attribute keeps its default value, and an extension or the
+// subject is not set.
+type CloudPubSubSourceEventMapping struct {
+	// Type is the template of the type of the events.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source is the template of the source of the events.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Subject is the template of the subject of the events.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// ID is the template of the ID of the events.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Extensions are the templates of the extensions of the events, keyed by
+	// extension name.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// UnwrapData sets the data of the events to the data of the messages,
+	// instead of their Pub/Sub push envelope.
+	// +optional
+	UnwrapData bool `json:"unwrapData,omitempty"`
 }
 
 // GetAckDeadline parses AckDeadline and returns the default if an error occurs.
@@ -177,6 +222,27 @@ func (s *CloudPubSubSource) PubSubStatus() *duckv1beta1.PubSubStatus {
 	return &s.Status.PubSubStatus
 }
 
+// CloudPubSubSourceAdapterOptions are the options of the receive adapter of a
+// CloudPubSubSource, which maps the messages to events.
+type CloudPubSubSourceAdapterOptions struct {
+	EventMapping *CloudPubSubSourceEventMapping `json:"eventMapping,omitempty"`
+}
+
+// Methods for adapterConfigurable interface.
+
+// AdapterOptions returns the JSON serialized CloudPubSubSourceAdapterOptions, or
+// the empty string if the messages are not mapped.
+func (s *CloudPubSubSource) AdapterOptions() string {
+	if s.Spec.EventMapping == nil {
+		return ""
+	}
+	// Marshaling can't fail, as the options only consist of strings and booleans.
+	b, _ := json.Marshal(CloudPubSubSourceAdapterOptions{
+		EventMapping: s.Spec.EventMapping,
+	})
+	return string(b)
+}
+
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
 func (*CloudPubSubSource) GetConditionSet() apis.ConditionSet {
 	return pubSubCondSet
@@ -162,4 +162,32 @@ func TestCloudPubSubSource_GetStatus(t *testing.T) {
 	if got, want := s.GetStatus(), &s.Status.Status; got != want {
 		t.Errorf("GetStatus=%v, want=%v", got, want)
 	}
-}
\ No newline at end of file
+}
+
+func TestCloudPubSubSourceAdapterOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec CloudPubSubSourceSpec
+		want string
+	}{{
+		name: "no mapping",
+	}, {
+		name: "mapping",
+		spec: CloudPubSubSourceSpec{
+			EventMapping: &CloudPubSubSourceEventMapping{
+				Type:       "${attributes.eventType}",
+				Extensions: map[string]string{"tenant": "${data.tenant}"},
+				UnwrapData: true,
+			},
+		},
+		want: `{"eventMapping":{"type":"${attributes.eventType}","extensions":{"tenant":"${data.tenant}"},"unwrapData":true}}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &CloudPubSubSource{Spec: tc.spec}
+			if got := s.AdapterOptions(); got != tc.want {
+				t.Errorf("AdapterOptions got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
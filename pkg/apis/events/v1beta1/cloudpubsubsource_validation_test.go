@@ -175,6 +175,81 @@ func TestCloudPubSubSourceCheckValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"event mapping": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type:       "com.example.${attributes.eventType}",
+					Source:     "//example.com/${attributes.app.name}",
+					Subject:    "orders/${data.order.id}",
+					ID:         "${attributes.eventId}",
+					Extensions: map[string]string{"tenant": "${data.tenant}"},
+					UnwrapData: true,
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad event mapping, unknown reference": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type: "${headers.type}",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, empty data path segment": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Subject: "${data..id}",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, unclosed reference": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					ID: "${attributes.id",
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, extension name": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Extensions: map[string]string{"Tenant-ID": "${data.tenant}"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, reserved extension name": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Extensions: map[string]string{"subject": "${data.tenant}"},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad event mapping, empty extension": {
+			spec: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Extensions: map[string]string{"tenant": ""},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
 		"invalid secret, missing key": {
 			spec: func() CloudPubSubSourceSpec {
 				obj := pubSubSourceSpec.DeepCopy()
@@ -438,6 +513,18 @@ func TestCloudPubSubSourceCheckImmutableFields(t *testing.T) {
 			updated: pubSubSourceSpec,
 			allowed: true,
 		},
+		"EventMapping changed": {
+			orig: &pubSubSourceSpec,
+			updated: func() CloudPubSubSourceSpec {
+				obj := pubSubSourceSpec.DeepCopy()
+				obj.EventMapping = &CloudPubSubSourceEventMapping{
+					Type:       "${attributes.eventType}",
+					UnwrapData: true,
+				}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"not spec": {
 			orig:    []string{"wrong"},
 			updated: pubSubSourceSpec,
@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"knative.dev/pkg/apis"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 )
 
 // GetCondition returns the condition currently associated with the given type, or nil.
@@ -52,3 +54,14 @@ func (s *CloudSchedulerSourceStatus) MarkJobReady(jobName string) {
 	schedulerCondSet.Manage(s).MarkTrue(JobReady)
 	s.JobName = jobName
 }
+
+// MarkHTTPDelivery sets the Topic and PullSubscription conditions of a CloudSchedulerSource
+// which delivers its executions over HTTP, and so doesn't need them, and clears their
+// status fields.
+func (s *CloudSchedulerSourceStatus) MarkHTTPDelivery() {
+	for _, t := range []apis.ConditionType{duckv1beta1.TopicReady, duckv1beta1.PullSubscriptionReady} {
+		schedulerCondSet.Manage(s).MarkTrueWithReason(t, HTTPDeliveryReason, "Executions are delivered over HTTP")
+	}
+	s.TopicID = ""
+	s.SubscriptionID = ""
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 )
 
 func TestCloudSchedulerSourceStatusIsReady(t *testing.T) {
@@ -116,6 +118,16 @@ func TestCloudSchedulerSourceStatusIsReady(t *testing.T) {
 				return &s.Status
 			}(),
 			want: true,
+		}, {
+			name: "ready with http delivery",
+			s: func() *CloudSchedulerSourceStatus {
+				s := &CloudSchedulerSource{}
+				s.Status.InitializeConditions()
+				s.Status.MarkHTTPDelivery()
+				s.Status.MarkJobReady("jobName")
+				return &s.Status
+			}(),
+			want: true,
 		}}
 
 	for _, test := range tests {
@@ -185,6 +197,21 @@ func TestCloudSchedulerSourceStatusGetCondition(t *testing.T) {
 			Type:   JobReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "http delivery",
+		s: func() *CloudSchedulerSourceStatus {
+			s := &CloudSchedulerSourceStatus{}
+			s.InitializeConditions()
+			s.MarkHTTPDelivery()
+			return s
+		}(),
+		condQuery: duckv1beta1.TopicReady,
+		want: &apis.Condition{
+			Type:    duckv1beta1.TopicReady,
+			Status:  corev1.ConditionTrue,
+			Reason:  HTTPDeliveryReason,
+			Message: "Executions are delivered over HTTP",
+		},
 	}}
 
 	for _, test := range tests {
@@ -84,6 +84,76 @@ type CloudSchedulerSourceSpec struct {
 
 	// What data to send
 	Data string `json:"data"`
+
+	// TimeZone is the time zone the schedule is interpreted in, as a name from the tz
+	// database, e.g. "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RetryConfig is the retry policy of failed executions of the job. By default, failed
+	// executions are not retried.
+	// +optional
+	RetryConfig *SchedulerRetryConfig `json:"retryConfig,omitempty"`
+
+	// AttemptDeadline is the deadline of an execution attempt of the job, as an ISO 8601
+	// duration between 15 seconds and 30 minutes, e.g. "PT5M". Defaults to 3 minutes.
+	// +optional
+	AttemptDeadline *string `json:"attemptDeadline,omitempty"`
+
+	// Paused pauses the job. The job is not executed while it's paused, and resumes on
+	// its schedule once Paused is set to false.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Delivery is how the job delivers its executions, either "PubSub" or "HTTP". With
+	// "PubSub", the job publishes to a Pub/Sub topic, which is pulled by a receive adapter
+	// dedicated to the source. With "HTTP", the job calls the shared scheduler ingress of
+	// the cluster, which sends the events to the sink, and no Topic, PullSubscription or
+	// receive adapter is created. Defaults to "PubSub". Immutable.
+	// +optional
+	Delivery SchedulerDelivery `json:"delivery,omitempty"`
+}
+
+// SchedulerDelivery is how a CloudSchedulerSource job delivers its executions.
+type SchedulerDelivery string
+
+const (
+	// SchedulerDeliveryPubSub delivers the executions through a Pub/Sub topic and a receive adapter.
+	SchedulerDeliveryPubSub SchedulerDelivery = "PubSub"
+	// SchedulerDeliveryHTTP delivers the executions through the shared scheduler ingress.
+	SchedulerDeliveryHTTP SchedulerDelivery = "HTTP"
+)
+
+// IsHTTPDelivery returns true if the job delivers its executions through the shared scheduler ingress.
+func (s *CloudSchedulerSourceSpec) IsHTTPDelivery() bool {
+	return s.Delivery == SchedulerDeliveryHTTP
+}
+
+// SchedulerRetryConfig is the retry policy of failed executions of a CloudSchedulerSource job.
+// Durations are ISO 8601 durations, e.g. "PT10S".
+type SchedulerRetryConfig struct {
+	// RetryCount is the number of times a failed execution is retried, from 0 to 5.
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// MaxRetryDuration is the time limit for retrying a failed execution, measured from
+	// its first attempt. Defaults to unlimited.
+	// +optional
+	MaxRetryDuration *string `json:"maxRetryDuration,omitempty"`
+
+	// MinBackoffDuration is the minimum time to wait before retrying. Defaults to 5
+	// seconds.
+	// +optional
+	MinBackoffDuration *string `json:"minBackoffDuration,omitempty"`
+
+	// MaxBackoffDuration is the maximum time to wait before retrying. Defaults to 1 hour.
+	// +optional
+	MaxBackoffDuration *string `json:"maxBackoffDuration,omitempty"`
+
+	// MaxDoublings is the number of times the time between retries doubles before it
+	// increases linearly. Defaults to 5.
+	// +optional
+	MaxDoublings *int32 `json:"maxDoublings,omitempty"`
 }
 
 const (
@@ -92,6 +162,10 @@ const (
 
 	// JobReady has status True when CloudSchedulerSource Job has been successfully created.
 	JobReady apis.ConditionType = "JobReady"
+
+	// HTTPDeliveryReason is the reason of the Topic and PullSubscription conditions of a
+	// CloudSchedulerSource which delivers its executions over HTTP.
+	HTTPDeliveryReason = "HTTPDelivery"
 )
 
 var schedulerCondSet = apis.NewLivingConditionSet(
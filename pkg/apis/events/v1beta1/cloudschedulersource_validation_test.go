@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -347,6 +348,96 @@ func TestCloudSchedulerSourceSpecValidationFields(t *testing.T) {
 
 }
 
+func TestCloudSchedulerSourceSpecValidationJobOptions(t *testing.T) {
+	withOptions := func(f func(*CloudSchedulerSourceSpec)) *CloudSchedulerSourceSpec {
+		spec := minimalCloudSchedulerSourceSpec.DeepCopy()
+		f(spec)
+		return spec
+	}
+	testCases := []struct {
+		name string
+		spec *CloudSchedulerSourceSpec
+		want *apis.FieldError
+	}{{
+		name: "valid job options",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.TimeZone = "America/New_York"
+			spec.RetryConfig = &SchedulerRetryConfig{
+				RetryCount:         3,
+				MaxRetryDuration:   ptr.String("PT1H"),
+				MinBackoffDuration: ptr.String("PT10S"),
+				MaxBackoffDuration: ptr.String("PT10M"),
+				MaxDoublings:       ptr.Int32(2),
+			}
+			spec.AttemptDeadline = ptr.String("PT5M")
+			spec.Paused = true
+			spec.Delivery = SchedulerDeliveryHTTP
+		}),
+	}, {
+		name: "invalid time zone",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.TimeZone = "Mars/Olympus_Mons"
+		}),
+		want: apis.ErrInvalidValue("Mars/Olympus_Mons", "timeZone"),
+	}, {
+		name: "retry count out of bounds",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.RetryConfig = &SchedulerRetryConfig{RetryCount: 6}
+		}),
+		want: apis.ErrOutOfBoundsValue(6, 0, 5, "retryConfig.retryCount"),
+	}, {
+		name: "negative max doublings",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.RetryConfig = &SchedulerRetryConfig{MaxDoublings: ptr.Int32(-1)}
+		}),
+		want: apis.ErrInvalidValue(-1, "retryConfig.maxDoublings"),
+	}, {
+		name: "invalid retry duration",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.RetryConfig = &SchedulerRetryConfig{MaxRetryDuration: ptr.String("1h")}
+		}),
+		want: apis.ErrInvalidValue("1h", "retryConfig.maxRetryDuration"),
+	}, {
+		name: "min backoff greater than max backoff",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.RetryConfig = &SchedulerRetryConfig{
+				MinBackoffDuration: ptr.String("PT1H"),
+				MaxBackoffDuration: ptr.String("PT1M"),
+			}
+		}),
+		want: (&apis.FieldError{
+			Message: "minBackoffDuration must not be greater than maxBackoffDuration",
+			Paths:   []string{"minBackoffDuration", "maxBackoffDuration"},
+		}).ViaField("retryConfig"),
+	}, {
+		name: "attempt deadline too short",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.AttemptDeadline = ptr.String("PT10S")
+		}),
+		want: apis.ErrInvalidValue("PT10S", "attemptDeadline"),
+	}, {
+		name: "attempt deadline too long",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.AttemptDeadline = ptr.String("PT1H")
+		}),
+		want: apis.ErrInvalidValue("PT1H", "attemptDeadline"),
+	}, {
+		name: "invalid delivery",
+		spec: withOptions(func(spec *CloudSchedulerSourceSpec) {
+			spec.Delivery = "Carrier"
+		}),
+		want: apis.ErrInvalidValue("Carrier", "delivery"),
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.spec.Validate(context.TODO())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate CloudSchedulerSourceSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
 func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
 		orig    interface{}
@@ -375,7 +466,7 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       schedulerWithSecret.Data,
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"Data changed": {
 			orig: &schedulerWithSecret,
@@ -385,6 +476,31 @@ func TestCloudSchedulerSourceSpecCheckImmutableFields(t *testing.T) {
 				Data:       "some-other-data",
 				PubSubSpec: schedulerWithSecret.PubSubSpec,
 			},
+			allowed: true,
+		},
+		"Job options changed": {
+			orig: &schedulerWithSecret,
+			updated: CloudSchedulerSourceSpec{
+				Location:        schedulerWithSecret.Location,
+				Schedule:        schedulerWithSecret.Schedule,
+				Data:            schedulerWithSecret.Data,
+				TimeZone:        "America/New_York",
+				RetryConfig:     &SchedulerRetryConfig{RetryCount: 3},
+				AttemptDeadline: ptr.String("PT5M"),
+				Paused:          true,
+				PubSubSpec:      schedulerWithSecret.PubSubSpec,
+			},
+			allowed: true,
+		},
+		"Delivery changed": {
+			orig: &schedulerWithSecret,
+			updated: CloudSchedulerSourceSpec{
+				Location:   schedulerWithSecret.Location,
+				Schedule:   schedulerWithSecret.Schedule,
+				Data:       schedulerWithSecret.Data,
+				Delivery:   SchedulerDeliveryHTTP,
+				PubSubSpec: schedulerWithSecret.PubSubSpec,
+			},
 			allowed: false,
 		},
 		"Secret.Name changed": {
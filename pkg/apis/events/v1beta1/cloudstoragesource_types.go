@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"fmt"
 
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
@@ -46,15 +47,16 @@ type CloudStorageSource struct {
 
 // Verify that CloudStorageSource matches various duck types.
 var (
-	_ apis.Convertible             = (*CloudStorageSource)(nil)
-	_ apis.Defaultable             = (*CloudStorageSource)(nil)
-	_ apis.Validatable             = (*CloudStorageSource)(nil)
-	_ runtime.Object               = (*CloudStorageSource)(nil)
-	_ kmeta.OwnerRefable           = (*CloudStorageSource)(nil)
-	_ resourcesemantics.GenericCRD = (*CloudStorageSource)(nil)
-	_ kngcpduck.Identifiable       = (*CloudStorageSource)(nil)
-	_ kngcpduck.PubSubable         = (*CloudStorageSource)(nil)
-	_ duckv1.KRShaped              = (*CloudStorageSource)(nil)
+	_ apis.Convertible              = (*CloudStorageSource)(nil)
+	_ apis.Defaultable              = (*CloudStorageSource)(nil)
+	_ apis.Validatable              = (*CloudStorageSource)(nil)
+	_ runtime.Object                = (*CloudStorageSource)(nil)
+	_ kmeta.OwnerRefable            = (*CloudStorageSource)(nil)
+	_ resourcesemantics.GenericCRD  = (*CloudStorageSource)(nil)
+	_ kngcpduck.Identifiable        = (*CloudStorageSource)(nil)
+	_ kngcpduck.PubSubable          = (*CloudStorageSource)(nil)
+	_ kngcpduck.AdapterConfigurable = (*CloudStorageSource)(nil)
+	_ duckv1.KRShaped               = (*CloudStorageSource)(nil)
 )
 
 // CloudStorageSourceSpec is the spec for a CloudStorageSource resource
@@ -78,8 +80,58 @@ type CloudStorageSourceSpec struct {
 	// See https://cloud.google.com/storage/docs/pubsub-notifications#payload.
 	// +optional
 	PayloadFormat string `json:"payloadFormat,omitempty"`
+
+	// CustomAttributes are added to the Pub/Sub messages of the notifications,
+	// and are set as extensions of the CloudEvents sent to the sink.
+	// +optional
+	CustomAttributes map[string]string `json:"customAttributes,omitempty"`
+
+	// ObjectNameSuffix limits the events sent to the sink to objects with this suffix.
+	// +optional
+	ObjectNameSuffix string `json:"objectNameSuffix,omitempty"`
+
+	// ObjectNameFilter limits the events sent to the sink to objects whose names
+	// match its patterns.
+	// +optional
+	ObjectNameFilter *ObjectNameFilter `json:"objectNameFilter,omitempty"`
+}
+
+// ObjectNameFilter filters the events of a CloudStorageSource by object name.
+// The events of objects which are not selected are acknowledged and dropped
+// without being sent to the sink.
+type ObjectNameFilter struct {
+	// Include selects the objects whose names match any of the patterns. If
+	// unspecified, then all objects are selected.
+	// +optional
+	Include []ObjectNamePattern `json:"include,omitempty"`
+
+	// Exclude deselects the objects whose names match any of the patterns.
+	// +optional
+	Exclude []ObjectNamePattern `json:"exclude,omitempty"`
+}
+
+// ObjectNamePattern matches object names. Exactly one of Glob and Regex must be set.
+type ObjectNamePattern struct {
+	// Glob is a glob pattern matching the whole object name. '*' matches any
+	// sequence of characters except '/', '**' matches any sequence of characters
+	// and '?' matches any character except '/'.
+	// +optional
+	Glob string `json:"glob,omitempty"`
+
+	// Regex is a regular expression in RE2 syntax matching any part of the
+	// object name.
+	// +optional
+	Regex string `json:"regex,omitempty"`
 }
 
+const (
+	// CloudStorageSourcePayloadFormatJSON sends the JSON representation of the object
+	// as the payload of the notifications.
+	CloudStorageSourcePayloadFormatJSON = "JSON_API_V1"
+	// CloudStorageSourcePayloadFormatNone sends notifications without a payload.
+	CloudStorageSourcePayloadFormatNone = "NONE"
+)
+
 const (
 	// CloudEvent types used by CloudStorageSource.
 	CloudStorageSourceFinalize       = "com.google.cloud.storage.object.finalize"
@@ -152,6 +204,29 @@ func (s *CloudStorageSource) PubSubStatus() *duckv1beta1.PubSubStatus {
 	return &s.Status.PubSubStatus
 }
 
+// CloudStorageSourceAdapterOptions are the options of the receive adapter of a
+// CloudStorageSource, which filters the events sent to the sink.
+type CloudStorageSourceAdapterOptions struct {
+	ObjectNameSuffix string            `json:"objectNameSuffix,omitempty"`
+	ObjectNameFilter *ObjectNameFilter `json:"objectNameFilter,omitempty"`
+}
+
+// Methods for adapterConfigurable interface.
+
+// AdapterOptions returns the JSON serialized CloudStorageSourceAdapterOptions, or
+// the empty string if the events are not filtered.
+func (s *CloudStorageSource) AdapterOptions() string {
+	if s.Spec.ObjectNameSuffix == "" && s.Spec.ObjectNameFilter == nil {
+		return ""
+	}
+	// Marshaling can't fail, as the options only consist of strings.
+	b, _ := json.Marshal(CloudStorageSourceAdapterOptions{
+		ObjectNameSuffix: s.Spec.ObjectNameSuffix,
+		ObjectNameFilter: s.Spec.ObjectNameFilter,
+	})
+	return string(b)
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // CloudStorageSourceList is a list of CloudStorageSource resources
@@ -106,6 +106,38 @@ func TestCloudStorageSourceIdentityStatus(t *testing.T) {
 	}
 }
 
+func TestCloudStorageSourceAdapterOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec CloudStorageSourceSpec
+		want string
+	}{{
+		name: "no filter",
+		spec: CloudStorageSourceSpec{ObjectNamePrefix: "images/"},
+	}, {
+		name: "suffix",
+		spec: CloudStorageSourceSpec{ObjectNameSuffix: ".jpg"},
+		want: `{"objectNameSuffix":".jpg"}`,
+	}, {
+		name: "patterns",
+		spec: CloudStorageSourceSpec{
+			ObjectNameFilter: &ObjectNameFilter{
+				Include: []ObjectNamePattern{{Glob: "images/**"}},
+				Exclude: []ObjectNamePattern{{Regex: "^images/tmp/"}},
+			},
+		},
+		want: `{"objectNameFilter":{"include":[{"glob":"images/**"}],"exclude":[{"regex":"^images/tmp/"}]}}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &CloudStorageSource{Spec: tc.spec}
+			if got := s.AdapterOptions(); got != tc.want {
+				t.Errorf("AdapterOptions got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestCloudStorageSource_GetConditionSet(t *testing.T) {
 	s := &CloudStorageSource{}
 
@@ -18,9 +18,11 @@ package v1beta1
 
 import (
 	"context"
+	"regexp"
 
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
@@ -28,6 +30,16 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
+var (
+	// CustomAttributeKeyRegexp matches the keys of the custom attributes of CloudStorageSources.
+	// Custom attributes are set as extensions of the events, so their keys must be valid
+	// CloudEvent extension names.
+	CustomAttributeKeyRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+
+	cloudEventContextAttributes = sets.NewString("id", "source", "specversion", "type", "datacontenttype",
+		"dataschema", "subject", "time", "data")
+)
+
 func (current *CloudStorageSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := current.Spec.Validate(ctx).ViaField("spec")
 	return duckv1beta1.ValidateAutoscalingAnnotations(ctx, current.Annotations, errs)
@@ -48,6 +60,23 @@ func (current *CloudStorageSourceSpec) Validate(ctx context.Context) *apis.Field
 		errs = errs.Also(apis.ErrMissingField("bucket"))
 	}
 
+	switch current.PayloadFormat {
+	case "", CloudStorageSourcePayloadFormatJSON, CloudStorageSourcePayloadFormatNone:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(current.PayloadFormat, "payloadFormat"))
+	}
+
+	for key := range current.CustomAttributes {
+		if !CustomAttributeKeyRegexp.MatchString(key) || cloudEventContextAttributes.Has(key) {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, "customAttributes",
+				"custom attribute keys must consist of lowercase letters and digits, and must not be a CloudEvent context attribute"))
+		}
+	}
+
+	if current.ObjectNameFilter != nil {
+		errs = errs.Also(current.ObjectNameFilter.Validate(ctx).ViaField("objectNameFilter"))
+	}
+
 	if err := duckv1beta1.ValidateCredential(current.Secret, current.ServiceAccountName); err != nil {
 		errs = errs.Also(err)
 	}
@@ -55,14 +84,40 @@ func (current *CloudStorageSourceSpec) Validate(ctx context.Context) *apis.Field
 	return errs
 }
 
+func (f *ObjectNameFilter) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, p := range f.Include {
+		errs = errs.Also(p.Validate(ctx).ViaFieldIndex("include", i))
+	}
+	for i, p := range f.Exclude {
+		errs = errs.Also(p.Validate(ctx).ViaFieldIndex("exclude", i))
+	}
+	return errs
+}
+
+func (p *ObjectNamePattern) Validate(ctx context.Context) *apis.FieldError {
+	switch {
+	case p.Glob == "" && p.Regex == "":
+		return apis.ErrMissingOneOf("glob", "regex")
+	case p.Glob != "" && p.Regex != "":
+		return apis.ErrMultipleOneOf("glob", "regex")
+	case p.Regex != "":
+		if _, err := regexp.Compile(p.Regex); err != nil {
+			return apis.ErrInvalidValue(p.Regex, "regex")
+		}
+	}
+	return nil
+}
+
 func (current *CloudStorageSource) CheckImmutableFields(ctx context.Context, original *CloudStorageSource) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
-	// Modification of EventType, Secret, ServiceAccount, Project, Bucket, ObjectNamePrefix and PayloadFormat are not allowed. Everything else is mutable.
+	// Modification of Secret, Project and Bucket are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(CloudStorageSourceSpec{},
-			"Sink", "CloudEventOverrides", "ServiceAccountName")); diff != "" {
+			"Sink", "CloudEventOverrides", "ServiceAccountName", "EventTypes", "ObjectNamePrefix", "PayloadFormat", "CustomAttributes",
+			"ObjectNameSuffix", "ObjectNameFilter")); diff != "" {
 		return &apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
@@ -20,7 +20,6 @@ import (
 	"context"
 	"testing"
 
-	cloudevents "github.com/cloudevents/sdk-go"
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -75,7 +74,7 @@ var (
 		Bucket:           "my-test-bucket",
 		EventTypes:       []string{CloudStorageSourceFinalize, CloudStorageSourceDelete},
 		ObjectNamePrefix: "test-prefix",
-		PayloadFormat:    cloudevents.ApplicationJSON,
+		PayloadFormat:    CloudStorageSourcePayloadFormatJSON,
 		PubSubSpec: duckv1beta1.PubSubSpec{
 			SourceSpec: duckv1.SourceSpec{
 				Sink: duckv1.Destination{
@@ -341,6 +340,93 @@ func TestSpecValidationFields(t *testing.T) {
 	}
 }
 
+func TestSpecValidationNotificationFields(t *testing.T) {
+	withFields := func(payloadFormat string, customAttributes map[string]string) *CloudStorageSourceSpec {
+		spec := storageSourceSpec.DeepCopy()
+		spec.PubSubSpec.Secret = nil
+		spec.PayloadFormat = payloadFormat
+		spec.CustomAttributes = customAttributes
+		return spec
+	}
+	testCases := []struct {
+		name string
+		spec *CloudStorageSourceSpec
+		want *apis.FieldError
+	}{{
+		name: "no payload",
+		spec: withFields(CloudStorageSourcePayloadFormatNone, map[string]string{"team": "storage", "env1": "prod"}),
+	}, {
+		name: "invalid payload format",
+		spec: withFields("application/json", nil),
+		want: apis.ErrInvalidValue("application/json", "payloadFormat"),
+	}, {
+		name: "invalid custom attribute key",
+		spec: withFields("", map[string]string{"Team": "storage"}),
+		want: apis.ErrInvalidKeyName("Team", "customAttributes",
+			"custom attribute keys must consist of lowercase letters and digits, and must not be a CloudEvent context attribute"),
+	}, {
+		name: "context attribute as custom attribute key",
+		spec: withFields("", map[string]string{"subject": "object"}),
+		want: apis.ErrInvalidKeyName("subject", "customAttributes",
+			"custom attribute keys must consist of lowercase letters and digits, and must not be a CloudEvent context attribute"),
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.spec.Validate(context.TODO())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate CloudStorageSourceSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestSpecValidationObjectNameFilter(t *testing.T) {
+	withFilter := func(filter *ObjectNameFilter) *CloudStorageSourceSpec {
+		spec := storageSourceSpec.DeepCopy()
+		spec.PubSubSpec.Secret = nil
+		spec.ObjectNameSuffix = ".jpg"
+		spec.ObjectNameFilter = filter
+		return spec
+	}
+	testCases := []struct {
+		name string
+		spec *CloudStorageSourceSpec
+		want *apis.FieldError
+	}{{
+		name: "valid patterns",
+		spec: withFilter(&ObjectNameFilter{
+			Include: []ObjectNamePattern{{Glob: "images/**"}, {Regex: "^thumbnails/[0-9]+"}},
+			Exclude: []ObjectNamePattern{{Glob: "images/tmp/*"}},
+		}),
+	}, {
+		name: "empty pattern",
+		spec: withFilter(&ObjectNameFilter{
+			Include: []ObjectNamePattern{{}},
+		}),
+		want: apis.ErrMissingOneOf("glob", "regex").ViaFieldIndex("include", 0).ViaField("objectNameFilter"),
+	}, {
+		name: "glob and regex",
+		spec: withFilter(&ObjectNameFilter{
+			Exclude: []ObjectNamePattern{{Glob: "images/**"}, {Glob: "*.tmp", Regex: "tmp$"}},
+		}),
+		want: apis.ErrMultipleOneOf("glob", "regex").ViaFieldIndex("exclude", 1).ViaField("objectNameFilter"),
+	}, {
+		name: "invalid regex",
+		spec: withFilter(&ObjectNameFilter{
+			Include: []ObjectNamePattern{{Regex: "images/(.*"}},
+		}),
+		want: apis.ErrInvalidValue("images/(.*", "regex").ViaFieldIndex("include", 0).ViaField("objectNameFilter"),
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.spec.Validate(context.TODO())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate CloudStorageSourceSpec (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
 func TestCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
 		orig    interface{}
@@ -371,7 +457,7 @@ func TestCheckImmutableFields(t *testing.T) {
 				PayloadFormat:    storageSourceSpec.PayloadFormat,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"ObjectNamePrefix changed": {
 			orig: &storageSourceSpec,
@@ -382,7 +468,7 @@ func TestCheckImmutableFields(t *testing.T) {
 				PayloadFormat:    storageSourceSpec.PayloadFormat,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"PayloadFormat changed": {
 			orig: &storageSourceSpec,
@@ -390,10 +476,37 @@ func TestCheckImmutableFields(t *testing.T) {
 				Bucket:           storageSourceSpec.Bucket,
 				EventTypes:       storageSourceSpec.EventTypes,
 				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
-				PayloadFormat:    "some-other-format",
+				PayloadFormat:    CloudStorageSourcePayloadFormatNone,
 				PubSubSpec:       storageSourceSpec.PubSubSpec,
 			},
-			allowed: false,
+			allowed: true,
+		},
+		"CustomAttributes changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Bucket:           storageSourceSpec.Bucket,
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				PayloadFormat:    storageSourceSpec.PayloadFormat,
+				CustomAttributes: map[string]string{"team": "storage"},
+				PubSubSpec:       storageSourceSpec.PubSubSpec,
+			},
+			allowed: true,
+		},
+		"ObjectNameFilter changed": {
+			orig: &storageSourceSpec,
+			updated: CloudStorageSourceSpec{
+				Bucket:           storageSourceSpec.Bucket,
+				EventTypes:       storageSourceSpec.EventTypes,
+				ObjectNamePrefix: storageSourceSpec.ObjectNamePrefix,
+				PayloadFormat:    storageSourceSpec.PayloadFormat,
+				ObjectNameSuffix: ".jpg",
+				ObjectNameFilter: &ObjectNameFilter{
+					Exclude: []ObjectNamePattern{{Glob: "tmp/**"}},
+				},
+				PubSubSpec: storageSourceSpec.PubSubSpec,
+			},
+			allowed: true,
 		},
 		"Secret.Name changed": {
 			orig: &storageSourceSpec,
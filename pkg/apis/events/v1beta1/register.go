@@ -55,6 +55,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CloudPubSubSourceList{},
 		&CloudBuildSource{},
 		&CloudBuildSourceList{},
+		&CloudFirestoreSource{},
+		&CloudFirestoreSourceList{},
+		&CloudMonitoringAlertSource{},
+		&CloudMonitoringAlertSourceList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -63,6 +63,8 @@ func TestKnownTypes(t *testing.T) {
 		"CloudStorageSource",
 		"CloudSchedulerSource",
 		"CloudBuildSource",
+		"CloudFirestoreSource",
+		"CloudMonitoringAlertSource",
 	} {
 		if _, ok := types[name]; !ok {
 			t.Errorf("Did not find %q as registered type", name)
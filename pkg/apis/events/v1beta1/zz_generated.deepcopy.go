@@ -89,6 +89,16 @@ func (in *CloudAuditLogsSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudAuditLogsSourceSpec) DeepCopyInto(out *CloudAuditLogsSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.MethodNames != nil {
+		in, out := &in.MethodNames, &out.MethodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrincipalEmails != nil {
+		in, out := &in.PrincipalEmails, &out.PrincipalEmails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -147,6 +157,42 @@ func (in *CloudBuildSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudBuildSourceAdapterOptions) DeepCopyInto(out *CloudBuildSourceAdapterOptions) {
+	*out = *in
+	if in.BuildStatuses != nil {
+		in, out := &in.BuildStatuses, &out.BuildStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerIDs != nil {
+		in, out := &in.TriggerIDs, &out.TriggerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerNames != nil {
+		in, out := &in.TriggerNames, &out.TriggerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBuildSourceAdapterOptions.
+func (in *CloudBuildSourceAdapterOptions) DeepCopy() *CloudBuildSourceAdapterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudBuildSourceAdapterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudBuildSourceList) DeepCopyInto(out *CloudBuildSourceList) {
 	*out = *in
@@ -184,6 +230,26 @@ func (in *CloudBuildSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudBuildSourceSpec) DeepCopyInto(out *CloudBuildSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.BuildStatuses != nil {
+		in, out := &in.BuildStatuses, &out.BuildStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerIDs != nil {
+		in, out := &in.TriggerIDs, &out.TriggerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggerNames != nil {
+		in, out := &in.TriggerNames, &out.TriggerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -214,6 +280,232 @@ func (in *CloudBuildSourceStatus) DeepCopy() *CloudBuildSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFirestoreSource) DeepCopyInto(out *CloudFirestoreSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFirestoreSource.
+func (in *CloudFirestoreSource) DeepCopy() *CloudFirestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFirestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudFirestoreSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFirestoreSourceAdapterOptions) DeepCopyInto(out *CloudFirestoreSourceAdapterOptions) {
+	*out = *in
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFirestoreSourceAdapterOptions.
+func (in *CloudFirestoreSourceAdapterOptions) DeepCopy() *CloudFirestoreSourceAdapterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFirestoreSourceAdapterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFirestoreSourceList) DeepCopyInto(out *CloudFirestoreSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudFirestoreSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFirestoreSourceList.
+func (in *CloudFirestoreSourceList) DeepCopy() *CloudFirestoreSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFirestoreSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudFirestoreSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFirestoreSourceSpec) DeepCopyInto(out *CloudFirestoreSourceSpec) {
+	*out = *in
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFirestoreSourceSpec.
+func (in *CloudFirestoreSourceSpec) DeepCopy() *CloudFirestoreSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFirestoreSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFirestoreSourceStatus) DeepCopyInto(out *CloudFirestoreSourceStatus) {
+	*out = *in
+	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFirestoreSourceStatus.
+func (in *CloudFirestoreSourceStatus) DeepCopy() *CloudFirestoreSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFirestoreSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSource) DeepCopyInto(out *CloudMonitoringAlertSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSource.
+func (in *CloudMonitoringAlertSource) DeepCopy() *CloudMonitoringAlertSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudMonitoringAlertSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceList) DeepCopyInto(out *CloudMonitoringAlertSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudMonitoringAlertSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceList.
+func (in *CloudMonitoringAlertSourceList) DeepCopy() *CloudMonitoringAlertSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudMonitoringAlertSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceSpec) DeepCopyInto(out *CloudMonitoringAlertSourceSpec) {
+	*out = *in
+	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.AlertPolicies != nil {
+		in, out := &in.AlertPolicies, &out.AlertPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceSpec.
+func (in *CloudMonitoringAlertSourceSpec) DeepCopy() *CloudMonitoringAlertSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMonitoringAlertSourceStatus) DeepCopyInto(out *CloudMonitoringAlertSourceStatus) {
+	*out = *in
+	in.PubSubStatus.DeepCopyInto(&out.PubSubStatus)
+	if in.AlertPolicies != nil {
+		in, out := &in.AlertPolicies, &out.AlertPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMonitoringAlertSourceStatus.
+func (in *CloudMonitoringAlertSourceStatus) DeepCopy() *CloudMonitoringAlertSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMonitoringAlertSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudPubSubSource) DeepCopyInto(out *CloudPubSubSource) {
 	*out = *in
@@ -242,6 +534,50 @@ func (in *CloudPubSubSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPubSubSourceAdapterOptions) DeepCopyInto(out *CloudPubSubSourceAdapterOptions) {
+	*out = *in
+	if in.EventMapping != nil {
+		in, out := &in.EventMapping, &out.EventMapping
+		*out = new(CloudPubSubSourceEventMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPubSubSourceAdapterOptions.
+func (in *CloudPubSubSourceAdapterOptions) DeepCopy() *CloudPubSubSourceAdapterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPubSubSourceAdapterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPubSubSourceEventMapping) DeepCopyInto(out *CloudPubSubSourceEventMapping) {
+	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPubSubSourceEventMapping.
+func (in *CloudPubSubSourceEventMapping) DeepCopy() *CloudPubSubSourceEventMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPubSubSourceEventMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudPubSubSourceList) DeepCopyInto(out *CloudPubSubSourceList) {
 	*out = *in
@@ -289,6 +625,11 @@ func (in *CloudPubSubSourceSpec) DeepCopyInto(out *CloudPubSubSourceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EventMapping != nil {
+		in, out := &in.EventMapping, &out.EventMapping
+		*out = new(CloudPubSubSourceEventMapping)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -384,6 +725,16 @@ func (in *CloudSchedulerSourceList) DeepCopyObject() runtime.Object {
 func (in *CloudSchedulerSourceSpec) DeepCopyInto(out *CloudSchedulerSourceSpec) {
 	*out = *in
 	in.PubSubSpec.DeepCopyInto(&out.PubSubSpec)
+	if in.RetryConfig != nil {
+		in, out := &in.RetryConfig, &out.RetryConfig
+		*out = new(SchedulerRetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AttemptDeadline != nil {
+		in, out := &in.AttemptDeadline, &out.AttemptDeadline
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -442,6 +793,27 @@ func (in *CloudStorageSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudStorageSourceAdapterOptions) DeepCopyInto(out *CloudStorageSourceAdapterOptions) {
+	*out = *in
+	if in.ObjectNameFilter != nil {
+		in, out := &in.ObjectNameFilter, &out.ObjectNameFilter
+		*out = new(ObjectNameFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudStorageSourceAdapterOptions.
+func (in *CloudStorageSourceAdapterOptions) DeepCopy() *CloudStorageSourceAdapterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudStorageSourceAdapterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudStorageSourceList) DeepCopyInto(out *CloudStorageSourceList) {
 	*out = *in
@@ -484,6 +856,18 @@ func (in *CloudStorageSourceSpec) DeepCopyInto(out *CloudStorageSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CustomAttributes != nil {
+		in, out := &in.CustomAttributes, &out.CustomAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ObjectNameFilter != nil {
+		in, out := &in.ObjectNameFilter, &out.ObjectNameFilter
+		*out = new(ObjectNameFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -513,3 +897,81 @@ func (in *CloudStorageSourceStatus) DeepCopy() *CloudStorageSourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectNameFilter) DeepCopyInto(out *ObjectNameFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]ObjectNamePattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]ObjectNamePattern, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectNameFilter.
+func (in *ObjectNameFilter) DeepCopy() *ObjectNameFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectNameFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectNamePattern) DeepCopyInto(out *ObjectNamePattern) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectNamePattern.
+func (in *ObjectNamePattern) DeepCopy() *ObjectNamePattern {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectNamePattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerRetryConfig) DeepCopyInto(out *SchedulerRetryConfig) {
+	*out = *in
+	if in.MaxRetryDuration != nil {
+		in, out := &in.MaxRetryDuration, &out.MaxRetryDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinBackoffDuration != nil {
+		in, out := &in.MinBackoffDuration, &out.MinBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackoffDuration != nil {
+		in, out := &in.MaxBackoffDuration, &out.MaxBackoffDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDoublings != nil {
+		in, out := &in.MaxDoublings, &out.MaxDoublings
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerRetryConfig.
+func (in *SchedulerRetryConfig) DeepCopy() *SchedulerRetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerRetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -18,9 +18,148 @@ package v1alpha1
 
 import (
 	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	defaultMinReplicas       int32 = 1
+	defaultMaxReplicas       int32 = 10
+	defaultAvgCPUUtilization int32 = 95
+
+	defaultSubscriptionSize        int32 = 100
+	defaultOldestUnackedMessageAge int32 = 300
+	defaultPollingInterval         int32 = 15
+	defaultCooldownPeriod          int32 = 120
 )
 
+// The defaults of the data plane components.
+var (
+	ingressDefaults = componentDefaults{
+		cpuRequest:     "1000m",
+		memoryRequest:  "500Mi",
+		memoryLimit:    "1000Mi",
+		avgMemoryUsage: "700Mi",
+	}
+	// The memory limit of the fanout and the retry is mostly used to prevent surging memory
+	// usage causing OOM. The target memory usage is only half of the limit so that in case of
+	// surging memory usage, HPA could have enough time to kick in.
+	// See: https://github.com/google/knative-gcp/issues/1265
+	fanoutDefaults = componentDefaults{
+		cpuRequest:     "1500m",
+		memoryRequest:  "500Mi",
+		memoryLimit:    "3000Mi",
+		avgMemoryUsage: "1500Mi",
+	}
+	retryDefaults = componentDefaults{
+		cpuRequest:     "1000m",
+		memoryRequest:  "500Mi",
+		memoryLimit:    "3000Mi",
+		avgMemoryUsage: "1500Mi",
+	}
+)
+
+// componentDefaults are the default resources of a data plane component.
+type componentDefaults struct {
+	cpuRequest     string
+	memoryRequest  string
+	memoryLimit    string
+	avgMemoryUsage string
+}
+
 // SetDefaults sets the default field values for a BrokerCell.
 func (bc *BrokerCell) SetDefaults(ctx context.Context) {
-	// The BrokerCell doesn't have any default values.
+	bc.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults sets the default field values for a BrokerCellSpec.
+func (bcs *BrokerCellSpec) SetDefaults(ctx context.Context) {
+	c := &bcs.Components
+	if c.Ingress == nil {
+		c.Ingress = &ComponentParameters{}
+	}
+	c.Ingress.setDefaults(ingressDefaults)
+	if c.Fanout == nil {
+		c.Fanout = &ComponentParameters{}
+	}
+	c.Fanout.setDefaults(fanoutDefaults)
+	if c.Retry == nil {
+		c.Retry = &ComponentParameters{}
+	}
+	c.Retry.setDefaults(retryDefaults)
+}
+
+// setDefaults sets the fields of the component which are not set to the defaults.
+func (cp *ComponentParameters) setDefaults(defaults componentDefaults) {
+	cp.setDefaultResource(corev1.ResourceCPU, defaults.cpuRequest, "")
+	cp.setDefaultResource(corev1.ResourceMemory, defaults.memoryRequest, defaults.memoryLimit)
+	if cp.MinReplicas == nil {
+		minReplicas := defaultMinReplicas
+		cp.MinReplicas = &minReplicas
+	}
+	if cp.MaxReplicas == nil {
+		maxReplicas := defaultMaxReplicas
+		if maxReplicas < *cp.MinReplicas {
+			maxReplicas = *cp.MinReplicas
+		}
+		cp.MaxReplicas = &maxReplicas
+	}
+	if cp.AvgCPUUtilization == nil {
+		avgCPUUtilization := defaultAvgCPUUtilization
+		cp.AvgCPUUtilization = &avgCPUUtilization
+	}
+	if cp.AvgMemoryUsage == nil {
+		avgMemoryUsage := resource.MustParse(defaults.avgMemoryUsage)
+		cp.AvgMemoryUsage = &avgMemoryUsage
+	}
+	if cp.BacklogAutoscaling != nil {
+		cp.BacklogAutoscaling.SetDefaults()
+	}
+}
+
+// SetDefaults sets the default field values for a BacklogAutoscaling.
+func (ba *BacklogAutoscaling) SetDefaults() {
+	if ba.SubscriptionSize == nil {
+		subscriptionSize := defaultSubscriptionSize
+		ba.SubscriptionSize = &subscriptionSize
+	}
+	if ba.OldestUnackedMessageAge == nil {
+		oldestUnackedMessageAge := defaultOldestUnackedMessageAge
+		ba.OldestUnackedMessageAge = &oldestUnackedMessageAge
+	}
+	if ba.PollingInterval == nil {
+		pollingInterval := defaultPollingInterval
+		ba.PollingInterval = &pollingInterval
+	}
+	if ba.CooldownPeriod == nil {
+		cooldownPeriod := defaultCooldownPeriod
+		ba.CooldownPeriod = &cooldownPeriod
+	}
+}
+
+// setDefaultResource sets the request and the limit of the resource to the defaults if they are
+// not set. An empty default means no default. The defaults never make the request exceed the
+// limit.
+func (cp *ComponentParameters) setDefaultResource(name corev1.ResourceName, request, limit string) {
+	if cp.Resources.Requests == nil {
+		cp.Resources.Requests = corev1.ResourceList{}
+	}
+	if cp.Resources.Limits == nil {
+		cp.Resources.Limits = corev1.ResourceList{}
+	}
+	if _, ok := cp.Resources.Requests[name]; !ok && request != "" {
+		q := resource.MustParse(request)
+		if l, ok := cp.Resources.Limits[name]; ok && l.Cmp(q) < 0 {
+			q = l.DeepCopy()
+		}
+		cp.Resources.Requests[name] = q
+	}
+	if _, ok := cp.Resources.Limits[name]; !ok && limit != "" {
+		q := resource.MustParse(limit)
+		if r, ok := cp.Resources.Requests[name]; ok && r.Cmp(q) > 0 {
+			q = r.DeepCopy()
+		}
+		cp.Resources.Limits[name] = q
+	}
 }
@@ -19,9 +19,145 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func quantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
 func TestBrokerCell_SetDefaults(t *testing.T) {
-	bc := BrokerCell{}
-	bc.SetDefaults(context.TODO())
+	tests := []struct {
+		name  string
+		start *BrokerCell
+		want  *BrokerCell
+	}{{
+		name:  "empty",
+		start: &BrokerCell{},
+		want: &BrokerCell{
+			Spec: BrokerCellSpec{
+				Components: ComponentsParametersSpec{
+					Ingress: &ComponentParameters{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1000m"),
+								corev1.ResourceMemory: resource.MustParse("500Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("1000Mi"),
+							},
+						},
+						MinReplicas:       int32Ptr(1),
+						MaxReplicas:       int32Ptr(10),
+						AvgCPUUtilization: int32Ptr(95),
+						AvgMemoryUsage:    quantityPtr("700Mi"),
+					},
+					Fanout: &ComponentParameters{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1500m"),
+								corev1.ResourceMemory: resource.MustParse("500Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("3000Mi"),
+							},
+						},
+						MinReplicas:       int32Ptr(1),
+						MaxReplicas:       int32Ptr(10),
+						AvgCPUUtilization: int32Ptr(95),
+						AvgMemoryUsage:    quantityPtr("1500Mi"),
+					},
+					Retry: &ComponentParameters{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1000m"),
+								corev1.ResourceMemory: resource.MustParse("500Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("3000Mi"),
+							},
+						},
+						MinReplicas:       int32Ptr(1),
+						MaxReplicas:       int32Ptr(10),
+						AvgCPUUtilization: int32Ptr(95),
+						AvgMemoryUsage:    quantityPtr("1500Mi"),
+					},
+				},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.start
+			got.SetDefaults(context.Background())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SetDefaults (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestComponentParameters_SetDefaults(t *testing.T) {
+	got := &ComponentParameters{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("2000Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+		},
+		MinReplicas:  int32Ptr(20),
+		NodeSelector: map[string]string{"pool": "ingress"},
+	}
+	want := &ComponentParameters{
+		Resources: corev1.ResourceRequirements{
+			// The default requests and limits don't exceed the limits and requests which are set.
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("2000Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("2000Mi"),
+			},
+		},
+		MinReplicas:       int32Ptr(20),
+		MaxReplicas:       int32Ptr(20),
+		AvgCPUUtilization: int32Ptr(95),
+		AvgMemoryUsage:    quantityPtr("700Mi"),
+		NodeSelector:      map[string]string{"pool": "ingress"},
+	}
+	got.setDefaults(ingressDefaults)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("setDefaults (-want, +got) = %v", diff)
+	}
+}
+
+func TestBacklogAutoscaling_SetDefaults(t *testing.T) {
+	got := &ComponentParameters{
+		MinReplicas:        int32Ptr(0),
+		BacklogAutoscaling: &BacklogAutoscaling{SubscriptionSize: int32Ptr(10)},
+	}
+	got.setDefaults(fanoutDefaults)
+	want := &BacklogAutoscaling{
+		SubscriptionSize:        int32Ptr(10),
+		OldestUnackedMessageAge: int32Ptr(300),
+		PollingInterval:         int32Ptr(15),
+		CooldownPeriod:          int32Ptr(120),
+	}
+	if diff := cmp.Diff(want, got.BacklogAutoscaling); diff != "" {
+		t.Errorf("setDefaults (-want, +got) = %v", diff)
+	}
+	if *got.MinReplicas != 0 || *got.MaxReplicas != 10 {
+		t.Errorf("setDefaults got replicas [%d, %d], want [0, 10]", *got.MinReplicas, *got.MaxReplicas)
+	}
 }
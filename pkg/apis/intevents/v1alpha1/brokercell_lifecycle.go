@@ -158,3 +158,29 @@ func (bs *BrokerCellStatus) MarkTargetsConfigFailed(reason, format string, args
 func (bs *BrokerCellStatus) SetIngressTemplate(address string) {
 	bs.IngressTemplate = address
 }
+
+// SetDataPlaneStatus sets the health of the handlers of the data plane pods.
+func (bs *BrokerCellStatus) SetDataPlaneStatus(dp *DataPlaneStatus) {
+	bs.DataPlane = dp
+}
+
+// UnhealthyBroker returns the status of the stopped handler of the Broker, or nil if the
+// handler of the Broker hasn't stopped.
+func (dp *DataPlaneStatus) UnhealthyBroker(namespace, name string) *HandlerStatus {
+	return findHandlerStatus(dp.UnhealthyBrokers, namespace, name)
+}
+
+// UnhealthyTrigger returns the status of the stopped handler of the Trigger, or nil if the
+// handler of the Trigger hasn't stopped.
+func (dp *DataPlaneStatus) UnhealthyTrigger(namespace, name string) *HandlerStatus {
+	return findHandlerStatus(dp.UnhealthyTriggers, namespace, name)
+}
+
+func findHandlerStatus(statuses []HandlerStatus, namespace, name string) *HandlerStatus {
+	for i := range statuses {
+		if statuses[i].Namespace == namespace && statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
@@ -328,3 +328,27 @@ func TestBrokerCellConditionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestDataPlaneStatusUnhealthyHandlers(t *testing.T) {
+	bs := TestHelper.ReadyBrokerCellStatus()
+	bs.SetDataPlaneStatus(&DataPlaneStatus{
+		ProbedPods:        2,
+		UnhealthyBrokers:  []HandlerStatus{{Namespace: "ns", Name: "broker", Message: "broker stopped"}},
+		UnhealthyTriggers: []HandlerStatus{{Namespace: "ns", Name: "trigger", Message: "trigger stopped"}},
+	})
+	if !bs.IsReady() {
+		t.Error("expected happy true, got false")
+	}
+	if got := bs.DataPlane.UnhealthyBroker("ns", "broker"); got == nil || got.Message != "broker stopped" {
+		t.Errorf("unexpected unhealthy broker: %+v", got)
+	}
+	if got := bs.DataPlane.UnhealthyBroker("ns", "trigger"); got != nil {
+		t.Errorf("unexpected unhealthy broker: %+v", got)
+	}
+	if got := bs.DataPlane.UnhealthyTrigger("ns", "trigger"); got == nil || got.Message != "trigger stopped" {
+		t.Errorf("unexpected unhealthy trigger: %+v", got)
+	}
+	if got := bs.DataPlane.UnhealthyTrigger("other", "trigger"); got != nil {
+		t.Errorf("unexpected unhealthy trigger: %+v", got)
+	}
+}
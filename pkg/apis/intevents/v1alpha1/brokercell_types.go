@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -70,6 +72,102 @@ var (
 
 // BrokerCellSpec defines the desired state of a Brokercell.
 type BrokerCellSpec struct {
+	// Components is the configuration of the data plane components of the BrokerCell.
+	// +optional
+	Components ComponentsParametersSpec `json:"components,omitempty"`
+}
+
+// ComponentsParametersSpec is the configuration of each data plane component of a BrokerCell.
+type ComponentsParametersSpec struct {
+	// Ingress is the configuration of the ingress, which receives the events sent to the Brokers.
+	// +optional
+	Ingress *ComponentParameters `json:"ingress,omitempty"`
+
+	// Fanout is the configuration of the fanout, which delivers the events to the Triggers.
+	// +optional
+	Fanout *ComponentParameters `json:"fanout,omitempty"`
+
+	// Retry is the configuration of the retry, which retries the failed deliveries.
+	// +optional
+	Retry *ComponentParameters `json:"retry,omitempty"`
+}
+
+// ComponentParameters is the configuration of the deployment and the horizontal pod autoscaler
+// of a data plane component. The fields which are not set are defaulted by the webhook.
+type ComponentParameters struct {
+	// Resources are the compute resources of the container of the component.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// MinReplicas is the minimum number of replicas of the component. It can be 0 only if the
+	// component has backlog autoscaling.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas of the component.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// AvgCPUUtilization is the target average CPU utilization of the replicas, as a percentage
+	// of the requested CPU.
+	// +optional
+	AvgCPUUtilization *int32 `json:"avgCPUUtilization,omitempty"`
+
+	// AvgMemoryUsage is the target average memory usage of the replicas.
+	// +optional
+	AvgMemoryUsage *resource.Quantity `json:"avgMemoryUsage,omitempty"`
+
+	// NodeSelector is the node selector of the pods of the component.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the tolerations of the pods of the component.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the scheduling constraints of the pods of the component.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PodAnnotations are added to the pods of the component.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// Env are the environment variables of the container of the component. They override the
+	// environment variables set by the controller with the same names.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// BacklogAutoscaling scales the component on the backlog of its Pub/Sub subscriptions with
+	// KEDA, instead of on its CPU and memory usage. Only the fanout and the retry support it.
+	// +optional
+	BacklogAutoscaling *BacklogAutoscaling `json:"backlogAutoscaling,omitempty"`
+}
+
+// BacklogAutoscaling is the configuration of the autoscaling of a component on the backlog of
+// its Pub/Sub subscriptions, i.e. the decouple subscriptions of the Brokers for the fanout and
+// the retry subscriptions of the Triggers for the retry. The component is scaled on the total
+// number of undelivered messages and on the age of the oldest unacknowledged message of the
+// subscriptions.
+type BacklogAutoscaling struct {
+	// SubscriptionSize is the target number of undelivered messages of the subscriptions per
+	// replica.
+	// +optional
+	SubscriptionSize *int32 `json:"subscriptionSize,omitempty"`
+
+	// OldestUnackedMessageAge is the target age in seconds of the oldest unacknowledged message
+	// of the subscriptions.
+	// +optional
+	OldestUnackedMessageAge *int32 `json:"oldestUnackedMessageAge,omitempty"`
+
+	// PollingInterval is the interval in seconds to check the backlog of the subscriptions.
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds to wait after the subscriptions have no backlog
+	// before scaling the component down to MinReplicas.
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
 }
 
 // BrokerCellStatus represents the current state of a BrokerCell.
@@ -84,6 +182,38 @@ type BrokerCellStatus struct {
 	// `namespace`.
 	// Example: "http://broker-ingress.cloud-run-events.svc.cluster.local/{namespace}/{name}"
 	IngressTemplate string `json:"ingressTemplate,omitempty"`
+
+	// DataPlane is the health of the handlers of the Brokers and Triggers in the fanout and
+	// retry pods of the BrokerCell, as of the last time the pods were probed.
+	// +optional
+	DataPlane *DataPlaneStatus `json:"dataPlane,omitempty"`
+}
+
+// DataPlaneStatus is the health of the handlers of the data plane pods of a BrokerCell. Only
+// the Brokers and Triggers whose handlers have stopped are listed, the others are healthy.
+type DataPlaneStatus struct {
+	// ProbedPods is the number of fanout and retry pods which reported the status of their
+	// handlers.
+	ProbedPods int32 `json:"probedPods"`
+
+	// UnhealthyBrokers are the Brokers whose fanout handlers have stopped in a fanout pod.
+	// +optional
+	UnhealthyBrokers []HandlerStatus `json:"unhealthyBrokers,omitempty"`
+
+	// UnhealthyTriggers are the Triggers whose retry handlers have stopped in a retry pod.
+	// +optional
+	UnhealthyTriggers []HandlerStatus `json:"unhealthyTriggers,omitempty"`
+}
+
+// HandlerStatus is the status of the stopped handler of a Broker or a Trigger.
+type HandlerStatus struct {
+	// Namespace and Name are the namespace and the name of the Broker or the Trigger.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Message tells the pod the handler has stopped in, and the error it has stopped with.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
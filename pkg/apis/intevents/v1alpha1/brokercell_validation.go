@@ -18,12 +18,104 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"math"
 
 	"knative.dev/pkg/apis"
 )
 
+const (
+	minimumSubscriptionSize        = 5
+	minimumOldestUnackedMessageAge = 10
+	minimumPollingInterval         = 5
+	minimumCooldownPeriod          = 15
+)
+
 // Validate verifies that the BrokerCell is valid.
 func (bc *BrokerCell) Validate(ctx context.Context) *apis.FieldError {
-	// The BrokerCell doesn't have any validations.
-	return nil
+	return bc.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate verifies that the BrokerCellSpec is valid.
+func (bcs *BrokerCellSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	c := bcs.Components
+	errs = errs.Also(c.Ingress.Validate(ctx).ViaField("components", "ingress"))
+	if c.Ingress != nil && c.Ingress.BacklogAutoscaling != nil {
+		// The ingress doesn't pull from subscriptions.
+		errs = errs.Also(&apis.FieldError{
+			Message: "the ingress doesn't support backlog autoscaling",
+			Paths:   []string{"components.ingress.backlogAutoscaling"},
+		})
+	}
+	errs = errs.Also(c.Fanout.Validate(ctx).ViaField("components", "fanout"))
+	errs = errs.Also(c.Retry.Validate(ctx).ViaField("components", "retry"))
+	return errs
+}
+
+// Validate verifies that the ComponentParameters are valid.
+func (cp *ComponentParameters) Validate(ctx context.Context) *apis.FieldError {
+	if cp == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	for name, request := range cp.Resources.Requests {
+		if limit, ok := cp.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("request %s must not exceed limit %s", request.String(), limit.String()),
+				Paths:   []string{fmt.Sprintf("resources.requests.%s", name)},
+			})
+		}
+	}
+	minReplicas := int32(1)
+	if cp.MinReplicas != nil {
+		// Only KEDA can scale a deployment to zero.
+		if *cp.MinReplicas < 1 && (cp.BacklogAutoscaling == nil || *cp.MinReplicas < 0) {
+			errs = errs.Also(apis.ErrInvalidValue(*cp.MinReplicas, "minReplicas"))
+		} else {
+			minReplicas = *cp.MinReplicas
+		}
+	}
+	if cp.MaxReplicas != nil && *cp.MaxReplicas < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*cp.MaxReplicas, "maxReplicas"))
+	} else if cp.MaxReplicas != nil && *cp.MaxReplicas < minReplicas {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("maxReplicas %d must not be less than minReplicas %d", *cp.MaxReplicas, minReplicas),
+			Paths:   []string{"maxReplicas"},
+		})
+	}
+	if cp.AvgCPUUtilization != nil && *cp.AvgCPUUtilization < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*cp.AvgCPUUtilization, "avgCPUUtilization"))
+	}
+	if cp.AvgMemoryUsage != nil && cp.AvgMemoryUsage.Sign() <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(cp.AvgMemoryUsage.String(), "avgMemoryUsage"))
+	}
+	for i, env := range cp.Env {
+		if env.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("env", i))
+		}
+	}
+	errs = errs.Also(cp.BacklogAutoscaling.Validate(ctx).ViaField("backlogAutoscaling"))
+	return errs
+}
+
+// Validate verifies that the BacklogAutoscaling is valid.
+func (ba *BacklogAutoscaling) Validate(ctx context.Context) *apis.FieldError {
+	if ba == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if ba.SubscriptionSize != nil && *ba.SubscriptionSize < minimumSubscriptionSize {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*ba.SubscriptionSize, minimumSubscriptionSize, math.MaxInt32, "subscriptionSize"))
+	}
+	if ba.OldestUnackedMessageAge != nil && *ba.OldestUnackedMessageAge < minimumOldestUnackedMessageAge {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*ba.OldestUnackedMessageAge, minimumOldestUnackedMessageAge, math.MaxInt32, "oldestUnackedMessageAge"))
+	}
+	if ba.PollingInterval != nil && *ba.PollingInterval < minimumPollingInterval {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*ba.PollingInterval, minimumPollingInterval, math.MaxInt32, "pollingInterval"))
+	}
+	if ba.CooldownPeriod != nil && *ba.CooldownPeriod < minimumCooldownPeriod {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*ba.CooldownPeriod, minimumCooldownPeriod, math.MaxInt32, "cooldownPeriod"))
+	}
+	return errs
 }
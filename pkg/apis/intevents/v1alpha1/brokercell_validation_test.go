@@ -19,11 +19,107 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestBrokerCell_Validate(t *testing.T) {
-	bc := BrokerCell{}
-	if err := bc.Validate(context.TODO()); err != nil {
-		t.Errorf("expected nil, got %v", err)
+	tests := []struct {
+		name    string
+		ingress *ComponentParameters
+		fanout  *ComponentParameters
+		wantErr string
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		ingress: &ComponentParameters{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			MinReplicas:       int32Ptr(2),
+			MaxReplicas:       int32Ptr(2),
+			AvgCPUUtilization: int32Ptr(80),
+			AvgMemoryUsage:    quantityPtr("1Gi"),
+			Env:               []corev1.EnvVar{{Name: "GODEBUG", Value: "http2debug=1"}},
+		},
+	}, {
+		name: "request exceeds limit",
+		ingress: &ComponentParameters{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+		wantErr: "request 2Gi must not exceed limit 1Gi: spec.components.ingress.resources.requests.memory",
+	}, {
+		name:    "invalid min replicas",
+		ingress: &ComponentParameters{MinReplicas: int32Ptr(0)},
+		wantErr: "invalid value: 0: spec.components.ingress.minReplicas",
+	}, {
+		name:    "max replicas less than min replicas",
+		ingress: &ComponentParameters{MinReplicas: int32Ptr(3), MaxReplicas: int32Ptr(2)},
+		wantErr: "maxReplicas 2 must not be less than minReplicas 3: spec.components.ingress.maxReplicas",
+	}, {
+		name:    "invalid avg CPU utilization",
+		ingress: &ComponentParameters{AvgCPUUtilization: int32Ptr(0)},
+		wantErr: "invalid value: 0: spec.components.ingress.avgCPUUtilization",
+	}, {
+		name:    "invalid avg memory usage",
+		ingress: &ComponentParameters{AvgMemoryUsage: quantityPtr("0")},
+		wantErr: "invalid value: 0: spec.components.ingress.avgMemoryUsage",
+	}, {
+		name:    "env without name",
+		ingress: &ComponentParameters{Env: []corev1.EnvVar{{Value: "value"}}},
+		wantErr: "missing field(s): spec.components.ingress.env[0].name",
+	}, {
+		name: "valid backlog autoscaling",
+		fanout: &ComponentParameters{
+			MinReplicas: int32Ptr(0),
+			MaxReplicas: int32Ptr(5),
+			BacklogAutoscaling: &BacklogAutoscaling{
+				SubscriptionSize:        int32Ptr(50),
+				OldestUnackedMessageAge: int32Ptr(60),
+				PollingInterval:         int32Ptr(30),
+				CooldownPeriod:          int32Ptr(300),
+			},
+		},
+	}, {
+		name:    "invalid max replicas with backlog autoscaling",
+		fanout:  &ComponentParameters{MinReplicas: int32Ptr(0), MaxReplicas: int32Ptr(0), BacklogAutoscaling: &BacklogAutoscaling{}},
+		wantErr: "invalid value: 0: spec.components.fanout.maxReplicas",
+	}, {
+		name:    "invalid subscription size",
+		fanout:  &ComponentParameters{BacklogAutoscaling: &BacklogAutoscaling{SubscriptionSize: int32Ptr(1)}},
+		wantErr: "expected 5 <= 1 <= 2147483647: spec.components.fanout.backlogAutoscaling.subscriptionSize",
+	}, {
+		name:    "invalid oldest unacked message age",
+		fanout:  &ComponentParameters{BacklogAutoscaling: &BacklogAutoscaling{OldestUnackedMessageAge: int32Ptr(5)}},
+		wantErr: "expected 10 <= 5 <= 2147483647: spec.components.fanout.backlogAutoscaling.oldestUnackedMessageAge",
+	}, {
+		name:    "invalid cooldown period",
+		fanout:  &ComponentParameters{BacklogAutoscaling: &BacklogAutoscaling{CooldownPeriod: int32Ptr(10)}},
+		wantErr: "expected 15 <= 10 <= 2147483647: spec.components.fanout.backlogAutoscaling.cooldownPeriod",
+	}, {
+		name:    "ingress with backlog autoscaling",
+		ingress: &ComponentParameters{BacklogAutoscaling: &BacklogAutoscaling{}},
+		wantErr: "the ingress doesn't support backlog autoscaling: spec.components.ingress.backlogAutoscaling",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := BrokerCell{Spec: BrokerCellSpec{Components: ComponentsParametersSpec{Ingress: tc.ingress, Fanout: tc.fanout}}}
+			err := bc.Validate(context.Background())
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate got error %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Validate got error %v, want %q", err, tc.wantErr)
+			}
+		})
 	}
 }
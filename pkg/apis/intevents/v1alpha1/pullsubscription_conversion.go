@@ -43,9 +43,13 @@ func (source *PullSubscription) ConvertTo(_ context.Context, to apis.Convertible
 			sink.Spec.Mode = mode
 		}
 		sink.Spec.AdapterType = source.Spec.AdapterType
+		sink.Spec.AdapterOptions = source.Spec.AdapterOptions
+		sink.Spec.Delivery = source.Spec.Delivery
 		sink.Status.PubSubStatus = convert.ToV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.TransformerURI = source.Status.TransformerURI
 		sink.Status.SubscriptionID = source.Status.SubscriptionID
+		sink.Status.DeadLetterSinkURI = source.Status.DeadLetterSinkURI
+		sink.Status.DeadLetterTopicID = source.Status.DeadLetterTopicID
 		return nil
 	default:
 		return fmt.Errorf("unknown conversion, got: %T", sink)
@@ -71,9 +75,13 @@ func (sink *PullSubscription) ConvertFrom(_ context.Context, from apis.Convertib
 			sink.Spec.Mode = mode
 		}
 		sink.Spec.AdapterType = source.Spec.AdapterType
+		sink.Spec.AdapterOptions = source.Spec.AdapterOptions
+		sink.Spec.Delivery = source.Spec.Delivery
 		sink.Status.PubSubStatus = convert.FromV1beta1PubSubStatus(source.Status.PubSubStatus)
 		sink.Status.TransformerURI = source.Status.TransformerURI
 		sink.Status.SubscriptionID = source.Status.SubscriptionID
+		sink.Status.DeadLetterSinkURI = source.Status.DeadLetterSinkURI
+		sink.Status.DeadLetterTopicID = source.Status.DeadLetterTopicID
 		return nil
 	default:
 		return fmt.Errorf("unknown conversion, got: %T", source)
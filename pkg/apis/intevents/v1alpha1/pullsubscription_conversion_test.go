@@ -28,6 +28,7 @@ import (
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
 	"github.com/google/knative-gcp/pkg/apis/intevents/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -35,8 +36,10 @@ import (
 // These variables are used to create a 'complete' version of PullSubscription where every field is
 // filled in.
 var (
-	seconds  = int64(314)
-	duration = "30s"
+	seconds      = int64(314)
+	duration     = "30s"
+	retry        = int32(3)
+	backoffDelay = "PT1S"
 
 	completeObjectMeta = metav1.ObjectMeta{
 		Name:            "name",
@@ -158,11 +161,19 @@ var (
 			Transformer:         &completeDestination,
 			Mode:                ModeCloudEventsBinary,
 			AdapterType:         "adapterType",
+			AdapterOptions:      "adapterOptions",
+			Delivery: &eventingduckv1beta1.DeliverySpec{
+				DeadLetterSink: &completeDestination,
+				Retry:          &retry,
+				BackoffDelay:   &backoffDelay,
+			},
 		},
 		Status: PullSubscriptionStatus{
-			PubSubStatus:   completePubSubStatus,
-			TransformerURI: &completeURL,
-			SubscriptionID: "subscriptionID",
+			PubSubStatus:      completePubSubStatus,
+			TransformerURI:    &completeURL,
+			SubscriptionID:    "subscriptionID",
+			DeadLetterSinkURI: &completeURL,
+			DeadLetterTopicID: "deadLetterTopicID",
 		},
 	}
 )
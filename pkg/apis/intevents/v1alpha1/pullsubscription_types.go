@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -99,6 +100,19 @@ type PullSubscriptionSpec struct {
 	// PullSubscription uses.
 	// +optional
 	AdapterType string `json:"adapterType,omitempty"`
+
+	// AdapterOptions are the options of the receive adapter, serialized as
+	// JSON. Their format depends on the AdapterType.
+	// +optional
+	AdapterOptions string `json:"adapterOptions,omitempty"`
+
+	// Delivery is the delivery spec of the events which fail to be delivered
+	// to the sink, or to the transformer if any. Failed events are retried
+	// with backoff and are then sent to the dead letter sink, if any, or
+	// dropped. Without a delivery spec, failed events are retried until they
+	// expire from the subscription.
+	// +optional
+	Delivery *eventingduckv1beta1.DeliverySpec `json:"delivery,omitempty"`
 }
 
 // GetAckDeadline parses AckDeadline and returns the default if an error occurs.
@@ -178,6 +192,17 @@ type PullSubscriptionStatus struct {
 	// SubscriptionID is the created subscription ID used by the PullSubscription.
 	// +optional
 	SubscriptionID string `json:"subscriptionId,omitempty"`
+
+	// DeadLetterSinkURI is the current active dead letter sink URI that has
+	// been configured for the PullSubscription.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
+
+	// DeadLetterTopicID is the ID of the topic the subscription of the
+	// PullSubscription forwards undeliverable messages to. A subscription
+	// with the same ID is created on it for the receive adapter.
+	// +optional
+	DeadLetterTopicID string `json:"deadLetterTopicId,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
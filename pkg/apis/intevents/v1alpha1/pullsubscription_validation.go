@@ -24,9 +24,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	duckv1alpha1 "github.com/google/knative-gcp/pkg/apis/duck/v1alpha1"
+	"github.com/google/knative-gcp/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"knative.dev/pkg/apis"
@@ -64,6 +66,11 @@ func (current *PullSubscriptionSpec) Validate(ctx context.Context) *apis.FieldEr
 		}
 	}
 
+	// Delivery [optional]
+	if current.Delivery != nil {
+		errs = errs.Also(validateDelivery(ctx, current.Delivery).ViaField("delivery"))
+	}
+
 	if current.RetentionDuration != nil {
 		// If set, RetentionDuration Cannot be longer than 7 days or shorter than 10 minutes.
 		rd, err := time.ParseDuration(*current.RetentionDuration)
@@ -104,6 +111,30 @@ func (current *PullSubscriptionSpec) Validate(ctx context.Context) *apis.FieldEr
 	return errs
 }
 
+func validateDelivery(ctx context.Context, ds *eventingduckv1beta1.DeliverySpec) *apis.FieldError {
+	var errs *apis.FieldError
+	if ds.DeadLetterSink != nil {
+		errs = errs.Also(ds.DeadLetterSink.Validate(ctx).ViaField("deadLetterSink"))
+	}
+	if ds.Retry != nil && *ds.Retry < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*ds.Retry, "retry"))
+	}
+	if ds.BackoffPolicy != nil {
+		switch *ds.BackoffPolicy {
+		case eventingduckv1beta1.BackoffPolicyExponential, eventingduckv1beta1.BackoffPolicyLinear:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffPolicy, "backoffPolicy"))
+		}
+	}
+	if ds.BackoffDelay != nil {
+		// Unlike the eventing webhook, the backoff delay is validated as an ISO 8601 duration.
+		if _, err := utils.ParseISO8601Duration(*ds.BackoffDelay); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffDelay, "backoffDelay"))
+		}
+	}
+	return errs
+}
+
 // TODO move this to a common place.
 func validateSecret(secret *corev1.SecretKeySelector) *apis.FieldError {
 	var errs *apis.FieldError
@@ -125,7 +156,7 @@ func (current *PullSubscription) CheckImmutableFields(ctx context.Context, origi
 	// Modification of Topic, Secret and Project are not allowed. Everything else is mutable.
 	if diff := cmp.Diff(original.Spec, current.Spec,
 		cmpopts.IgnoreFields(PullSubscriptionSpec{},
-			"Sink", "Transformer", "Mode", "AckDeadline", "RetainAckedMessages", "RetentionDuration", "CloudEventOverrides")); diff != "" {
+			"Sink", "Transformer", "Mode", "AckDeadline", "RetainAckedMessages", "RetentionDuration", "CloudEventOverrides", "AdapterOptions", "Delivery")); diff != "" {
 		errs = errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
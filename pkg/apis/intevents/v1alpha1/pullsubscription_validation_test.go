@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/ptr"
@@ -31,6 +32,8 @@ import (
 )
 
 var (
+	linear = eventingduckv1beta1.BackoffPolicyLinear
+
 	pullSubscriptionSpec = PullSubscriptionSpec{
 		PubSubSpec: v1alpha1.PubSubSpec{
 			Secret: &corev1.SecretKeySelector{
@@ -186,6 +189,60 @@ func TestPubSubCheckValidationFields(t *testing.T) {
 			}(),
 			error: true,
 		},
+		"valid delivery": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					DeadLetterSink: obj.Sink.DeepCopy(),
+					Retry:          ptr.Int32(3),
+					BackoffPolicy:  &linear,
+					BackoffDelay:   ptr.String("PT1S"),
+				}
+				return *obj
+			}(),
+			error: false,
+		},
+		"bad delivery, dead letter sink": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					DeadLetterSink: &duckv1.Destination{},
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad delivery, negative retry": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					Retry: ptr.Int32(-1),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad delivery, backoff policy": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				policy := eventingduckv1beta1.BackoffPolicyType("random")
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					BackoffPolicy: &policy,
+				}
+				return *obj
+			}(),
+			error: true,
+		},
+		"bad delivery, backoff delay": {
+			spec: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					BackoffDelay: ptr.String("1s"),
+				}
+				return *obj
+			}(),
+			error: true,
+		},
 		"bad secret, missing key": {
 			spec: func() PullSubscriptionSpec {
 				obj := pullSubscriptionSpec.DeepCopy()
@@ -570,6 +627,17 @@ func TestPubSubCheckImmutableFields(t *testing.T) {
 			},
 			allowed: true,
 		},
+		"Delivery changed": {
+			orig: &pullSubscriptionSpec,
+			updated: func() PullSubscriptionSpec {
+				obj := pullSubscriptionSpec.DeepCopy()
+				obj.Delivery = &eventingduckv1beta1.DeliverySpec{
+					Retry: ptr.Int32(5),
+				}
+				return *obj
+			}(),
+			allowed: true,
+		},
 		"no change": {
 			orig:    &pullSubscriptionSpec,
 			updated: pullSubscriptionSpec,
@@ -21,18 +21,55 @@ limitations under the License.
 package v1alpha1
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	apis "knative.dev/pkg/apis"
-	v1 "knative.dev/pkg/apis/duck/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BacklogAutoscaling) DeepCopyInto(out *BacklogAutoscaling) {
+	*out = *in
+	if in.SubscriptionSize != nil {
+		in, out := &in.SubscriptionSize, &out.SubscriptionSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OldestUnackedMessageAge != nil {
+		in, out := &in.OldestUnackedMessageAge, &out.OldestUnackedMessageAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BacklogAutoscaling.
+func (in *BacklogAutoscaling) DeepCopy() *BacklogAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(BacklogAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCell) DeepCopyInto(out *BrokerCell) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -91,6 +128,7 @@ func (in *BrokerCellList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCellSpec) DeepCopyInto(out *BrokerCellSpec) {
 	*out = *in
+	in.Components.DeepCopyInto(&out.Components)
 	return
 }
 
@@ -108,6 +146,11 @@ func (in *BrokerCellSpec) DeepCopy() *BrokerCellSpec {
 func (in *BrokerCellStatus) DeepCopyInto(out *BrokerCellStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.DataPlane != nil {
+		in, out := &in.DataPlane, &out.DataPlane
+		*out = new(DataPlaneStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -121,6 +164,154 @@ func (in *BrokerCellStatus) DeepCopy() *BrokerCellStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentParameters) DeepCopyInto(out *ComponentParameters) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AvgCPUUtilization != nil {
+		in, out := &in.AvgCPUUtilization, &out.AvgCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AvgMemoryUsage != nil {
+		in, out := &in.AvgMemoryUsage, &out.AvgMemoryUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BacklogAutoscaling != nil {
+		in, out := &in.BacklogAutoscaling, &out.BacklogAutoscaling
+		*out = new(BacklogAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParameters.
+func (in *ComponentParameters) DeepCopy() *ComponentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentsParametersSpec) DeepCopyInto(out *ComponentsParametersSpec) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ComponentParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fanout != nil {
+		in, out := &in.Fanout, &out.Fanout
+		*out = new(ComponentParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ComponentParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsParametersSpec.
+func (in *ComponentsParametersSpec) DeepCopy() *ComponentsParametersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentsParametersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneStatus) DeepCopyInto(out *DataPlaneStatus) {
+	*out = *in
+	if in.UnhealthyBrokers != nil {
+		in, out := &in.UnhealthyBrokers, &out.UnhealthyBrokers
+		*out = make([]HandlerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyTriggers != nil {
+		in, out := &in.UnhealthyTriggers, &out.UnhealthyTriggers
+		*out = make([]HandlerStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneStatus.
+func (in *DataPlaneStatus) DeepCopy() *DataPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HandlerStatus) DeepCopyInto(out *HandlerStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HandlerStatus.
+func (in *HandlerStatus) DeepCopy() *HandlerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HandlerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullSubscription) DeepCopyInto(out *PullSubscription) {
 	*out = *in
@@ -198,7 +389,12 @@ func (in *PullSubscriptionSpec) DeepCopyInto(out *PullSubscriptionSpec) {
 	}
 	if in.Transformer != nil {
 		in, out := &in.Transformer, &out.Transformer
-		*out = new(v1.Destination)
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(v1beta1.DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -223,6 +419,11 @@ func (in *PullSubscriptionStatus) DeepCopyInto(out *PullSubscriptionStatus) {
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeadLetterSinkURI != nil {
+		in, out := &in.DeadLetterSinkURI, &out.DeadLetterSinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -303,7 +504,7 @@ func (in *TopicSpec) DeepCopyInto(out *TopicSpec) {
 	out.IdentitySpec = in.IdentitySpec
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(corev1.SecretKeySelector)
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.EnablePublisher != nil {
@@ -63,6 +63,14 @@ type BrokerMutation interface {
 	SetDecoupleQueue(q *Queue) BrokerMutation
 	// SetState sets the broker state.
 	SetState(s State) BrokerMutation
+	// SetOrderingKeyAttribute sets the broker ordering key attribute.
+	SetOrderingKeyAttribute(attr string) BrokerMutation
+	// SetAllowedIdentities sets the identities allowed to send events to the broker.
+	SetAllowedIdentities(ids []string) BrokerMutation
+	// SetRateLimits sets the rate limit of the broker and the rate limit of each source.
+	SetRateLimits(limit, sourceLimit *RateLimit) BrokerMutation
+	// SetEventSchemas sets the schemas of the data of the events sent to the broker.
+	SetEventSchemas(schemas *EventSchemas) BrokerMutation
 	// UpsertTargets upserts Targets to the broker.
 	// The targets' namespace and broker will be forced to be
 	// the same as the broker's namespace and name.
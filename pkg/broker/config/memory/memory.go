@@ -52,6 +52,31 @@ func (m *brokerMutation) SetState(s config.State) config.BrokerMutation {
 	return m
 }
 
+func (m *brokerMutation) SetOrderingKeyAttribute(attr string) config.BrokerMutation {
+	m.delete = false
+	m.b.OrderingKeyAttribute = attr
+	return m
+}
+
+func (m *brokerMutation) SetAllowedIdentities(ids []string) config.BrokerMutation {
+	m.delete = false
+	m.b.AllowedIdentities = ids
+	return m
+}
+
+func (m *brokerMutation) SetRateLimits(limit, sourceLimit *config.RateLimit) config.BrokerMutation {
+	m.delete = false
+	m.b.RateLimit = limit
+	m.b.SourceRateLimit = sourceLimit
+	return m
+}
+
+func (m *brokerMutation) SetEventSchemas(schemas *config.EventSchemas) config.BrokerMutation {
+	m.delete = false
+	m.b.EventSchemas = schemas
+	return m
+}
+
 func (m *brokerMutation) UpsertTargets(targets ...*config.Target) config.BrokerMutation {
 	m.delete = false
 	if m.b.Targets == nil {
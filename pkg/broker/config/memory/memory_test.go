@@ -93,6 +93,39 @@ func TestMutateBroker(t *testing.T) {
 		assertBroker(t, wantBroker, "ns", "broker", targets)
 	})
 
+	t.Run("update broker ordering key attribute", func(t *testing.T) {
+		wantBroker.OrderingKeyAttribute = "partitionkey"
+		targets.MutateBroker("ns", "broker", func(m config.BrokerMutation) {
+			m.SetOrderingKeyAttribute("partitionkey")
+		})
+		assertBroker(t, wantBroker, "ns", "broker", targets)
+	})
+
+	t.Run("update broker allowed identities", func(t *testing.T) {
+		wantBroker.AllowedIdentities = []string{"system:serviceaccount:ns:*"}
+		targets.MutateBroker("ns", "broker", func(m config.BrokerMutation) {
+			m.SetAllowedIdentities([]string{"system:serviceaccount:ns:*"})
+		})
+		assertBroker(t, wantBroker, "ns", "broker", targets)
+	})
+
+	t.Run("update broker rate limits", func(t *testing.T) {
+		wantBroker.RateLimit = &config.RateLimit{EventsPerSecond: 100, Burst: 200}
+		wantBroker.SourceRateLimit = &config.RateLimit{EventsPerSecond: 10, Burst: 10}
+		targets.MutateBroker("ns", "broker", func(m config.BrokerMutation) {
+			m.SetRateLimits(&config.RateLimit{EventsPerSecond: 100, Burst: 200}, &config.RateLimit{EventsPerSecond: 10, Burst: 10})
+		})
+		assertBroker(t, wantBroker, "ns", "broker", targets)
+	})
+
+	t.Run("update broker event schemas", func(t *testing.T) {
+		wantBroker.EventSchemas = &config.EventSchemas{Types: map[string]string{"type": `{"required": ["id"]}`}}
+		targets.MutateBroker("ns", "broker", func(m config.BrokerMutation) {
+			m.SetEventSchemas(&config.EventSchemas{Types: map[string]string{"type": `{"required": ["id"]}`}})
+		})
+		assertBroker(t, wantBroker, "ns", "broker", targets)
+	})
+
 	t1 := &config.Target{
 		Id:      "uid-1",
 		Address: "consumer1.example.com",
@@ -174,6 +207,10 @@ func TestMutateBroker(t *testing.T) {
 				Topic:        "topic",
 				Subscription: "sub",
 			})
+			m.SetOrderingKeyAttribute("partitionkey")
+			m.SetAllowedIdentities([]string{"system:serviceaccount:ns:*"})
+			m.SetRateLimits(&config.RateLimit{EventsPerSecond: 100, Burst: 200}, &config.RateLimit{EventsPerSecond: 10, Burst: 10})
+			m.SetEventSchemas(&config.EventSchemas{Types: map[string]string{"type": `{"required": ["id"]}`}})
 			m.UpsertTargets(t1, t2)
 		})
 		assertBroker(t, wantBroker, "ns", "broker", targets)
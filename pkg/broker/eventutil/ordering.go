@@ -0,0 +1,47 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventutil
+
+import (
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// OrderingKey returns the value of the given attribute of the event to be used
+// as the ordering key. The attribute can be one of the type, source, subject
+// and id attributes or an extension. An empty string is returned if the attribute
+// name is empty or the event doesn't have the attribute.
+func OrderingKey(e *event.Event, attr string) string {
+	switch attr {
+	case "":
+		return ""
+	case "type":
+		return e.Type()
+	case "source":
+		return e.Source()
+	case "subject":
+		return e.Subject()
+	case "id":
+		return e.ID()
+	}
+	if v, ok := e.Extensions()[attr]; ok {
+		if s, err := types.Format(v); err == nil {
+			return s
+		}
+	}
+	return ""
+}
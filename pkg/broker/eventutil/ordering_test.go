@@ -0,0 +1,52 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventutil
+
+import (
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+func TestOrderingKey(t *testing.T) {
+	e := event.New()
+	e.SetID("id")
+	e.SetSource("example/uri")
+	e.SetType("example.type")
+	e.SetSubject("subject")
+	e.SetExtension("partitionkey", "key")
+	e.SetExtension("partition", 10)
+
+	cases := []struct {
+		attr string
+		want string
+	}{
+		{attr: "", want: ""},
+		{attr: "type", want: "example.type"},
+		{attr: "source", want: "example/uri"},
+		{attr: "subject", want: "subject"},
+		{attr: "id", want: "id"},
+		{attr: "partitionkey", want: "key"},
+		{attr: "partition", want: "10"},
+		{attr: "missing", want: ""},
+	}
+	for _, tc := range cases {
+		if got := OrderingKey(&e, tc.attr); got != tc.want {
+			t.Errorf("OrderingKey(%q) got=%q, want=%q", tc.attr, got, tc.want)
+		}
+	}
+}
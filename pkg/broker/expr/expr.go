@@ -0,0 +1,406 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expr implements a small SQL-like expression language over
+// CloudEvent attributes, used by trigger filters. For example:
+//
+//	type LIKE 'com.example.%' AND (source = 'a' OR source = 'b')
+//	EXISTS myext AND NOT myext IN ('x', 'y')
+//	priority >= 3
+//
+// Supported predicates are =, != (or <>), <, <=, >, >=, [NOT] LIKE,
+// [NOT] IN, EXISTS, TRUE and FALSE, combined with NOT, AND, OR and
+// parentheses. Keywords are case insensitive. String literals are single or
+// double quoted, with the quote escaped by doubling it. In LIKE patterns, %
+// matches any sequence of characters, _ matches a single character and a
+// backslash escapes the next character. The ordering operators compare
+// integers and evaluate to false if either side is not an integer.
+//
+// A predicate that refers to an attribute the event does not have
+// evaluates to false. Use EXISTS to check whether an attribute is present.
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expression is a parsed expression which can be evaluated against the
+// attributes of events. It is safe for concurrent use.
+type Expression struct {
+	src  string
+	root node
+}
+
+// Parse parses the expression.
+func Parse(s string) (*Expression, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v at position %d", t, t.pos)
+	}
+	return &Expression{src: s, root: root}, nil
+}
+
+// Eval evaluates the expression against the given event attributes.
+func (e *Expression) Eval(attrs map[string]string) bool {
+	return e.root.eval(attrs)
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// node is a boolean node of the expression tree.
+type node interface {
+	eval(attrs map[string]string) bool
+}
+
+// operand is either an attribute reference or a literal.
+type operand struct {
+	attr    string
+	literal string
+}
+
+// value returns the value of the operand, and false if the operand refers
+// to a missing attribute.
+func (o operand) value(attrs map[string]string) (string, bool) {
+	if o.attr == "" {
+		return o.literal, true
+	}
+	v, ok := attrs[o.attr]
+	return v, ok
+}
+
+type boolNode bool
+
+func (n boolNode) eval(map[string]string) bool {
+	return bool(n)
+}
+
+type notNode struct {
+	n node
+}
+
+func (n notNode) eval(attrs map[string]string) bool {
+	return !n.n.eval(attrs)
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(attrs map[string]string) bool {
+	return n.left.eval(attrs) && n.right.eval(attrs)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(attrs map[string]string) bool {
+	return n.left.eval(attrs) || n.right.eval(attrs)
+}
+
+type existsNode struct {
+	attr string
+}
+
+func (n existsNode) eval(attrs map[string]string) bool {
+	_, ok := attrs[n.attr]
+	return ok
+}
+
+type compareNode struct {
+	op          string
+	left, right operand
+}
+
+func (n compareNode) eval(attrs map[string]string) bool {
+	l, ok := n.left.value(attrs)
+	if !ok {
+		return false
+	}
+	r, ok := n.right.value(attrs)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "=":
+		return l == r
+	case "!=":
+		return l != r
+	}
+	li, err := strconv.ParseInt(l, 10, 64)
+	if err != nil {
+		return false
+	}
+	ri, err := strconv.ParseInt(r, 10, 64)
+	if err != nil {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return li < ri
+	case "<=":
+		return li <= ri
+	case ">":
+		return li > ri
+	case ">=":
+		return li >= ri
+	}
+	return false
+}
+
+type likeNode struct {
+	o       operand
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+func (n likeNode) eval(attrs map[string]string) bool {
+	v, ok := n.o.value(attrs)
+	if !ok {
+		return false
+	}
+	return n.pattern.MatchString(v) != n.negate
+}
+
+type inNode struct {
+	o      operand
+	set    []operand
+	negate bool
+}
+
+func (n inNode) eval(attrs map[string]string) bool {
+	v, ok := n.o.value(attrs)
+	if !ok {
+		return false
+	}
+	for _, o := range n.set {
+		if s, ok := o.value(attrs); ok && s == v {
+			return !n.negate
+		}
+	}
+	return n.negate
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the given keyword.
+func (p *parser) accept(keyword string) bool {
+	if t := p.peek(); t.kind == tokenKeyword && t.text == keyword {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s but got %v at position %d", what, t, t.pos)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.accept("NOT") {
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n: n}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (node, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenLParen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case p.accept("TRUE"):
+		return boolNode(true), nil
+	case p.accept("FALSE"):
+		return boolNode(false), nil
+	case p.accept("EXISTS"):
+		t, err := p.expect(tokenIdent, "attribute name")
+		if err != nil {
+			return nil, err
+		}
+		return existsNode{attr: t.text}, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	negate := p.accept("NOT")
+	t = p.next()
+	switch {
+	case t.kind == tokenKeyword && t.text == "LIKE":
+		pt, err := p.expect(tokenString, "string pattern")
+		if err != nil {
+			return nil, err
+		}
+		return likeNode{o: left, pattern: likePattern(pt.text), negate: negate}, nil
+	case t.kind == tokenKeyword && t.text == "IN":
+		set, err := p.parseSet()
+		if err != nil {
+			return nil, err
+		}
+		return inNode{o: left, set: set, negate: negate}, nil
+	case negate:
+		return nil, fmt.Errorf("expected LIKE or IN but got %v at position %d", t, t.pos)
+	case t.kind == tokenOperator:
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: t.text, left: left, right: right}, nil
+	default:
+		return nil, fmt.Errorf("expected operator but got %v at position %d", t, t.pos)
+	}
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenIdent:
+		return operand{attr: t.text}, nil
+	case tokenString:
+		return operand{literal: t.text}, nil
+	case tokenInt:
+		i, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("invalid integer %v at position %d", t, t.pos)
+		}
+		// Integers are compared to attribute values by their canonical
+		// decimal form.
+		return operand{literal: strconv.FormatInt(i, 10)}, nil
+	default:
+		return operand{}, fmt.Errorf("expected attribute name or literal but got %v at position %d", t, t.pos)
+	}
+}
+
+func (p *parser) parseSet() ([]operand, error) {
+	if _, err := p.expect(tokenLParen, `"("`); err != nil {
+		return nil, err
+	}
+	var set []operand
+	for {
+		o, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, o)
+		t := p.next()
+		if t.kind == tokenRParen {
+			return set, nil
+		}
+		if t.kind != tokenComma {
+			return nil, fmt.Errorf(`expected "," or ")" but got %v at position %d`, t, t.pos)
+		}
+	}
+}
+
+// likePattern converts a LIKE pattern to an anchored regular expression.
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString(`)$`)
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expr
+
+import (
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	attrs := map[string]string{
+		"type":     "com.example.object.created",
+		"source":   "//example.com/bucket",
+		"subject":  "it's 100%",
+		"priority": "5",
+		"empty":    "",
+	}
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "TRUE", want: true},
+		{expr: "false", want: false},
+		{expr: "type = 'com.example.object.created'", want: true},
+		{expr: `type = "com.example.object.deleted"`, want: false},
+		{expr: "type != 'com.example.object.deleted'", want: true},
+		{expr: "type <> 'com.example.object.created'", want: false},
+		{expr: "'com.example.object.created' = type", want: true},
+		{expr: "subject = 'it''s 100%'", want: true},
+		{expr: "type LIKE 'com.example.%'", want: true},
+		{expr: "type like '%.created'", want: true},
+		{expr: "type LIKE 'com.example.object.create_'", want: true},
+		{expr: "type LIKE 'com.example'", want: false},
+		{expr: "type NOT LIKE 'com.example.%'", want: false},
+		{expr: `subject LIKE '%100\%'`, want: true},
+		{expr: `subject LIKE '%10\%'`, want: false},
+		{expr: "source IN ('a', '//example.com/bucket')", want: true},
+		{expr: "source IN ('a', 'b')", want: false},
+		{expr: "source NOT IN ('a', 'b')", want: true},
+		{expr: "priority = 5", want: true},
+		{expr: "priority = 005", want: true},
+		{expr: "priority > 3", want: true},
+		{expr: "priority >= 5", want: true},
+		{expr: "priority < -1", want: false},
+		{expr: "priority <= 4", want: false},
+		{expr: "type > 3", want: false},
+		{expr: "EXISTS empty", want: true},
+		{expr: "exists missing", want: false},
+		{expr: "missing = 'x'", want: false},
+		{expr: "missing != 'x'", want: false},
+		{expr: "missing NOT IN ('x')", want: false},
+		{expr: "NOT missing = 'x'", want: true},
+		{expr: "type LIKE 'com.%' AND priority > 3", want: true},
+		{expr: "type LIKE 'org.%' AND priority > 3", want: false},
+		{expr: "type LIKE 'org.%' OR priority > 3", want: true},
+		{expr: "NOT type LIKE 'org.%'", want: true},
+		{expr: "FALSE AND FALSE OR TRUE", want: true},
+		{expr: "FALSE AND (FALSE OR TRUE)", want: false},
+		{expr: "NOT (source = 'a' OR source = 'b') AND EXISTS priority", want: true},
+		{expr: "subject = type", want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := Parse(tc.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.expr, err)
+			}
+			if got := e.Eval(attrs); got != tc.want {
+				t.Errorf("Eval(%q) got=%v, want=%v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	cases := []string{
+		"",
+		"type",
+		"type =",
+		"type = 'a",
+		"type == 'a'",
+		"type ! 'a'",
+		"type = 'a' AND",
+		"(type = 'a'",
+		"type = 'a')",
+		"type NOT = 'a'",
+		"type LIKE type",
+		"type IN 'a'",
+		"type IN ('a' 'b')",
+		"type IN ()",
+		"EXISTS 'type'",
+		"type = 'a' source = 'b'",
+		"type = 99999999999999999999",
+		"type = 'a' # comment",
+	}
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			if _, err := Parse(tc); err == nil {
+				t.Errorf("Parse(%q) got nil error, want error", tc)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	const s = "type LIKE 'com.example.%'"
+	e, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.String(); got != s {
+		t.Errorf("String() got=%q, want=%q", got, s)
+	}
+}
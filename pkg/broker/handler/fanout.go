@@ -51,9 +51,15 @@ type FanoutPool struct {
 	// For sending retry events. We only need a shared client.
 	// And we can set retry topic dynamically.
 	deliverRetryClient ceclient.Client
+	// For sending events of ordered brokers to retry topics with ordering keys.
+	orderedRetryPublisher *deliver.OrderedPublisher
 	// For initial events delivery. We only need a shared client.
 	// And we can set target address dynamically.
 	deliverClient *http.Client
+	// For delivering to targets with transport options.
+	targetClients *deliver.TargetClients
+	// Compiled trigger filters shared by the filter processors of the handlers.
+	matchers      *filter.MatcherCache
 	statsReporter *metrics.DeliveryReporter
 }
 
@@ -107,16 +113,29 @@ func NewFanoutPool(
 		options.DeliveryTimeout = options.TimeoutPerEvent - (5 * time.Second)
 	}
 	p := &FanoutPool{
-		targets:            targets,
-		options:            options,
-		pubsubClient:       pubsubClient,
-		deliverClient:      deliverClient,
-		deliverRetryClient: retryClient,
-		statsReporter:      statsReporter,
+		targets:               targets,
+		options:               options,
+		pubsubClient:          pubsubClient,
+		deliverClient:         deliverClient,
+		targetClients:         deliver.NewTargetClients(deliverClient, deliver.DefaultTargetCertsDir),
+		matchers:              filter.NewMatcherCache(),
+		deliverRetryClient:    retryClient,
+		orderedRetryPublisher: deliver.NewOrderedPublisher(pubsubClient),
+		statsReporter:         statsReporter,
 	}
 	return p, nil
 }
 
+// Status returns the status of the handlers of the brokers by their keys.
+func (p *FanoutPool) Status() PoolStatus {
+	status := PoolStatus{Handlers: make(map[string]HandlerStatus)}
+	p.pool.Range(func(key, value interface{}) bool {
+		status.Handlers[key.(string)] = value.(*fanoutHandlerCache).Status()
+		return true
+	})
+	return status
+}
+
 // SyncOnce syncs once the handler pool based on the targets config.
 func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 	ctx, err := p.statsReporter.AddTags(ctx)
@@ -132,6 +151,32 @@ func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 		return true
 	})
 
+	// Stop publishing to the retry topics of targets which are no longer ordered.
+	orderedRetryTopics := make(map[string]bool)
+	// Close the clients of transport options no target uses anymore.
+	var transports []*config.Transport
+	p.targets.RangeAllTargets(func(t *config.Target) bool {
+		if t.Transport != nil {
+			transports = append(transports, t.Transport)
+		}
+		return true
+	})
+	p.targetClients.Retain(transports)
+	p.targets.RangeBrokers(func(b *config.Broker) bool {
+		if b.OrderingKeyAttribute == "" {
+			return true
+		}
+		for _, t := range b.Targets {
+			if t.RetryQueue != nil {
+				orderedRetryTopics[t.RetryQueue.Topic] = true
+			}
+		}
+		return true
+	})
+	p.orderedRetryPublisher.Retain(orderedRetryTopics)
+	// Drop the compiled filters of the targets which were deleted.
+	p.matchers.Retain(p.targets)
+
 	p.targets.RangeBrokers(func(b *config.Broker) bool {
 		if value, ok := p.pool.Load(b.Key()); ok {
 			// Skip if we don't need to renew the handler.
@@ -156,14 +201,17 @@ func (p *FanoutPool) SyncOnce(ctx context.Context) error {
 			sub,
 			processors.ChainProcessors(
 				&fanout.Processor{MaxConcurrency: p.options.MaxConcurrencyPerEvent, Targets: p.targets},
-				&filter.Processor{Targets: p.targets},
+				&filter.Processor{Targets: p.targets, Matchers: p.matchers},
 				&deliver.Processor{
-					DeliverClient:      p.deliverClient,
-					Targets:            p.targets,
-					RetryOnFailure:     true,
-					DeliverRetryClient: p.deliverRetryClient,
-					DeliverTimeout:     p.options.DeliveryTimeout,
-					StatsReporter:      p.statsReporter,
+					DeliverClient:         p.deliverClient,
+					TargetClients:         p.targetClients,
+					Targets:               p.targets,
+					RetryOnFailure:        true,
+					DeliverRetryClient:    p.deliverRetryClient,
+					DeliverTimeout:        p.options.DeliveryTimeout,
+					StatsReporter:         p.statsReporter,
+					OrderedRetryPublisher: p.orderedRetryPublisher,
+					ReplyTokenSource:      p.options.ReplyTokenSource,
 				},
 			),
 			p.options.TimeoutPerEvent,
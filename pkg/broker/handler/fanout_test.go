@@ -93,6 +93,18 @@ func TestFanoutWatchAndSync(t *testing.T) {
 		assertFanoutHandlers(t, syncPool, helper.Targets)
 	})
 
+	t.Run("status reports the handlers of the brokers", func(t *testing.T) {
+		status := getPoolStatus(t, p)
+		if got, want := len(status.Handlers), len(bs); got != want {
+			t.Errorf("pool status got %d handlers, want %d", got, want)
+		}
+		for _, b := range bs {
+			if !status.Handlers[b.Key()].Alive {
+				t.Errorf("pool status got handler of broker %q not alive", b.Key())
+			}
+		}
+	})
+
 	t.Run("adding and deleting brokers changes handlers", func(t *testing.T) {
 		// Delete old and add new.
 		for i := 0; i < 2; i++ {
@@ -25,6 +25,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	cepubsub "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
+	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	"github.com/google/knative-gcp/pkg/broker/handler/processors"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"go.uber.org/zap"
@@ -52,6 +53,14 @@ type Handler struct {
 	// cancel is function to stop pulling messages.
 	cancel context.CancelFunc
 	alive  atomic.Value
+	// stopErr is the error the handler has stopped with, if any.
+	stopErr atomic.Value
+}
+
+// stoppedWith wraps the error a handler has stopped with, as an atomic.Value must always store
+// values of the same type.
+type stoppedWith struct {
+	err error
 }
 
 // NewHandler creates a new Handler.
@@ -65,11 +74,18 @@ func NewHandler(
 		Subscription: sub,
 		Processor:    processor,
 		Timeout:      timeout,
-		retryLimiter: workqueue.NewItemExponentialFailureRateLimiter(retryPolicy.MinBackoff, retryPolicy.MaxBackoff),
+		retryLimiter: newRetryLimiter(retryPolicy),
 		delayNack:    time.Sleep,
 	}
 }
 
+func newRetryLimiter(retryPolicy RetryPolicy) workqueue.RateLimiter {
+	if retryPolicy.Linear {
+		return newItemLinearFailureRateLimiter(retryPolicy.MinBackoff, retryPolicy.MaxBackoff)
+	}
+	return workqueue.NewItemExponentialFailureRateLimiter(retryPolicy.MinBackoff, retryPolicy.MaxBackoff)
+}
+
 // Start starts the handler.
 // done func will be called if the pubsub inbound is closed.
 func (h *Handler) Start(ctx context.Context, done func(error)) {
@@ -79,7 +95,11 @@ func (h *Handler) Start(ctx context.Context, done func(error)) {
 	go func() {
 		// For any reason if inbound is closed, mark alive as false.
 		defer h.alive.Store(false)
-		done(h.Subscription.Receive(ctx, h.receive))
+		err := h.Subscription.Receive(ctx, h.receive)
+		if err != nil {
+			h.stopErr.Store(stoppedWith{err: err})
+		}
+		done(err)
 	}()
 }
 
@@ -93,13 +113,32 @@ func (h *Handler) IsAlive() bool {
 	return h.alive.Load().(bool)
 }
 
+// Err returns the error the handler has stopped with, or nil if it is alive or has stopped
+// without error.
+func (h *Handler) Err() error {
+	if v, ok := h.stopErr.Load().(stoppedWith); ok {
+		return v.err
+	}
+	return nil
+}
+
+// Status returns the status of the handler.
+func (h *Handler) Status() HandlerStatus {
+	status := HandlerStatus{Alive: h.IsAlive()}
+	if err := h.Err(); err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
 func (h *Handler) receive(ctx context.Context, msg *pubsub.Message) {
 	ctx = metrics.StartEventProcessing(ctx)
 	event, err := binding.ToEvent(ctx, cepubsub.NewMessage(msg))
 	if isNonRetryable(err) {
 		logEventConversionError(ctx, msg, err, "failed to convert received message to an event, check the msg format")
 		// Ack the message so it won't be retried.
-		// TODO Should this go to the DLQ once DLQ is implemented?
+		// The message cannot be sent to a dead letter sink either since
+		// dead letter sinks only accept events.
 		msg.Ack()
 		return
 	}
@@ -109,6 +148,7 @@ func (h *Handler) receive(ctx context.Context, msg *pubsub.Message) {
 		return
 	}
 
+	ctx = handlerctx.WithDeliveryAttempt(ctx, h.deliveryAttempt(msg))
 	if h.Timeout != 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
@@ -126,6 +166,18 @@ func (h *Handler) receive(ctx context.Context, msg *pubsub.Message) {
 	msg.Ack()
 }
 
+// deliveryAttempt returns the delivery attempt of the message. Pubsub only
+// populates the delivery attempt for subscriptions with a dead letter policy,
+// which the retry subscriptions of the Triggers with a retry limit have.
+// Otherwise we fall back to the number of times this handler has nacked the
+// message, which is only used for logging as it doesn't survive restarts.
+func (h *Handler) deliveryAttempt(msg *pubsub.Message) int {
+	if msg.DeliveryAttempt != nil {
+		return *msg.DeliveryAttempt
+	}
+	return h.retryLimiter.NumRequeues(msg.ID) + 1
+}
+
 func isNonRetryable(err error) bool {
 	// The following errors can be returned by ToEvent and are not retryable.
 	// TODO Should binding.ToEvent consolidate them and return the generic ErrCannotConvertToEvent?
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
 
+	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	"github.com/google/knative-gcp/pkg/broker/handler/processors"
 )
 
@@ -161,9 +162,12 @@ type firstNErrProc struct {
 	processors.BaseProcessor
 	desiredErrCount, currErrCount int
 	successSignal                 chan struct{}
+	attempts                      []int
 }
 
-func (p *firstNErrProc) Process(_ context.Context, _ *event.Event) error {
+func (p *firstNErrProc) Process(ctx context.Context, _ *event.Event) error {
+	attempt, _ := handlerctx.GetDeliveryAttempt(ctx)
+	p.attempts = append(p.attempts, attempt)
 	if p.currErrCount < p.desiredErrCount {
 		p.currErrCount++
 		return errors.New("always error")
@@ -246,6 +250,39 @@ func TestRetryBackoff(t *testing.T) {
 			t.Errorf("delays[%d] got=%v, want=%v", i, delays[i], wantDelay)
 		}
 	}
+	// Each redelivery should be counted as a new delivery attempt.
+	for i, attempt := range processor.attempts {
+		if attempt != i+1 {
+			t.Errorf("attempts[%d] got=%d, want=%d", i, attempt, i+1)
+		}
+	}
+}
+
+func TestHandlerStatus(t *testing.T) {
+	ctx := context.Background()
+	c, close := testPubsubClient(ctx, t, testProjectID)
+	defer close()
+
+	// The subscription doesn't exist, so the handler stops as soon as it starts pulling.
+	done := make(chan error, 1)
+	h := NewHandler(c.Subscription("missing"), &processors.FakeProcessor{}, time.Second, RetryPolicy{})
+	h.Start(ctx, func(err error) { done <- err })
+	defer h.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler didn't stop")
+	}
+	// The handler is marked as stopped right after done is called.
+	time.Sleep(100 * time.Millisecond)
+	status := h.Status()
+	if status.Alive {
+		t.Error("Status got alive handler, want stopped")
+	}
+	if status.Error == "" {
+		t.Error("Status got no error, want the error the handler has stopped with")
+	}
 }
 
 func nextEventWithTimeout(eventCh <-chan *event.Event) *event.Event {
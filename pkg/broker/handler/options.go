@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+
+	"github.com/google/knative-gcp/pkg/broker/handler/processors/deliver"
 )
 
 var (
@@ -37,9 +39,11 @@ var (
 )
 
 // RetryPolicy defines the retry policy for pubsub messages.
-// TODO: https://github.com/google/knative-gcp/issues/1100#issuecomment-638304147
 type RetryPolicy struct {
 	MinBackoff, MaxBackoff time.Duration
+	// Linear makes the backoff grow linearly with the number of retries
+	// instead of exponentially.
+	Linear bool
 }
 
 // Options holds all the options for create handler pool.
@@ -58,6 +62,8 @@ type Options struct {
 	PubsubReceiveSettings pubsub.ReceiveSettings
 	// RetryPolicy defines the retry policy for pubsub messages.
 	RetryPolicy RetryPolicy
+	// ReplyTokenSource provides the token sent with replies to the broker ingress.
+	ReplyTokenSource deliver.TokenSource
 }
 
 // NewOptions creates a Options.
@@ -122,3 +128,10 @@ func WithRetryPolicy(r RetryPolicy) Option {
 		o.RetryPolicy = r
 	}
 }
+
+// WithReplyTokenSource sets the ReplyTokenSource.
+func WithReplyTokenSource(ts deliver.TokenSource) Option {
+	return func(o *Options) {
+		o.ReplyTokenSource = ts
+	}
+}
@@ -18,6 +18,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"sync"
@@ -30,17 +31,42 @@ import (
 const (
 	// DefaultHealthCheckPort is the default port for checking sync pool health.
 	DefaultHealthCheckPort = 8080
+	// StatusPath is the path on the health check port of the status of the handlers of the
+	// sync pool.
+	StatusPath = "/status"
 )
 
 type SyncPool interface {
 	SyncOnce(ctx context.Context) error
 }
 
+// HandlerStatus is the status of a handler of a sync pool.
+type HandlerStatus struct {
+	// Alive tells whether the handler is pulling messages.
+	Alive bool `json:"alive"`
+	// Error is the error the handler has stopped with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// PoolStatus is the status of the handlers of a sync pool.
+type PoolStatus struct {
+	// Handlers are the status of the handlers by the keys of their brokers for the fanout
+	// pool, or the keys of their targets for the retry pool.
+	Handlers map[string]HandlerStatus `json:"handlers"`
+}
+
+// StatusReporter is implemented by the sync pools which report the status of their handlers.
+type StatusReporter interface {
+	Status() PoolStatus
+}
+
 type healthChecker struct {
 	mux              sync.RWMutex
 	lastReportTime   time.Time
 	maxStaleDuration time.Duration
 	port             int
+	// statusReporter reports the status of the handlers, if the sync pool supports it.
+	statusReporter StatusReporter
 }
 
 func (c *healthChecker) reportHealth() {
@@ -76,6 +102,10 @@ func (c *healthChecker) start(ctx context.Context) {
 }
 
 func (c *healthChecker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == StatusPath && c.statusReporter != nil {
+		c.serveStatus(w)
+		return
+	}
 	if req.URL.Path != "/healthz" {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -92,6 +122,17 @@ func (c *healthChecker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// serveStatus responds with the status of the handlers of the sync pool.
+func (c *healthChecker) serveStatus(w http.ResponseWriter) {
+	b, err := json.Marshal(c.statusReporter.Status())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
 // StartSyncPool starts the sync pool.
 func StartSyncPool(
 	ctx context.Context,
@@ -108,6 +149,9 @@ func StartSyncPool(
 		maxStaleDuration: maxStaleDuration,
 		port:             healthCheckPort,
 	}
+	if r, ok := syncPool.(StatusReporter); ok {
+		c.statusReporter = r
+	}
 	go c.start(ctx)
 	if syncSignal != nil {
 		go watch(ctx, syncPool, syncSignal, c)
@@ -18,6 +18,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -80,6 +81,53 @@ func TestSyncPool(t *testing.T) {
 		time.Sleep(time.Second)
 		assertHealthCheckResult(t, p, false)
 	})
+
+	t.Run("Status of the handlers of the sync pool", func(t *testing.T) {
+		want := PoolStatus{Handlers: map[string]HandlerStatus{
+			"ns/broker1": {Alive: true},
+			"ns/broker2": {Error: "rpc error: code = NotFound desc = subscription not found"},
+		}}
+		syncPool := &fakeStatusSyncPool{
+			fakeSyncPool: fakeSyncPool{syncCalled: make(chan struct{}, 1)},
+			status:       want,
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		p, err := GetFreePort()
+		if err != nil {
+			t.Fatalf("failed to get random free port: %v", err)
+		}
+
+		if _, err := StartSyncPool(ctx, syncPool, nil, time.Minute, p); err != nil {
+			t.Errorf("StartSyncPool got unexpected error: %v", err)
+		}
+		syncPool.verifySyncOnceCalled(t)
+		// Make sure the health checker is up.
+		time.Sleep(500 * time.Millisecond)
+
+		if diff := cmp.Diff(want, getPoolStatus(t, p)); diff != "" {
+			t.Errorf("pool status (-want,+got): %v", diff)
+		}
+	})
+}
+
+// getPoolStatus gets the status of the handlers from the health checker on the port.
+func getPoolStatus(t *testing.T, port int) PoolStatus {
+	t.Helper()
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, StatusPath))
+	if err != nil {
+		t.Fatalf("Failed to get pool status: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Pool status got status code %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var status PoolStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode pool status: %v", err)
+	}
+	return status
 }
 
 func assertHealthCheckResult(t *testing.T, port int, ok bool) {
@@ -124,6 +172,15 @@ func (p *fakeSyncPool) SyncOnce(ctx context.Context) error {
 	return nil
 }
 
+type fakeStatusSyncPool struct {
+	fakeSyncPool
+	status PoolStatus
+}
+
+func (p *fakeStatusSyncPool) Status() PoolStatus {
+	return p.status
+}
+
 // GetFreePort asks a free open port.
 func GetFreePort() (int, error) {
 	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
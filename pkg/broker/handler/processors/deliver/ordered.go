@@ -0,0 +1,130 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliver
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+	cepubsub "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"go.opencensus.io/trace"
+)
+
+// OrderedPublisher publishes events to pubsub topics with ordering keys.
+// The cloudevents pubsub protocol doesn't support ordering keys, so events of
+// ordered brokers are sent to the retry topics with it instead.
+type OrderedPublisher struct {
+	client *pubsub.Client
+
+	mu     sync.Mutex
+	topics map[string]*pubsub.Topic
+}
+
+// NewOrderedPublisher creates a new OrderedPublisher.
+func NewOrderedPublisher(client *pubsub.Client) *OrderedPublisher {
+	return &OrderedPublisher{
+		client: client,
+		topics: make(map[string]*pubsub.Topic),
+	}
+}
+
+// Publish publishes the event to the topic with the ordering key and waits
+// for the result.
+func (p *OrderedPublisher) Publish(ctx context.Context, topicID, orderingKey string, e *event.Event) error {
+	dt := extensions.FromSpanContext(trace.FromContext(ctx).SpanContext())
+	msg := new(pubsub.Message)
+	if err := cepubsub.WritePubSubMessage(ctx, binding.ToMessage(e), msg, dt.WriteTransformer()); err != nil {
+		return err
+	}
+	msg.OrderingKey = orderingKey
+
+	topic := p.topic(topicID)
+	if _, err := topic.Publish(ctx, msg).Get(ctx); err != nil {
+		// Publishing is paused for the ordering key after a failure. The event
+		// is nacked and redelivered along with the later events of the key, so
+		// it's safe to resume publishing.
+		topic.ResumePublish(orderingKey)
+		return err
+	}
+	return nil
+}
+
+// Retain stops and removes the topics which are not in topicIDs.
+func (p *OrderedPublisher) Retain(topicIDs map[string]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, topic := range p.topics {
+		if !topicIDs[id] {
+			topic.Stop()
+			delete(p.topics, id)
+		}
+	}
+}
+
+func (p *OrderedPublisher) topic(id string) *pubsub.Topic {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	topic, ok := p.topics[id]
+	if !ok {
+		topic = p.client.Topic(id)
+		topic.EnableMessageOrdering = true
+		p.topics[id] = topic
+	}
+	return topic
+}
+
+// keyMutex is a set of mutexes keyed by ordering key.
+type keyMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	// waiters is the number of holders and waiters of the lock.
+	waiters int
+}
+
+// lock locks the mutex of the key and returns the function to unlock it.
+func (m *keyMutex) lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyLock{}
+		m.locks[key] = l
+	}
+	l.waiters++
+	m.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(m.locks, key)
+		}
+	}
+}
@@ -18,8 +18,11 @@ package deliver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -40,6 +43,29 @@ import (
 
 const defaultEventHopsLimit int32 = 255
 
+// Extensions set on events sent to the dead letter sink to describe
+// why the delivery to the target failed.
+const (
+	deadLetterDestExtension = "knativeerrordest"
+	deadLetterCodeExtension = "knativeerrorcode"
+	deadLetterDataExtension = "knativeerrordata"
+)
+
+// statusError is returned when the target responds with a non-2xx status code.
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("event delivery failed: HTTP status code %d", e.statusCode)
+}
+
+// TokenSource provides the bearer tokens the data plane presents to the broker ingress.
+type TokenSource interface {
+	// Token returns a valid token.
+	Token(ctx context.Context) (string, error)
+}
+
 // Processor delivers events based on the broker/target in the context.
 type Processor struct {
 	processors.BaseProcessor
@@ -47,6 +73,12 @@ type Processor struct {
 	// DeliverClient is the cloudevents client to send events.
 	DeliverClient *http.Client
 
+	// TargetClients provides the clients to deliver events to targets with
+	// transport options. If nil, events are delivered to all targets with
+	// DeliverClient. Replies and dead letter events are always sent with
+	// DeliverClient.
+	TargetClients *TargetClients
+
 	// Targets is the targets from config.
 	Targets config.ReadonlyTargets
 
@@ -64,6 +96,22 @@ type Processor struct {
 
 	// StatsReporter is used to report delivery metrics.
 	StatsReporter *metrics.DeliveryReporter
+
+	// OrderedRetryPublisher is used to send events of ordered brokers to the
+	// retry topics with ordering keys. If RetryOnFailure is true, events with
+	// an ordering key are sent to the retry topic instead of being delivered,
+	// so that each target receives them in order from its retry subscription.
+	// Required if RetryOnFailure is true and any broker is ordered.
+	OrderedRetryPublisher *OrderedPublisher
+
+	// ReplyTokenSource provides the token sent with replies to the ingress, if the ingress
+	// authenticates its callers. The token is only sent with replies to the Brokers in the
+	// namespace of the target which share the ingress of the target's Broker, so that it
+	// doesn't leak to other reply destinations.
+	ReplyTokenSource TokenSource
+
+	// orderingKeys serializes the deliveries of events with the same ordering key.
+	orderingKeys keyMutex
 }
 
 var _ processors.Interface = (*Processor)(nil)
@@ -110,6 +158,18 @@ func (p *Processor) Process(ctx context.Context, event *event.Event) error {
 
 	p.StatsReporter.FinishEventProcessing(ctx)
 
+	orderingKey := eventutil.OrderingKey(event, broker.OrderingKeyAttribute)
+	if orderingKey != "" {
+		if p.RetryOnFailure {
+			return p.sendToOrderedRetryTopic(ctx, target, orderingKey, event)
+		}
+		// Deliver events of the same ordering key one at a time. Together with
+		// the ordered retry subscription, a failed event blocks the later events
+		// of its key until it's delivered or sent to the dead letter sink.
+		unlock := p.orderingKeys.lock(orderingKey)
+		defer unlock()
+	}
+
 	dctx := ctx
 	if p.DeliverTimeout > 0 {
 		var cancel context.CancelFunc
@@ -119,6 +179,9 @@ func (p *Processor) Process(ctx context.Context, event *event.Event) error {
 
 	// Forward the event copy that has hops removed.
 	if err := p.deliver(dctx, target, broker, (*binding.EventMessage)(&copy), hops); err != nil {
+		if p.retriesExhausted(ctx, target, orderingKey != "") {
+			return p.sendToDeadLetter(ctx, target, &copy, err)
+		}
 		if !p.RetryOnFailure {
 			return err
 		}
@@ -134,10 +197,18 @@ func (p *Processor) Process(ctx context.Context, event *event.Event) error {
 	return p.Next().Process(ctx, event)
 }
 
-// deliver delivers msg to target and sends the target's reply to the broker ingress.
+// deliver delivers msg to target and sends the target's reply to its reply destination, which is
+// the broker ingress by default.
 func (p *Processor) deliver(ctx context.Context, target *config.Target, broker *config.Broker, msg binding.Message, hops int32) error {
+	client := p.DeliverClient
+	if p.TargetClients != nil {
+		var err error
+		if client, err = p.TargetClients.Get(target); err != nil {
+			return err
+		}
+	}
 	startTime := time.Now()
-	resp, err := p.sendMsg(ctx, target.Address, msg)
+	resp, err := p.sendMsg(ctx, client, target.Address, msg)
 	if err != nil {
 		return err
 	}
@@ -149,7 +220,7 @@ func (p *Processor) deliver(ctx context.Context, target *config.Target, broker *
 
 	p.StatsReporter.ReportEventDispatchTime(ctx, time.Since(startTime), resp.StatusCode)
 	if resp.StatusCode/100 != 2 {
-		return fmt.Errorf("event delivery failed: HTTP status code %d", resp.StatusCode)
+		return &statusError{statusCode: resp.StatusCode}
 	}
 
 	respMsg := cehttp.NewMessageFromHttpResponse(resp)
@@ -164,6 +235,12 @@ func (p *Processor) deliver(ctx context.Context, target *config.Target, broker *
 		}, "event reply received")
 	}
 
+	if target.DropReplies {
+		logging.FromContext(ctx).Debug("target drops replies: dropping reply", zap.String("target", target.Name))
+		trace.FromContext(ctx).Annotate(nil, "Event reply dropped by target config")
+		return nil
+	}
+
 	if hops <= 0 {
 		e, err := binding.ToEvent(ctx, respMsg)
 		if err != nil {
@@ -190,8 +267,25 @@ func (p *Processor) deliver(ctx context.Context, target *config.Target, broker *
 		return nil
 	}
 
-	// Attach the previous hops for the reply.
-	replyResp, err := p.sendMsg(ctx, broker.Address, respMsg, eventutil.SetRemainingHopsTransformer(hops))
+	// Replies are sent to the broker unless the target has a reply destination.
+	replyAddress := broker.Address
+	if target.ReplyAddress != "" {
+		replyAddress = target.ReplyAddress
+	}
+	// Attach the previous hops for the reply. The hops are kept when the reply is sent to
+	// another broker, so that replies can't loop between brokers forever.
+	req, err := newRequest(ctx, replyAddress, respMsg, eventutil.SetRemainingHopsTransformer(hops))
+	if err != nil {
+		return err
+	}
+	if p.ReplyTokenSource != nil && isIngressOfNamespace(broker.Address, replyAddress, target.Namespace) {
+		token, err := p.ReplyTokenSource.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get reply token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	replyResp, err := p.DeliverClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -201,7 +295,15 @@ func (p *Processor) deliver(ctx context.Context, target *config.Target, broker *
 	return nil
 }
 
-func (p *Processor) sendMsg(ctx context.Context, address string, msg binding.Message, transformers ...binding.Transformer) (*http.Response, error) {
+func (p *Processor) sendMsg(ctx context.Context, client *http.Client, address string, msg binding.Message, transformers ...binding.Transformer) (*http.Response, error) {
+	req, err := newRequest(ctx, address, msg, transformers...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func newRequest(ctx context.Context, address string, msg binding.Message, transformers ...binding.Transformer) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address, nil)
 	if err != nil {
 		return nil, err
@@ -209,7 +311,26 @@ func (p *Processor) sendMsg(ctx context.Context, address string, msg binding.Mes
 	if err := cehttp.WriteRequest(ctx, msg, req, transformers...); err != nil {
 		return nil, err
 	}
-	return p.DeliverClient.Do(req)
+	return req, nil
+}
+
+// isIngressOfNamespace returns true if address is the address of a Broker in the namespace which
+// shares the ingress of the Broker with brokerAddress. Broker addresses have the path
+// "/<namespace>/<name>".
+func isIngressOfNamespace(brokerAddress, address, namespace string) bool {
+	b, err := url.Parse(brokerAddress)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+	if u.Scheme != b.Scheme || u.Host != b.Host {
+		return false
+	}
+	pieces := strings.Split(u.Path, "/")
+	return len(pieces) == 3 && pieces[0] == "" && pieces[1] == namespace && pieces[2] != ""
 }
 
 func (p *Processor) sendToRetryTopic(ctx context.Context, target *config.Target, event *event.Event) error {
@@ -219,3 +340,92 @@ func (p *Processor) sendToRetryTopic(ctx context.Context, target *config.Target,
 	}
 	return nil
 }
+
+func (p *Processor) sendToOrderedRetryTopic(ctx context.Context, target *config.Target, orderingKey string, event *event.Event) error {
+	if p.OrderedRetryPublisher == nil {
+		return errors.New("failed to send event to retry topic: ordered retry publisher is not configured")
+	}
+	if err := p.OrderedRetryPublisher.Publish(ctx, target.RetryQueue.Topic, orderingKey, event); err != nil {
+		return fmt.Errorf("failed to send event to retry topic: %w", err)
+	}
+	return nil
+}
+
+// retriesExhausted returns true if the target allows no more retries
+// after the current delivery attempt failed. For ordered events, the
+// initial delivery is made from the retry topic.
+func (p *Processor) retriesExhausted(ctx context.Context, target *config.Target, ordered bool) bool {
+	ds := target.DeliverySpec
+	if ds == nil || (ds.DeadLetter == "" && ds.Retry <= 0) {
+		// Without a dead letter sink or a retry limit, retry until the delivery succeeds.
+		return false
+	}
+	// The initial delivery is made by the processor that retries on failure,
+	// so every attempt made by the other processor is a retry.
+	retries := 0
+	if !p.RetryOnFailure {
+		attempt, err := handlerctx.GetDeliveryAttempt(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Warn("delivery attempt not present in the context; continue retrying", zap.Error(err))
+			return false
+		}
+		if attempt >= handlerctx.MaxDeliveryAttempts {
+			// Pub/Sub forwards the message to the dead letter topic of the retry subscription
+			// if this attempt fails, so this is the last chance to dead letter the event.
+			return true
+		}
+		retries = attempt
+		if ordered {
+			retries--
+		}
+	}
+	return retries >= int(ds.Retry)
+}
+
+// sendToDeadLetter sends the event to the target's dead letter sink with extensions describing
+// the delivery failure. If the target has no dead letter sink, the event is dropped.
+func (p *Processor) sendToDeadLetter(ctx context.Context, target *config.Target, event *event.Event, deliveryErr error) error {
+	deadLetter := target.DeliverySpec.GetDeadLetter()
+	if deadLetter == "" {
+		logging.FromContext(ctx).Warn("target delivery retries exhausted: dropping event",
+			zap.String("target", target.Key()),
+			zap.Int32("retry", target.DeliverySpec.GetRetry()),
+			zap.Error(deliveryErr),
+		)
+		trace.FromContext(ctx).Annotate(
+			ceclient.EventTraceAttributes(event),
+			"event dropped: delivery retries exhausted",
+		)
+		return nil
+	}
+
+	logging.FromContext(ctx).Warn("target delivery retries exhausted: sending event to dead letter sink",
+		zap.String("target", target.Key()),
+		zap.Int32("retry", target.DeliverySpec.GetRetry()),
+		zap.Error(deliveryErr),
+	)
+	trace.FromContext(ctx).Annotate(
+		[]trace.Attribute{trace.StringAttribute("error_message", deliveryErr.Error())},
+		"sending to dead letter sink",
+	)
+	event.SetExtension(deadLetterDestExtension, target.Address)
+	event.SetExtension(deadLetterDataExtension, deliveryErr.Error())
+	var se *statusError
+	if errors.As(deliveryErr, &se) {
+		event.SetExtension(deadLetterCodeExtension, se.statusCode)
+	}
+
+	resp, err := p.sendMsg(ctx, p.DeliverClient, deadLetter, (*binding.EventMessage)(event))
+	if err != nil {
+		return fmt.Errorf("failed to send event to dead letter sink: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logging.FromContext(ctx).Warn("failed to close dead letter sink response body", zap.Error(err))
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send event to dead letter sink: HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -86,6 +86,9 @@ func TestDeliverSuccess(t *testing.T) {
 		wantOrigin *event.Event
 		reply      *event.Event
 		wantReply  *event.Event
+		// replyDestination sends the replies to a reply destination instead of the broker.
+		replyDestination bool
+		dropReplies      bool
 	}{{
 		name:       "success",
 		origin:     sampleEvent,
@@ -105,6 +108,23 @@ func TestDeliverSuccess(t *testing.T) {
 		}(),
 		wantOrigin: sampleEvent,
 		reply:      &sampleReply,
+	}, {
+		name:       "success with reply destination",
+		origin:     sampleEvent,
+		wantOrigin: sampleEvent,
+		reply:      &sampleReply,
+		wantReply: func() *event.Event {
+			copy := sampleReply.Clone()
+			eventutil.UpdateRemainingHops(context.Background(), &copy, defaultEventHopsLimit)
+			return &copy
+		}(),
+		replyDestination: true,
+	}, {
+		name:        "success with replies dropped by target",
+		origin:      sampleEvent,
+		wantOrigin:  sampleEvent,
+		reply:       &sampleReply,
+		dropReplies: true,
 	}}
 
 	for _, tc := range cases {
@@ -126,10 +146,21 @@ func TestDeliverSuccess(t *testing.T) {
 			defer ingressSvr.Close()
 
 			broker := &config.Broker{Namespace: "ns", Name: "broker"}
-			target := &config.Target{Namespace: "ns", Name: "target", Broker: "broker", Address: targetSvr.URL}
+			target := &config.Target{Namespace: "ns", Name: "target", Broker: "broker", Address: targetSvr.URL, DropReplies: tc.dropReplies}
+			brokerAddress := ingressSvr.URL
+			if tc.replyDestination {
+				// The ingress server stands for the reply destination, and the broker must not
+				// receive the reply.
+				brokerSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("unexpected reply sent to the broker")
+				}))
+				defer brokerSvr.Close()
+				brokerAddress = brokerSvr.URL
+				target.ReplyAddress = ingressSvr.URL
+			}
 			testTargets := memory.NewEmptyTargets()
 			testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
-				bm.SetAddress(ingressSvr.URL)
+				bm.SetAddress(brokerAddress)
 				bm.UpsertTargets(target)
 			})
 			ctx = handlerctx.WithBrokerKey(ctx, broker.Key())
@@ -200,6 +231,99 @@ func TestDeliverSuccess(t *testing.T) {
 	}
 }
 
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func TestDeliverReplyToken(t *testing.T) {
+	// The target replies with an event in binary mode.
+	targetSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Ce-Specversion", "1.0")
+		w.Header().Set("Ce-Id", "reply")
+		w.Header().Set("Ce-Source", "target")
+		w.Header().Set("Ce-Type", "reply")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer targetSvr.Close()
+	// authorization receives the authorization header of the replies.
+	authorization := make(chan string, 1)
+	replySvr := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization <- r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+	}
+	ingressSvr := replySvr()
+	defer ingressSvr.Close()
+	externalSvr := replySvr()
+	defer externalSvr.Close()
+
+	cases := []struct {
+		name         string
+		replyAddress string
+		tokenSource  TokenSource
+		want         string
+	}{{
+		name:        "reply to the broker",
+		tokenSource: staticTokenSource("token"),
+		want:        "Bearer token",
+	}, {
+		name:         "reply to a broker in the same namespace",
+		replyAddress: ingressSvr.URL + "/ns/other",
+		tokenSource:  staticTokenSource("token"),
+		want:         "Bearer token",
+	}, {
+		name:         "reply to a broker in another namespace",
+		replyAddress: ingressSvr.URL + "/other/broker",
+		tokenSource:  staticTokenSource("token"),
+	}, {
+		name:         "reply to another address",
+		replyAddress: externalSvr.URL + "/ns/broker",
+		tokenSource:  staticTokenSource("token"),
+	}, {
+		name: "no token source",
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reportertest.ResetDeliveryMetrics()
+			ctx := logtest.TestContextWithLogger(t)
+			broker := &config.Broker{Namespace: "ns", Name: "broker"}
+			target := &config.Target{Namespace: "ns", Name: "target", Broker: "broker", Address: targetSvr.URL, ReplyAddress: tc.replyAddress}
+			testTargets := memory.NewEmptyTargets()
+			testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+				bm.SetAddress(ingressSvr.URL + "/ns/broker")
+				bm.UpsertTargets(target)
+			})
+			ctx = handlerctx.WithBrokerKey(ctx, broker.Key())
+			ctx = handlerctx.WithTargetKey(ctx, target.Key())
+
+			r, err := metrics.NewDeliveryReporter("pod", "container")
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &Processor{
+				DeliverClient:    http.DefaultClient,
+				Targets:          testTargets,
+				StatsReporter:    r,
+				ReplyTokenSource: tc.tokenSource,
+			}
+			if err := p.Process(ctx, newSampleEvent()); err != nil {
+				t.Fatalf("unexpected error from processing: %v", err)
+			}
+			select {
+			case got := <-authorization:
+				if got != tc.want {
+					t.Errorf("reply authorization got=%q, want=%q", got, tc.want)
+				}
+			default:
+				t.Error("reply not received")
+			}
+		})
+	}
+}
+
 func TestDeliverFailure(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -331,6 +455,179 @@ func TestDeliverFailure(t *testing.T) {
 	}
 }
 
+func TestDeliverFailureDeadLetter(t *testing.T) {
+	cases := []struct {
+		name             string
+		withRetry        bool
+		attempt          int
+		orderingKey      string
+		deliverySpec     *config.DeliverySpec
+		deadLetterStatus int
+		wantDeadLetter   bool
+		wantErr          bool
+	}{{
+		name:      "no delivery spec",
+		attempt:   100,
+		wantErr:   true,
+		withRetry: false,
+	}, {
+		name:             "initial delivery with zero retry",
+		withRetry:        true,
+		attempt:          1,
+		deliverySpec:     &config.DeliverySpec{Retry: 0},
+		deadLetterStatus: http.StatusAccepted,
+		wantDeadLetter:   true,
+	}, {
+		name:             "retry attempt under limit",
+		attempt:          2,
+		deliverySpec:     &config.DeliverySpec{Retry: 3},
+		deadLetterStatus: http.StatusAccepted,
+		wantErr:          true,
+	}, {
+		name:             "retry attempt reaches limit",
+		attempt:          3,
+		deliverySpec:     &config.DeliverySpec{Retry: 3},
+		deadLetterStatus: http.StatusAccepted,
+		wantDeadLetter:   true,
+	}, {
+		name:         "retry attempt reaches limit without dead letter sink",
+		attempt:      3,
+		deliverySpec: &config.DeliverySpec{Retry: 3},
+	}, {
+		name:             "dead letter sink failure",
+		attempt:          3,
+		deliverySpec:     &config.DeliverySpec{Retry: 3},
+		deadLetterStatus: http.StatusInternalServerError,
+		wantDeadLetter:   true,
+		wantErr:          true,
+	}, {
+		// The initial delivery of ordered events is made from the retry topic.
+		name:             "ordered retry attempt under limit",
+		attempt:          3,
+		orderingKey:      "key1",
+		deliverySpec:     &config.DeliverySpec{Retry: 3},
+		deadLetterStatus: http.StatusAccepted,
+		wantErr:          true,
+	}, {
+		name:             "ordered retry attempt reaches limit",
+		attempt:          4,
+		orderingKey:      "key1",
+		deliverySpec:     &config.DeliverySpec{Retry: 3},
+		deadLetterStatus: http.StatusAccepted,
+		wantDeadLetter:   true,
+	}, {
+		// Pub/Sub forwards the message to the dead letter topic of the retry subscription after
+		// the max delivery attempts.
+		name:             "retry attempt reaches max delivery attempts",
+		attempt:          handlerctx.MaxDeliveryAttempts,
+		deliverySpec:     &config.DeliverySpec{Retry: 200},
+		deadLetterStatus: http.StatusAccepted,
+		wantDeadLetter:   true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reportertest.ResetDeliveryMetrics()
+			ctx := logtest.TestContextWithLogger(t)
+			targetSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer targetSvr.Close()
+			deadLetterCh := make(chan *event.Event, 1)
+			deadLetterSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				e, err := binding.ToEvent(req.Context(), cehttp.NewMessageFromHttpRequest(req))
+				if err != nil {
+					t.Errorf("dead letter sink received message cannot be converted to an event: %v", err)
+				}
+				deadLetterCh <- e
+				w.WriteHeader(tc.deadLetterStatus)
+			}))
+			defer deadLetterSvr.Close()
+
+			_, c, close := testPubsubClient(ctx, t, "test-project")
+			defer close()
+			if _, err := c.CreateTopic(ctx, "test-retry-topic"); err != nil {
+				t.Fatalf("failed to create test pubsub topc: %v", err)
+			}
+			ps, err := cepubsub.New(ctx, cepubsub.WithClient(c), cepubsub.WithProjectID("test-project"))
+			if err != nil {
+				t.Fatalf("failed to create pubsub protocol: %v", err)
+			}
+			deliverRetryClient, err := ceclient.New(ps)
+			if err != nil {
+				t.Fatalf("failed to create cloudevents client: %v", err)
+			}
+
+			if tc.deadLetterStatus != 0 {
+				tc.deliverySpec.DeadLetter = deadLetterSvr.URL
+			}
+			broker := &config.Broker{Namespace: "ns", Name: "broker"}
+			target := &config.Target{
+				Namespace:    "ns",
+				Name:         "target",
+				Broker:       "broker",
+				Address:      targetSvr.URL,
+				RetryQueue:   &config.Queue{Topic: "test-retry-topic"},
+				DeliverySpec: tc.deliverySpec,
+			}
+			testTargets := memory.NewEmptyTargets()
+			testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+				bm.UpsertTargets(target)
+				if tc.orderingKey != "" {
+					bm.SetOrderingKeyAttribute("partitionkey")
+				}
+			})
+			ctx = handlerctx.WithBrokerKey(ctx, broker.Key())
+			ctx = handlerctx.WithTargetKey(ctx, target.Key())
+			ctx = handlerctx.WithDeliveryAttempt(ctx, tc.attempt)
+
+			r, err := metrics.NewDeliveryReporter("pod", "container")
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &Processor{
+				DeliverClient:      http.DefaultClient,
+				Targets:            testTargets,
+				RetryOnFailure:     tc.withRetry,
+				DeliverRetryClient: deliverRetryClient,
+				StatsReporter:      r,
+			}
+
+			e := newSampleEvent()
+			if tc.orderingKey != "" {
+				e.SetExtension("partitionkey", tc.orderingKey)
+			}
+			err = p.Process(ctx, e)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("processing got error=%v, want=%v", err, tc.wantErr)
+			}
+
+			var gotEvent *event.Event
+			select {
+			case gotEvent = <-deadLetterCh:
+			default:
+			}
+			if (gotEvent != nil) != tc.wantDeadLetter {
+				t.Fatalf("dead letter sink received event=%v, want=%v", gotEvent, tc.wantDeadLetter)
+			}
+			if gotEvent == nil {
+				return
+			}
+			wantExtensions := map[string]interface{}{
+				deadLetterDestExtension: targetSvr.URL,
+				deadLetterCodeExtension: "503",
+				deadLetterDataExtension: "event delivery failed: HTTP status code 503",
+			}
+			if tc.orderingKey != "" {
+				wantExtensions["partitionkey"] = tc.orderingKey
+			}
+			if diff := cmp.Diff(wantExtensions, gotEvent.Extensions()); diff != "" {
+				t.Errorf("dead letter event extensions (-want,+got): %v", diff)
+			}
+		})
+	}
+}
+
 type NoReplyHandler struct{}
 
 func (NoReplyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -548,6 +845,138 @@ func toFakePubsubMessage(m *pstest.Message) *pubsub.Message {
 	}
 }
 
+func TestDeliverOrdered(t *testing.T) {
+	reportertest.ResetDeliveryMetrics()
+	ctx := logtest.TestContextWithLogger(t)
+	targetCh := make(chan *event.Event, 1)
+	targetSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		e, err := binding.ToEvent(req.Context(), cehttp.NewMessageFromHttpRequest(req))
+		if err != nil {
+			t.Errorf("target received message cannot be converted to an event: %v", err)
+		}
+		targetCh <- e
+	}))
+	defer targetSvr.Close()
+
+	_, c, close := testPubsubClient(ctx, t, "test-project")
+	defer close()
+	topic, err := c.CreateTopic(ctx, "test-retry-topic")
+	if err != nil {
+		t.Fatalf("failed to create test pubsub topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, "test-retry-sub", pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatalf("failed to create test pubsub subscription: %v", err)
+	}
+
+	broker := &config.Broker{Namespace: "ns", Name: "broker"}
+	target := &config.Target{
+		Namespace:  "ns",
+		Name:       "target",
+		Broker:     "broker",
+		Address:    targetSvr.URL,
+		RetryQueue: &config.Queue{Topic: "test-retry-topic"},
+	}
+	testTargets := memory.NewEmptyTargets()
+	testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+		bm.SetOrderingKeyAttribute("partitionkey").UpsertTargets(target)
+	})
+	ctx = handlerctx.WithBrokerKey(ctx, broker.Key())
+	ctx = handlerctx.WithTargetKey(ctx, target.Key())
+
+	r, err := metrics.NewDeliveryReporter("pod", "container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Processor{
+		DeliverClient:         http.DefaultClient,
+		Targets:               testTargets,
+		RetryOnFailure:        true,
+		StatsReporter:         r,
+		OrderedRetryPublisher: NewOrderedPublisher(c),
+	}
+
+	t.Run("event with ordering key is sent to retry topic", func(t *testing.T) {
+		origin := newSampleEvent()
+		origin.SetExtension("partitionkey", "key1")
+		if err := p.Process(ctx, origin); err != nil {
+			t.Fatalf("unexpected error from processing: %v", err)
+		}
+
+		rctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		msgCh := make(chan *pubsub.Message, 1)
+		if err := sub.Receive(rctx, func(ctx context.Context, m *pubsub.Message) {
+			m.Ack()
+			msgCh <- m
+			cancel()
+		}); err != nil {
+			t.Fatalf("failed to receive from retry subscription: %v", err)
+		}
+		var msg *pubsub.Message
+		select {
+		case msg = <-msgCh:
+		default:
+			t.Fatal("retry topic didn't receive the event")
+		}
+		if msg.OrderingKey != "key1" {
+			t.Errorf("retry message ordering key got=%q, want=%q", msg.OrderingKey, "key1")
+		}
+		got, err := binding.ToEvent(ctx, cepubsub.NewMessage(msg))
+		if err != nil {
+			t.Fatalf("retry message cannot be converted to an event: %v", err)
+		}
+		if diff := cmp.Diff(origin, got); diff != "" {
+			t.Errorf("retry event (-want,+got): %v", diff)
+		}
+		select {
+		case e := <-targetCh:
+			t.Errorf("target received event %v, want none", e)
+		default:
+		}
+	})
+
+	t.Run("event without ordering key is delivered", func(t *testing.T) {
+		origin := newSampleEvent()
+		if err := p.Process(ctx, origin); err != nil {
+			t.Fatalf("unexpected error from processing: %v", err)
+		}
+		select {
+		case got := <-targetCh:
+			if diff := cmp.Diff(origin, got); diff != "" {
+				t.Errorf("target received event (-want,+got): %v", diff)
+			}
+		default:
+			t.Error("target didn't receive the event")
+		}
+	})
+}
+
+func TestKeyMutex(t *testing.T) {
+	var m keyMutex
+	unlock := m.lock("key1")
+
+	// Other keys are not blocked.
+	m.lock("key2")()
+
+	locked := make(chan struct{})
+	go func() {
+		defer m.lock("key1")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("key1 was locked twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("key1 was not locked after it was unlocked")
+	}
+}
+
 func testPubsubClient(ctx context.Context, t *testing.T, projectID string) (*pstest.Server, *pubsub.Client, func()) {
 	t.Helper()
 	srv := pstest.NewServer()
@@ -0,0 +1,209 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+)
+
+// DefaultTargetCertsDir is the directory the client certificates presented to targets are read
+// from. The certificate and key of the client certificate <name> are the <name>.crt and
+// <name>.key files.
+const DefaultTargetCertsDir = "/var/secrets/broker-target-certs"
+
+// TargetClients provides the HTTP clients to deliver events to targets. Targets without transport
+// options share the default client, and targets with the same transport options share a client
+// built from them, so that their connections are pooled together.
+type TargetClients struct {
+	defaultClient *http.Client
+	certsDir      string
+
+	mu sync.Mutex
+	// clients caches the clients by the serialized transport options.
+	clients map[string]*targetClient
+}
+
+type targetClient struct {
+	*http.Client
+	closeIdleConnections func()
+}
+
+// NewTargetClients creates a new TargetClients which provides defaultClient to targets without
+// transport options, and reads client certificates from certsDir.
+func NewTargetClients(defaultClient *http.Client, certsDir string) *TargetClients {
+	return &TargetClients{
+		defaultClient: defaultClient,
+		certsDir:      certsDir,
+		clients:       make(map[string]*targetClient),
+	}
+}
+
+// Get returns the client to deliver events to the target.
+func (c *TargetClients) Get(target *config.Target) (*http.Client, error) {
+	if target.Transport == nil {
+		return c.defaultClient, nil
+	}
+	key, err := transportKey(target.Transport)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if tc, ok := c.clients[key]; ok {
+		return tc.Client, nil
+	}
+	tc, err := c.newClient(target.Transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of target %s: %w", target.Key(), err)
+	}
+	c.clients[key] = tc
+	return tc.Client, nil
+}
+
+// Retain closes and removes the clients of the transport options which are not in transports.
+func (c *TargetClients) Retain(transports []*config.Transport) {
+	keys := make(map[string]bool, len(transports))
+	for _, t := range transports {
+		if key, err := transportKey(t); err == nil {
+			keys[key] = true
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, tc := range c.clients {
+		if !keys[key] {
+			tc.closeIdleConnections()
+			delete(c.clients, key)
+		}
+	}
+}
+
+func transportKey(t *config.Transport) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize transport options: %w", err)
+	}
+	return string(b), nil
+}
+
+// newClient creates a client with the transport options. The options which are not set default to
+// the settings of handler.DefaultHTTPClient.
+func (c *TargetClients) newClient(t *config.Transport) (*targetClient, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if t.KeepAlive != nil {
+		d, err := ptypes.Duration(t.KeepAlive)
+		if err != nil {
+			return nil, err
+		}
+		dialer.KeepAlive = d
+	}
+
+	if t.H2C {
+		// HTTP/2 without TLS is only used by the client if dialing TLS connections is overridden.
+		base := &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dialer.Dial(network, addr)
+			},
+		}
+		return &targetClient{Client: tracedClient(base), closeIdleConnections: base.CloseIdleConnections}, nil
+	}
+
+	base := &http.Transport{
+		DialContext:         dialer.DialContext,
+		MaxIdleConns:        1000,
+		MaxIdleConnsPerHost: 500,
+		MaxConnsPerHost:     500,
+		IdleConnTimeout:     30 * time.Second,
+		// HTTP/2 is negotiated with TLS targets even with a custom TLS config.
+		ForceAttemptHTTP2: true,
+	}
+	if t.MaxIdleConnsPerHost > 0 {
+		base.MaxIdleConnsPerHost = int(t.MaxIdleConnsPerHost)
+		if base.MaxIdleConnsPerHost > base.MaxConnsPerHost {
+			base.MaxConnsPerHost = base.MaxIdleConnsPerHost
+		}
+	}
+	if t.IdleConnTimeout != nil {
+		d, err := ptypes.Duration(t.IdleConnTimeout)
+		if err != nil {
+			return nil, err
+		}
+		base.IdleConnTimeout = d
+	}
+	if t.Tls != nil {
+		tlsConfig, err := c.tlsConfig(t.Tls)
+		if err != nil {
+			return nil, err
+		}
+		base.TLSClientConfig = tlsConfig
+	}
+	return &targetClient{Client: tracedClient(base), closeIdleConnections: base.CloseIdleConnections}, nil
+}
+
+func (c *TargetClients) tlsConfig(t *config.TransportTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if t.CaBundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(t.CaBundle)) {
+			return nil, errors.New("no PEM-encoded certificate found in the CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if name := t.ClientCertificate; name != "" {
+		certFile := filepath.Join(c.certsDir, name+".crt")
+		keyFile := filepath.Join(c.certsDir, name+".key")
+		// The certificate is loaded on every handshake so that rotated certificates are picked up
+		// without recreating the client.
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate %q: %w", name, err)
+			}
+			return &cert, nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+func tracedClient(base http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: &ochttp.Transport{
+			Base:        base,
+			Propagation: &tracecontext.HTTPFormat{},
+		},
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+)
+
+func TestTargetClientsCache(t *testing.T) {
+	defaultClient := &http.Client{}
+	c := NewTargetClients(defaultClient, "")
+
+	get := func(transport *config.Transport) *http.Client {
+		t.Helper()
+		client, err := c.Get(&config.Target{Namespace: "ns", Name: "target", Transport: transport})
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		return client
+	}
+
+	if got := get(nil); got != defaultClient {
+		t.Error("Get of a target without transport options didn't return the default client")
+	}
+	pooled := get(&config.Transport{MaxIdleConnsPerHost: 10, IdleConnTimeout: ptypes.DurationProto(time.Minute)})
+	if pooled == defaultClient {
+		t.Error("Get of a target with transport options returned the default client")
+	}
+	if got := get(&config.Transport{MaxIdleConnsPerHost: 10, IdleConnTimeout: ptypes.DurationProto(time.Minute)}); got != pooled {
+		t.Error("Get of targets with the same transport options returned different clients")
+	}
+	h2cClient := get(&config.Transport{H2C: true})
+	if h2cClient == pooled {
+		t.Error("Get of targets with different transport options returned the same client")
+	}
+
+	c.Retain([]*config.Transport{{H2C: true}})
+	if got := get(&config.Transport{H2C: true}); got != h2cClient {
+		t.Error("Retain removed the client of retained transport options")
+	}
+	if got := get(&config.Transport{MaxIdleConnsPerHost: 10, IdleConnTimeout: ptypes.DurationProto(time.Minute)}); got == pooled {
+		t.Error("Retain kept the client of transport options which are not retained")
+	}
+}
+
+func TestTargetClientsH2C(t *testing.T) {
+	var gotProto string
+	svr := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotProto = r.Proto
+	}), &http2.Server{}))
+	defer svr.Close()
+
+	c := NewTargetClients(http.DefaultClient, "")
+	client, err := c.Get(&config.Target{Transport: &config.Transport{H2C: true}})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp, err := client.Post(svr.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	resp.Body.Close()
+	if gotProto != "HTTP/2.0" {
+		t.Errorf("Request protocol got %q, want %q", gotProto, "HTTP/2.0")
+	}
+}
+
+func TestTargetClientsMutualTLS(t *testing.T) {
+	certsDir, err := ioutil.TempDir("", "certs-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(certsDir)
+	clientCert := writeClientCert(t, certsDir, "mesh")
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	svr.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	svr.StartTLS()
+	defer svr.Close()
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw}))
+
+	tests := []struct {
+		name    string
+		tls     *config.TransportTLS
+		wantErr bool
+	}{{
+		name: "client certificate",
+		tls:  &config.TransportTLS{CaBundle: caBundle, ClientCertificate: "mesh"},
+	}, {
+		name:    "no client certificate",
+		tls:     &config.TransportTLS{CaBundle: caBundle},
+		wantErr: true,
+	}, {
+		name:    "missing client certificate",
+		tls:     &config.TransportTLS{CaBundle: caBundle, ClientCertificate: "missing"},
+		wantErr: true,
+	}, {
+		name:    "untrusted target",
+		tls:     &config.TransportTLS{ClientCertificate: "mesh"},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewTargetClients(http.DefaultClient, certsDir)
+			client, err := c.Get(&config.Target{Transport: &config.Transport{Tls: tc.tls}})
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			resp, err := client.Post(svr.URL, "application/json", nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Post got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTargetClientsInvalidCABundle(t *testing.T) {
+	c := NewTargetClients(http.DefaultClient, "")
+	if _, err := c.Get(&config.Target{Transport: &config.Transport{Tls: &config.TransportTLS{CaBundle: "invalid"}}}); err == nil {
+		t.Error("Get with an invalid CA bundle got nil error")
+	}
+}
+
+// writeClientCert writes a self-signed client certificate and its key to dir and returns the
+// certificate.
+func writeClientCert(t *testing.T, dir, name string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "broker"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM := func(file, blockType string, b []byte) {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePEM(name+".crt", "CERTIFICATE", der)
+	writePEM(name+".key", "EC PRIVATE KEY", keyDER)
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
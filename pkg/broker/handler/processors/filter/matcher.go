@@ -0,0 +1,211 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/expr"
+)
+
+// matcher reports whether the event attributes pass a filter.
+type matcher func(attrs map[string]string) bool
+
+func matchAll(ms []matcher) matcher {
+	if len(ms) == 1 {
+		return ms[0]
+	}
+	return func(attrs map[string]string) bool {
+		for _, m := range ms {
+			if !m(attrs) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func matchAny(ms []matcher) matcher {
+	return func(attrs map[string]string) bool {
+		for _, m := range ms {
+			if m(attrs) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchAttributes returns a matcher which applies the check to the values
+// of the given attributes.
+func matchAttributes(attrs map[string]string, check func(value, want string) bool) matcher {
+	return func(got map[string]string) bool {
+		for k, want := range attrs {
+			v, ok := got[k]
+			if !ok || !check(v, want) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// compileTarget compiles the filter attributes and the advanced filters of
+// the target into a single matcher.
+func compileTarget(target *config.Target) (matcher, error) {
+	var ms []matcher
+	if len(target.FilterAttributes) > 0 {
+		// An empty filter attribute value only requires the attribute to be present.
+		ms = append(ms, matchAttributes(target.FilterAttributes, func(value, want string) bool {
+			return want == "" || value == want
+		}))
+	}
+	for i, f := range target.Filters {
+		m, err := compileFilter(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %d: %w", i, err)
+		}
+		ms = append(ms, m)
+	}
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	return matchAll(ms), nil
+}
+
+func compileFilter(f *config.Filter) (matcher, error) {
+	var ms []matcher
+	if len(f.Exact) > 0 {
+		ms = append(ms, matchAttributes(f.Exact, func(value, want string) bool {
+			return value == want
+		}))
+	}
+	if len(f.Prefix) > 0 {
+		ms = append(ms, matchAttributes(f.Prefix, strings.HasPrefix))
+	}
+	if len(f.Suffix) > 0 {
+		ms = append(ms, matchAttributes(f.Suffix, strings.HasSuffix))
+	}
+	if len(f.Exists) > 0 {
+		exists := f.Exists
+		ms = append(ms, func(attrs map[string]string) bool {
+			for _, k := range exists {
+				if _, ok := attrs[k]; !ok {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	if f.Not != nil {
+		m, err := compileFilter(f.Not)
+		if err != nil {
+			return nil, fmt.Errorf("not: %w", err)
+		}
+		ms = append(ms, func(attrs map[string]string) bool {
+			return !m(attrs)
+		})
+	}
+	if len(f.Any) > 0 {
+		anyOf, err := compileFilters(f.Any)
+		if err != nil {
+			return nil, fmt.Errorf("any: %w", err)
+		}
+		ms = append(ms, matchAny(anyOf))
+	}
+	if len(f.All) > 0 {
+		allOf, err := compileFilters(f.All)
+		if err != nil {
+			return nil, fmt.Errorf("all: %w", err)
+		}
+		ms = append(ms, matchAll(allOf))
+	}
+	if f.Expression != "" {
+		e, err := expr.Parse(f.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("expression %q: %w", f.Expression, err)
+		}
+		ms = append(ms, e.Eval)
+	}
+	if len(ms) == 0 {
+		// An empty filter matches everything.
+		return func(map[string]string) bool { return true }, nil
+	}
+	return matchAll(ms), nil
+}
+
+func compileFilters(fs []*config.Filter) ([]matcher, error) {
+	ms := make([]matcher, 0, len(fs))
+	for i, f := range fs {
+		m, err := compileFilter(f)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// compiledTarget is the matcher compiled from a target.
+type compiledTarget struct {
+	// target is the target the matcher was compiled from. The targets
+	// config replaces targets instead of modifying them, so a different
+	// pointer means the matcher needs to be compiled again.
+	target *config.Target
+	match  matcher
+	err    error
+}
+
+// MatcherCache caches the compiled matchers by target key so that filters
+// are only compiled when the target changes.
+type MatcherCache struct {
+	targets sync.Map
+}
+
+// NewMatcherCache creates a MatcherCache to share between the filter
+// processors of a handler pool.
+func NewMatcherCache() *MatcherCache {
+	return &MatcherCache{}
+}
+
+// Retain removes the matchers of the targets which are no longer in the
+// targets config.
+func (c *MatcherCache) Retain(targets config.ReadonlyTargets) {
+	c.targets.Range(func(key, _ interface{}) bool {
+		if _, ok := targets.GetTargetByKey(key.(string)); !ok {
+			c.targets.Delete(key)
+		}
+		return true
+	})
+}
+
+// get returns the matcher of the target. A nil matcher means the target
+// has no filters.
+func (c *MatcherCache) get(target *config.Target) (matcher, error) {
+	key := target.Key()
+	if v, ok := c.targets.Load(key); ok {
+		if ct := v.(*compiledTarget); ct.target == target {
+			return ct.match, ct.err
+		}
+	}
+	m, err := compileTarget(target)
+	c.targets.Store(key, &compiledTarget{target: target, match: m, err: err})
+	return m, err
+}
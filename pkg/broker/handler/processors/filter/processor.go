@@ -21,6 +21,7 @@ import (
 
 	"github.com/cloudevents/sdk-go/v2/event"
 	"github.com/cloudevents/sdk-go/v2/extensions"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +40,14 @@ type Processor struct {
 
 	// Targets is the targets from config.
 	Targets config.ReadonlyTargets
+
+	// Matchers caches the compiled filters of the targets. It is shared by the
+	// processors of a handler pool, which prunes it when the targets config
+	// changes. If nil, the processor caches the filters on its own.
+	Matchers *MatcherCache
+
+	// matchers caches the compiled filters of the targets if Matchers is nil.
+	matchers MatcherCache
 }
 
 var _ processors.Interface = (*Processor)(nil)
@@ -64,14 +73,27 @@ func (p *Processor) Process(ctx context.Context, event *event.Event) error {
 	ctx, span := startSpan(ctx, trigger, event)
 	defer span.End()
 
-	if target.FilterAttributes == nil {
+	matchers := p.Matchers
+	if matchers == nil {
+		matchers = &p.matchers
+	}
+	match, err := matchers.get(target)
+	if err != nil {
+		// The trigger webhook rejects invalid filters, so this should not happen. Drop the
+		// event rather than delivering events the trigger did not ask for.
+		logging.FromContext(ctx).Error("failed to compile filters for target", zap.String("target", tk), zap.Error(err))
+		trace.FromContext(ctx).Annotatef(nil, "invalid filters: %v", err)
+		return nil
+	}
+	if match == nil {
 		return p.Next().Process(ctx, event)
 	}
 
-	if p.passFilter(ctx, target.FilterAttributes, event) {
+	if match(eventAttributes(event)) {
 		return p.Next().Process(ctx, event)
 	}
 	logging.FromContext(ctx).Debug("event does not pass filter for target", zap.Any("target", target))
+	trace.FromContext(ctx).Annotate(nil, "event does not pass filter")
 	return nil
 }
 
@@ -93,43 +115,40 @@ func startSpan(ctx context.Context, trigger types.NamespacedName, event *event.E
 	return ctx, span
 }
 
-func (p *Processor) passFilter(ctx context.Context, attrs map[string]string, event *event.Event) bool {
-	// Set standard context attributes. The attributes available may not be
-	// exactly the same as the attributes defined in the current version of the
-	// CloudEvents spec.
-	ce := map[string]interface{}{
-		"specversion":     event.SpecVersion(),
-		"type":            event.Type(),
-		"source":          event.Source(),
+// eventAttributes returns the context attributes of the event, formatted as
+// strings. The optional attributes are only included if the event sets them,
+// so that a filter can tell a missing attribute from an empty one. The
+// attributes available may not be exactly the same as the attributes defined
+// in the current version of the CloudEvents spec.
+func eventAttributes(event *event.Event) map[string]string {
+	attrs := map[string]string{
+		"specversion": event.SpecVersion(),
+		"type":        event.Type(),
+		"source":      event.Source(),
+		"id":          event.ID(),
+	}
+	optional := map[string]string{
 		"subject":         event.Subject(),
-		"id":              event.ID(),
-		"time":            event.Time().String(),
 		"schemaurl":       event.DataSchema(),
 		"datacontenttype": event.DataContentType(),
 		"datamediatype":   event.DataMediaType(),
 		// TODO: use data_base64 when SDK supports it.
 		"datacontentencoding": event.DeprecatedDataContentEncoding(),
 	}
-	ext := event.Extensions()
-	for k, v := range ext {
-		ce[k] = v
+	if !event.Time().IsZero() {
+		if s, err := cetypes.Format(event.Time()); err == nil {
+			optional["time"] = s
+		}
 	}
-
-	for k, v := range attrs {
-		var value interface{}
-		value, ok := ce[k]
-		// If the attribute does not exist in the event, return false.
-		if !ok {
-			logging.FromContext(ctx).Debug("Attribute not found", zap.String("attribute", k))
-			trace.FromContext(ctx).Annotatef(nil, "event missing filter attribute %q", k)
-			return false
+	for k, v := range optional {
+		if v != "" {
+			attrs[k] = v
 		}
-		// If the attribute is not set to any and is different than the one from the event, return false.
-		if v != "" && v != value {
-			logging.FromContext(ctx).Debug("Attribute had non-matching value", zap.String("attribute", k), zap.String("filter", v), zap.Any("received", value))
-			trace.FromContext(ctx).Annotatef(nil, "event attribute %q does not match filter value %q", k, v)
-			return false
+	}
+	for k, v := range event.Extensions() {
+		if s, err := cetypes.Format(v); err == nil {
+			attrs[k] = s
 		}
 	}
-	return true
+	return attrs
 }
@@ -189,7 +189,7 @@ func TestFilterProcessor(t *testing.T) {
 			return e
 		}(),
 		filter: map[string]string{
-			"time": tn.String(),
+			"time": tn.UTC().Format(time.RFC3339Nano),
 		},
 		shouldPass: true,
 	}, {
@@ -200,7 +200,7 @@ func TestFilterProcessor(t *testing.T) {
 			return e
 		}(),
 		filter: map[string]string{
-			"time": time.Now().Add(time.Hour).String(),
+			"time": tn.Add(time.Hour).UTC().Format(time.RFC3339Nano),
 		},
 		shouldPass: false,
 	}, {
@@ -311,12 +311,295 @@ func TestFilterProcessor(t *testing.T) {
 	}
 }
 
+func TestAdvancedFilters(t *testing.T) {
+	e := event.New()
+	e.SetID("id")
+	e.SetSource("//storage.googleapis.com/buckets/bucket")
+	e.SetType("com.google.cloud.storage.object.finalize")
+	e.SetSubject("objects/image.png")
+	e.SetExtension("priority", 5)
+
+	cases := []struct {
+		name       string
+		attributes map[string]string
+		filters    []*config.Filter
+		shouldPass bool
+	}{{
+		name:       "prefix pass",
+		filters:    []*config.Filter{{Prefix: map[string]string{"type": "com.google.cloud.storage."}}},
+		shouldPass: true,
+	}, {
+		name:       "prefix not pass",
+		filters:    []*config.Filter{{Prefix: map[string]string{"type": "com.google.cloud.pubsub."}}},
+		shouldPass: false,
+	}, {
+		name:       "suffix pass",
+		filters:    []*config.Filter{{Suffix: map[string]string{"subject": ".png"}}},
+		shouldPass: true,
+	}, {
+		name:       "suffix not pass",
+		filters:    []*config.Filter{{Suffix: map[string]string{"subject": ".jpg"}}},
+		shouldPass: false,
+	}, {
+		name:       "suffix missing attribute not pass",
+		filters:    []*config.Filter{{Suffix: map[string]string{"missing": ".png"}}},
+		shouldPass: false,
+	}, {
+		name:       "exact extension pass",
+		filters:    []*config.Filter{{Exact: map[string]string{"priority": "5"}}},
+		shouldPass: true,
+	}, {
+		name:       "exists pass",
+		filters:    []*config.Filter{{Exists: []string{"priority", "subject"}}},
+		shouldPass: true,
+	}, {
+		name:       "exists not pass",
+		filters:    []*config.Filter{{Exists: []string{"priority", "missing"}}},
+		shouldPass: false,
+	}, {
+		name:       "not pass",
+		filters:    []*config.Filter{{Not: &config.Filter{Suffix: map[string]string{"subject": ".jpg"}}}},
+		shouldPass: true,
+	}, {
+		name:       "not not pass",
+		filters:    []*config.Filter{{Not: &config.Filter{Suffix: map[string]string{"subject": ".png"}}}},
+		shouldPass: false,
+	}, {
+		name: "any pass",
+		filters: []*config.Filter{{Any: []*config.Filter{
+			{Suffix: map[string]string{"subject": ".jpg"}},
+			{Suffix: map[string]string{"subject": ".png"}},
+		}}},
+		shouldPass: true,
+	}, {
+		name: "any not pass",
+		filters: []*config.Filter{{Any: []*config.Filter{
+			{Suffix: map[string]string{"subject": ".jpg"}},
+			{Suffix: map[string]string{"subject": ".gif"}},
+		}}},
+		shouldPass: false,
+	}, {
+		name: "all pass",
+		filters: []*config.Filter{{All: []*config.Filter{
+			{Prefix: map[string]string{"type": "com.google.cloud.storage."}},
+			{Suffix: map[string]string{"subject": ".png"}},
+		}}},
+		shouldPass: true,
+	}, {
+		name: "all not pass",
+		filters: []*config.Filter{{All: []*config.Filter{
+			{Prefix: map[string]string{"type": "com.google.cloud.storage."}},
+			{Suffix: map[string]string{"subject": ".jpg"}},
+		}}},
+		shouldPass: false,
+	}, {
+		name:       "expression pass",
+		filters:    []*config.Filter{{Expression: "type LIKE 'com.google.cloud.storage.%' AND priority > 3"}},
+		shouldPass: true,
+	}, {
+		name:       "expression not pass",
+		filters:    []*config.Filter{{Expression: "type LIKE 'com.google.cloud.storage.%' AND priority > 5"}},
+		shouldPass: false,
+	}, {
+		name:       "invalid expression not pass",
+		filters:    []*config.Filter{{Expression: "type LIKE"}},
+		shouldPass: false,
+	}, {
+		name: "multiple fields in a filter not pass",
+		filters: []*config.Filter{{
+			Prefix: map[string]string{"type": "com.google.cloud.storage."},
+			Suffix: map[string]string{"subject": ".jpg"},
+		}},
+		shouldPass: false,
+	}, {
+		name: "multiple filters pass",
+		filters: []*config.Filter{
+			{Prefix: map[string]string{"type": "com.google.cloud.storage."}},
+			{Suffix: map[string]string{"subject": ".png"}},
+		},
+		shouldPass: true,
+	}, {
+		name: "multiple filters not pass",
+		filters: []*config.Filter{
+			{Prefix: map[string]string{"type": "com.google.cloud.storage."}},
+			{Suffix: map[string]string{"subject": ".jpg"}},
+		},
+		shouldPass: false,
+	}, {
+		name:       "filter attributes and filters pass",
+		attributes: map[string]string{"id": "id"},
+		filters:    []*config.Filter{{Suffix: map[string]string{"subject": ".png"}}},
+		shouldPass: true,
+	}, {
+		name:       "filter attributes and filters not pass",
+		attributes: map[string]string{"id": "other"},
+		filters:    []*config.Filter{{Suffix: map[string]string{"subject": ".png"}}},
+		shouldPass: false,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, testTargets := newTestTargetsWithFilters(tc.attributes, tc.filters)
+			next := &processors.FakeProcessor{}
+			p := &Processor{Targets: testTargets}
+			p.WithNext(next)
+			ch := make(chan *event.Event, 1)
+			next.PrevEventsCh = ch
+
+			if err := p.Process(ctx, &e); err != nil {
+				t.Errorf("unexpected error from processing: %v", err)
+			}
+			close(ch)
+			if gotEvent := <-ch; (gotEvent != nil) != tc.shouldPass {
+				t.Errorf("event passed filters got=%v, want=%v", gotEvent != nil, tc.shouldPass)
+			}
+		})
+	}
+}
+
+func TestFiltersUnsetAttributes(t *testing.T) {
+	e := event.New()
+	e.SetID("id")
+	e.SetSource("source")
+	e.SetType("type")
+	withTime := e.Clone()
+	withTime.SetTime(time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC))
+
+	cases := []struct {
+		name       string
+		e          event.Event
+		filters    []*config.Filter
+		shouldPass bool
+	}{{
+		name:       "exists unset subject not pass",
+		e:          e,
+		filters:    []*config.Filter{{Exists: []string{"subject"}}},
+		shouldPass: false,
+	}, {
+		name:       "EXISTS unset subject not pass",
+		e:          e,
+		filters:    []*config.Filter{{Expression: "EXISTS subject"}},
+		shouldPass: false,
+	}, {
+		name:       "NOT EXISTS unset subject pass",
+		e:          e,
+		filters:    []*config.Filter{{Expression: "NOT EXISTS subject"}},
+		shouldPass: true,
+	}, {
+		name:       "exists unset time not pass",
+		e:          e,
+		filters:    []*config.Filter{{Exists: []string{"time"}}},
+		shouldPass: false,
+	}, {
+		name:       "time prefix in RFC 3339 pass",
+		e:          withTime,
+		filters:    []*config.Filter{{Prefix: map[string]string{"time": "2020-06-01T12:30:00"}}},
+		shouldPass: true,
+	}, {
+		name:       "time suffix in UTC pass",
+		e:          withTime,
+		filters:    []*config.Filter{{Suffix: map[string]string{"time": "Z"}}},
+		shouldPass: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, testTargets := newTestTargetsWithFilters(nil, tc.filters)
+			next := &processors.FakeProcessor{}
+			p := &Processor{Targets: testTargets}
+			p.WithNext(next)
+			ch := make(chan *event.Event, 1)
+			next.PrevEventsCh = ch
+
+			if err := p.Process(ctx, &tc.e); err != nil {
+				t.Errorf("unexpected error from processing: %v", err)
+			}
+			close(ch)
+			if gotEvent := <-ch; (gotEvent != nil) != tc.shouldPass {
+				t.Errorf("event passed filters got=%v, want=%v", gotEvent != nil, tc.shouldPass)
+			}
+		})
+	}
+}
+
+func TestMatcherCacheRetain(t *testing.T) {
+	e := event.New()
+	e.SetType("com.example.foo")
+
+	ctx, testTargets := newTestTargetsWithFilters(nil, []*config.Filter{{Prefix: map[string]string{"type": "com.example."}}})
+	matchers := NewMatcherCache()
+	p := &Processor{Targets: testTargets, Matchers: matchers}
+	p.WithNext(&processors.FakeProcessor{PrevEventsCh: make(chan *event.Event, 1)})
+	if err := p.Process(ctx, &e); err != nil {
+		t.Fatalf("unexpected error from processing: %v", err)
+	}
+	tk, _ := handlerctx.GetTargetKey(ctx)
+	if _, ok := matchers.targets.Load(tk); !ok {
+		t.Fatalf("matcher of target %q was not cached", tk)
+	}
+
+	matchers.Retain(testTargets)
+	if _, ok := matchers.targets.Load(tk); !ok {
+		t.Errorf("matcher of existing target %q was removed", tk)
+	}
+
+	testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+		bm.Delete()
+	})
+	matchers.Retain(testTargets)
+	if _, ok := matchers.targets.Load(tk); ok {
+		t.Errorf("matcher of deleted target %q was retained", tk)
+	}
+}
+
+func TestFiltersUpdated(t *testing.T) {
+	e := event.New()
+	e.SetType("com.example.foo")
+
+	ctx, testTargets := newTestTargetsWithFilters(nil, []*config.Filter{{Prefix: map[string]string{"type": "com.example."}}})
+	next := &processors.FakeProcessor{}
+	p := &Processor{Targets: testTargets}
+	p.WithNext(next)
+	ch := make(chan *event.Event, 2)
+	next.PrevEventsCh = ch
+
+	if err := p.Process(ctx, &e); err != nil {
+		t.Fatalf("unexpected error from processing: %v", err)
+	}
+	select {
+	case <-ch:
+	default:
+		t.Error("event did not pass the filter")
+	}
+
+	testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
+		bm.UpsertTargets(&config.Target{
+			Name:      "target",
+			Broker:    "broker",
+			Namespace: "ns",
+			Filters:   []*config.Filter{{Prefix: map[string]string{"type": "org.example."}}},
+		})
+	})
+	if err := p.Process(ctx, &e); err != nil {
+		t.Fatalf("unexpected error from processing: %v", err)
+	}
+	close(ch)
+	if gotEvent := <-ch; gotEvent != nil {
+		t.Error("event passed the updated filter")
+	}
+}
+
 func newTestTargets(filter map[string]string) (context.Context, config.Targets) {
+	return newTestTargetsWithFilters(filter, nil)
+}
+
+func newTestTargetsWithFilters(filter map[string]string, filters []*config.Filter) (context.Context, config.Targets) {
 	testTarget := &config.Target{
 		Name:             "target",
 		Broker:           "broker",
 		Namespace:        "ns",
 		FilterAttributes: filter,
+		Filters:          filters,
 	}
 	testTargets := memory.NewEmptyTargets()
 	testTargets.MutateBroker("ns", "broker", func(bm config.BrokerMutation) {
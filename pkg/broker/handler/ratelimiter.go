@@ -0,0 +1,70 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// itemLinearFailureRateLimiter does a simple baseDelay*failures limit,
+// i.e. the linear counterpart of workqueue's ItemExponentialFailureRateLimiter.
+type itemLinearFailureRateLimiter struct {
+	failuresLock sync.Mutex
+	failures     map[interface{}]int
+
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+var _ workqueue.RateLimiter = (*itemLinearFailureRateLimiter)(nil)
+
+func newItemLinearFailureRateLimiter(baseDelay, maxDelay time.Duration) workqueue.RateLimiter {
+	return &itemLinearFailureRateLimiter{
+		failures:  map[interface{}]int{},
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+	}
+}
+
+func (r *itemLinearFailureRateLimiter) When(item interface{}) time.Duration {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	r.failures[item]++
+	// Avoid overflowing for large numbers of failures.
+	if r.baseDelay > 0 && time.Duration(r.failures[item]) > r.maxDelay/r.baseDelay {
+		return r.maxDelay
+	}
+	return r.baseDelay * time.Duration(r.failures[item])
+}
+
+func (r *itemLinearFailureRateLimiter) NumRequeues(item interface{}) int {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	return r.failures[item]
+}
+
+func (r *itemLinearFailureRateLimiter) Forget(item interface{}) {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	delete(r.failures, item)
+}
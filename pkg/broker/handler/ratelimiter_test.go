@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestItemLinearFailureRateLimiter(t *testing.T) {
+	limiter := newItemLinearFailureRateLimiter(time.Second, 3500*time.Millisecond)
+
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3500 * time.Millisecond, 3500 * time.Millisecond} {
+		if got := limiter.When("one"); got != want {
+			t.Errorf("When() #%d got=%v, want=%v", i, got, want)
+		}
+	}
+	if got := limiter.NumRequeues("one"); got != 5 {
+		t.Errorf("NumRequeues() got=%d, want=5", got)
+	}
+
+	if got := limiter.When("two"); got != time.Second {
+		t.Errorf("When() for another item got=%v, want=%v", got, time.Second)
+	}
+
+	limiter.Forget("one")
+	if got := limiter.NumRequeues("one"); got != 0 {
+		t.Errorf("NumRequeues() after Forget() got=%d, want=0", got)
+	}
+	if got := limiter.When("one"); got != time.Second {
+		t.Errorf("When() after Forget() got=%v, want=%v", got, time.Second)
+	}
+}
+
+func TestNewRetryLimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{{
+		name:   "exponential",
+		policy: RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second},
+		want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+	}, {
+		name:   "linear",
+		policy: RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second, Linear: true},
+		want:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter := newRetryLimiter(tc.policy)
+			for i, want := range tc.want {
+				if got := limiter.When("item"); got != want {
+					t.Errorf("When() #%d got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"knative.dev/eventing/pkg/logging"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/google/knative-gcp/pkg/broker/config"
 	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
@@ -47,17 +48,22 @@ type RetryPool struct {
 	// For initial events delivery. We only need a shared client.
 	// And we can set target address dynamically.
 	deliverClient *http.Client
+	// For delivering to targets with transport options.
+	targetClients *deliver.TargetClients
+	// Compiled trigger filters shared by the filter processors of the handlers.
+	matchers      *filter.MatcherCache
 	statsReporter *metrics.DeliveryReporter
 }
 
 type retryHandlerCache struct {
 	Handler
-	t *config.Target
+	t           *config.Target
+	retryPolicy RetryPolicy
 }
 
 // If somehow the existing handler's setting has deviated from the current target config,
 // we need to renew the handler.
-func (hc *retryHandlerCache) shouldRenew(t *config.Target) bool {
+func (hc *retryHandlerCache) shouldRenew(t *config.Target, retryPolicy RetryPolicy) bool {
 	if !hc.IsAlive() {
 		return true
 	}
@@ -70,9 +76,36 @@ func (hc *retryHandlerCache) shouldRenew(t *config.Target) bool {
 		t.RetryQueue.Subscription != hc.t.RetryQueue.Subscription {
 		return true
 	}
+	// The retry policy is fixed when the handler is created.
+	if retryPolicy != hc.retryPolicy {
+		return true
+	}
 	return false
 }
 
+// targetRetryPolicy returns the retry policy for the target. The backoff
+// policy and delay in the target's delivery spec override the retry policy
+// of the pool.
+func (p *RetryPool) targetRetryPolicy(t *config.Target) RetryPolicy {
+	rp := p.options.RetryPolicy
+	ds := t.GetDeliverySpec()
+	if ds == nil {
+		return rp
+	}
+	rp.Linear = ds.BackoffPolicy == config.BackoffPolicy_LINEAR
+	if ds.BackoffDelay != nil {
+		d, err := ptypes.Duration(ds.BackoffDelay)
+		if err != nil {
+			return rp
+		}
+		rp.MinBackoff = d
+		// The pool's max backoff is meant for its own min backoff. Allow targets
+		// to back off for longer, up to the max time a handler can hold a message.
+		rp.MaxBackoff = maxTimeout
+	}
+	return rp
+}
+
 // NewRetryPool creates a new retry handler pool.
 func NewRetryPool(
 	targets config.ReadonlyTargets,
@@ -90,11 +123,23 @@ func NewRetryPool(
 		options:       options,
 		pubsubClient:  pubsubClient,
 		deliverClient: deliverClient,
+		targetClients: deliver.NewTargetClients(deliverClient, deliver.DefaultTargetCertsDir),
+		matchers:      filter.NewMatcherCache(),
 		statsReporter: statsReporter,
 	}
 	return p, nil
 }
 
+// Status returns the status of the handlers of the targets by their keys.
+func (p *RetryPool) Status() PoolStatus {
+	status := PoolStatus{Handlers: make(map[string]HandlerStatus)}
+	p.pool.Range(func(key, value interface{}) bool {
+		status.Handlers[key.(string)] = value.(*retryHandlerCache).Status()
+		return true
+	})
+	return status
+}
+
 // SyncOnce syncs once the handler pool based on the targets config.
 func (p *RetryPool) SyncOnce(ctx context.Context) error {
 	ctx, err := p.statsReporter.AddTags(ctx)
@@ -111,10 +156,23 @@ func (p *RetryPool) SyncOnce(ctx context.Context) error {
 		return true
 	})
 
+	// Close the clients of transport options no target uses anymore.
+	var transports []*config.Transport
+	p.targets.RangeAllTargets(func(t *config.Target) bool {
+		if t.Transport != nil {
+			transports = append(transports, t.Transport)
+		}
+		return true
+	})
+	p.targetClients.Retain(transports)
+	// Drop the compiled filters of the targets which were deleted.
+	p.matchers.Retain(p.targets)
+
 	p.targets.RangeAllTargets(func(t *config.Target) bool {
+		retryPolicy := p.targetRetryPolicy(t)
 		if value, ok := p.pool.Load(t.Key()); ok {
 			// Skip if we don't need to renew the handler.
-			if !value.(*retryHandlerCache).shouldRenew(t) {
+			if !value.(*retryHandlerCache).shouldRenew(t, retryPolicy) {
 				return true
 			}
 			// Stop and clean up the old handler before we start a new one.
@@ -134,19 +192,22 @@ func (p *RetryPool) SyncOnce(ctx context.Context) error {
 		h := NewHandler(
 			sub,
 			processors.ChainProcessors(
-				&filter.Processor{Targets: p.targets},
+				&filter.Processor{Targets: p.targets, Matchers: p.matchers},
 				&deliver.Processor{
-					DeliverClient: p.deliverClient,
-					Targets:       p.targets,
-					StatsReporter: p.statsReporter,
+					DeliverClient:    p.deliverClient,
+					TargetClients:    p.targetClients,
+					Targets:          p.targets,
+					StatsReporter:    p.statsReporter,
+					ReplyTokenSource: p.options.ReplyTokenSource,
 				},
 			),
 			p.options.TimeoutPerEvent,
-			p.options.RetryPolicy,
+			retryPolicy,
 		)
 		hc := &retryHandlerCache{
-			Handler: *h,
-			t:       t,
+			Handler:     *h,
+			t:           t,
+			retryPolicy: retryPolicy,
 		}
 
 		ctx, err := metrics.AddTargetTags(ctx, t)
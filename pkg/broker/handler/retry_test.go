@@ -22,8 +22,10 @@ import (
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/google/knative-gcp/pkg/broker/config"
 	"github.com/google/knative-gcp/pkg/broker/eventutil"
@@ -109,6 +111,37 @@ func TestRetryWatchAndSync(t *testing.T) {
 		assertRetryHandlers(t, syncPool, helper.Targets)
 	})
 
+	t.Run("updating the backoff of a target renews its handler", func(t *testing.T) {
+		b, ok := helper.Targets.GetBrokerByKey(bs[2].Key())
+		if !ok {
+			t.Fatalf("broker %q not found", bs[2].Key())
+		}
+		var target *config.Target
+		for _, bt := range b.Targets {
+			target = bt
+		}
+		updated := proto.Clone(target).(*config.Target)
+		updated.DeliverySpec = &config.DeliverySpec{
+			BackoffPolicy: config.BackoffPolicy_LINEAR,
+			BackoffDelay:  ptypes.DurationProto(2 * time.Second),
+		}
+		helper.Targets.MutateBroker(bs[2].Namespace, bs[2].Name, func(bm config.BrokerMutation) {
+			bm.UpsertTargets(updated)
+		})
+		signal <- struct{}{}
+		// Wait a short period for the handlers to be updated.
+		<-time.After(time.Second)
+		assertRetryHandlers(t, syncPool, helper.Targets)
+		value, ok := syncPool.pool.Load(updated.Key())
+		if !ok {
+			t.Fatalf("handler for target %q not found", updated.Key())
+		}
+		want := RetryPolicy{MinBackoff: 2 * time.Second, MaxBackoff: maxTimeout, Linear: true}
+		if got := value.(*retryHandlerCache).retryPolicy; got != want {
+			t.Errorf("handler retry policy got=%+v, want=%+v", got, want)
+		}
+	})
+
 	t.Run("deleting all brokers with their targets", func(t *testing.T) {
 		// clean up all brokers
 		for _, b := range bs {
@@ -121,6 +154,48 @@ func TestRetryWatchAndSync(t *testing.T) {
 	})
 }
 
+func TestTargetRetryPolicy(t *testing.T) {
+	poolPolicy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute}
+	tests := []struct {
+		name   string
+		target *config.Target
+		want   RetryPolicy
+	}{{
+		name:   "no delivery spec",
+		target: &config.Target{},
+		want:   poolPolicy,
+	}, {
+		name:   "retry only",
+		target: &config.Target{DeliverySpec: &config.DeliverySpec{Retry: 3}},
+		want:   poolPolicy,
+	}, {
+		name:   "linear",
+		target: &config.Target{DeliverySpec: &config.DeliverySpec{BackoffPolicy: config.BackoffPolicy_LINEAR}},
+		want:   RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute, Linear: true},
+	}, {
+		name: "exponential with delay",
+		target: &config.Target{DeliverySpec: &config.DeliverySpec{
+			BackoffDelay: ptypes.DurationProto(100 * time.Millisecond),
+		}},
+		want: RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: maxTimeout},
+	}, {
+		name: "linear with delay",
+		target: &config.Target{DeliverySpec: &config.DeliverySpec{
+			BackoffPolicy: config.BackoffPolicy_LINEAR,
+			BackoffDelay:  ptypes.DurationProto(2 * time.Minute),
+		}},
+		want: RetryPolicy{MinBackoff: 2 * time.Minute, MaxBackoff: maxTimeout, Linear: true},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &RetryPool{options: &Options{RetryPolicy: poolPolicy}}
+			if got := p.targetRetryPolicy(tc.target); got != tc.want {
+				t.Errorf("targetRetryPolicy() got=%+v, want=%+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestRetrySyncPoolE2E(t *testing.T) {
 	reportertest.ResetDeliveryMetrics()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
+)
+
+// TokenVerifier verifies bearer tokens.
+type TokenVerifier interface {
+	// Verify verifies the token and returns the identity of the caller.
+	Verify(ctx context.Context, token string) (string, error)
+}
+
+// Authorizer authorizes requests to send events to brokers.
+type Authorizer interface {
+	// Authorize returns ErrUnauthenticated if the caller of the request can't be authenticated,
+	// and ErrPermissionDenied if the caller isn't allowed to send events to the broker.
+	Authorize(ctx context.Context, request *nethttp.Request, broker types.NamespacedName) error
+}
+
+// NewAuthorizer creates an Authorizer which authenticates callers with the verifier and checks
+// them against the allowed identities of the brokers. If the verifier is nil, all requests are
+// allowed.
+func NewAuthorizer(brokerConfig config.ReadonlyTargets, verifier TokenVerifier) Authorizer {
+	if verifier == nil {
+		return allowAll{}
+	}
+	return &tokenAuthorizer{brokerConfig: brokerConfig, verifier: verifier}
+}
+
+type allowAll struct{}
+
+func (allowAll) Authorize(context.Context, *nethttp.Request, types.NamespacedName) error {
+	return nil
+}
+
+type tokenAuthorizer struct {
+	brokerConfig config.ReadonlyTargets
+	verifier     TokenVerifier
+}
+
+func (a *tokenAuthorizer) Authorize(ctx context.Context, request *nethttp.Request, broker types.NamespacedName) error {
+	token, ok := bearerToken(request)
+	if !ok {
+		return fmt.Errorf("missing bearer token: %w", ErrUnauthenticated)
+	}
+	identity, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return fmt.Errorf("%v: %w", err, ErrUnauthenticated)
+		}
+		return err
+	}
+	b, ok := a.brokerConfig.GetBroker(broker.Namespace, broker.Name)
+	if !ok {
+		return fmt.Errorf("%q: %w", broker, ErrNotFound)
+	}
+	for _, allowed := range b.AllowedIdentities {
+		if identityMatches(identity, allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not allowed to send events to %q: %w", identity, broker, ErrPermissionDenied)
+}
+
+// bearerToken returns the token of the bearer authorization header of the request.
+func bearerToken(request *nethttp.Request) (string, bool) {
+	const prefix = "bearer "
+	h := request.Header.Get("Authorization")
+	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(h[len(prefix):]), true
+}
+
+// identityMatches reports whether the identity matches an allowed identity. An allowed
+// identity ending with "*" matches any identity with that prefix.
+func identityMatches(identity, allowed string) bool {
+	if strings.HasSuffix(allowed, "*") {
+		return strings.HasPrefix(identity, strings.TrimSuffix(allowed, "*"))
+	}
+	return identity == allowed
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	logtest "knative.dev/pkg/logging/testing"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/metrics"
+	reportertest "github.com/google/knative-gcp/pkg/metrics/testing"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
+)
+
+// fakeVerifier maps tokens to identities.
+type fakeVerifier map[string]string
+
+func (v fakeVerifier) Verify(_ context.Context, token string) (string, error) {
+	if token == "unavailable" {
+		return "", errors.New("issuer unavailable")
+	}
+	identity, ok := v[token]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown token", oidc.ErrInvalidToken)
+	}
+	return identity, nil
+}
+
+func TestHandlerAuthorization(t *testing.T) {
+	verifier := fakeVerifier{
+		"sa-ns1":   "system:serviceaccount:ns1:sa",
+		"sa-ns2":   "system:serviceaccount:ns2:sa",
+		"google":   "publisher@project.iam.gserviceaccount.com",
+		"outsider": "outsider@example.com",
+	}
+	targets := memory.NewTargets(&config.TargetsConfig{
+		Brokers: map[string]*config.Broker{
+			"ns1/broker1": {
+				Id:                "b-uid-1",
+				Name:              "broker1",
+				Namespace:         "ns1",
+				DecoupleQueue:     &config.Queue{Topic: topicID},
+				State:             config.State_READY,
+				AllowedIdentities: []string{"system:serviceaccount:ns1:*", "publisher@project.iam.gserviceaccount.com"},
+			},
+			"ns2/broker2": {
+				Id:            "b-uid-2",
+				Name:          "broker2",
+				Namespace:     "ns2",
+				DecoupleQueue: &config.Queue{Topic: topicID},
+				State:         config.State_READY,
+			},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantCode      int
+	}{{
+		name:          "service account in namespace",
+		path:          "/ns1/broker1",
+		authorization: "Bearer sa-ns1",
+		wantCode:      nethttp.StatusAccepted,
+	}, {
+		name:          "allowed google service account",
+		path:          "/ns1/broker1",
+		authorization: "bearer google",
+		wantCode:      nethttp.StatusAccepted,
+	}, {
+		name:          "service account in another namespace",
+		path:          "/ns1/broker1",
+		authorization: "Bearer sa-ns2",
+		wantCode:      nethttp.StatusForbidden,
+	}, {
+		name:          "not allowed identity",
+		path:          "/ns1/broker1",
+		authorization: "Bearer outsider",
+		wantCode:      nethttp.StatusForbidden,
+	}, {
+		name:          "no allowed identities",
+		path:          "/ns2/broker2",
+		authorization: "Bearer sa-ns2",
+		wantCode:      nethttp.StatusForbidden,
+	}, {
+		name:     "missing token",
+		path:     "/ns1/broker1",
+		wantCode: nethttp.StatusUnauthorized,
+	}, {
+		name:          "not a bearer token",
+		path:          "/ns1/broker1",
+		authorization: "Basic c2EtbnMxOg==",
+		wantCode:      nethttp.StatusUnauthorized,
+	}, {
+		name:          "invalid token",
+		path:          "/ns1/broker1",
+		authorization: "Bearer forged",
+		wantCode:      nethttp.StatusUnauthorized,
+	}, {
+		name:          "verifier error",
+		path:          "/ns1/broker1",
+		authorization: "Bearer unavailable",
+		wantCode:      nethttp.StatusInternalServerError,
+	}, {
+		name:          "broker not found",
+		path:          "/ns1/broker-not-found",
+		authorization: "Bearer sa-ns1",
+		wantCode:      nethttp.StatusNotFound,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reportertest.ResetIngressMetrics()
+			ctx := logging.WithLogger(context.Background(), logtest.TestLogger(t))
+
+			psSrv := pstest.NewServer()
+			defer psSrv.Close()
+			psClient := createPubsubClient(ctx, t, psSrv)
+			if _, err := psClient.CreateTopic(ctx, topicID); err != nil {
+				t.Fatal(err)
+			}
+			statsReporter, err := metrics.NewIngressReporter(metrics.PodName(pod), metrics.ContainerName(container))
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := NewHandler(ctx, nil, NewMultiTopicDecoupleSink(ctx, targets, psClient), NewAuthorizer(targets, verifier), NewRateLimiter(targets), NewSchemaValidator(targets), statsReporter)
+
+			req := httptest.NewRequest(nethttp.MethodPost, tc.path, nil)
+			if err := http.WriteRequest(ctx, binding.ToMessage(createTestEvent("test-event")), req); err != nil {
+				t.Fatal(err)
+			}
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if got := w.Result().StatusCode; got != tc.wantCode {
+				t.Errorf("StatusCode mismatch. got: %v, want: %v", got, tc.wantCode)
+			}
+			if got, want := w.Result().Header.Get("WWW-Authenticate"), wwwAuthenticate(tc.wantCode); got != want {
+				t.Errorf("WWW-Authenticate mismatch. got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func wwwAuthenticate(statusCode int) string {
+	if statusCode == nethttp.StatusUnauthorized {
+		return "Bearer"
+	}
+	return ""
+}
+
+func TestAllowAll(t *testing.T) {
+	a := NewAuthorizer(memory.NewEmptyTargets(), nil)
+	req := httptest.NewRequest(nethttp.MethodPost, "/ns/broker", nil)
+	if err := a.Authorize(context.Background(), req, types.NamespacedName{Namespace: "ns", Name: "broker"}); err != nil {
+		t.Errorf("Authorize got error %v, want nil", err)
+	}
+}
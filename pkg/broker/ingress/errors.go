@@ -28,3 +28,9 @@ var ErrIncomplete = errors.New("incomplete config")
 
 // ErrNotReady is the error when a broker is not ready.
 var ErrNotReady = errors.New("not ready")
+
+// ErrUnauthenticated is the error when the caller of a request can't be authenticated.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrPermissionDenied is the error when the caller of a request isn't allowed to send events to a broker.
+var ErrPermissionDenied = errors.New("permission denied")
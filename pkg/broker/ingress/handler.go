@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	nethttp "net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	cev2 "github.com/cloudevents/sdk-go/v2"
@@ -31,6 +33,7 @@ import (
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/google/knative-gcp/pkg/metrics"
 	"github.com/google/knative-gcp/pkg/tracing"
+	"github.com/google/knative-gcp/pkg/utils/batch"
 	"github.com/google/knative-gcp/pkg/utils/clients"
 	"github.com/google/wire"
 	"go.opencensus.io/trace"
@@ -62,6 +65,9 @@ var HandlerSet wire.ProviderSet = wire.NewSet(
 	wire.Bind(new(HttpMessageReceiver), new(*kncloudevents.HttpMessageReceiver)),
 	NewMultiTopicDecoupleSink,
 	wire.Bind(new(DecoupleSink), new(*multiTopicDecoupleSink)),
+	NewAuthorizer,
+	NewRateLimiter,
+	NewSchemaValidator,
 	clients.NewPubsubClient,
 	metrics.NewIngressReporter,
 )
@@ -70,6 +76,9 @@ var HandlerSet wire.ProviderSet = wire.NewSet(
 type DecoupleSink interface {
 	// Send sends the event from a broker to the corresponding decoupling sink.
 	Send(ctx context.Context, ns, broker string, event cev2.Event) protocol.Result
+	// OrderingKey returns the ordering key of the event sent to a broker, or an empty string if
+	// the broker doesn't deliver events in order.
+	OrderingKey(ns, broker string, event *cev2.Event) string
 }
 
 // HttpMessageReceiver is an interface to listen on http requests.
@@ -83,15 +92,24 @@ type Handler struct {
 	httpReceiver HttpMessageReceiver
 	// decouple is the client to send events to a decouple sink.
 	decouple DecoupleSink
-	logger   *zap.Logger
-	reporter *metrics.IngressReporter
+	// authorizer authorizes the callers of requests.
+	authorizer Authorizer
+	// limiter enforces the rate limits of brokers.
+	limiter *RateLimiter
+	// validator validates the data of events against the schemas of brokers.
+	validator *SchemaValidator
+	logger    *zap.Logger
+	reporter  *metrics.IngressReporter
 }
 
 // NewHandler creates a new ingress handler.
-func NewHandler(ctx context.Context, httpReceiver HttpMessageReceiver, decouple DecoupleSink, reporter *metrics.IngressReporter) *Handler {
+func NewHandler(ctx context.Context, httpReceiver HttpMessageReceiver, decouple DecoupleSink, authorizer Authorizer, limiter *RateLimiter, validator *SchemaValidator, reporter *metrics.IngressReporter) *Handler {
 	return &Handler{
 		httpReceiver: httpReceiver,
 		decouple:     decouple,
+		authorizer:   authorizer,
+		limiter:      limiter,
+		validator:    validator,
 		reporter:     reporter,
 		logger:       logging.FromContext(ctx),
 	}
@@ -105,8 +123,11 @@ func (h *Handler) Start(ctx context.Context) error {
 // ServeHTTP implements net/http Handler interface method.
 // 1. Performs basic validation of the request.
 // 2. Parse request URL to get namespace and broker.
-// 3. Convert request to event.
-// 4. Send event to decouple sink.
+// 3. Authorize the caller to send events to the broker.
+// 4. Convert request to event, or to events in the batched content mode.
+// 5. Validate the data of events against the schemas of the broker.
+// 6. Check the rate limits of the broker.
+// 7. Send events to decouple sink.
 func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
 	if request.URL.Path == heathCheckPath {
 		response.WriteHeader(nethttp.StatusOK)
@@ -133,6 +154,27 @@ func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Re
 		Name:      pieces[2],
 	}
 
+	if err := h.authorizer.Authorize(ctx, request, broker); err != nil {
+		statusCode := nethttp.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrUnauthenticated):
+			response.Header().Set("WWW-Authenticate", "Bearer")
+			statusCode = nethttp.StatusUnauthorized
+		case errors.Is(err, ErrPermissionDenied):
+			statusCode = nethttp.StatusForbidden
+		case errors.Is(err, ErrNotFound):
+			statusCode = nethttp.StatusNotFound
+		}
+		h.logger.Info("Failed to authorize request", zap.Stringer("broker", broker), zap.Error(err))
+		nethttp.Error(response, err.Error(), statusCode)
+		return
+	}
+
+	if batch.IsBatch(request) {
+		h.serveBatch(ctx, response, request, broker)
+		return
+	}
+
 	event, err := h.toEvent(request)
 	if err != nil {
 		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
@@ -152,27 +194,103 @@ func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Re
 		)
 	}
 
-	// Optimistically set status code to StatusAccepted. It will be updated if there is an error.
-	// According to the data plane spec (https://github.com/knative/eventing/blob/master/docs/spec/data-plane.md), a
-	// non-callable SINK (which broker is) MUST respond with 202 Accepted if the request is accepted.
-	statusCode := nethttp.StatusAccepted
 	ctx, cancel := context.WithTimeout(ctx, decoupleSinkTimeout)
 	defer cancel()
-	defer func() { h.reportMetrics(request.Context(), broker, event, statusCode) }()
+	statusCode, err := h.send(ctx, broker, event)
+	h.reportMetrics(request.Context(), broker, event, statusCode, err)
+	if err != nil {
+		var rle *rateLimitError
+		if errors.As(err, &rle) {
+			setRetryAfter(response, rle.retryAfter)
+		}
+		nethttp.Error(response, err.Error(), statusCode)
+		return
+	}
+
+	response.WriteHeader(statusCode)
+}
+
+// serveBatch sends the events of a request in the batched content mode to the
+// decouple sink and responds with the result of each event.
+func (h *Handler) serveBatch(ctx context.Context, response nethttp.ResponseWriter, request *nethttp.Request, broker types.NamespacedName) {
+	events, err := batch.ReadEvents(response, request)
+	if err != nil {
+		h.logger.Debug("Failed to read batch", zap.Error(err))
+		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+
+	arrivalTime := cev2.Timestamp{Time: time.Now()}
+	for _, event := range events {
+		event.SetExtension(EventArrivalTime, arrivalTime)
+	}
+
+	ctx, span := trace.StartSpan(ctx, kntracing.BrokerMessagingDestination(broker))
+	defer span.End()
+	if span.IsRecordingEvents() {
+		span.AddAttributes(
+			kntracing.MessagingSystemAttribute,
+			tracing.PubSubProtocolAttribute,
+			kntracing.BrokerMessagingDestinationAttribute(broker),
+			trace.Int64Attribute("messaging.batch_size", int64(len(events))),
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, decoupleSinkTimeout)
+	defer cancel()
+	var (
+		mu         sync.Mutex
+		retryAfter time.Duration
+	)
+	orderingKey := func(event *cev2.Event) string {
+		return h.decouple.OrderingKey(broker.Namespace, broker.Name, event)
+	}
+	results := batch.SendAll(events, orderingKey, func(event *cev2.Event) (int, error) {
+		statusCode, err := h.send(ctx, broker, event)
+		h.reportMetrics(request.Context(), broker, event, statusCode, err)
+		var rle *rateLimitError
+		if errors.As(err, &rle) {
+			mu.Lock()
+			if rle.retryAfter > retryAfter {
+				retryAfter = rle.retryAfter
+			}
+			mu.Unlock()
+		}
+		return statusCode, err
+	})
+	if retryAfter > 0 {
+		setRetryAfter(response, retryAfter)
+	}
+	if err := batch.WriteResults(response, results); err != nil {
+		h.logger.Warn("Failed to write batch results", zap.Error(err))
+	}
+}
+
+// send sends the event to the decouple sink and returns the status code for the event.
+func (h *Handler) send(ctx context.Context, broker types.NamespacedName, event *cev2.Event) (int, error) {
+	if err := h.validator.validate(broker, event); err != nil {
+		h.logger.Debug("Event rejected by schema", zap.Stringer("broker", broker), zap.String("event", event.ID()), zap.Error(err))
+		return nethttp.StatusBadRequest, err
+	}
+	if rle := h.limiter.allow(broker, event); rle != nil {
+		h.logger.Debug("Event rejected by rate limit", zap.Stringer("broker", broker), zap.String("event", event.ID()), zap.Error(rle))
+		h.reportThrottled(ctx, broker, event, rle.limit)
+		return nethttp.StatusTooManyRequests, rle
+	}
+	// According to the data plane spec (https://github.com/knative/eventing/blob/master/docs/spec/data-plane.md), a
+	// non-callable SINK (which broker is) MUST respond with 202 Accepted if the request is accepted.
 	if res := h.decouple.Send(ctx, broker.Namespace, broker.Name, *event); !cev2.IsACK(res) {
 		msg := fmt.Sprintf("Error publishing to PubSub for broker %s. event: %+v, err: %v.", broker, event, res)
 		h.logger.Error(msg)
-		statusCode = nethttp.StatusInternalServerError
+		statusCode := nethttp.StatusInternalServerError
 		if errors.Is(res, ErrNotFound) {
 			statusCode = nethttp.StatusNotFound
 		} else if errors.Is(res, ErrNotReady) {
 			statusCode = nethttp.StatusServiceUnavailable
 		}
-		nethttp.Error(response, msg, statusCode)
-		return
+		return statusCode, errors.New(msg)
 	}
-
-	response.WriteHeader(statusCode)
+	return nethttp.StatusAccepted, nil
 }
 
 // toEvent converts an http request to an event.
@@ -198,14 +316,36 @@ func (h *Handler) toEvent(request *nethttp.Request) (*cev2.Event, error) {
 	return event, nil
 }
 
-func (h *Handler) reportMetrics(ctx context.Context, broker types.NamespacedName, event *cev2.Event, statusCode int) {
+func (h *Handler) reportMetrics(ctx context.Context, broker types.NamespacedName, event *cev2.Event, statusCode int, err error) {
 	args := metrics.IngressReportArgs{
 		Namespace:    broker.Namespace,
 		Broker:       broker.Name,
 		EventType:    event.Type(),
 		ResponseCode: statusCode,
 	}
+	var se *schemaError
+	if errors.As(err, &se) {
+		args.RejectReason = invalidSchemaReason
+	}
 	if err := h.reporter.ReportEventCount(ctx, args); err != nil {
 		h.logger.Warn("Failed to record metrics.", zap.Any("namespace", broker.Namespace), zap.Any("broker", broker.Name), zap.Error(err))
 	}
 }
+
+func (h *Handler) reportThrottled(ctx context.Context, broker types.NamespacedName, event *cev2.Event, limit string) {
+	args := metrics.IngressThrottleArgs{
+		Namespace: broker.Namespace,
+		Broker:    broker.Name,
+		EventType: event.Type(),
+		RateLimit: limit,
+	}
+	if err := h.reporter.ReportThrottledEventCount(ctx, args); err != nil {
+		h.logger.Warn("Failed to record metrics.", zap.Any("namespace", broker.Namespace), zap.Any("broker", broker.Name), zap.Error(err))
+	}
+}
+
+// setRetryAfter sets the Retry-After header of the response in whole seconds, rounded up.
+func setRetryAfter(response nethttp.ResponseWriter, d time.Duration) {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	response.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	nethttp "net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,15 +36,19 @@ import (
 	cecontext "github.com/cloudevents/sdk-go/v2/context"
 	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/knative-gcp/pkg/broker/config"
 	"github.com/google/knative-gcp/pkg/broker/config/memory"
 	"github.com/google/knative-gcp/pkg/metrics"
 	reportertest "github.com/google/knative-gcp/pkg/metrics/testing"
 	kgcptesting "github.com/google/knative-gcp/pkg/testing"
+	"github.com/google/knative-gcp/pkg/utils/batch"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/logging"
 	logtest "knative.dev/pkg/logging/testing"
@@ -317,6 +323,130 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		wantCode    int
+		wantResults []batch.Result
+	}{
+		{
+			name: "happy case",
+			path: "/ns1/broker1",
+			body: `[{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type"},` +
+				`{"specversion":"1.0","id":"2","source":"test-source","type":"test-event-type"}]`,
+			wantCode: nethttp.StatusAccepted,
+			wantResults: []batch.Result{
+				{ID: "1", Status: nethttp.StatusAccepted},
+				{ID: "2", Status: nethttp.StatusAccepted},
+			},
+		},
+		{
+			name:     "broker not ready",
+			path:     "/ns4/broker-not-ready",
+			body:     `[{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type"}]`,
+			wantCode: nethttp.StatusServiceUnavailable,
+			wantResults: []batch.Result{
+				{ID: "1", Status: nethttp.StatusServiceUnavailable},
+			},
+		},
+		{
+			name:     "invalid event in batch",
+			path:     "/ns1/broker1",
+			body:     `[{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type"},{"specversion":"1.0","id":"2"}]`,
+			wantCode: nethttp.StatusBadRequest,
+		},
+		{
+			name:     "malformed batch",
+			path:     "/ns1/broker1",
+			body:     `{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type"}`,
+			wantCode: nethttp.StatusBadRequest,
+		},
+	}
+
+	client := nethttp.Client{}
+	defer client.CloseIdleConnections()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reportertest.ResetIngressMetrics()
+			ctx := logging.WithLogger(context.Background(), logtest.TestLogger(t))
+			ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+			defer cancel()
+
+			psSrv := pstest.NewServer()
+			defer psSrv.Close()
+
+			url := createAndStartIngress(ctx, t, psSrv)
+			rec := setupTestReceiver(ctx, t, psSrv)
+
+			req, err := nethttp.NewRequest(nethttp.MethodPost, url+tc.path, bytes.NewBufferString(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsBatchJSON)
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Unexpected error from http client: %v", err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tc.wantCode {
+				t.Errorf("StatusCode mismatch. got: %v, want: %v", res.StatusCode, tc.wantCode)
+			}
+			if tc.wantResults == nil {
+				metricstest.CheckStatsNotReported(t, "event_count")
+				return
+			}
+
+			var body struct {
+				Results []batch.Result `json:"results"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResults, body.Results, cmpopts.IgnoreFields(batch.Result{}, "Error")); diff != "" {
+				t.Errorf("Unexpected results (-want,+got): %v", diff)
+			}
+			metricstest.CheckStatsReported(t, "event_count")
+			metricstest.CheckCountData(t, "event_count", map[string]string{
+				metricskey.LabelNamespaceName:     strings.Split(tc.path, "/")[1],
+				metricskey.LabelBrokerName:        strings.Split(tc.path, "/")[2],
+				metricskey.LabelEventType:         eventType,
+				metricskey.LabelResponseCode:      strconv.Itoa(tc.wantCode),
+				metricskey.LabelResponseCodeClass: fmt.Sprintf("%dxx", tc.wantCode/100),
+				metricskey.PodName:                pod,
+				metricskey.ContainerName:          container,
+			}, int64(len(tc.wantResults)))
+
+			if res.StatusCode != nethttp.StatusAccepted {
+				return
+			}
+			// Check that all events are stored in the decouple sink.
+			gotIDs := sets.NewString()
+			for range tc.wantResults {
+				m, err := rec.Receive(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				savedToSink, err := binding.ToEvent(ctx, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.Finish(nil)
+				gotIDs.Insert(savedToSink.ID())
+				assertExtensionsExist(EventArrivalTime)(t, savedToSink)
+			}
+			wantIDs := sets.NewString()
+			for _, r := range tc.wantResults {
+				wantIDs.Insert(r.ID)
+			}
+			if !gotIDs.Equal(wantIDs) {
+				t.Errorf("Saved event IDs mismatch. got: %v, want: %v", gotIDs.List(), wantIDs.List())
+			}
+		})
+	}
+}
+
 func BenchmarkIngressHandler(b *testing.B) {
 	for _, eventSize := range kgcptesting.BenchmarkEventSizes {
 		b.Run(fmt.Sprintf("%d bytes", eventSize), func(b *testing.B) {
@@ -338,12 +468,13 @@ func runIngressHandlerBenchmark(b *testing.B, eventSize int) {
 	defer psSrv.Close()
 
 	psClient := createPubsubClient(ctx, b, psSrv)
-	decouple := NewMultiTopicDecoupleSink(ctx, memory.NewTargets(brokerConfig), psClient)
+	targets := memory.NewTargets(brokerConfig)
+	decouple := NewMultiTopicDecoupleSink(ctx, targets, psClient)
 	statsReporter, err := metrics.NewIngressReporter(metrics.PodName(pod), metrics.ContainerName(container))
 	if err != nil {
 		b.Fatal(err)
 	}
-	h := NewHandler(ctx, nil, decouple, statsReporter)
+	h := NewHandler(ctx, nil, decouple, NewAuthorizer(targets, nil), NewRateLimiter(targets), NewSchemaValidator(targets), statsReporter)
 
 	if _, err := psClient.CreateTopic(ctx, topicID); err != nil {
 		b.Fatal(err)
@@ -409,14 +540,15 @@ func setupTestReceiver(ctx context.Context, t testing.TB, psSrv *pstest.Server)
 
 // createAndStartIngress creates an ingress and calls its Start() method in a goroutine.
 func createAndStartIngress(ctx context.Context, t testing.TB, psSrv *pstest.Server) string {
-	decouple := NewMultiTopicDecoupleSink(ctx, memory.NewTargets(brokerConfig), createPubsubClient(ctx, t, psSrv))
+	targets := memory.NewTargets(brokerConfig)
+	decouple := NewMultiTopicDecoupleSink(ctx, targets, createPubsubClient(ctx, t, psSrv))
 
 	receiver := &testHttpMessageReceiver{urlCh: make(chan string)}
 	statsReporter, err := metrics.NewIngressReporter(metrics.PodName(pod), metrics.ContainerName(container))
 	if err != nil {
 		t.Fatal(err)
 	}
-	h := NewHandler(ctx, receiver, decouple, statsReporter)
+	h := NewHandler(ctx, receiver, decouple, NewAuthorizer(targets, nil), NewRateLimiter(targets), NewSchemaValidator(targets), statsReporter)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -32,6 +32,7 @@ import (
 	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/eventutil"
 	"knative.dev/eventing/pkg/logging"
 )
 
@@ -64,7 +65,7 @@ type multiTopicDecoupleSink struct {
 
 // Send sends incoming event to its corresponding pubsub topic based on which broker it belongs to.
 func (m *multiTopicDecoupleSink) Send(ctx context.Context, ns, broker string, event cev2.Event) protocol.Result {
-	topic, err := m.getTopicForBroker(types.NamespacedName{Namespace: ns, Name: broker})
+	topic, b, err := m.getTopicForBroker(types.NamespacedName{Namespace: ns, Name: broker})
 	if err != nil {
 		return err
 	}
@@ -74,22 +75,38 @@ func (m *multiTopicDecoupleSink) Send(ctx context.Context, ns, broker string, ev
 	if err := cepubsub.WritePubSubMessage(ctx, binding.ToMessage(&event), msg, dt.WriteTransformer()); err != nil {
 		return err
 	}
+	msg.OrderingKey = eventutil.OrderingKey(&event, b.OrderingKeyAttribute)
 
 	_, err = topic.Publish(ctx, msg).Get(ctx)
+	if err != nil && msg.OrderingKey != "" {
+		// Publishing is paused for the ordering key after a failure so that later
+		// events are not published ahead of this one. The sender gets an error and
+		// is expected to retry, so resume publishing for the key.
+		topic.ResumePublish(msg.OrderingKey)
+	}
 	return err
 }
 
+// OrderingKey returns the ordering key of the event from the ordering key attribute of the broker.
+func (m *multiTopicDecoupleSink) OrderingKey(ns, broker string, event *cev2.Event) string {
+	b, err := m.getBrokerConfig(types.NamespacedName{Namespace: ns, Name: broker})
+	if err != nil {
+		return ""
+	}
+	return eventutil.OrderingKey(event, b.OrderingKeyAttribute)
+}
+
 // getTopicForBroker finds the corresponding decouple topic for the broker from the mounted broker configmap volume.
-func (m *multiTopicDecoupleSink) getTopicForBroker(broker types.NamespacedName) (*pubsub.Topic, error) {
-	topicID, err := m.getTopicIDForBroker(broker)
+func (m *multiTopicDecoupleSink) getTopicForBroker(broker types.NamespacedName) (*pubsub.Topic, *config.Broker, error) {
+	b, err := m.getBrokerConfig(broker)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if topic, ok := m.getExistingTopic(broker); ok {
-		// Check that the broker's topic ID hasn't changed.
-		if topic.ID() == topicID {
-			return topic, nil
+		// Check that the broker's topic hasn't changed.
+		if topicMatches(topic, b) {
+			return topic, b, nil
 		}
 	}
 
@@ -97,46 +114,55 @@ func (m *multiTopicDecoupleSink) getTopicForBroker(broker types.NamespacedName)
 	return m.updateTopicForBroker(broker)
 }
 
-func (m *multiTopicDecoupleSink) updateTopicForBroker(broker types.NamespacedName) (*pubsub.Topic, error) {
+func (m *multiTopicDecoupleSink) updateTopicForBroker(broker types.NamespacedName) (*pubsub.Topic, *config.Broker, error) {
 	m.topicsMut.Lock()
 	defer m.topicsMut.Unlock()
-	// Fetch latest decouple topic ID under lock.
-	topicID, err := m.getTopicIDForBroker(broker)
+	// Fetch latest broker config under lock.
+	b, err := m.getBrokerConfig(broker)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if topic, ok := m.topics[broker]; ok {
-		if topic.ID() == topicID {
+		if topicMatches(topic, b) {
 			// Topic already updated.
-			return topic, nil
+			return topic, b, nil
 		}
 		// Stop old topic.
 		m.topics[broker].Stop()
 	}
-	topic := m.pubsub.Topic(topicID)
+	topic := m.pubsub.Topic(b.DecoupleQueue.Topic)
+	// Message ordering has to be enabled to publish messages with ordering keys.
+	topic.EnableMessageOrdering = b.OrderingKeyAttribute != ""
 	m.topics[broker] = topic
-	return topic, nil
+	return topic, b, nil
+}
+
+// topicMatches returns true if the topic is the decouple topic of the broker
+// and has the broker's message ordering setting.
+func topicMatches(topic *pubsub.Topic, b *config.Broker) bool {
+	return topic.ID() == b.DecoupleQueue.Topic && topic.EnableMessageOrdering == (b.OrderingKeyAttribute != "")
 }
 
-func (m *multiTopicDecoupleSink) getTopicIDForBroker(broker types.NamespacedName) (string, error) {
+// getBrokerConfig returns the config of the broker if the broker is ready and has a decouple topic.
+func (m *multiTopicDecoupleSink) getBrokerConfig(broker types.NamespacedName) (*config.Broker, error) {
 	brokerConfig, ok := m.brokerConfig.GetBroker(broker.Namespace, broker.Name)
 	if !ok {
 		// There is an propagation delay between the controller reconciles the broker config and
 		// the config being pushed to the configmap volume in the ingress pod. So sometimes we return
 		// an error even if the request is valid.
 		m.logger.Warn("config is not found for", zap.String("broker", broker.String()))
-		return "", fmt.Errorf("%q: %w", broker, ErrNotFound)
+		return nil, fmt.Errorf("%q: %w", broker, ErrNotFound)
 	}
 	if brokerConfig.State != config.State_READY {
 		m.logger.Debug("broker is not ready", zap.Any("ns", broker.Namespace), zap.Any("broker", broker))
-		return "", fmt.Errorf("%q: %w", broker, ErrNotReady)
+		return nil, fmt.Errorf("%q: %w", broker, ErrNotReady)
 	}
 	if brokerConfig.DecoupleQueue == nil || brokerConfig.DecoupleQueue.Topic == "" {
 		m.logger.Error("DecoupleQueue or topic missing for broker, this should NOT happen.", zap.Any("brokerConfig", brokerConfig))
-		return "", fmt.Errorf("decouple queue of %q: %w", broker, ErrIncomplete)
+		return nil, fmt.Errorf("decouple queue of %q: %w", broker, ErrIncomplete)
 	}
-	return brokerConfig.DecoupleQueue.Topic, nil
+	return brokerConfig, nil
 }
 
 func (m *multiTopicDecoupleSink) getExistingTopic(broker types.NamespacedName) (*pubsub.Topic, bool) {
@@ -206,6 +206,79 @@ func TestMultiTopicDecoupleSink(t *testing.T) {
 	}
 }
 
+func TestMultiTopicDecoupleSinkOrderingKey(t *testing.T) {
+	ctx := logtest.TestContextWithLogger(t)
+	psSrv := pstest.NewServer()
+	defer psSrv.Close()
+	psClient := createPubsubClient(ctx, t, psSrv)
+	topic, err := psClient.CreateTopic(ctx, "test_topic_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription, err := psClient.CreateSubscription(ctx, "test-sub", pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	brokerConfig := memory.NewTargets(&config.TargetsConfig{
+		Brokers: map[string]*config.Broker{
+			"test_ns_1/test_broker_1": {State: config.State_READY, DecoupleQueue: &config.Queue{Topic: "test_topic_1"}},
+		},
+	})
+	sink := NewMultiTopicDecoupleSink(ctx, brokerConfig, psClient)
+
+	receive := func() *pubsub.Message {
+		rctx, cancel := context.WithCancel(ctx)
+		msgCh := make(chan *pubsub.Message, 1)
+		subscription.Receive(rctx,
+			func(ctx context.Context, m *pubsub.Message) {
+				select {
+				case msgCh <- m:
+					cancel()
+				case <-ctx.Done():
+				}
+				m.Ack()
+			},
+		)
+		return <-msgCh
+	}
+
+	event := createTestEvent(uuid.New().String())
+	event.SetExtension("partitionkey", "key1")
+	if err := sink.Send(context.Background(), "test_ns_1", "test_broker_1", *event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := receive().OrderingKey; got != "" {
+		t.Errorf("Unordered broker got ordering key %q, want empty", got)
+	}
+
+	// Enable ordered delivery for the broker.
+	brokerConfig.MutateBroker("test_ns_1", "test_broker_1", func(m config.BrokerMutation) {
+		m.SetOrderingKeyAttribute("partitionkey")
+	})
+	if err := sink.Send(context.Background(), "test_ns_1", "test_broker_1", *event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := receive().OrderingKey; got != "key1" {
+		t.Errorf("Ordered broker got ordering key %q, want %q", got, "key1")
+	}
+	if got := sink.OrderingKey("test_ns_1", "test_broker_1", event); got != "key1" {
+		t.Errorf("OrderingKey got %q, want %q", got, "key1")
+	}
+	if got := sink.OrderingKey("test_ns_1", "non-existent", event); got != "" {
+		t.Errorf("OrderingKey of non-existent broker got %q, want empty", got)
+	}
+
+	// Events without the ordering key attribute are published without ordering key.
+	event = createTestEvent(uuid.New().String())
+	if err := sink.Send(context.Background(), "test_ns_1", "test_broker_1", *event); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := receive().OrderingKey; got != "" {
+		t.Errorf("Event without ordering key attribute got ordering key %q, want empty", got)
+	}
+}
+
 type fakePubsubClient struct {
 	t *testing.T
 	// topics is the mapping from topic name to corresponding channel which contains the event.
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+)
+
+const (
+	// brokerRateLimit is the name of the rate limit of all events sent to a broker.
+	brokerRateLimit = "broker"
+	// sourceRateLimit is the name of the rate limit of the events from each source.
+	sourceRateLimit = "source"
+
+	// minSourceSweep is the number of source limiters of a broker above which idle
+	// limiters are removed.
+	minSourceSweep = 1024
+)
+
+// rateLimitError is the error when an event is rejected by a rate limit of a broker.
+type rateLimitError struct {
+	broker types.NamespacedName
+	// limit is the name of the rate limit which rejected the event.
+	limit string
+	// retryAfter is the time after which the event would be accepted.
+	retryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit of broker %s exceeded, retry after %v", e.limit, e.broker, e.retryAfter)
+}
+
+// RateLimiter enforces the rate limits of brokers. The limits are enforced by each ingress
+// replica independently.
+type RateLimiter struct {
+	brokerConfig config.ReadonlyTargets
+	now          func() time.Time
+
+	// brokers maps the keys of the brokers with rate limits to their *brokerLimiter.
+	brokers sync.Map
+}
+
+// NewRateLimiter creates a RateLimiter which enforces the rate limits in the broker config.
+func NewRateLimiter(brokerConfig config.ReadonlyTargets) *RateLimiter {
+	return &RateLimiter{
+		brokerConfig: brokerConfig,
+		now:          time.Now,
+	}
+}
+
+// brokerLimiter holds the token buckets of a broker.
+type brokerLimiter struct {
+	mu sync.Mutex
+	// config is the generation of the broker config the limits were last updated from. Every
+	// update of the broker config replaces the config of the broker, so the limits are only
+	// compared when the config changes.
+	config      *config.Broker
+	limiter     *rate.Limiter
+	sourceLimit *config.RateLimit
+	sources     map[string]*sourceLimiter
+	// nextSweep is the number of source limiters above which idle limiters are removed.
+	nextSweep int
+}
+
+type sourceLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// allow takes a token from the buckets of the broker for the event. If the event is rejected,
+// it returns the rate limit error.
+func (l *RateLimiter) allow(broker types.NamespacedName, event *cev2.Event) *rateLimitError {
+	key := config.BrokerKey(broker.Namespace, broker.Name)
+	b, ok := l.brokerConfig.GetBroker(broker.Namespace, broker.Name)
+	if !ok || (b.RateLimit == nil && b.SourceRateLimit == nil) {
+		l.brokers.Delete(key)
+		return nil
+	}
+	v, ok := l.brokers.Load(key)
+	if !ok {
+		v, _ = l.brokers.LoadOrStore(key, &brokerLimiter{nextSweep: minSourceSweep})
+	}
+	return v.(*brokerLimiter).allow(broker, b, event.Source(), l.now())
+}
+
+func (bl *brokerLimiter) allow(broker types.NamespacedName, b *config.Broker, source string, now time.Time) *rateLimitError {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	if bl.config != b {
+		bl.update(b, now)
+	}
+
+	var sourceReservation *rate.Reservation
+	if bl.sourceLimit != nil {
+		r := bl.sourceLimiter(source, now).ReserveN(now, 1)
+		if d := r.DelayFrom(now); d > 0 {
+			r.CancelAt(now)
+			return &rateLimitError{broker: broker, limit: sourceRateLimit, retryAfter: d}
+		}
+		sourceReservation = r
+	}
+	if bl.limiter != nil {
+		r := bl.limiter.ReserveN(now, 1)
+		if d := r.DelayFrom(now); d > 0 {
+			r.CancelAt(now)
+			if sourceReservation != nil {
+				// Give back the token of the source, as the event is rejected.
+				sourceReservation.CancelAt(now)
+			}
+			return &rateLimitError{broker: broker, limit: brokerRateLimit, retryAfter: d}
+		}
+	}
+	return nil
+}
+
+// update updates the limits to the given generation of the broker config.
+func (bl *brokerLimiter) update(b *config.Broker, now time.Time) {
+	bl.config = b
+	if b.RateLimit != nil {
+		bl.limiter = updateLimiter(bl.limiter, b.RateLimit, now)
+	} else {
+		bl.limiter = nil
+	}
+	bl.sourceLimit = b.SourceRateLimit
+	if b.SourceRateLimit == nil {
+		bl.sources = nil
+		return
+	}
+	for _, sl := range bl.sources {
+		sl.limiter = updateLimiter(sl.limiter, b.SourceRateLimit, now)
+	}
+}
+
+// sourceLimiter returns the limiter of the source, creating it if needed.
+func (bl *brokerLimiter) sourceLimiter(source string, now time.Time) *rate.Limiter {
+	if bl.sources == nil {
+		bl.sources = make(map[string]*sourceLimiter)
+	}
+	sl, ok := bl.sources[source]
+	if !ok {
+		if len(bl.sources) >= bl.nextSweep {
+			bl.sweep(now)
+		}
+		sl = &sourceLimiter{limiter: updateLimiter(nil, bl.sourceLimit, now)}
+		bl.sources[source] = sl
+	}
+	sl.lastSeen = now
+	return sl.limiter
+}
+
+// sweep removes the limiters of the sources which haven't sent events for long enough for
+// their buckets to be full again. Removing them doesn't change the limits, as new limiters
+// start with full buckets.
+func (bl *brokerLimiter) sweep(now time.Time) {
+	refill := time.Duration(float64(bl.sourceLimit.Burst) / bl.sourceLimit.EventsPerSecond * float64(time.Second))
+	for source, sl := range bl.sources {
+		if now.Sub(sl.lastSeen) >= refill {
+			delete(bl.sources, source)
+		}
+	}
+	bl.nextSweep = 2 * len(bl.sources)
+	if bl.nextSweep < minSourceSweep {
+		bl.nextSweep = minSourceSweep
+	}
+}
+
+// updateLimiter returns a limiter with the given limit, reusing the existing limiter so that
+// its tokens are kept when the limit changes.
+func updateLimiter(l *rate.Limiter, limit *config.RateLimit, now time.Time) *rate.Limiter {
+	if l == nil {
+		return rate.NewLimiter(rate.Limit(limit.EventsPerSecond), int(limit.Burst))
+	}
+	if l.Limit() != rate.Limit(limit.EventsPerSecond) {
+		l.SetLimitAt(now, rate.Limit(limit.EventsPerSecond))
+	}
+	if l.Burst() != int(limit.Burst) {
+		l.SetBurstAt(now, int(limit.Burst))
+	}
+	return l
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/pstest"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	logtest "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/metrics/metricskey"
+	"knative.dev/pkg/metrics/metricstest"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/metrics"
+	reportertest "github.com/google/knative-gcp/pkg/metrics/testing"
+)
+
+func rateLimitedBroker(limit, sourceLimit *config.RateLimit) config.Targets {
+	return memory.NewTargets(&config.TargetsConfig{
+		Brokers: map[string]*config.Broker{
+			"ns1/broker1": {
+				Id:              "b-uid-1",
+				Name:            "broker1",
+				Namespace:       "ns1",
+				DecoupleQueue:   &config.Queue{Topic: topicID},
+				State:           config.State_READY,
+				RateLimit:       limit,
+				SourceRateLimit: sourceLimit,
+			},
+		},
+	})
+}
+
+func eventFromSource(source string) *cloudevents.Event {
+	e := createTestEvent("test-event")
+	e.SetSource(source)
+	return e
+}
+
+func TestRateLimiter(t *testing.T) {
+	broker := types.NamespacedName{Namespace: "ns1", Name: "broker1"}
+	type step struct {
+		// advance is the time to advance the clock by before the event.
+		advance time.Duration
+		source  string
+		// wantLimit is the rate limit which should reject the event, if any.
+		wantLimit      string
+		wantRetryAfter time.Duration
+	}
+	tests := []struct {
+		name        string
+		limit       *config.RateLimit
+		sourceLimit *config.RateLimit
+		steps       []step
+	}{{
+		name: "no rate limits",
+		steps: []step{
+			{source: "a"}, {source: "a"}, {source: "a"},
+		},
+	}, {
+		name:  "broker rate limit",
+		limit: &config.RateLimit{EventsPerSecond: 2, Burst: 2},
+		steps: []step{
+			{source: "a"},
+			{source: "b"},
+			{source: "a", wantLimit: brokerRateLimit, wantRetryAfter: 500 * time.Millisecond},
+			{advance: 250 * time.Millisecond, source: "a", wantLimit: brokerRateLimit, wantRetryAfter: 250 * time.Millisecond},
+			{advance: 250 * time.Millisecond, source: "a"},
+			{source: "a", wantLimit: brokerRateLimit, wantRetryAfter: 500 * time.Millisecond},
+			{advance: time.Second, source: "a"},
+			{source: "a"},
+		},
+	}, {
+		name:        "source rate limit",
+		sourceLimit: &config.RateLimit{EventsPerSecond: 1, Burst: 1},
+		steps: []step{
+			{source: "a"},
+			{source: "b"},
+			{source: "a", wantLimit: sourceRateLimit, wantRetryAfter: time.Second},
+			{source: "b", wantLimit: sourceRateLimit, wantRetryAfter: time.Second},
+			{source: "c"},
+			{advance: time.Second, source: "a"},
+		},
+	}, {
+		name:        "broker and source rate limits",
+		limit:       &config.RateLimit{EventsPerSecond: 1, Burst: 2},
+		sourceLimit: &config.RateLimit{EventsPerSecond: 1, Burst: 1},
+		steps: []step{
+			{source: "a"},
+			{source: "a", wantLimit: sourceRateLimit, wantRetryAfter: time.Second},
+			{source: "b"},
+			// The rejected event doesn't use the token of source c.
+			{source: "c", wantLimit: brokerRateLimit, wantRetryAfter: time.Second},
+			{advance: time.Second, source: "c"},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewRateLimiter(rateLimitedBroker(tc.limit, tc.sourceLimit))
+			now := time.Now()
+			l.now = func() time.Time { return now }
+			for i, s := range tc.steps {
+				now = now.Add(s.advance)
+				rle := l.allow(broker, eventFromSource(s.source))
+				if s.wantLimit == "" {
+					if rle != nil {
+						t.Errorf("step %d: allow got error %v, want nil", i, rle)
+					}
+					continue
+				}
+				if rle == nil {
+					t.Errorf("step %d: allow got nil, want error of the %s rate limit", i, s.wantLimit)
+					continue
+				}
+				if rle.limit != s.wantLimit || rle.retryAfter != s.wantRetryAfter {
+					t.Errorf("step %d: allow got %s rate limit with retry after %v, want %s rate limit with retry after %v",
+						i, rle.limit, rle.retryAfter, s.wantLimit, s.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	broker := types.NamespacedName{Namespace: "ns1", Name: "broker1"}
+	l := NewRateLimiter(rateLimitedBroker(nil, &config.RateLimit{EventsPerSecond: 1, Burst: 1}))
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	for i := 0; i < minSourceSweep; i++ {
+		if rle := l.allow(broker, eventFromSource(fmt.Sprintf("old-%d", i))); rle != nil {
+			t.Fatalf("allow got error %v, want nil", rle)
+		}
+	}
+	now = now.Add(time.Second)
+	if rle := l.allow(broker, eventFromSource("old-0")); rle != nil {
+		t.Fatalf("allow got error %v, want nil", rle)
+	}
+	if rle := l.allow(broker, eventFromSource("new")); rle != nil {
+		t.Fatalf("allow got error %v, want nil", rle)
+	}
+	// All idle sources are removed, and the source seen after the refill is kept.
+	bl, ok := l.brokers.Load("ns1/broker1")
+	if !ok {
+		t.Fatal("no limiter of the broker")
+	}
+	if got, want := len(bl.(*brokerLimiter).sources), 2; got != want {
+		t.Errorf("got %d source limiters after the sweep, want %d", got, want)
+	}
+	if rle := l.allow(broker, eventFromSource("old-0")); rle == nil {
+		t.Error("allow got nil after the sweep, want the source to still be rate limited")
+	}
+}
+
+func TestRateLimiterConfigUpdate(t *testing.T) {
+	broker := types.NamespacedName{Namespace: "ns1", Name: "broker1"}
+	targets := rateLimitedBroker(&config.RateLimit{EventsPerSecond: 1, Burst: 1}, nil)
+	l := NewRateLimiter(targets)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	if rle := l.allow(broker, eventFromSource("a")); rle != nil {
+		t.Fatalf("allow got error %v, want nil", rle)
+	}
+	if rle := l.allow(broker, eventFromSource("a")); rle == nil {
+		t.Fatal("allow got nil, want the broker to be rate limited")
+	}
+
+	// The new limit applies to the next event, and the bucket is still empty.
+	targets.MutateBroker("ns1", "broker1", func(m config.BrokerMutation) {
+		m.SetRateLimits(&config.RateLimit{EventsPerSecond: 4, Burst: 1}, nil)
+	})
+	if rle := l.allow(broker, eventFromSource("a")); rle == nil || rle.retryAfter != 250*time.Millisecond {
+		t.Errorf("allow after the limit is raised got error %v, want retry after %v", rle, 250*time.Millisecond)
+	}
+	now = now.Add(250 * time.Millisecond)
+	if rle := l.allow(broker, eventFromSource("a")); rle != nil {
+		t.Errorf("allow after the limit is raised got error %v, want nil", rle)
+	}
+
+	targets.MutateBroker("ns1", "broker1", func(m config.BrokerMutation) {
+		m.SetRateLimits(nil, nil)
+	})
+	if rle := l.allow(broker, eventFromSource("a")); rle != nil {
+		t.Errorf("allow after the limit is removed got error %v, want nil", rle)
+	}
+	if _, ok := l.brokers.Load("ns1/broker1"); ok {
+		t.Error("the limiter of the broker is kept after the limit is removed")
+	}
+}
+
+func TestHandlerRateLimit(t *testing.T) {
+	reportertest.ResetIngressMetrics()
+	ctx := logging.WithLogger(context.Background(), logtest.TestLogger(t))
+
+	psSrv := pstest.NewServer()
+	defer psSrv.Close()
+	psClient := createPubsubClient(ctx, t, psSrv)
+	if _, err := psClient.CreateTopic(ctx, topicID); err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, err := metrics.NewIngressReporter(metrics.PodName(pod), metrics.ContainerName(container))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A rate low enough that no token is added during the test.
+	targets := rateLimitedBroker(&config.RateLimit{EventsPerSecond: 0.01, Burst: 2}, nil)
+	h := NewHandler(ctx, nil, NewMultiTopicDecoupleSink(ctx, targets, psClient), NewAuthorizer(targets, nil), NewRateLimiter(targets), NewSchemaValidator(targets), statsReporter)
+
+	for i, want := range []int{nethttp.StatusAccepted, nethttp.StatusAccepted, nethttp.StatusTooManyRequests} {
+		req := httptest.NewRequest(nethttp.MethodPost, "/ns1/broker1", nil)
+		if err := http.WriteRequest(ctx, binding.ToMessage(createTestEvent("test-event")), req); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if got := w.Result().StatusCode; got != want {
+			t.Errorf("request %d: StatusCode mismatch. got: %v, want: %v", i, got, want)
+		}
+		if want == nethttp.StatusTooManyRequests {
+			if got := w.Result().Header.Get("Retry-After"); got != "100" {
+				t.Errorf("request %d: Retry-After mismatch. got: %q, want: %q", i, got, "100")
+			}
+		}
+	}
+
+	// Events of a batch over the limit are rejected individually.
+	req := httptest.NewRequest(nethttp.MethodPost, "/ns1/broker1", bytes.NewBufferString(
+		`[{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type"}]`))
+	req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsBatchJSON)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Result().StatusCode; got != nethttp.StatusTooManyRequests {
+		t.Errorf("batch: StatusCode mismatch. got: %v, want: %v", got, nethttp.StatusTooManyRequests)
+	}
+	if got := w.Result().Header.Get("Retry-After"); got != "100" {
+		t.Errorf("batch: Retry-After mismatch. got: %q, want: %q", got, "100")
+	}
+
+	metricstest.CheckCountData(t, "throttled_event_count", map[string]string{
+		metricskey.LabelNamespaceName: "ns1",
+		metricskey.LabelBrokerName:    "broker1",
+		metricskey.LabelEventType:     eventType,
+		"rate_limit":                  brokerRateLimit,
+		metricskey.PodName:            pod,
+		metricskey.ContainerName:      container,
+	}, 2)
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: time.Millisecond, want: "1"},
+		{d: time.Second, want: "1"},
+		{d: 1500 * time.Millisecond, want: "2"},
+	} {
+		w := httptest.NewRecorder()
+		setRetryAfter(w, tc.d)
+		if got := w.Header().Get("Retry-After"); got != tc.want {
+			t.Errorf("setRetryAfter(%v) got %q, want %q", tc.d, got, tc.want)
+		}
+	}
+}
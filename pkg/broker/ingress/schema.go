@@ -0,0 +1,145 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/schema"
+)
+
+// invalidSchemaReason is the reject reason reported for events with data invalid against the
+// schemas of the broker.
+const invalidSchemaReason = "invalid_schema"
+
+// schemaError is the error when the data of an event is invalid against the schemas of a broker.
+type schemaError struct {
+	broker types.NamespacedName
+	// schema is the event type or dataschema the schema of which rejected the event.
+	schema string
+	err    error
+}
+
+func (e *schemaError) Error() string {
+	return fmt.Sprintf("event data is invalid against the schema of %q of broker %s: %v", e.schema, e.broker, e.err)
+}
+
+func (e *schemaError) Unwrap() error {
+	return e.err
+}
+
+// SchemaValidator validates the data of events against the schemas of brokers.
+type SchemaValidator struct {
+	brokerConfig config.ReadonlyTargets
+	// brokers caches the compiled schemas by broker key.
+	brokers sync.Map
+}
+
+// compiledSchemas are the compiled schemas of a broker.
+type compiledSchemas struct {
+	// schemas is the config the schemas are compiled from. The broker config replaces
+	// the schemas when they are updated, so a different pointer means the schemas need
+	// to be compiled again.
+	schemas     *config.EventSchemas
+	types       map[string]*schema.Schema
+	dataSchemas map[string]*schema.Schema
+}
+
+// NewSchemaValidator creates a SchemaValidator which validates events against the schemas in
+// the broker config.
+func NewSchemaValidator(brokerConfig config.ReadonlyTargets) *SchemaValidator {
+	return &SchemaValidator{brokerConfig: brokerConfig}
+}
+
+// validate validates the data of the event against the schema of its dataschema if the broker
+// has one, otherwise against the schema of its type. It returns a *schemaError if the data is
+// invalid.
+func (v *SchemaValidator) validate(broker types.NamespacedName, event *cev2.Event) error {
+	b, ok := v.brokerConfig.GetBroker(broker.Namespace, broker.Name)
+	if !ok || b.EventSchemas == nil {
+		return nil
+	}
+	cs := v.compiled(b)
+	name := event.DataSchema()
+	s, ok := cs.dataSchemas[name]
+	if !ok {
+		name = event.Type()
+		if s, ok = cs.types[name]; !ok {
+			return nil
+		}
+	}
+	if !isJSON(event.DataContentType()) {
+		return &schemaError{broker: broker, schema: name, err: fmt.Errorf("data content type %q is not JSON", event.DataContentType())}
+	}
+	data := event.Data()
+	if len(data) == 0 {
+		data = []byte("null")
+	}
+	if err := s.Validate(data); err != nil {
+		return &schemaError{broker: broker, schema: name, err: err}
+	}
+	return nil
+}
+
+// compiled returns the compiled schemas of the broker.
+func (v *SchemaValidator) compiled(b *config.Broker) *compiledSchemas {
+	key := b.Key()
+	if c, ok := v.brokers.Load(key); ok {
+		if cs := c.(*compiledSchemas); cs.schemas == b.EventSchemas {
+			return cs
+		}
+	}
+	cs := &compiledSchemas{
+		schemas:     b.EventSchemas,
+		types:       compileSchemas(b.EventSchemas.Types),
+		dataSchemas: compileSchemas(b.EventSchemas.DataSchemas),
+	}
+	v.brokers.Store(key, cs)
+	return cs
+}
+
+// compileSchemas compiles the schemas. The webhook rejects invalid schemas, so schemas which
+// fail to compile are left out rather than rejecting every event.
+func compileSchemas(raw map[string]string) map[string]*schema.Schema {
+	compiled := make(map[string]*schema.Schema, len(raw))
+	for k, r := range raw {
+		if s, err := schema.Compile([]byte(r)); err == nil {
+			compiled[k] = s
+		}
+	}
+	return compiled
+}
+
+// isJSON returns true if the data content type is JSON. Events without a data content type
+// are JSON, as in the structured content mode.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
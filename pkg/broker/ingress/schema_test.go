@@ -0,0 +1,233 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/pubsub/pstest"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.opencensus.io/stats/view"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	logtest "knative.dev/pkg/logging/testing"
+
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/broker/schema"
+	"github.com/google/knative-gcp/pkg/metrics"
+	reportertest "github.com/google/knative-gcp/pkg/metrics/testing"
+)
+
+const (
+	orderSchema   = `{"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}}`
+	orderV2Schema = `{"type": "object", "required": ["orderId"]}`
+	orderV2URI    = "https://example.com/schemas/order/v2"
+)
+
+func brokerWithSchemas(schemas *config.EventSchemas) config.Targets {
+	return memory.NewTargets(&config.TargetsConfig{
+		Brokers: map[string]*config.Broker{
+			"ns1/broker1": {
+				Id:            "b-uid-1",
+				Name:          "broker1",
+				Namespace:     "ns1",
+				DecoupleQueue: &config.Queue{Topic: topicID},
+				State:         config.State_READY,
+				EventSchemas:  schemas,
+			},
+		},
+	})
+}
+
+func eventWithData(t *testing.T, contentType, dataSchema, data string) *cloudevents.Event {
+	e := createTestEvent("test-event")
+	if dataSchema != "" {
+		e.SetDataSchema(dataSchema)
+	}
+	if data != "" {
+		if err := e.SetData(contentType, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return e
+}
+
+func TestSchemaValidator(t *testing.T) {
+	broker := types.NamespacedName{Namespace: "ns1", Name: "broker1"}
+	schemas := &config.EventSchemas{
+		Types:       map[string]string{eventType: orderSchema},
+		DataSchemas: map[string]string{orderV2URI: orderV2Schema},
+	}
+	tests := []struct {
+		name    string
+		schemas *config.EventSchemas
+		event   *cloudevents.Event
+		// wantErr is the prefix of the expected error, if any.
+		wantErr string
+	}{{
+		name:  "no schemas",
+		event: eventWithData(t, cloudevents.ApplicationJSON, "", `"not an order"`),
+	}, {
+		name:    "valid data",
+		schemas: schemas,
+		event:   eventWithData(t, cloudevents.ApplicationJSON, "", `{"id": "1"}`),
+	}, {
+		name:    "invalid data",
+		schemas: schemas,
+		event:   eventWithData(t, cloudevents.ApplicationJSON, "", `{"id": 1}`),
+		wantErr: `event data is invalid against the schema of "test-event-type" of broker ns1/broker1: id: Invalid type. Expected: string, given: integer`,
+	}, {
+		name:    "no data",
+		schemas: schemas,
+		event:   eventWithData(t, "", "", ""),
+		wantErr: `event data is invalid against the schema of "test-event-type" of broker ns1/broker1: (root): Invalid type. Expected: object, given: null`,
+	}, {
+		name:    "not JSON",
+		schemas: schemas,
+		event:   eventWithData(t, "text/plain", "", `{"id": "1"}`),
+		wantErr: `event data is invalid against the schema of "test-event-type" of broker ns1/broker1: data content type "text/plain" is not JSON`,
+	}, {
+		name:    "JSON content type with parameters",
+		schemas: schemas,
+		event:   eventWithData(t, "application/vnd.order+json; charset=utf-8", "", `{"id": "1"}`),
+	}, {
+		name:    "dataschema takes precedence over type",
+		schemas: schemas,
+		event:   eventWithData(t, cloudevents.ApplicationJSON, orderV2URI, `{"id": "1"}`),
+		wantErr: `event data is invalid against the schema of "https://example.com/schemas/order/v2" of broker ns1/broker1: (root): orderId is required`,
+	}, {
+		name:    "unknown dataschema falls back to type",
+		schemas: schemas,
+		event:   eventWithData(t, cloudevents.ApplicationJSON, "https://example.com/other", `{"id": "1"}`),
+	}, {
+		name:    "other type",
+		schemas: &config.EventSchemas{Types: map[string]string{"other-type": orderSchema}},
+		event:   eventWithData(t, cloudevents.ApplicationJSON, "", `"not an order"`),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewSchemaValidator(brokerWithSchemas(tc.schemas))
+			err := v.validate(broker, tc.event)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate got error %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+				t.Errorf("validate got error %v, want prefix %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaValidatorConfigUpdate(t *testing.T) {
+	broker := types.NamespacedName{Namespace: "ns1", Name: "broker1"}
+	targets := brokerWithSchemas(&config.EventSchemas{Types: map[string]string{eventType: orderSchema}})
+	v := NewSchemaValidator(targets)
+	event := eventWithData(t, cloudevents.ApplicationJSON, "", `{"orderId": "1"}`)
+	var errs schema.ValidationErrors
+	if err := v.validate(broker, event); !errors.As(err, &errs) {
+		t.Fatalf("validate got error %v, want schema.ValidationErrors", err)
+	}
+	targets.MutateBroker("ns1", "broker1", func(m config.BrokerMutation) {
+		m.SetEventSchemas(&config.EventSchemas{Types: map[string]string{eventType: orderV2Schema}})
+	})
+	if err := v.validate(broker, event); err != nil {
+		t.Errorf("validate after the schema update got error %v, want nil", err)
+	}
+}
+
+func TestHandlerSchemaValidation(t *testing.T) {
+	reportertest.ResetIngressMetrics()
+	ctx := logging.WithLogger(context.Background(), logtest.TestLogger(t))
+
+	psSrv := pstest.NewServer()
+	defer psSrv.Close()
+	psClient := createPubsubClient(ctx, t, psSrv)
+	if _, err := psClient.CreateTopic(ctx, topicID); err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, err := metrics.NewIngressReporter(metrics.PodName(pod), metrics.ContainerName(container))
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := brokerWithSchemas(&config.EventSchemas{Types: map[string]string{eventType: orderSchema}})
+	h := NewHandler(ctx, nil, NewMultiTopicDecoupleSink(ctx, targets, psClient), NewAuthorizer(targets, nil), NewRateLimiter(targets), NewSchemaValidator(targets), statsReporter)
+
+	for _, tc := range []struct {
+		data     string
+		want     int
+		wantBody string
+	}{
+		{data: `{"id": "1"}`, want: nethttp.StatusAccepted},
+		{data: `{"name": "order"}`, want: nethttp.StatusBadRequest, wantBody: `(root): id is required`},
+	} {
+		req := httptest.NewRequest(nethttp.MethodPost, "/ns1/broker1", nil)
+		if err := http.WriteRequest(ctx, binding.ToMessage(eventWithData(t, cloudevents.ApplicationJSON, "", tc.data)), req); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if got := w.Result().StatusCode; got != tc.want {
+			t.Errorf("%s: StatusCode mismatch. got: %v, want: %v", tc.data, got, tc.want)
+		}
+		if got := w.Body.String(); !strings.Contains(got, tc.wantBody) {
+			t.Errorf("%s: body mismatch. got: %q, want it to contain %q", tc.data, got, tc.wantBody)
+		}
+	}
+
+	// Events of a batch with invalid data are rejected individually.
+	req := httptest.NewRequest(nethttp.MethodPost, "/ns1/broker1", bytes.NewBufferString(
+		`[{"specversion":"1.0","id":"1","source":"test-source","type":"test-event-type","data":{"id":"1"}},`+
+			`{"specversion":"1.0","id":"2","source":"test-source","type":"test-event-type","data":{"id":2}}]`))
+	req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsBatchJSON)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Result().StatusCode; got != nethttp.StatusBadRequest {
+		t.Errorf("batch: StatusCode mismatch. got: %v, want: %v", got, nethttp.StatusBadRequest)
+	}
+	if got, want := w.Body.String(), "id: Invalid type. Expected: string, given: integer"; !strings.Contains(got, want) {
+		t.Errorf("batch: body mismatch. got: %q, want it to contain %q", got, want)
+	}
+
+	// The accepted events are reported separately, so look for the row of the rejected events.
+	rows, err := view.RetrieveData("event_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rejected int64
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == "reject_reason" && tag.Value == invalidSchemaReason {
+				rejected += row.Data.(*view.CountData).Value
+			}
+		}
+	}
+	if rejected != 2 {
+		t.Errorf("got %d events reported with reject reason %q, want 2", rejected, invalidSchemaReason)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema validates JSON documents against JSON Schemas, used to validate the data of
+// events at the broker ingress. Schemas are JSON Schema draft 7, validated with
+// github.com/xeipuuv/gojsonschema.
+//
+// References ($ref) are only supported as JSON pointers within the same schema, e.g.
+// "#/definitions/address", so that compiling a schema never loads other documents.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Schema is a compiled JSON Schema. It is safe for concurrent use.
+type Schema struct {
+	schema *gojsonschema.Schema
+}
+
+// Compile parses and compiles a JSON Schema.
+func Compile(raw []byte) (*Schema, error) {
+	var doc interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if d.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the schema")
+	}
+	if err := checkLocal(doc); err != nil {
+		return nil, err
+	}
+	c := &cycleChecker{root: doc, state: make(map[uintptr]int)}
+	if err := c.checkCycles(doc); err != nil {
+		return nil, err
+	}
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Draft = gojsonschema.Draft7
+	loader.AutoDetect = false
+	loader.Validate = true
+	s, err := loader.Compile(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: s}, nil
+}
+
+// checkLocal returns an error if the schema refers to or identifies itself as another
+// document, which would make gojsonschema load it.
+func checkLocal(v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && ref != "#" && !strings.HasPrefix(ref, "#/") {
+			return fmt.Errorf("$ref %q is not a JSON pointer within the schema", ref)
+		}
+		if id, ok := v["$id"].(string); ok && !strings.HasPrefix(id, "#") {
+			return fmt.Errorf("$id %q is not within the schema", id)
+		}
+		for _, e := range v {
+			if err := checkLocal(e); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := checkLocal(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cycleChecker finds the cycles of references which apply a schema to the same value again,
+// e.g. {"definitions": {"a": {"$ref": "#/definitions/a"}}}, as gojsonschema would recurse
+// forever when validating a document against them. The cycles through the schemas of
+// properties or items end with the document, so they are allowed.
+type cycleChecker struct {
+	root interface{}
+	// state is 1 for the schemas being checked, and 2 for the schemas checked.
+	state map[uintptr]int
+}
+
+// checkCycles checks the schemas in the document v, and the schemas they refer to.
+func (c *cycleChecker) checkCycles(v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if err := c.check(v); err != nil {
+			return err
+		}
+		for _, e := range v {
+			if err := c.checkCycles(e); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := c.checkCycles(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *cycleChecker) check(s map[string]interface{}) error {
+	id := reflect.ValueOf(s).Pointer()
+	switch c.state[id] {
+	case 1:
+		return errors.New("$ref refers to itself without a property or item in between")
+	case 2:
+		return nil
+	}
+	c.state[id] = 1
+	for _, next := range c.sameValueSchemas(s) {
+		if err := c.check(next); err != nil {
+			return err
+		}
+	}
+	c.state[id] = 2
+	return nil
+}
+
+// sameValueSchemas returns the subschemas which are applied to the same value as the schema.
+func (c *cycleChecker) sameValueSchemas(s map[string]interface{}) []map[string]interface{} {
+	var next []map[string]interface{}
+	add := func(v interface{}) {
+		if m, ok := v.(map[string]interface{}); ok {
+			next = append(next, m)
+		}
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		add(lookup(c.root, ref))
+	}
+	for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+		if a, ok := s[k].([]interface{}); ok {
+			for _, e := range a {
+				add(e)
+			}
+		}
+	}
+	for _, k := range []string{"not", "if", "then", "else"} {
+		add(s[k])
+	}
+	if deps, ok := s["dependencies"].(map[string]interface{}); ok {
+		for _, d := range deps {
+			add(d)
+		}
+	}
+	return next
+}
+
+// lookup returns the value at the JSON pointer fragment, e.g. "#/definitions/address", or nil
+// if there is none.
+func lookup(root interface{}, ref string) interface{} {
+	ptr := strings.TrimPrefix(ref, "#")
+	if ptr == "" {
+		return root
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil
+	}
+	v := root
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token, err := url.PathUnescape(token)
+		if err != nil {
+			return nil
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch c := v.(type) {
+		case map[string]interface{}:
+			v = c[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil
+			}
+			v = c[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// ValidationError is an error of a JSON document against a schema.
+type ValidationError struct {
+	// Field is the path of the invalid value in the document, e.g. "items.0.qty", or "(root)"
+	// for the document itself.
+	Field string
+	// Type is the kind of the error, e.g. "required" or "invalid_type".
+	Type    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors are the errors of a JSON document against a schema.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate validates the JSON document against the schema. It returns ValidationErrors if the
+// document is invalid.
+func (s *Schema) Validate(doc []byte) error {
+	res, err := s.schema.Validate(gojsonschema.NewBytesLoader(doc))
+	if err != nil {
+		return ValidationErrors{{Field: "(root)", Type: "invalid_json", Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+	if res.Valid() {
+		return nil
+	}
+	errs := make(ValidationErrors, 0, len(res.Errors()))
+	for _, e := range res.Errors() {
+		errs = append(errs, ValidationError{Field: e.Field(), Type: e.Type(), Message: e.Description()})
+	}
+	return errs
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		doc    string
+		want   ValidationErrors
+	}{
+		{name: "true schema", schema: `true`, doc: `{"a": 1}`},
+		{name: "false schema", schema: `false`, doc: `1`,
+			want: ValidationErrors{{Field: "(root)", Type: "false"}}},
+		{name: "empty schema", schema: `{}`, doc: `[1, "a", null]`},
+		{name: "type", schema: `{"type": "object"}`, doc: `{}`},
+		{name: "wrong type", schema: `{"type": "object"}`, doc: `"a"`,
+			want: ValidationErrors{{Field: "(root)", Type: "invalid_type"}}},
+		{name: "multiple types", schema: `{"type": ["string", "null"]}`, doc: `null`},
+		{name: "integer", schema: `{"type": "integer"}`, doc: `2.0`},
+		{name: "not an integer", schema: `{"type": "integer"}`, doc: `2.5`,
+			want: ValidationErrors{{Field: "(root)", Type: "invalid_type"}}},
+		{name: "enum", schema: `{"enum": ["a", 1, {"b": [true]}]}`, doc: `{"b": [true]}`},
+		{name: "enum numbers by value", schema: `{"enum": [1]}`, doc: `1.0`},
+		{name: "not in enum", schema: `{"enum": ["a", "b"]}`, doc: `"c"`,
+			want: ValidationErrors{{Field: "(root)", Type: "enum"}}},
+		{name: "const", schema: `{"const": "a"}`, doc: `"b"`,
+			want: ValidationErrors{{Field: "(root)", Type: "const"}}},
+		{name: "number range", schema: `{"minimum": 1, "maximum": 10}`, doc: `10`},
+		{name: "number out of range", schema: `{"minimum": 1, "exclusiveMaximum": 10}`, doc: `10`,
+			want: ValidationErrors{{Field: "(root)", Type: "number_lt"}}},
+		{name: "multipleOf decimal", schema: `{"multipleOf": 0.01}`, doc: `19.99`},
+		{name: "large numbers", schema: `{"maximum": 18446744073709551615}`, doc: `18446744073709551616`,
+			want: ValidationErrors{{Field: "(root)", Type: "number_lte"}}},
+		{name: "string length in characters", schema: `{"maxLength": 2}`, doc: `"éé"`},
+		{name: "pattern", schema: `{"pattern": "^[a-z]+$"}`, doc: `"Ab"`,
+			want: ValidationErrors{{Field: "(root)", Type: "pattern"}}},
+		{name: "keywords of other types are ignored", schema: `{"minLength": 3, "minimum": 5}`, doc: `true`},
+		{
+			name:   "object",
+			schema: `{"type": "object", "required": ["id", "name"], "properties": {"id": {"type": "string"}, "name": {"type": "string"}}}`,
+			doc:    `{"id": 1}`,
+			want: ValidationErrors{
+				{Field: "(root)", Type: "required"},
+				{Field: "id", Type: "invalid_type"},
+			},
+		},
+		{
+			name:   "additional properties",
+			schema: `{"properties": {"a": {}}, "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`,
+			doc:    `{"a": 1, "x-b": "c", "d": 2}`,
+			want:   ValidationErrors{{Field: "(root)", Type: "additional_property_not_allowed"}},
+		},
+		{
+			name:   "items",
+			schema: `{"type": "array", "items": {"type": "integer"}, "minItems": 1}`,
+			doc:    `[1, "a", 3]`,
+			want:   ValidationErrors{{Field: "1", Type: "invalid_type"}},
+		},
+		{
+			name:   "nested field",
+			schema: `{"properties": {"items": {"items": {"properties": {"qty": {"minimum": 1}}}}}}`,
+			doc:    `{"items": [{"qty": 1}, {"qty": 0}]}`,
+			want:   ValidationErrors{{Field: "items.1.qty", Type: "number_gte"}},
+		},
+		{
+			name:   "if then",
+			schema: `{"if": {"properties": {"kind": {"const": "order"}}}, "then": {"required": ["orderId"]}, "else": {"required": ["id"]}}`,
+			doc:    `{"kind": "order"}`,
+			want:   ValidationErrors{{Field: "(root)", Type: "condition_then"}, {Field: "(root)", Type: "required"}},
+		},
+		{
+			name:   "if else",
+			schema: `{"if": {"properties": {"kind": {"const": "order"}}}, "then": {"required": ["orderId"]}, "else": {"required": ["id"]}}`,
+			doc:    `{"kind": "other", "id": 1}`,
+		},
+		{
+			name:   "ref",
+			schema: `{"definitions": {"positive": {"type": "integer", "minimum": 1}}, "properties": {"a": {"$ref": "#/definitions/positive"}}}`,
+			doc:    `{"a": 0}`,
+			want:   ValidationErrors{{Field: "a", Type: "number_gte"}},
+		},
+		{
+			name:   "recursive ref",
+			schema: `{"$defs": {"node": {"properties": {"children": {"items": {"$ref": "#/$defs/node"}}, "v": {"type": "number"}}}}, "$ref": "#/$defs/node"}`,
+			doc:    `{"children": [{"children": [{"v": "x"}]}]}`,
+			want:   ValidationErrors{{Field: "children.0.children.0.v", Type: "invalid_type"}},
+		},
+		{name: "escaped ref", schema: `{"definitions": {"a/b": {"type": "string"}}, "properties": {"x": {"$ref": "#/definitions/a~1b"}}}`, doc: `{"x": 1}`,
+			want: ValidationErrors{{Field: "x", Type: "invalid_type"}}},
+		{name: "invalid document", schema: `{}`, doc: `{"a": `,
+			want: ValidationErrors{{Field: "(root)", Type: "invalid_json"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Compile([]byte(tc.schema))
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+			err = s.Validate([]byte(tc.doc))
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("Validate got error %v, want nil", err)
+				}
+				return
+			}
+			var got ValidationErrors
+			if !errors.As(err, &got) {
+				t.Fatalf("Validate got error %v, want ValidationErrors", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ValidationError{}, "Message")); diff != "" {
+				t.Errorf("Validate unexpected errors (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	cases := []string{
+		``,
+		`"object"`,
+		`{"type": "obj"}`,
+		`{"type": 1}`,
+		`{"minimum": "1"}`,
+		`{"minLength": -1}`,
+		`{"maxItems": 1.5}`,
+		`{"multipleOf": 0}`,
+		`{"pattern": "("}`,
+		`{"patternProperties": {"(": {}}}`,
+		`{"enum": "a"}`,
+		`{"properties": {"a": 1}}`,
+		`{"allOf": []}`,
+		`{"required": [1]}`,
+		`{"$ref": "other.json#/a"}`,
+		`{"$ref": "#anchor"}`,
+		`{"$id": "https://example.com/schema.json"}`,
+		`{"$ref": "#/definitions/missing"}`,
+		`{"$ref": "#"}`,
+		`{"definitions": {"a": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`,
+		`{"definitions": {"a": {"allOf": [{"$ref": "#/definitions/b"}]}, "b": {"not": {"$ref": "#/definitions/a"}}}}`,
+		`{} {}`,
+	}
+	for _, schema := range cases {
+		t.Run(schema, func(t *testing.T) {
+			if _, err := Compile([]byte(schema)); err == nil {
+				t.Errorf("Compile(%q) got nil error", schema)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	err := ValidationErrors{
+		{Field: "(root)", Type: "required", Message: "id is required"},
+		{Field: "qty", Type: "number_gte", Message: "Must be greater than or equal to 1"},
+	}
+	want := "(root): id is required; qty: Must be greater than or equal to 1"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"time"
+
+	v1beta1 "github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	scheme "github.com/google/knative-gcp/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CloudFirestoreSourcesGetter has a method to return a CloudFirestoreSourceInterface.
+// A group's client should implement this interface.
+type CloudFirestoreSourcesGetter interface {
+	CloudFirestoreSources(namespace string) CloudFirestoreSourceInterface
+}
+
+// CloudFirestoreSourceInterface has methods to work with CloudFirestoreSource resources.
+type CloudFirestoreSourceInterface interface {
+	Create(*v1beta1.CloudFirestoreSource) (*v1beta1.CloudFirestoreSource, error)
+	Update(*v1beta1.CloudFirestoreSource) (*v1beta1.CloudFirestoreSource, error)
+	UpdateStatus(*v1beta1.CloudFirestoreSource) (*v1beta1.CloudFirestoreSource, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1beta1.CloudFirestoreSource, error)
+	List(opts v1.ListOptions) (*v1beta1.CloudFirestoreSourceList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudFirestoreSource, err error)
+	CloudFirestoreSourceExpansion
+}
+
+// cloudFirestoreSources implements CloudFirestoreSourceInterface
+type cloudFirestoreSources struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCloudFirestoreSources returns a CloudFirestoreSources
+func newCloudFirestoreSources(c *EventsV1beta1Client, namespace string) *cloudFirestoreSources {
+	return &cloudFirestoreSources{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the cloudFirestoreSource, and returns the corresponding cloudFirestoreSource object, and an error if there is any.
+func (c *cloudFirestoreSources) Get(name string, options v1.GetOptions) (result *v1beta1.CloudFirestoreSource, err error) {
+	result = &v1beta1.CloudFirestoreSource{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CloudFirestoreSources that match those selectors.
+func (c *cloudFirestoreSources) List(opts v1.ListOptions) (result *v1beta1.CloudFirestoreSourceList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.CloudFirestoreSourceList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested cloudFirestoreSources.
+func (c *cloudFirestoreSources) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a cloudFirestoreSource and creates it.  Returns the server's representation of the cloudFirestoreSource, and an error, if there is any.
+func (c *cloudFirestoreSources) Create(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (result *v1beta1.CloudFirestoreSource, err error) {
+	result = &v1beta1.CloudFirestoreSource{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		Body(cloudFirestoreSource).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a cloudFirestoreSource and updates it. Returns the server's representation of the cloudFirestoreSource, and an error, if there is any.
+func (c *cloudFirestoreSources) Update(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (result *v1beta1.CloudFirestoreSource, err error) {
+	result = &v1beta1.CloudFirestoreSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		Name(cloudFirestoreSource.Name).
+		Body(cloudFirestoreSource).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *cloudFirestoreSources) UpdateStatus(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (result *v1beta1.CloudFirestoreSource, err error) {
+	result = &v1beta1.CloudFirestoreSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		Name(cloudFirestoreSource.Name).
+		SubResource("status").
+		Body(cloudFirestoreSource).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the cloudFirestoreSource and deletes it. Returns an error if one occurs.
+func (c *cloudFirestoreSources) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *cloudFirestoreSources) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched cloudFirestoreSource.
+func (c *cloudFirestoreSources) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudFirestoreSource, err error) {
+	result = &v1beta1.CloudFirestoreSource{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("cloudfirestoresources").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"time"
+
+	v1beta1 "github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	scheme "github.com/google/knative-gcp/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CloudMonitoringAlertSourcesGetter has a method to return a CloudMonitoringAlertSourceInterface.
+// A group's client should implement this interface.
+type CloudMonitoringAlertSourcesGetter interface {
+	CloudMonitoringAlertSources(namespace string) CloudMonitoringAlertSourceInterface
+}
+
+// CloudMonitoringAlertSourceInterface has methods to work with CloudMonitoringAlertSource resources.
+type CloudMonitoringAlertSourceInterface interface {
+	Create(*v1beta1.CloudMonitoringAlertSource) (*v1beta1.CloudMonitoringAlertSource, error)
+	Update(*v1beta1.CloudMonitoringAlertSource) (*v1beta1.CloudMonitoringAlertSource, error)
+	UpdateStatus(*v1beta1.CloudMonitoringAlertSource) (*v1beta1.CloudMonitoringAlertSource, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1beta1.CloudMonitoringAlertSource, error)
+	List(opts v1.ListOptions) (*v1beta1.CloudMonitoringAlertSourceList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudMonitoringAlertSource, err error)
+	CloudMonitoringAlertSourceExpansion
+}
+
+// cloudMonitoringAlertSources implements CloudMonitoringAlertSourceInterface
+type cloudMonitoringAlertSources struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCloudMonitoringAlertSources returns a CloudMonitoringAlertSources
+func newCloudMonitoringAlertSources(c *EventsV1beta1Client, namespace string) *cloudMonitoringAlertSources {
+	return &cloudMonitoringAlertSources{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the cloudMonitoringAlertSource, and returns the corresponding cloudMonitoringAlertSource object, and an error if there is any.
+func (c *cloudMonitoringAlertSources) Get(name string, options v1.GetOptions) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	result = &v1beta1.CloudMonitoringAlertSource{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CloudMonitoringAlertSources that match those selectors.
+func (c *cloudMonitoringAlertSources) List(opts v1.ListOptions) (result *v1beta1.CloudMonitoringAlertSourceList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.CloudMonitoringAlertSourceList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested cloudMonitoringAlertSources.
+func (c *cloudMonitoringAlertSources) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a cloudMonitoringAlertSource and creates it.  Returns the server's representation of the cloudMonitoringAlertSource, and an error, if there is any.
+func (c *cloudMonitoringAlertSources) Create(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	result = &v1beta1.CloudMonitoringAlertSource{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		Body(cloudMonitoringAlertSource).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a cloudMonitoringAlertSource and updates it. Returns the server's representation of the cloudMonitoringAlertSource, and an error, if there is any.
+func (c *cloudMonitoringAlertSources) Update(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	result = &v1beta1.CloudMonitoringAlertSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		Name(cloudMonitoringAlertSource.Name).
+		Body(cloudMonitoringAlertSource).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *cloudMonitoringAlertSources) UpdateStatus(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	result = &v1beta1.CloudMonitoringAlertSource{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		Name(cloudMonitoringAlertSource.Name).
+		SubResource("status").
+		Body(cloudMonitoringAlertSource).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the cloudMonitoringAlertSource and deletes it. Returns an error if one occurs.
+func (c *cloudMonitoringAlertSources) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *cloudMonitoringAlertSources) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched cloudMonitoringAlertSource.
+func (c *cloudMonitoringAlertSources) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	result = &v1beta1.CloudMonitoringAlertSource{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("cloudmonitoringalertsources").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
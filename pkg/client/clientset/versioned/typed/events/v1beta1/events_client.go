@@ -28,6 +28,8 @@ type EventsV1beta1Interface interface {
 	RESTClient() rest.Interface
 	CloudAuditLogsSourcesGetter
 	CloudBuildSourcesGetter
+	CloudFirestoreSourcesGetter
+	CloudMonitoringAlertSourcesGetter
 	CloudPubSubSourcesGetter
 	CloudSchedulerSourcesGetter
 	CloudStorageSourcesGetter
@@ -46,6 +48,14 @@ func (c *EventsV1beta1Client) CloudBuildSources(namespace string) CloudBuildSour
 	return newCloudBuildSources(c, namespace)
 }
 
+func (c *EventsV1beta1Client) CloudFirestoreSources(namespace string) CloudFirestoreSourceInterface {
+	return newCloudFirestoreSources(c, namespace)
+}
+
+func (c *EventsV1beta1Client) CloudMonitoringAlertSources(namespace string) CloudMonitoringAlertSourceInterface {
+	return newCloudMonitoringAlertSources(c, namespace)
+}
+
 func (c *EventsV1beta1Client) CloudPubSubSources(namespace string) CloudPubSubSourceInterface {
 	return newCloudPubSubSources(c, namespace)
 }
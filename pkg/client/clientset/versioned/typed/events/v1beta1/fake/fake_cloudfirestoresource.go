@@ -0,0 +1,140 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCloudFirestoreSources implements CloudFirestoreSourceInterface
+type FakeCloudFirestoreSources struct {
+	Fake *FakeEventsV1beta1
+	ns   string
+}
+
+var cloudfirestoresourcesResource = schema.GroupVersionResource{Group: "events.cloud.google.com", Version: "v1beta1", Resource: "cloudfirestoresources"}
+
+var cloudfirestoresourcesKind = schema.GroupVersionKind{Group: "events.cloud.google.com", Version: "v1beta1", Kind: "CloudFirestoreSource"}
+
+// Get takes name of the cloudFirestoreSource, and returns the corresponding cloudFirestoreSource object, and an error if there is any.
+func (c *FakeCloudFirestoreSources) Get(name string, options v1.GetOptions) (result *v1beta1.CloudFirestoreSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(cloudfirestoresourcesResource, c.ns, name), &v1beta1.CloudFirestoreSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), err
+}
+
+// List takes label and field selectors, and returns the list of CloudFirestoreSources that match those selectors.
+func (c *FakeCloudFirestoreSources) List(opts v1.ListOptions) (result *v1beta1.CloudFirestoreSourceList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(cloudfirestoresourcesResource, cloudfirestoresourcesKind, c.ns, opts), &v1beta1.CloudFirestoreSourceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.CloudFirestoreSourceList{ListMeta: obj.(*v1beta1.CloudFirestoreSourceList).ListMeta}
+	for _, item := range obj.(*v1beta1.CloudFirestoreSourceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cloudFirestoreSources.
+func (c *FakeCloudFirestoreSources) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(cloudfirestoresourcesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cloudFirestoreSource and creates it.  Returns the server's representation of the cloudFirestoreSource, and an error, if there is any.
+func (c *FakeCloudFirestoreSources) Create(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (result *v1beta1.CloudFirestoreSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(cloudfirestoresourcesResource, c.ns, cloudFirestoreSource), &v1beta1.CloudFirestoreSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), err
+}
+
+// Update takes the representation of a cloudFirestoreSource and updates it. Returns the server's representation of the cloudFirestoreSource, and an error, if there is any.
+func (c *FakeCloudFirestoreSources) Update(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (result *v1beta1.CloudFirestoreSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(cloudfirestoresourcesResource, c.ns, cloudFirestoreSource), &v1beta1.CloudFirestoreSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCloudFirestoreSources) UpdateStatus(cloudFirestoreSource *v1beta1.CloudFirestoreSource) (*v1beta1.CloudFirestoreSource, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(cloudfirestoresourcesResource, "status", c.ns, cloudFirestoreSource), &v1beta1.CloudFirestoreSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), err
+}
+
+// Delete takes name of the cloudFirestoreSource and deletes it. Returns an error if one occurs.
+func (c *FakeCloudFirestoreSources) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(cloudfirestoresourcesResource, c.ns, name), &v1beta1.CloudFirestoreSource{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCloudFirestoreSources) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(cloudfirestoresourcesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.CloudFirestoreSourceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cloudFirestoreSource.
+func (c *FakeCloudFirestoreSources) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudFirestoreSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cloudfirestoresourcesResource, c.ns, name, pt, data, subresources...), &v1beta1.CloudFirestoreSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), err
+}
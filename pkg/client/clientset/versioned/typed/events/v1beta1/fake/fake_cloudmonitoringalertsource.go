@@ -0,0 +1,140 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCloudMonitoringAlertSources implements CloudMonitoringAlertSourceInterface
+type FakeCloudMonitoringAlertSources struct {
+	Fake *FakeEventsV1beta1
+	ns   string
+}
+
+var cloudmonitoringalertsourcesResource = schema.GroupVersionResource{Group: "events.cloud.google.com", Version: "v1beta1", Resource: "cloudmonitoringalertsources"}
+
+var cloudmonitoringalertsourcesKind = schema.GroupVersionKind{Group: "events.cloud.google.com", Version: "v1beta1", Kind: "CloudMonitoringAlertSource"}
+
+// Get takes name of the cloudMonitoringAlertSource, and returns the corresponding cloudMonitoringAlertSource object, and an error if there is any.
+func (c *FakeCloudMonitoringAlertSources) Get(name string, options v1.GetOptions) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(cloudmonitoringalertsourcesResource, c.ns, name), &v1beta1.CloudMonitoringAlertSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudMonitoringAlertSource), err
+}
+
+// List takes label and field selectors, and returns the list of CloudMonitoringAlertSources that match those selectors.
+func (c *FakeCloudMonitoringAlertSources) List(opts v1.ListOptions) (result *v1beta1.CloudMonitoringAlertSourceList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(cloudmonitoringalertsourcesResource, cloudmonitoringalertsourcesKind, c.ns, opts), &v1beta1.CloudMonitoringAlertSourceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.CloudMonitoringAlertSourceList{ListMeta: obj.(*v1beta1.CloudMonitoringAlertSourceList).ListMeta}
+	for _, item := range obj.(*v1beta1.CloudMonitoringAlertSourceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cloudMonitoringAlertSources.
+func (c *FakeCloudMonitoringAlertSources) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(cloudmonitoringalertsourcesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cloudMonitoringAlertSource and creates it.  Returns the server's representation of the cloudMonitoringAlertSource, and an error, if there is any.
+func (c *FakeCloudMonitoringAlertSources) Create(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(cloudmonitoringalertsourcesResource, c.ns, cloudMonitoringAlertSource), &v1beta1.CloudMonitoringAlertSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudMonitoringAlertSource), err
+}
+
+// Update takes the representation of a cloudMonitoringAlertSource and updates it. Returns the server's representation of the cloudMonitoringAlertSource, and an error, if there is any.
+func (c *FakeCloudMonitoringAlertSources) Update(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(cloudmonitoringalertsourcesResource, c.ns, cloudMonitoringAlertSource), &v1beta1.CloudMonitoringAlertSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudMonitoringAlertSource), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCloudMonitoringAlertSources) UpdateStatus(cloudMonitoringAlertSource *v1beta1.CloudMonitoringAlertSource) (*v1beta1.CloudMonitoringAlertSource, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(cloudmonitoringalertsourcesResource, "status", c.ns, cloudMonitoringAlertSource), &v1beta1.CloudMonitoringAlertSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudMonitoringAlertSource), err
+}
+
+// Delete takes name of the cloudMonitoringAlertSource and deletes it. Returns an error if one occurs.
+func (c *FakeCloudMonitoringAlertSources) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(cloudmonitoringalertsourcesResource, c.ns, name), &v1beta1.CloudMonitoringAlertSource{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCloudMonitoringAlertSources) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(cloudmonitoringalertsourcesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.CloudMonitoringAlertSourceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cloudMonitoringAlertSource.
+func (c *FakeCloudMonitoringAlertSources) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.CloudMonitoringAlertSource, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cloudmonitoringalertsourcesResource, c.ns, name, pt, data, subresources...), &v1beta1.CloudMonitoringAlertSource{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.CloudMonitoringAlertSource), err
+}
@@ -36,6 +36,14 @@ func (c *FakeEventsV1beta1) CloudBuildSources(namespace string) v1beta1.CloudBui
 	return &FakeCloudBuildSources{c, namespace}
 }
 
+func (c *FakeEventsV1beta1) CloudFirestoreSources(namespace string) v1beta1.CloudFirestoreSourceInterface {
+	return &FakeCloudFirestoreSources{c, namespace}
+}
+
+func (c *FakeEventsV1beta1) CloudMonitoringAlertSources(namespace string) v1beta1.CloudMonitoringAlertSourceInterface {
+	return &FakeCloudMonitoringAlertSources{c, namespace}
+}
+
 func (c *FakeEventsV1beta1) CloudPubSubSources(namespace string) v1beta1.CloudPubSubSourceInterface {
 	return &FakeCloudPubSubSources{c, namespace}
 }
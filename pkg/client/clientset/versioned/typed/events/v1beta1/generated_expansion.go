@@ -22,6 +22,10 @@ type CloudAuditLogsSourceExpansion interface{}
 
 type CloudBuildSourceExpansion interface{}
 
+type CloudFirestoreSourceExpansion interface{}
+
+type CloudMonitoringAlertSourceExpansion interface{}
+
 type CloudPubSubSourceExpansion interface{}
 
 type CloudSchedulerSourceExpansion interface{}
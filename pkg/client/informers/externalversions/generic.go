@@ -81,6 +81,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1beta1().CloudAuditLogsSources().Informer()}, nil
 	case eventsv1beta1.SchemeGroupVersion.WithResource("cloudbuildsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1beta1().CloudBuildSources().Informer()}, nil
+	case eventsv1beta1.SchemeGroupVersion.WithResource("cloudfirestoresources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1beta1().CloudFirestoreSources().Informer()}, nil
+	case eventsv1beta1.SchemeGroupVersion.WithResource("cloudmonitoringalertsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1beta1().CloudMonitoringAlertSources().Informer()}, nil
 	case eventsv1beta1.SchemeGroupVersion.WithResource("cloudpubsubsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Events().V1beta1().CloudPubSubSources().Informer()}, nil
 	case eventsv1beta1.SchemeGroupVersion.WithResource("cloudschedulersources"):
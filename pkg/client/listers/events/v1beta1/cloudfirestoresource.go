@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CloudFirestoreSourceLister helps list CloudFirestoreSources.
+type CloudFirestoreSourceLister interface {
+	// List lists all CloudFirestoreSources in the indexer.
+	List(selector labels.Selector) (ret []*v1beta1.CloudFirestoreSource, err error)
+	// CloudFirestoreSources returns an object that can list and get CloudFirestoreSources.
+	CloudFirestoreSources(namespace string) CloudFirestoreSourceNamespaceLister
+	CloudFirestoreSourceListerExpansion
+}
+
+// cloudFirestoreSourceLister implements the CloudFirestoreSourceLister interface.
+type cloudFirestoreSourceLister struct {
+	indexer cache.Indexer
+}
+
+// NewCloudFirestoreSourceLister returns a new CloudFirestoreSourceLister.
+func NewCloudFirestoreSourceLister(indexer cache.Indexer) CloudFirestoreSourceLister {
+	return &cloudFirestoreSourceLister{indexer: indexer}
+}
+
+// List lists all CloudFirestoreSources in the indexer.
+func (s *cloudFirestoreSourceLister) List(selector labels.Selector) (ret []*v1beta1.CloudFirestoreSource, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.CloudFirestoreSource))
+	})
+	return ret, err
+}
+
+// CloudFirestoreSources returns an object that can list and get CloudFirestoreSources.
+func (s *cloudFirestoreSourceLister) CloudFirestoreSources(namespace string) CloudFirestoreSourceNamespaceLister {
+	return cloudFirestoreSourceNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CloudFirestoreSourceNamespaceLister helps list and get CloudFirestoreSources.
+type CloudFirestoreSourceNamespaceLister interface {
+	// List lists all CloudFirestoreSources in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1beta1.CloudFirestoreSource, err error)
+	// Get retrieves the CloudFirestoreSource from the indexer for a given namespace and name.
+	Get(name string) (*v1beta1.CloudFirestoreSource, error)
+	CloudFirestoreSourceNamespaceListerExpansion
+}
+
+// cloudFirestoreSourceNamespaceLister implements the CloudFirestoreSourceNamespaceLister
+// interface.
+type cloudFirestoreSourceNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all CloudFirestoreSources in the indexer for a given namespace.
+func (s cloudFirestoreSourceNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.CloudFirestoreSource, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.CloudFirestoreSource))
+	})
+	return ret, err
+}
+
+// Get retrieves the CloudFirestoreSource from the indexer for a given namespace and name.
+func (s cloudFirestoreSourceNamespaceLister) Get(name string) (*v1beta1.CloudFirestoreSource, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("cloudfirestoresource"), name)
+	}
+	return obj.(*v1beta1.CloudFirestoreSource), nil
+}
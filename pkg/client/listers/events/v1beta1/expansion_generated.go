@@ -34,6 +34,22 @@ type CloudBuildSourceListerExpansion interface{}
 // CloudBuildSourceNamespaceLister.
 type CloudBuildSourceNamespaceListerExpansion interface{}
 
+// CloudFirestoreSourceListerExpansion allows custom methods to be added to
+// CloudFirestoreSourceLister.
+type CloudFirestoreSourceListerExpansion interface{}
+
+// CloudFirestoreSourceNamespaceListerExpansion allows custom methods to be added to
+// CloudFirestoreSourceNamespaceLister.
+type CloudFirestoreSourceNamespaceListerExpansion interface{}
+
+// CloudMonitoringAlertSourceListerExpansion allows custom methods to be added to
+// CloudMonitoringAlertSourceLister.
+type CloudMonitoringAlertSourceListerExpansion interface{}
+
+// CloudMonitoringAlertSourceNamespaceListerExpansion allows custom methods to be added to
+// CloudMonitoringAlertSourceNamespaceLister.
+type CloudMonitoringAlertSourceNamespaceListerExpansion interface{}
+
 // CloudPubSubSourceListerExpansion allows custom methods to be added to
 // CloudPubSubSourceLister.
 type CloudPubSubSourceListerExpansion interface{}
@@ -29,3 +29,11 @@ type PubSubable interface {
 	// PubSubStatus returns the PubSubStatus portion of the Status.
 	PubSubStatus() *duckv1beta1.PubSubStatus
 }
+
+// AdapterConfigurable is an interface that the PubSubable duck types can
+// optionally support in order to configure their receive adapters.
+type AdapterConfigurable interface {
+	// AdapterOptions returns the options of the receive adapter, serialized as
+	// JSON, or the empty string if there are none.
+	AdapterOptions() string
+}
@@ -52,7 +52,13 @@ type TestClientData struct {
 	CreateJobErr    error
 	DeleteJobErr    error
 	GetJobErr       error
-	CloseErr        error
+	// GetJobResp is the job returned by GetJob. If nil, a job with only the
+	// requested name is returned.
+	GetJobResp   *schedulerpb.Job
+	UpdateJobErr error
+	PauseJobErr  error
+	ResumeJobErr error
+	CloseErr     error
 }
 
 // testClient is the test Scheduler client.
@@ -88,7 +94,40 @@ func (c *testClient) GetJob(ctx context.Context, req *schedulerpb.GetJobRequest,
 	if c.data.GetJobErr != nil {
 		return nil, c.data.GetJobErr
 	}
+	if c.data.GetJobResp != nil {
+		return c.data.GetJobResp, nil
+	}
 	return &schedulerpb.Job{
 		Name: req.Name,
 	}, nil
 }
+
+// UpdateJob implements client.UpdateJob
+func (c *testClient) UpdateJob(ctx context.Context, req *schedulerpb.UpdateJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error) {
+	if c.data.UpdateJobErr != nil {
+		return nil, c.data.UpdateJobErr
+	}
+	return req.Job, nil
+}
+
+// PauseJob implements client.PauseJob
+func (c *testClient) PauseJob(ctx context.Context, req *schedulerpb.PauseJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error) {
+	if c.data.PauseJobErr != nil {
+		return nil, c.data.PauseJobErr
+	}
+	return &schedulerpb.Job{
+		Name:  req.Name,
+		State: schedulerpb.Job_PAUSED,
+	}, nil
+}
+
+// ResumeJob implements client.ResumeJob
+func (c *testClient) ResumeJob(ctx context.Context, req *schedulerpb.ResumeJobRequest, opts ...gax.CallOption) (*schedulerpb.Job, error) {
+	if c.data.ResumeJobErr != nil {
+		return nil, c.data.ResumeJobErr
+	}
+	return &schedulerpb.Job{
+		Name:  req.Name,
+		State: schedulerpb.Job_ENABLED,
+	}, nil
+}
@@ -37,6 +37,17 @@ type IngressReportArgs struct {
 	Broker       string
 	EventType    string
 	ResponseCode int
+	// RejectReason is the reason the event was rejected by the ingress, e.g. "invalid_schema".
+	// It is only set for events rejected before they are sent to the broker.
+	RejectReason string
+}
+
+type IngressThrottleArgs struct {
+	Namespace string
+	Broker    string
+	EventType string
+	// RateLimit is the rate limit which rejected the event, e.g. "broker" or "source".
+	RateLimit string
 }
 
 func (r *IngressReporter) register() error {
@@ -46,6 +57,7 @@ func (r *IngressReporter) register() error {
 		EventTypeKey,
 		ResponseCodeKey,
 		ResponseCodeClassKey,
+		RejectReasonKey,
 		PodNameKey,
 		ContainerNameKey,
 	}
@@ -59,6 +71,20 @@ func (r *IngressReporter) register() error {
 			Aggregation: view.Count(),
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Name:        r.throttledEventCountM.Name(),
+			Description: r.throttledEventCountM.Description(),
+			Measure:     r.throttledEventCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				NamespaceNameKey,
+				BrokerNameKey,
+				EventTypeKey,
+				RateLimitKey,
+				PodNameKey,
+				ContainerNameKey,
+			},
+		},
 	)
 }
 
@@ -72,6 +98,11 @@ func NewIngressReporter(podName PodName, containerName ContainerName) (*IngressR
 			"Number of events received by a Broker",
 			stats.UnitDimensionless,
 		),
+		throttledEventCountM: stats.Int64(
+			"throttled_event_count",
+			"Number of events rejected by the rate limits of a Broker",
+			stats.UnitDimensionless,
+		),
 	}
 	if err := r.register(); err != nil {
 		return nil, fmt.Errorf("failed to register ingress stats: %w", err)
@@ -81,14 +112,14 @@ func NewIngressReporter(podName PodName, containerName ContainerName) (*IngressR
 
 // StatsReporter reports ingress metrics.
 type IngressReporter struct {
-	podName       PodName
-	containerName ContainerName
-	eventCountM   *stats.Int64Measure
+	podName              PodName
+	containerName        ContainerName
+	eventCountM          *stats.Int64Measure
+	throttledEventCountM *stats.Int64Measure
 }
 
 func (r *IngressReporter) ReportEventCount(ctx context.Context, args IngressReportArgs) error {
-	tag, err := tag.New(
-		ctx,
+	mutators := []tag.Mutator{
 		tag.Insert(PodNameKey, string(r.podName)),
 		tag.Insert(ContainerNameKey, string(r.containerName)),
 		tag.Insert(NamespaceNameKey, args.Namespace),
@@ -96,10 +127,31 @@ func (r *IngressReporter) ReportEventCount(ctx context.Context, args IngressRepo
 		tag.Insert(EventTypeKey, args.EventType),
 		tag.Insert(ResponseCodeKey, strconv.Itoa(args.ResponseCode)),
 		tag.Insert(ResponseCodeClassKey, metrics.ResponseCodeClass(args.ResponseCode)),
-	)
+	}
+	if args.RejectReason != "" {
+		mutators = append(mutators, tag.Insert(RejectReasonKey, args.RejectReason))
+	}
+	tag, err := tag.New(ctx, mutators...)
 	if err != nil {
 		return fmt.Errorf("failed to create metrics tag: %v", err)
 	}
 	metrics.Record(tag, r.eventCountM.M(1))
 	return nil
 }
+
+func (r *IngressReporter) ReportThrottledEventCount(ctx context.Context, args IngressThrottleArgs) error {
+	tag, err := tag.New(
+		ctx,
+		tag.Insert(PodNameKey, string(r.podName)),
+		tag.Insert(ContainerNameKey, string(r.containerName)),
+		tag.Insert(NamespaceNameKey, args.Namespace),
+		tag.Insert(BrokerNameKey, args.Broker),
+		tag.Insert(EventTypeKey, args.EventType),
+		tag.Insert(RateLimitKey, args.RateLimit),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics tag: %v", err)
+	}
+	metrics.Record(tag, r.throttledEventCountM.M(1))
+	return nil
+}
@@ -59,4 +59,50 @@ func TestStatsReporter(t *testing.T) {
 		return r.ReportEventCount(context.Background(), args)
 	})
 	metricstest.CheckCountData(t, "event_count", wantTags, 2)
+
+	// test ReportThrottledEventCount
+	reportertest.ExpectMetrics(t, func() error {
+		return r.ReportThrottledEventCount(context.Background(), IngressThrottleArgs{
+			Namespace: "testns",
+			Broker:    "testbroker",
+			EventType: "testeventtype",
+			RateLimit: "source",
+		})
+	})
+	metricstest.CheckCountData(t, "throttled_event_count", map[string]string{
+		metricskey.LabelNamespaceName: "testns",
+		metricskey.LabelBrokerName:    "testbroker",
+		metricskey.LabelEventType:     "testeventtype",
+		"rate_limit":                  "source",
+		metricskey.ContainerName:      "testcontainer",
+		metricskey.PodName:            "testpod",
+	}, 1)
+}
+
+func TestStatsReporterRejectReason(t *testing.T) {
+	reportertest.ResetIngressMetrics()
+
+	r, err := NewIngressReporter(PodName("testpod"), ContainerName("testcontainer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportertest.ExpectMetrics(t, func() error {
+		return r.ReportEventCount(context.Background(), IngressReportArgs{
+			Namespace:    "testns",
+			Broker:       "testbroker",
+			EventType:    "testeventtype",
+			ResponseCode: 400,
+			RejectReason: "invalid_schema",
+		})
+	})
+	metricstest.CheckCountData(t, "event_count", map[string]string{
+		metricskey.LabelNamespaceName:     "testns",
+		metricskey.LabelBrokerName:        "testbroker",
+		metricskey.LabelEventType:         "testeventtype",
+		metricskey.LabelResponseCode:      "400",
+		metricskey.LabelResponseCodeClass: "4xx",
+		"reject_reason":                   "invalid_schema",
+		metricskey.ContainerName:          "testcontainer",
+		metricskey.PodName:                "testpod",
+	}, 1)
 }
@@ -40,6 +40,9 @@ var (
 	ResponseCodeKey      = tag.MustNewKey(metricskey.LabelResponseCode)
 	ResponseCodeClassKey = tag.MustNewKey(metricskey.LabelResponseCodeClass)
 
+	RateLimitKey    = tag.MustNewKey("rate_limit")
+	RejectReasonKey = tag.MustNewKey("reject_reason")
+
 	PodNameKey       = tag.MustNewKey(metricskey.PodName)
 	ContainerNameKey = tag.MustNewKey(metricskey.ContainerName)
 )
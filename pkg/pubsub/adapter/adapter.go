@@ -18,9 +18,12 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	nethttp "net/http"
+	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
 	"cloud.google.com/go/pubsub"
 	cev2 "github.com/cloudevents/sdk-go/v2"
@@ -55,6 +58,16 @@ type AdapterArgs struct {
 
 	// ConverterType use to select which converter to use.
 	ConverterType converters.ConverterType
+
+	// Mapper maps the messages to the converted events. If nil, the events are sent as converted.
+	Mapper converters.Mapper
+
+	// Filter selects the converted events which are sent. If nil, all events are sent.
+	Filter converters.Filter
+
+	// Delivery configures the retries and the dead letter sink of the events which fail to be
+	// delivered. If nil, their messages are nacked right away and redelivered until they expire.
+	Delivery *DeliveryArgs
 }
 
 // Adapter implements the Pub/Sub adapter to deliver Pub/Sub messages from a
@@ -63,6 +76,10 @@ type Adapter struct {
 	// subscription is the pubsub subscription used to receive messages from pubsub.
 	subscription *pubsub.Subscription
 
+	// deadLetterSubscription is the pubsub subscription used to receive the messages pubsub
+	// forwards to the dead letter topic. They are sent to the dead letter sink.
+	deadLetterSubscription DeadLetterSubscription
+
 	// outbound is the client used to send events to.
 	outbound *nethttp.Client
 
@@ -87,6 +104,12 @@ type Adapter struct {
 	// cancel is function to stop pulling messages.
 	cancel context.CancelFunc
 
+	// failures counts the failed delivery attempts of the messages.
+	failures *failureTracker
+
+	// delayNack defaults to time.Sleep; could be overridden in test.
+	delayNack func(time.Duration)
+
 	logger *zap.Logger
 }
 
@@ -98,20 +121,24 @@ func NewAdapter(
 	name Name,
 	resourceGroup ResourceGroup,
 	subscription *pubsub.Subscription,
+	deadLetterSubscription DeadLetterSubscription,
 	outbound *nethttp.Client,
 	converter converters.Converter,
 	reporter StatsReporter,
 	args *AdapterArgs) *Adapter {
 	return &Adapter{
-		subscription:   subscription,
-		projectID:      string(projectID),
-		namespacedName: types.NamespacedName{Namespace: string(namespace), Name: string(name)},
-		resourceGroup:  string(resourceGroup),
-		outbound:       outbound,
-		converter:      converter,
-		reporter:       reporter,
-		args:           args,
-		logger:         logging.FromContext(ctx),
+		subscription:           subscription,
+		deadLetterSubscription: deadLetterSubscription,
+		projectID:              string(projectID),
+		namespacedName:         types.NamespacedName{Namespace: string(namespace), Name: string(name)},
+		resourceGroup:          string(resourceGroup),
+		outbound:               outbound,
+		converter:              converter,
+		reporter:               reporter,
+		args:                   args,
+		failures:               newFailureTracker(),
+		delayNack:              time.Sleep,
+		logger:                 logging.FromContext(ctx),
 	}
 }
 
@@ -123,7 +150,18 @@ func (a *Adapter) Start(ctx context.Context) error {
 	ctx = WithTopicKey(ctx, a.args.TopicID)
 	ctx = WithSubscriptionKey(ctx, a.subscription.ID())
 
-	return a.subscription.Receive(ctx, a.receive)
+	if a.deadLetterSubscription.Subscription == nil || a.args.Delivery == nil || a.args.Delivery.DeadLetterSinkURI == "" {
+		return a.subscription.Receive(ctx, a.receive)
+	}
+	// Stop receiving from both subscriptions as soon as one of them fails.
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return a.subscription.Receive(ctx, a.receive)
+	})
+	group.Go(func() error {
+		return a.deadLetterSubscription.Receive(ctx, a.receiveDeadLetter)
+	})
+	return group.Wait()
 }
 
 // Stop stops the adapter.
@@ -134,7 +172,7 @@ func (a *Adapter) Stop() {
 // TODO refactor this method. As our RA code is used both for Sources and our Channel, it also supports replies
 //  (in the case of Channels) and the logic is more convoluted.
 func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
-	event, err := a.converter.Convert(ctx, msg, a.args.ConverterType)
+	converted, err := a.converter.Convert(ctx, msg, a.args.ConverterType)
 	if err != nil {
 		a.logger.Debug("Failed to convert received message to an event, check the msg format: %w", zap.Error(err))
 		// Ack the message so it won't be retried, we consider all errors to be non-retryable.
@@ -142,14 +180,52 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 		return
 	}
 
-	ctx, span := a.startSpan(ctx, event)
-	defer span.End()
+	events := make([]*cev2.Event, 0, len(converted))
+	for _, event := range converted {
+		if a.args.Mapper != nil {
+			if err := a.args.Mapper(msg, event); err != nil {
+				a.logger.Debug("Failed to map received message to an event, check the msg format and the mapping", zap.Error(err))
+				// Ack the message so it won't be retried, as it would fail to be mapped again.
+				msg.Ack()
+				return
+			}
+		}
+		if a.args.Filter != nil && !a.args.Filter(event) {
+			a.logger.Debug("Dropping event filtered out by the adapter options", zap.String("id", event.ID()), zap.String("subject", event.Subject()))
+			a.reporter.ReportEventDropped(&ReportArgs{EventType: event.Type(), EventSource: event.Source()})
+			continue
+		}
+		events = append(events, event)
+	}
+
+	// The events of a message are delivered in order. The message is only acked once all of them
+	// are delivered, so a redelivered message also redelivers the events delivered before the
+	// failure.
+	for i, event := range events {
+		if err := a.deliverEvent(ctx, event); err != nil {
+			a.handleDeliveryFailure(ctx, msg, events[i:], err)
+			return
+		}
+	}
+
+	a.failures.forget(msg)
+	msg.Ack()
+}
 
+// deliverEvent delivers the event received from Pub/Sub within its span.
+func (a *Adapter) deliverEvent(ctx context.Context, event *cev2.Event) *deliveryError {
 	args := &ReportArgs{
 		EventType:   event.Type(),
 		EventSource: event.Source(),
 	}
+	ctx, span := a.startSpan(ctx, event)
+	defer span.End()
+	return a.deliver(ctx, event, args)
+}
 
+// deliver sends the event to the transformer, if any, and then sends the event or the reply of the
+// transformer to the sink.
+func (a *Adapter) deliver(ctx context.Context, event *cev2.Event, args *ReportArgs) *deliveryError {
 	// Using this variable to check whether the event came from a reply or not.
 	reply := false
 
@@ -161,8 +237,7 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 		resp, err := a.sendMsg(ctx, a.args.TransformerURI, (*binding.EventMessage)(event))
 		if err != nil {
 			a.logger.Error("Failed to send message to transformer", zap.String("address", a.args.TransformerURI), zap.Error(err))
-			msg.Nack()
-			return
+			return &deliveryError{address: a.args.TransformerURI, err: err}
 		}
 
 		defer func() {
@@ -175,15 +250,13 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 
 		if resp.StatusCode/100 != 2 {
 			a.logger.Error("Event delivery failed", zap.Int("StatusCode", resp.StatusCode))
-			msg.Nack()
-			return
+			return &deliveryError{address: a.args.TransformerURI, statusCode: resp.StatusCode}
 		}
 
 		respMsg := cehttp.NewMessageFromHttpResponse(resp)
 		if respMsg.ReadEncoding() == binding.EncodingUnknown {
 			// No reply
-			msg.Ack()
-			return
+			return nil
 		}
 
 		// If there was a reply, we need to send it to the sink.
@@ -192,8 +265,7 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 		if err != nil {
 			a.logger.Error("Failed to convert response message to event",
 				zap.Any("response", respMsg), zap.Error(err))
-			msg.Nack()
-			return
+			return &deliveryError{address: a.args.TransformerURI, statusCode: resp.StatusCode, err: err}
 		}
 
 		reply = true
@@ -211,8 +283,7 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 	response, err := a.sendMsg(ctx, a.args.SinkURI, (*binding.EventMessage)(event))
 	if err != nil {
 		a.logger.Error("Failed to send message to sink", zap.String("address", a.args.SinkURI), zap.Error(err))
-		msg.Nack()
-		return
+		return &deliveryError{address: a.args.SinkURI, err: err}
 	}
 
 	defer func() {
@@ -225,13 +296,108 @@ func (a *Adapter) receive(ctx context.Context, msg *pubsub.Message) {
 
 	if response.StatusCode/100 != 2 {
 		a.logger.Error("Event delivery failed", zap.Int("StatusCode", response.StatusCode))
+		return &deliveryError{address: a.args.SinkURI, statusCode: response.StatusCode}
+	}
+	return nil
+}
+
+// handleDeliveryFailure nacks the message of an event which failed to be delivered after the backoff
+// of the delivery args. Once the retries of the event are exhausted, it is sent to the dead letter
+// sink along with the undelivered events of the message after it, if any, or dropped. Without
+// delivery args, the message is nacked right away.
+func (a *Adapter) handleDeliveryFailure(ctx context.Context, msg *pubsub.Message, events []*cev2.Event, deliveryErr *deliveryError) {
+	event := events[0]
+	delivery := a.args.Delivery
+	if delivery == nil {
+		msg.Nack()
+		return
+	}
+
+	attempt := a.failures.attempt(msg)
+	if !delivery.retriesExhausted(attempt) {
+		backoff := delivery.backoff(attempt)
+		a.logger.Debug("Backing off before nacking the message", zap.String("id", event.ID()), zap.Int("attempt", attempt), zap.Duration("backoff", backoff))
+		a.delayNack(backoff)
 		msg.Nack()
 		return
 	}
 
+	if delivery.DeadLetterSinkURI == "" {
+		a.logger.Warn("Delivery retries exhausted, dropping event", zap.String("id", event.ID()), zap.Int("retry", delivery.Retry), zap.Error(deliveryErr))
+		a.failures.forget(msg)
+		msg.Ack()
+		return
+	}
+
+	for _, event := range events {
+		event.SetExtension(deadLetterDestExtension, deliveryErr.address)
+		event.SetExtension(deadLetterDataExtension, deliveryErr.Error())
+		if deliveryErr.statusCode != 0 {
+			event.SetExtension(deadLetterCodeExtension, deliveryErr.statusCode)
+		}
+		if err := a.sendToDeadLetterSink(ctx, event); err != nil {
+			a.logger.Error("Failed to send event to dead letter sink", zap.String("address", delivery.DeadLetterSinkURI), zap.Error(err))
+			msg.Nack()
+			return
+		}
+	}
+	a.failures.forget(msg)
+	msg.Ack()
+}
+
+// receiveDeadLetter sends the messages pubsub has forwarded to the dead letter topic to the dead
+// letter sink. They are the messages of the events which the adapter didn't dead letter itself, i.e.
+// the ones whose retries exceeded the max delivery attempts of the dead letter policy.
+func (a *Adapter) receiveDeadLetter(ctx context.Context, msg *pubsub.Message) {
+	events, err := a.converter.Convert(ctx, msg, a.args.ConverterType)
+	if err != nil {
+		a.logger.Debug("Failed to convert dead lettered message to an event, check the msg format", zap.Error(err))
+		msg.Ack()
+		return
+	}
+	for _, event := range events {
+		if a.args.Mapper != nil {
+			if err := a.args.Mapper(msg, event); err != nil {
+				a.logger.Debug("Failed to map dead lettered message to an event, check the msg format and the mapping", zap.Error(err))
+				msg.Ack()
+				return
+			}
+		}
+		for k, v := range a.args.Extensions {
+			event.SetExtension(k, v)
+		}
+		if err := a.sendDeadLetter(ctx, event); err != nil {
+			a.logger.Error("Failed to send event to dead letter sink", zap.String("address", a.args.Delivery.DeadLetterSinkURI), zap.Error(err))
+			msg.Nack()
+			return
+		}
+	}
 	msg.Ack()
 }
 
+// sendDeadLetter sends the dead lettered event to the dead letter sink within its span.
+func (a *Adapter) sendDeadLetter(ctx context.Context, event *cev2.Event) error {
+	ctx, span := a.startSpan(ctx, event)
+	defer span.End()
+	return a.sendToDeadLetterSink(ctx, event)
+}
+
+func (a *Adapter) sendToDeadLetterSink(ctx context.Context, event *cev2.Event) error {
+	resp, err := a.sendMsg(ctx, a.args.Delivery.DeadLetterSinkURI, (*binding.EventMessage)(event))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			a.logger.Warn("Failed to close dead letter sink response body", zap.Error(err))
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("HTTP status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func (a *Adapter) sendMsg(ctx context.Context, address string, msg binding.Message) (*nethttp.Response, error) {
 	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, address, nil)
 	if err != nil {
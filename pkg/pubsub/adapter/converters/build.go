@@ -18,7 +18,9 @@ package converters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"cloud.google.com/go/pubsub"
 	cev2 "github.com/cloudevents/sdk-go/v2"
@@ -28,8 +30,30 @@ import (
 
 const (
 	buildSchemaUrl      = "https://raw.githubusercontent.com/google/knative-gcp/master/schemas/build/schema.json"
+
+	// buildTriggerNameSubstitution is the substitution with the name of the build
+	// trigger which started the build.
+	buildTriggerNameSubstitution = "TRIGGER_NAME"
 )
 
+// build holds the fields of the Cloud Build builds published to the
+// cloud-builds topic which are used to filter the events.
+type build struct {
+	BuildTriggerID string            `json:"buildTriggerId"`
+	Tags           []string          `json:"tags"`
+	Substitutions  map[string]string `json:"substitutions"`
+}
+
+// parseBuild parses the build in the data of a message. It returns an empty
+// build if the data is not a build, so that such messages are still converted.
+func parseBuild(data []byte) *build {
+	var b build
+	if err := json.Unmarshal(data, &b); err != nil {
+		return &build{}
+	}
+	return &b
+}
+
 func convertCloudBuild(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
 	event := cev2.NewEvent(cev2.VersionV1)
 	event.SetID(msg.ID)
@@ -52,6 +76,10 @@ func convertCloudBuild(ctx context.Context, msg *pubsub.Message) (*cev2.Event, e
 		return nil, errors.New("received event did not have build status")
 	} else {
 		event.SetSubject(buildStatus)
+		event.SetExtension(v1beta1.CloudBuildSourceBuildStatusExtension, buildStatus)
+	}
+	if triggerID := parseBuild(msg.Data).BuildTriggerID; triggerID != "" {
+		event.SetExtension(v1beta1.CloudBuildSourceTriggerIdExtension, triggerID)
 	}
 
 	if err := event.SetData(cev2.ApplicationJSON, msg); err != nil {
@@ -60,3 +88,64 @@ func convertCloudBuild(ctx context.Context, msg *pubsub.Message) (*cev2.Event, e
 
 	return &event, nil
 }
+
+// buildFilter selects the events of the builds which match the options of a
+// CloudBuildSource.
+type buildFilter struct {
+	statuses     map[string]bool
+	triggerIDs   map[string]bool
+	triggerNames map[string]bool
+	tags         map[string]bool
+}
+
+func newBuildFilter(options string) (Filter, error) {
+	var opts v1beta1.CloudBuildSourceAdapterOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal adapter options: %w", err)
+	}
+	f := &buildFilter{
+		statuses:     stringSet(opts.BuildStatuses),
+		triggerIDs:   stringSet(opts.TriggerIDs),
+		triggerNames: stringSet(opts.TriggerNames),
+		tags:         stringSet(opts.Tags),
+	}
+	return f.matches, nil
+}
+
+// matches returns whether the build of the event matches the filter. The build
+// status is the subject of the event, and the trigger and tags are read from the
+// build in the data of the Pub/Sub message of the event.
+func (f *buildFilter) matches(event *cev2.Event) bool {
+	if len(f.statuses) > 0 && !f.statuses[event.Subject()] {
+		return false
+	}
+	if len(f.triggerIDs) == 0 && len(f.triggerNames) == 0 && len(f.tags) == 0 {
+		return true
+	}
+	var msg pubsub.Message
+	if err := json.Unmarshal(event.Data(), &msg); err != nil {
+		return false
+	}
+	b := parseBuild(msg.Data)
+	if (len(f.triggerIDs) > 0 || len(f.triggerNames) > 0) &&
+		!f.triggerIDs[b.BuildTriggerID] && !f.triggerNames[b.Substitutions[buildTriggerNameSubstitution]] {
+		return false
+	}
+	if len(f.tags) == 0 {
+		return true
+	}
+	for _, tag := range b.Tags {
+		if f.tags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
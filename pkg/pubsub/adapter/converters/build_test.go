@@ -87,7 +87,7 @@ func TestConvertCloudBuild(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := WithProjectKey(context.Background(), "testproject")
-			gotEvent, err := NewPubSubConverter().Convert(ctx, test.message, CloudBuild)
+			gotEvent, err := convertOne(ctx, test.message, CloudBuild)
 			if err != nil {
 				if !test.wantErr {
 					t.Errorf("converters.convertBuild got error %v want error=%v", err, test.wantErr)
@@ -111,6 +111,103 @@ func TestConvertCloudBuild(t *testing.T) {
 				if gotEvent.DataSchema() != buildSchemaUrl {
 					t.Errorf("DataSchema %q != %q", gotEvent.DataSchema(), buildSchemaUrl)
 				}
+				if got := gotEvent.Extensions()[v1beta1.CloudBuildSourceBuildStatusExtension]; got != buildStatus {
+					t.Errorf("Extension %q = %v, want %q", v1beta1.CloudBuildSourceBuildStatusExtension, got, buildStatus)
+				}
+				if got, ok := gotEvent.Extensions()[v1beta1.CloudBuildSourceTriggerIdExtension]; ok {
+					t.Errorf("Extension %q = %v, want unset", v1beta1.CloudBuildSourceTriggerIdExtension, got)
+				}
+			}
+		})
+	}
+}
+
+// newBuildMessage returns the message published by Cloud Build for a build with
+// the status and data.
+func newBuildMessage(status, data string) *pubsub.Message {
+	return &pubsub.Message{
+		ID:   "id",
+		Data: []byte(data),
+		Attributes: map[string]string{
+			"buildId": buildID,
+			"status":  status,
+		},
+	}
+}
+
+func TestConvertCloudBuildTriggerID(t *testing.T) {
+	ctx := WithProjectKey(context.Background(), "testproject")
+	msg := newBuildMessage("FAILURE", `{"id":"c9k3e360","status":"FAILURE","buildTriggerId":"trigger-id"}`)
+	event, err := convertOne(ctx, msg, CloudBuild)
+	if err != nil {
+		t.Fatalf("converters.convertBuild failed: %v", err)
+	}
+	if got := event.Extensions()[v1beta1.CloudBuildSourceTriggerIdExtension]; got != "trigger-id" {
+		t.Errorf("Extension %q = %v, want %q", v1beta1.CloudBuildSourceTriggerIdExtension, got, "trigger-id")
+	}
+	if got := event.Extensions()[v1beta1.CloudBuildSourceBuildStatusExtension]; got != "FAILURE" {
+		t.Errorf("Extension %q = %v, want %q", v1beta1.CloudBuildSourceBuildStatusExtension, got, "FAILURE")
+	}
+}
+
+func TestBuildFilter(t *testing.T) {
+	var (
+		succeeded = newBuildMessage("SUCCESS", `{"status":"SUCCESS","buildTriggerId":"id-1","substitutions":{"TRIGGER_NAME":"name-1"},"tags":["prod"]}`)
+		failed    = newBuildMessage("FAILURE", `{"status":"FAILURE","buildTriggerId":"id-1","substitutions":{"TRIGGER_NAME":"name-1"},"tags":["dev","prod"]}`)
+		timedOut  = newBuildMessage("TIMEOUT", `{"status":"TIMEOUT","buildTriggerId":"id-2","substitutions":{"TRIGGER_NAME":"name-2"}}`)
+		manual    = newBuildMessage("FAILURE", `{"status":"FAILURE","tags":["dev"]}`)
+	)
+	tests := []struct {
+		name    string
+		options string
+		pass    []*pubsub.Message
+		drop    []*pubsub.Message
+	}{{
+		name:    "statuses",
+		options: `{"buildStatuses":["FAILURE","TIMEOUT"]}`,
+		pass:    []*pubsub.Message{failed, timedOut, manual},
+		drop:    []*pubsub.Message{succeeded},
+	}, {
+		name:    "trigger IDs",
+		options: `{"triggerIds":["id-2"]}`,
+		pass:    []*pubsub.Message{timedOut},
+		drop:    []*pubsub.Message{succeeded, failed, manual},
+	}, {
+		name:    "trigger IDs and names",
+		options: `{"triggerIds":["id-2"],"triggerNames":["name-1"]}`,
+		pass:    []*pubsub.Message{succeeded, failed, timedOut},
+		drop:    []*pubsub.Message{manual},
+	}, {
+		name:    "tags",
+		options: `{"tags":["dev","staging"]}`,
+		pass:    []*pubsub.Message{failed, manual},
+		drop:    []*pubsub.Message{succeeded, timedOut},
+	}, {
+		name:    "all options",
+		options: `{"buildStatuses":["FAILURE"],"triggerNames":["name-1"],"tags":["prod"]}`,
+		pass:    []*pubsub.Message{failed},
+		drop:    []*pubsub.Message{succeeded, timedOut, manual},
+	}}
+	ctx := WithProjectKey(context.Background(), "testproject")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewFilter(CloudBuild, tc.options)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			for _, msgs := range []struct {
+				msgs []*pubsub.Message
+				want bool
+			}{{tc.pass, true}, {tc.drop, false}} {
+				for _, msg := range msgs.msgs {
+					event, err := convertOne(ctx, msg, CloudBuild)
+					if err != nil {
+						t.Fatalf("converters.convertBuild failed: %v", err)
+					}
+					if got := filter(event); got != msgs.want {
+						t.Errorf("Filter of build %s got %t, want %t", msg.Data, got, msgs.want)
+					}
+				}
 			}
 		})
 	}
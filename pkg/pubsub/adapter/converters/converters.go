@@ -44,18 +44,40 @@ type ConverterType string
 
 const (
 	// The different type of Converters for the different sources.
-	CloudPubSub    ConverterType = "pubsub"
-	CloudStorage   ConverterType = "storage"
-	CloudAuditLogs ConverterType = "auditlogs"
-	CloudScheduler ConverterType = "scheduler"
-	CloudBuild     ConverterType = "build"
-	PubSubPull     ConverterType = "pubsub_pull"
+	CloudPubSub     ConverterType = "pubsub"
+	CloudStorage    ConverterType = "storage"
+	CloudAuditLogs  ConverterType = "auditlogs"
+	CloudScheduler  ConverterType = "scheduler"
+	CloudBuild      ConverterType = "build"
+	CloudFirestore  ConverterType = "firestore"
+	CloudMonitoring ConverterType = "monitoring"
+	PubSubPull      ConverterType = "pubsub_pull"
 )
 
-type converterFn func(context.Context, *pubsub.Message) (*cev2.Event, error)
+type converterFn func(context.Context, *pubsub.Message) ([]*cev2.Event, error)
+
+// single makes the converterFn of a function converting a message to a single event.
+func single(convert func(context.Context, *pubsub.Message) (*cev2.Event, error)) converterFn {
+	return func(ctx context.Context, msg *pubsub.Message) ([]*cev2.Event, error) {
+		event, err := convert(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		return []*cev2.Event{event}, nil
+	}
+}
+
+// Filter returns whether a converted event should be sent.
+type Filter func(*cev2.Event) bool
+
+// Mapper maps the attributes and data of a message to the event converted from
+// it.
+type Mapper func(*pubsub.Message, *cev2.Event) error
 
 type Converter interface {
-	Convert(ctx context.Context, msg *pubsub.Message, converterType ConverterType) (*cev2.Event, error)
+	// Convert converts a message to its events. Most messages are converted to a single event,
+	// but e.g. the audit log of a Firestore commit is converted to an event per written document.
+	Convert(ctx context.Context, msg *pubsub.Message, converterType ConverterType) ([]*cev2.Event, error)
 }
 
 type PubSubConverter struct {
@@ -71,12 +93,14 @@ type PubSubConverter struct {
 func NewPubSubConverter() Converter {
 	return &PubSubConverter{
 		converters: map[ConverterType]converterFn{
-			CloudPubSub:    convertCloudPubSub,
-			CloudAuditLogs: convertCloudAuditLogs,
-			CloudStorage:   convertCloudStorage,
-			CloudScheduler: convertCloudScheduler,
-			CloudBuild:     convertCloudBuild,
-			PubSubPull:     convertPubSubPull,
+			CloudPubSub:     single(convertCloudPubSub),
+			CloudAuditLogs:  single(convertCloudAuditLogs),
+			CloudStorage:    single(convertCloudStorage),
+			CloudScheduler:  single(convertCloudScheduler),
+			CloudBuild:      single(convertCloudBuild),
+			CloudFirestore:  convertCloudFirestore,
+			CloudMonitoring: single(convertCloudMonitoring),
+			PubSubPull:      single(convertPubSubPull),
 		},
 	}
 }
@@ -84,7 +108,7 @@ func NewPubSubConverter() Converter {
 // Convert converts a message off the pubsub format to a source specific if
 // there's a registered handler for the type in the converters map.
 // If there's no registered handler, a default Pubsub one will be used.
-func (c *PubSubConverter) Convert(ctx context.Context, msg *pubsub.Message, converterType ConverterType) (*cev2.Event, error) {
+func (c *PubSubConverter) Convert(ctx context.Context, msg *pubsub.Message, converterType ConverterType) ([]*cev2.Event, error) {
 	if msg == nil {
 		return nil, fmt.Errorf("nil pubsub message")
 	}
@@ -96,6 +120,48 @@ func (c *PubSubConverter) Convert(ctx context.Context, msg *pubsub.Message, conv
 	}
 
 	// No converter, PubSub is the default one.
-	return binding.ToEvent(ctx, cepubsub.NewMessage(msg))
+	event, err := binding.ToEvent(ctx, cepubsub.NewMessage(msg))
+	if err != nil {
+		return nil, err
+	}
+	return []*cev2.Event{event}, nil
 
 }
+
+// NewFilter creates the Filter of the events converted by the converterType from
+// the JSON serialized adapter options. It returns a nil Filter if there are no
+// options.
+func NewFilter(converterType ConverterType, options string) (Filter, error) {
+	if options == "" {
+		return nil, nil
+	}
+	switch converterType {
+	case CloudPubSub:
+		// The options of CloudPubSub only configure the Mapper.
+		return nil, nil
+	case CloudStorage:
+		return newStorageFilter(options)
+	case CloudBuild:
+		return newBuildFilter(options)
+	case CloudFirestore:
+		return newFirestoreFilter(options)
+	default:
+		return nil, fmt.Errorf("adapter options are not supported by converter type %q", converterType)
+	}
+}
+
+// NewMapper creates the Mapper of the events converted by the converterType from
+// the JSON serialized adapter options. It returns a nil Mapper if the options
+// don't configure a mapping.
+func NewMapper(converterType ConverterType, options string) (Mapper, error) {
+	if options == "" {
+		return nil, nil
+	}
+	switch converterType {
+	case CloudPubSub:
+		return newPubSubMapper(options)
+	default:
+		// The options of the other converter types only configure the Filter.
+		return nil, nil
+	}
+}
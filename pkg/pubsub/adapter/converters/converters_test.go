@@ -0,0 +1,38 @@
+/*
+Copyright 2019 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+)
+
+// convertOne converts the message, which must be converted to a single event, with the
+// PubSubConverter.
+func convertOne(ctx context.Context, msg *pubsub.Message, converterType ConverterType) (*cev2.Event, error) {
+	events, err := NewPubSubConverter().Convert(ctx, msg, converterType)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) != 1 {
+		return nil, fmt.Errorf("message converted to %d events, want 1", len(events))
+	}
+	return events[0], nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/protobuf/ptypes"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	logpb "google.golang.org/genproto/googleapis/logging/v2"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+const (
+	firestoreMethodPrefix = "google.firestore.v1.Firestore."
+)
+
+// firestoreDocumentNameRegexp matches the names of Firestore documents, and
+// captures the project, database, and the document path within the database.
+var firestoreDocumentNameRegexp = regexp.MustCompile(`^projects/([^/]+)/databases/([^/]+)/documents/(.+)$`)
+
+// firestoreRequest holds the fields of the requests of the Firestore write
+// methods logged in the Data Access audit logs.
+type firestoreRequest struct {
+	// CreateDocument
+	Parent       string `json:"parent"`
+	CollectionID string `json:"collectionId"`
+	DocumentID   string `json:"documentId"`
+	// UpdateDocument
+	Document        *firestoreDocument     `json:"document"`
+	CurrentDocument *firestorePrecondition `json:"currentDocument"`
+	// DeleteDocument
+	Name string `json:"name"`
+	// Commit and BatchWrite
+	Writes []firestoreWrite `json:"writes"`
+}
+
+type firestoreDocument struct {
+	Name string `json:"name"`
+}
+
+type firestoreWrite struct {
+	Update          *firestoreDocument     `json:"update"`
+	Delete          string                 `json:"delete"`
+	Transform       *firestoreTransform    `json:"transform"`
+	CurrentDocument *firestorePrecondition `json:"currentDocument"`
+}
+
+type firestoreTransform struct {
+	Document string `json:"document"`
+}
+
+type firestorePrecondition struct {
+	Exists     *bool  `json:"exists"`
+	UpdateTime string `json:"updateTime"`
+}
+
+// eventType returns the type of the event of a document write with the
+// precondition. Writes which require the document to exist are updates, those
+// which require it not to exist are creations, and other writes may be either.
+func (p *firestorePrecondition) eventType() string {
+	switch {
+	case p == nil:
+		return v1beta1.CloudFirestoreSourceWrite
+	case p.Exists != nil && !*p.Exists:
+		return v1beta1.CloudFirestoreSourceCreate
+	case p.Exists != nil || p.UpdateTime != "":
+		return v1beta1.CloudFirestoreSourceUpdate
+	default:
+		return v1beta1.CloudFirestoreSourceWrite
+	}
+}
+
+// documentWrite is the write of a document by a request.
+type documentWrite struct {
+	// eventType is the type of the event of the write.
+	eventType string
+	// document is the name of the written document.
+	document string
+}
+
+// documentWrite returns the write of the document, or false if the kind of the
+// write is unknown.
+func (w *firestoreWrite) documentWrite() (documentWrite, bool) {
+	switch {
+	case w.Update != nil:
+		return documentWrite{w.CurrentDocument.eventType(), w.Update.Name}, true
+	case w.Delete != "":
+		return documentWrite{v1beta1.CloudFirestoreSourceDelete, w.Delete}, true
+	case w.Transform != nil:
+		return documentWrite{v1beta1.CloudFirestoreSourceUpdate, w.Transform.Document}, true
+	default:
+		return documentWrite{}, false
+	}
+}
+
+// documentWrites returns the writes of documents by the request of the method.
+// Commits and batch writes return a write per document, in the order of the
+// request.
+func (r *firestoreRequest) documentWrites(method string) ([]documentWrite, error) {
+	switch method {
+	case "CreateDocument":
+		// The IDs assigned by Firestore are not logged.
+		if r.DocumentID == "" {
+			return nil, errors.New("CreateDocument request did not have a document ID")
+		}
+		return []documentWrite{{v1beta1.CloudFirestoreSourceCreate, r.Parent + "/" + r.CollectionID + "/" + r.DocumentID}}, nil
+	case "UpdateDocument":
+		if r.Document == nil {
+			return nil, errors.New("UpdateDocument request did not have a document")
+		}
+		return []documentWrite{{r.CurrentDocument.eventType(), r.Document.Name}}, nil
+	case "DeleteDocument":
+		return []documentWrite{{v1beta1.CloudFirestoreSourceDelete, r.Name}}, nil
+	case "Commit", "BatchWrite":
+		if len(r.Writes) == 0 {
+			return nil, fmt.Errorf("%s request did not have writes", method)
+		}
+		writes := make([]documentWrite, 0, len(r.Writes))
+		for i := range r.Writes {
+			w, ok := r.Writes[i].documentWrite()
+			if !ok {
+				return nil, fmt.Errorf("unknown %s write %d", method, i)
+			}
+			writes = append(writes, w)
+		}
+		return writes, nil
+	default:
+		return nil, fmt.Errorf("unhandled Firestore method %q", method)
+	}
+}
+
+// convertCloudFirestore converts the Data Access audit log entry of a Firestore
+// write to the events of the written documents, one per document. The events
+// keep the log entry as their data. The events of commits and batch writes have
+// the index of their write in the request appended to their ID, so that they
+// don't have the same ID.
+func convertCloudFirestore(ctx context.Context, msg *pubsub.Message) ([]*cev2.Event, error) {
+	entry := logpb.LogEntry{}
+	if err := jsonpbUnmarshaller.Unmarshal(bytes.NewReader(msg.Data), &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode LogEntry: %w", err)
+	}
+	payload, ok := entry.Payload.(*logpb.LogEntry_ProtoPayload)
+	if !ok {
+		return nil, errors.New("non-AuditLog log entry")
+	}
+	var unpacked ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(payload.ProtoPayload, &unpacked); err != nil {
+		return nil, fmt.Errorf("unrecognized proto payload: %w", err)
+	}
+	auditLog, ok := unpacked.Message.(*auditpb.AuditLog)
+	if !ok {
+		return nil, fmt.Errorf("unhandled proto payload type: %T", unpacked.Message)
+	}
+	if code := auditLog.GetStatus().GetCode(); code != 0 {
+		return nil, fmt.Errorf("failed Firestore write with status code %d", code)
+	}
+	if !strings.HasPrefix(auditLog.MethodName, firestoreMethodPrefix) {
+		return nil, fmt.Errorf("unhandled method %q", auditLog.MethodName)
+	}
+
+	if auditLog.Request == nil {
+		return nil, errors.New("audit log did not have a request")
+	}
+	// The request is a google.protobuf.Struct, which is decoded through its JSON representation.
+	requestJSON, err := jsonpbMarshaler.MarshalToString(auditLog.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	var request firestoreRequest
+	if err := json.Unmarshal([]byte(requestJSON), &request); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	method := strings.TrimPrefix(auditLog.MethodName, firestoreMethodPrefix)
+	writes, err := request.documentWrites(method)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := ptypes.Timestamp(entry.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LogEntry timestamp: %w", err)
+	}
+	id := v1beta1.CloudAuditLogsSourceEventID(entry.InsertId, entry.LogName, ptypes.TimestampString(entry.Timestamp))
+
+	events := make([]*cev2.Event, 0, len(writes))
+	for i, w := range writes {
+		match := firestoreDocumentNameRegexp.FindStringSubmatch(w.document)
+		if match == nil {
+			return nil, fmt.Errorf("invalid document name %q", w.document)
+		}
+		event := cev2.NewEvent(cev2.VersionV1)
+		if method == "Commit" || method == "BatchWrite" {
+			event.SetID(fmt.Sprintf("%s-%d", id, i))
+		} else {
+			event.SetID(id)
+		}
+		event.SetTime(timestamp)
+		event.SetType(w.eventType)
+		event.SetSource(v1beta1.CloudFirestoreSourceEventSource(match[1], match[2]))
+		event.SetSubject("documents/" + match[3])
+		event.SetDataSchema(logEntrySchema)
+		if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// firestoreFilter selects the events of the documents and event types of a
+// CloudFirestoreSource.
+type firestoreFilter struct {
+	document   *regexp.Regexp
+	eventTypes map[string]bool
+}
+
+func newFirestoreFilter(options string) (Filter, error) {
+	var opts v1beta1.CloudFirestoreSourceAdapterOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal adapter options: %w", err)
+	}
+	document, err := regexp.Compile(documentPatternToRegexp(opts.Document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile document pattern: %w", err)
+	}
+	f := &firestoreFilter{
+		document:   document,
+		eventTypes: stringSet(opts.EventTypes),
+	}
+	return f.matches, nil
+}
+
+// matches returns whether the path of the document, which is the subject of the
+// event, and the event type match the filter.
+func (f *firestoreFilter) matches(event *cev2.Event) bool {
+	if len(f.eventTypes) > 0 && !f.eventTypes[event.Type()] {
+		return false
+	}
+	return f.document.MatchString(strings.TrimPrefix(event.Subject(), "documents/"))
+}
+
+// documentPatternToRegexp converts a document path pattern to a regular
+// expression matching the whole document path. A segment of '*' or '{name}'
+// matches a single segment, and a segment of '**' or '{name=**}' matches one or
+// more segments.
+func documentPatternToRegexp(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "**" || (strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "=**}")):
+			segments[i] = ".+"
+		case segment == "*" || (strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")):
+			segments[i] = "[^/]+"
+		default:
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+	return "^" + strings.Join(segments, "/") + "$"
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	logpb "google.golang.org/genproto/googleapis/logging/v2"
+	"google.golang.org/genproto/googleapis/rpc/status"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+const firestoreDatabase = "projects/test-project/databases/(default)"
+
+// newFirestoreMessage returns the message of the Data Access audit log entry of
+// the Firestore method with the JSON request.
+func newFirestoreMessage(t *testing.T, method, request string, code int32) *pubsub.Message {
+	t.Helper()
+	var req structpb.Struct
+	if err := jsonpb.UnmarshalString(request, &req); err != nil {
+		t.Fatalf("Failed to unmarshal request: %v", err)
+	}
+	auditLog := auditpb.AuditLog{
+		ServiceName:  "firestore.googleapis.com",
+		MethodName:   firestoreMethodPrefix + method,
+		ResourceName: firestoreDatabase,
+		Request:      &req,
+	}
+	if code != 0 {
+		auditLog.Status = &status.Status{Code: code}
+	}
+	payload, err := ptypes.MarshalAny(&auditLog)
+	if err != nil {
+		t.Fatalf("Failed to marshal proto payload: %v", err)
+	}
+	logEntry := logpb.LogEntry{
+		InsertId:  insertID,
+		LogName:   "projects/test-project/logs/cloudaudit.googleapis.com%2Fdata_access",
+		Timestamp: &timestamp.Timestamp{Seconds: 12345},
+		Payload:   &logpb.LogEntry_ProtoPayload{ProtoPayload: payload},
+	}
+	var buf bytes.Buffer
+	if err := new(jsonpb.Marshaler).Marshal(&buf, &logEntry); err != nil {
+		t.Fatalf("Failed to marshal LogEntry: %v", err)
+	}
+	return &pubsub.Message{Data: buf.Bytes()}
+}
+
+// firestoreEvent is the type and subject of an event of a Firestore write.
+type firestoreEvent struct {
+	eventType string
+	subject   string
+}
+
+func TestConvertCloudFirestore(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		request string
+		code    int32
+		want    []firestoreEvent
+		wantErr string
+	}{{
+		name:    "create document",
+		method:  "CreateDocument",
+		request: `{"parent":"` + firestoreDatabase + `/documents/users/alice","collectionId":"orders","documentId":"1"}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceCreate, "documents/users/alice/orders/1"}},
+	}, {
+		name:    "update existing document",
+		method:  "UpdateDocument",
+		request: `{"document":{"name":"` + firestoreDatabase + `/documents/users/alice"},"currentDocument":{"exists":true}}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceUpdate, "documents/users/alice"}},
+	}, {
+		name:    "upsert document",
+		method:  "UpdateDocument",
+		request: `{"document":{"name":"` + firestoreDatabase + `/documents/users/alice"}}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceWrite, "documents/users/alice"}},
+	}, {
+		name:    "delete document",
+		method:  "DeleteDocument",
+		request: `{"name":"` + firestoreDatabase + `/documents/users/alice"}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceDelete, "documents/users/alice"}},
+	}, {
+		name:    "commit create",
+		method:  "Commit",
+		request: `{"writes":[{"update":{"name":"` + firestoreDatabase + `/documents/users/bob"},"currentDocument":{"exists":false}}]}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceCreate, "documents/users/bob"}},
+	}, {
+		name:    "commit update time precondition",
+		method:  "Commit",
+		request: `{"writes":[{"update":{"name":"` + firestoreDatabase + `/documents/users/bob"},"currentDocument":{"updateTime":"2020-01-01T00:00:00Z"}}]}`,
+		want:    []firestoreEvent{{v1beta1.CloudFirestoreSourceUpdate, "documents/users/bob"}},
+	}, {
+		name:    "batch write delete",
+		method:  "BatchWrite",
+		request: `{"writes":[{"delete":"` + firestoreDatabase + `/documents/users/bob"},{"delete":"` + firestoreDatabase + `/documents/users/carol"}]}`,
+		want: []firestoreEvent{
+			{v1beta1.CloudFirestoreSourceDelete, "documents/users/bob"},
+			{v1beta1.CloudFirestoreSourceDelete, "documents/users/carol"},
+		},
+	}, {
+		name:   "commit of several writes",
+		method: "Commit",
+		request: `{"writes":[` +
+			`{"update":{"name":"` + firestoreDatabase + `/documents/users/bob"},"currentDocument":{"exists":false}},` +
+			`{"transform":{"document":"` + firestoreDatabase + `/documents/counters/users"}},` +
+			`{"delete":"` + firestoreDatabase + `/documents/users/carol"}]}`,
+		want: []firestoreEvent{
+			{v1beta1.CloudFirestoreSourceCreate, "documents/users/bob"},
+			{v1beta1.CloudFirestoreSourceUpdate, "documents/counters/users"},
+			{v1beta1.CloudFirestoreSourceDelete, "documents/users/carol"},
+		},
+	}, {
+		name:    "commit with unknown write",
+		method:  "Commit",
+		request: `{"writes":[{"delete":"` + firestoreDatabase + `/documents/users/bob"},{}]}`,
+		wantErr: "unknown Commit write 1",
+	}, {
+		name:    "commit without writes",
+		method:  "Commit",
+		request: `{"transaction":"abc"}`,
+		wantErr: "Commit request did not have writes",
+	}, {
+		name:    "create document with assigned ID",
+		method:  "CreateDocument",
+		request: `{"parent":"` + firestoreDatabase + `/documents","collectionId":"users"}`,
+		wantErr: "CreateDocument request did not have a document ID",
+	}, {
+		name:    "failed write",
+		method:  "DeleteDocument",
+		request: `{"name":"` + firestoreDatabase + `/documents/users/alice"}`,
+		code:    9,
+		wantErr: "failed Firestore write with status code 9",
+	}, {
+		name:    "read method",
+		method:  "GetDocument",
+		request: `{"name":"` + firestoreDatabase + `/documents/users/alice"}`,
+		wantErr: `unhandled Firestore method "GetDocument"`,
+	}, {
+		name:    "invalid document name",
+		method:  "DeleteDocument",
+		request: `{"name":"users/alice"}`,
+		wantErr: `invalid document name "users/alice"`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := newFirestoreMessage(t, tc.method, tc.request, tc.code)
+			events, err := NewPubSubConverter().Convert(context.Background(), msg, CloudFirestore)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("conversion got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("conversion failed: %v", err)
+			}
+			if len(events) != len(tc.want) {
+				t.Fatalf("conversion got %d events, want %d", len(events), len(tc.want))
+			}
+			id := v1beta1.CloudAuditLogsSourceEventID(insertID, "projects/test-project/logs/cloudaudit.googleapis.com%2Fdata_access", "1970-01-01T03:25:45Z")
+			for i, e := range events {
+				wantID := id
+				if tc.method == "Commit" || tc.method == "BatchWrite" {
+					wantID = fmt.Sprintf("%s-%d", id, i)
+				}
+				if e.ID() != wantID {
+					t.Errorf("ID %q != %q", e.ID(), wantID)
+				}
+				if e.Type() != tc.want[i].eventType {
+					t.Errorf("Type %q != %q", e.Type(), tc.want[i].eventType)
+				}
+				if e.Subject() != tc.want[i].subject {
+					t.Errorf("Subject %q != %q", e.Subject(), tc.want[i].subject)
+				}
+				if want := v1beta1.CloudFirestoreSourceEventSource("test-project", "(default)"); e.Source() != want {
+					t.Errorf("Source %q != %q", e.Source(), want)
+				}
+				if e.DataSchema() != logEntrySchema {
+					t.Errorf("DataSchema %q != %q", e.DataSchema(), logEntrySchema)
+				}
+				if !bytes.Equal(e.Data(), msg.Data) {
+					t.Errorf("Data %s != %s", e.Data(), msg.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestFirestoreFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		pass    []string
+		drop    []string
+	}{{
+		name:    "literal",
+		options: `{"document":"users/alice"}`,
+		pass:    []string{"users/alice"},
+		drop:    []string{"users/bob", "users/alice/orders/1", "users/alice2"},
+	}, {
+		name:    "single segment wildcards",
+		options: `{"document":"users/{userId}/orders/*"}`,
+		pass:    []string{"users/alice/orders/1", "users/bob/orders/2"},
+		drop:    []string{"users/alice", "users/alice/orders/1/items/1", "customers/alice/orders/1"},
+	}, {
+		name:    "multi-segment wildcard",
+		options: `{"document":"users/{path=**}"}`,
+		pass:    []string{"users/alice", "users/alice/orders/1"},
+		drop:    []string{"customers/alice"},
+	}, {
+		name:    "special characters",
+		options: `{"document":"users/a.b+c"}`,
+		pass:    []string{"users/a.b+c"},
+		drop:    []string{"users/aXb+c", "users/a.bbc"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewFilter(CloudFirestore, tc.options)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			event := cev2.NewEvent()
+			event.SetType(v1beta1.CloudFirestoreSourceCreate)
+			for _, path := range tc.pass {
+				event.SetSubject("documents/" + path)
+				if !filter(&event) {
+					t.Errorf("Filter dropped the event of document %q", path)
+				}
+			}
+			for _, path := range tc.drop {
+				event.SetSubject("documents/" + path)
+				if filter(&event) {
+					t.Errorf("Filter passed the event of document %q", path)
+				}
+			}
+		})
+	}
+}
+
+func TestFirestoreFilterEventTypes(t *testing.T) {
+	filter, err := NewFilter(CloudFirestore, `{"document":"**","eventTypes":["com.google.cloud.firestore.document.delete"]}`)
+	if err != nil {
+		t.Fatalf("NewFilter failed: %v", err)
+	}
+	event := cev2.NewEvent()
+	event.SetSubject("documents/users/alice")
+	for eventType, want := range map[string]bool{
+		v1beta1.CloudFirestoreSourceDelete: true,
+		v1beta1.CloudFirestoreSourceCreate: false,
+		v1beta1.CloudFirestoreSourceWrite:  false,
+	} {
+		event.SetType(eventType)
+		if got := filter(&event); got != want {
+			t.Errorf("Filter of event type %q got %t, want %t", eventType, got, want)
+		}
+	}
+}
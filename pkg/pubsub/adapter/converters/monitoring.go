@@ -0,0 +1,96 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+// monitoringNotification is the notification of an alerting incident published
+// by a Cloud Monitoring Pub/Sub notification channel.
+type monitoringNotification struct {
+	Incident *monitoringIncident `json:"incident"`
+	Version  string              `json:"version"`
+}
+
+// monitoringIncident holds the fields of an alerting incident which are used
+// to convert its notification.
+type monitoringIncident struct {
+	IncidentID       string `json:"incident_id"`
+	ScopingProjectID string `json:"scoping_project_id"`
+	State            string `json:"state"`
+	// StartedAt and EndedAt are in seconds since the epoch.
+	StartedAt     int64  `json:"started_at"`
+	EndedAt       int64  `json:"ended_at"`
+	PolicyName    string `json:"policy_name"`
+	ConditionName string `json:"condition_name"`
+	ResourceName  string `json:"resource_name"`
+}
+
+func convertCloudMonitoring(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
+	var notification monitoringNotification
+	if err := json.Unmarshal(msg.Data, &notification); err != nil {
+		return nil, fmt.Errorf("failed to decode incident notification: %w", err)
+	}
+	incident := notification.Incident
+	if incident == nil {
+		return nil, errors.New("notification did not have an incident")
+	}
+	if incident.IncidentID == "" {
+		return nil, errors.New("incident did not have an ID")
+	}
+	if incident.ScopingProjectID == "" {
+		return nil, errors.New("incident did not have a scoping project")
+	}
+
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID(msg.ID)
+	switch incident.State {
+	case "open":
+		event.SetType(v1beta1.CloudMonitoringAlertSourceIncidentOpened)
+		event.SetTime(time.Unix(incident.StartedAt, 0))
+	case "closed":
+		event.SetType(v1beta1.CloudMonitoringAlertSourceIncidentClosed)
+		event.SetTime(time.Unix(incident.EndedAt, 0))
+	default:
+		return nil, fmt.Errorf("unhandled incident state %q", incident.State)
+	}
+	event.SetSource(v1beta1.CloudMonitoringAlertSourceEventSource(incident.ScopingProjectID))
+	event.SetSubject("incidents/" + incident.IncidentID)
+	if incident.PolicyName != "" {
+		event.SetExtension(v1beta1.CloudMonitoringAlertSourcePolicyExtension, incident.PolicyName)
+	}
+	if incident.ConditionName != "" {
+		event.SetExtension(v1beta1.CloudMonitoringAlertSourceConditionExtension, incident.ConditionName)
+	}
+	if incident.ResourceName != "" {
+		event.SetExtension(v1beta1.CloudMonitoringAlertSourceResourceExtension, incident.ResourceName)
+	}
+	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+// incidentNotificationFormat formats the notification of an incident with its
+// end time and state.
+const incidentNotificationFormat = `{
+  "incident": {
+    "incident_id": "0.abcdef123456",
+    "scoping_project_id": "test-project",
+    "scoping_project_number": 12345,
+    "url": "https://console.cloud.google.com/monitoring/alerting/incidents/0.abcdef123456?project=test-project",
+    "started_at": 1577840400,
+    "ended_at": %s,
+    "state": "%s",
+    "resource_id": "",
+    "resource_name": "test-project VM Instance labels {project_id=test-project, instance_id=123, zone=us-central1-a}",
+    "resource_display_name": "my-instance",
+    "policy_name": "High CPU",
+    "condition_name": "VM Instance - CPU utilization",
+    "summary": "CPU utilization for test-project VM Instance labels {project_id=test-project, instance_id=123, zone=us-central1-a} is above the threshold of 0.8 with a value of 0.95."
+  },
+  "version": "1.2"
+}`
+
+func TestConvertCloudMonitoring(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantType string
+		wantTime time.Time
+		wantErr  string
+	}{{
+		name:     "opened",
+		data:     fmt.Sprintf(incidentNotificationFormat, "null", "open"),
+		wantType: v1beta1.CloudMonitoringAlertSourceIncidentOpened,
+		wantTime: time.Unix(1577840400, 0),
+	}, {
+		name:     "closed",
+		data:     fmt.Sprintf(incidentNotificationFormat, "1577844000", "closed"),
+		wantType: v1beta1.CloudMonitoringAlertSourceIncidentClosed,
+		wantTime: time.Unix(1577844000, 0),
+	}, {
+		name:    "unknown state",
+		data:    fmt.Sprintf(incidentNotificationFormat, "null", "acknowledged"),
+		wantErr: `unhandled incident state "acknowledged"`,
+	}, {
+		name:    "no incident",
+		data:    `{"version":"1.2"}`,
+		wantErr: "notification did not have an incident",
+	}, {
+		name:    "no incident ID",
+		data:    `{"incident":{"scoping_project_id":"test-project","state":"open"},"version":"1.2"}`,
+		wantErr: "incident did not have an ID",
+	}, {
+		name:    "invalid JSON",
+		data:    `incident`,
+		wantErr: "failed to decode incident notification",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &pubsub.Message{ID: "id", Data: []byte(tc.data)}
+			e, err := convertOne(context.Background(), msg, CloudMonitoring)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("conversion got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("conversion failed: %v", err)
+			}
+			if e.ID() != "id" {
+				t.Errorf("ID %q != %q", e.ID(), "id")
+			}
+			if e.Type() != tc.wantType {
+				t.Errorf("Type %q != %q", e.Type(), tc.wantType)
+			}
+			if !e.Time().Equal(tc.wantTime) {
+				t.Errorf("Time %v != %v", e.Time(), tc.wantTime)
+			}
+			if want := v1beta1.CloudMonitoringAlertSourceEventSource("test-project"); e.Source() != want {
+				t.Errorf("Source %q != %q", e.Source(), want)
+			}
+			if want := "incidents/0.abcdef123456"; e.Subject() != want {
+				t.Errorf("Subject %q != %q", e.Subject(), want)
+			}
+			for name, want := range map[string]string{
+				v1beta1.CloudMonitoringAlertSourcePolicyExtension:    "High CPU",
+				v1beta1.CloudMonitoringAlertSourceConditionExtension: "VM Instance - CPU utilization",
+				v1beta1.CloudMonitoringAlertSourceResourceExtension:  "test-project VM Instance labels {project_id=test-project, instance_id=123, zone=us-central1-a}",
+			} {
+				if got := e.Extensions()[name]; got != want {
+					t.Errorf("Extension %q %q != %q", name, got, want)
+				}
+			}
+			if !bytes.Equal(e.Data(), msg.Data) {
+				t.Errorf("Data %s != %s", e.Data(), msg.Data)
+			}
+		})
+	}
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package converters
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -64,6 +70,138 @@ func convertCloudPubSub(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 	return &event, nil
 }
 
+// templateReferenceRegexp matches the references of event mapping templates.
+var templateReferenceRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// pubSubMapper maps the attributes and data of Pub/Sub messages to the events of
+// a CloudPubSubSource.
+type pubSubMapper struct {
+	mapping *v1beta1.CloudPubSubSourceEventMapping
+}
+
+func newPubSubMapper(options string) (Mapper, error) {
+	var opts v1beta1.CloudPubSubSourceAdapterOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal adapter options: %w", err)
+	}
+	if opts.EventMapping == nil {
+		return nil, nil
+	}
+	m := &pubSubMapper{mapping: opts.EventMapping}
+	return m.mapEvent, nil
+}
+
+// mapEvent sets the attributes of the event whose templates reference values
+// of the message, and unwraps its data if configured. It returns an error if
+// the mapped event isn't valid.
+func (m *pubSubMapper) mapEvent(msg *pubsub.Message, event *cev2.Event) error {
+	values := &messageValues{msg: msg}
+	if v, ok := values.render(m.mapping.Type); ok {
+		event.SetType(v)
+	}
+	if v, ok := values.render(m.mapping.Source); ok {
+		event.SetSource(v)
+	}
+	if v, ok := values.render(m.mapping.Subject); ok {
+		event.SetSubject(v)
+	}
+	if v, ok := values.render(m.mapping.ID); ok {
+		event.SetID(v)
+	}
+	for name, template := range m.mapping.Extensions {
+		if v, ok := values.render(template); ok {
+			event.SetExtension(name, v)
+		}
+	}
+	if m.mapping.UnwrapData {
+		contentType := cev2.ApplicationJSON
+		if !json.Valid(msg.Data) {
+			contentType = "application/octet-stream"
+		}
+		if err := event.SetData(contentType, msg.Data); err != nil {
+			return err
+		}
+	}
+	// Validate doesn't check the errors of the setters.
+	if len(event.FieldErrors) > 0 {
+		return fmt.Errorf("invalid mapped event attributes: %v", event.FieldErrors)
+	}
+	return event.Validate()
+}
+
+// messageValues resolves the references of event mapping templates to the
+// values of a message.
+type messageValues struct {
+	msg *pubsub.Message
+	// data is the JSON message data, decoded when it's first referenced.
+	data    interface{}
+	decoded bool
+}
+
+// render replaces the references of the template by the values of the message.
+// It returns false if the template is empty, a referenced value is missing or
+// the result is empty.
+func (v *messageValues) render(template string) (string, bool) {
+	if template == "" {
+		return "", false
+	}
+	found := true
+	rendered := templateReferenceRegexp.ReplaceAllStringFunc(template, func(ref string) string {
+		value, ok := v.lookup(ref[len("${") : len(ref)-len("}")])
+		found = found && ok
+		return value
+	})
+	return rendered, found && rendered != ""
+}
+
+// lookup returns the value of the message attribute or data path of the reference.
+func (v *messageValues) lookup(ref string) (string, bool) {
+	if name := strings.TrimPrefix(ref, "attributes."); name != ref {
+		value, ok := v.msg.Attributes[name]
+		return value, ok
+	}
+	if path := strings.TrimPrefix(ref, "data."); path != ref {
+		return v.lookupData(strings.Split(path, "."))
+	}
+	return "", false
+}
+
+// lookupData returns the string, number or boolean at the path of the JSON
+// message data.
+func (v *messageValues) lookupData(path []string) (string, bool) {
+	if !v.decoded {
+		v.decoded = true
+		decoder := json.NewDecoder(bytes.NewReader(v.msg.Data))
+		// Keep the numbers as they are, as float64 would lose the precision of large IDs.
+		decoder.UseNumber()
+		if err := decoder.Decode(&v.data); err != nil {
+			// Data which isn't JSON has no values.
+			v.data = nil
+		}
+	}
+	value := v.data
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[key]; !ok {
+			return "", false
+		}
+	}
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case json.Number:
+		return value.String(), true
+	case bool:
+		return strconv.FormatBool(value), true
+	default:
+		// Objects, arrays and nulls can't be attribute values.
+		return "", false
+	}
+}
+
 // PushMessage represents the format Pub/Sub uses to push events.
 type PushMessage struct {
 	// Subscription is the subscription ID that received this Message.
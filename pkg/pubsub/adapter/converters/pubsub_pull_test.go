@@ -85,7 +85,7 @@ func TestConvertPubSubPull(t *testing.T) {
 			ctx = WithTopicKey(ctx, "testtopic")
 			ctx = WithSubscriptionKey(ctx, "testsubscription")
 
-			gotEvent, err := NewPubSubConverter().Convert(ctx, test.message, PubSubPull)
+			gotEvent, err := convertOne(ctx, test.message, PubSubPull)
 			if err != nil {
 				if !test.wantErr {
 					t.Errorf("converters.convertPubsubPull got error %v want error=%v", err, test.wantErr)
@@ -25,6 +25,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	cev2 "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	. "github.com/google/knative-gcp/pkg/pubsub/adapter/context"
 )
@@ -84,7 +85,7 @@ func TestConvertCloudPubSub(t *testing.T) {
 				ctx = WithSubscriptionKey(ctx, "testsubscription")
 			}
 
-			gotEvent, err := NewPubSubConverter().Convert(ctx, test.message, CloudPubSub)
+			gotEvent, err := convertOne(ctx, test.message, CloudPubSub)
 			if err != nil {
 				if !test.wantErr {
 					t.Errorf("converters.convertPubsub got error %v want error=%v", err, test.wantErr)
@@ -113,3 +114,172 @@ func pubSubCloudEvent(attributes map[string]string, data string) *cev2.Event {
 	e.DataBase64 = false
 	return &e
 }
+
+func TestPubSubMapper(t *testing.T) {
+	defaultSource := v1beta1.CloudPubSubSourceEventSource("testproject", "testtopic")
+	tests := []struct {
+		name            string
+		mapping         string
+		attributes      map[string]string
+		data            string
+		wantType        string
+		wantSource      string
+		wantSubject     string
+		wantID          string
+		wantExtensions  map[string]interface{}
+		wantContentType string
+		wantData        string
+		wantErr         bool
+	}{{
+		name:        "attributes and data paths",
+		mapping:     `{"type":"com.example.${attributes.eventType}","source":"//example.com/${attributes.app.name}","subject":"orders/${data.order.id}","id":"${attributes.eventId}","extensions":{"tenant":"${data.tenant}","priority":"${data.order.priority}","paid":"${data.order.paid}"}}`,
+		attributes:  map[string]string{"eventType": "order.created", "app.name": "shop", "eventId": "event-1"},
+		data:        `{"tenant":"acme","order":{"id":"o-1","priority":12345678901234567890,"paid":true}}`,
+		wantType:    "com.example.order.created",
+		wantSource:  "//example.com/shop",
+		wantSubject: "orders/o-1",
+		wantID:      "event-1",
+		wantExtensions: map[string]interface{}{
+			"tenant":   "acme",
+			"priority": "12345678901234567890",
+			"paid":     "true",
+		},
+		wantContentType: cev2.ApplicationJSON,
+	}, {
+		name:            "missing values",
+		mapping:         `{"type":"${attributes.eventType}","subject":"${data.order.id}","id":"${attributes.eventId}","extensions":{"tenant":"${data.tenant}"}}`,
+		attributes:      map[string]string{"eventType": ""},
+		data:            `{"order":{"id":{"nested":true}}}`,
+		wantType:        v1beta1.CloudPubSubSourcePublish,
+		wantSource:      defaultSource,
+		wantID:          "id",
+		wantContentType: cev2.ApplicationJSON,
+	}, {
+		name:            "data which isn't JSON",
+		mapping:         `{"subject":"${data.id}"}`,
+		data:            `plain text`,
+		wantType:        v1beta1.CloudPubSubSourcePublish,
+		wantSource:      defaultSource,
+		wantID:          "id",
+		wantContentType: cev2.ApplicationJSON,
+	}, {
+		name:            "unwrap JSON data",
+		mapping:         `{"unwrapData":true}`,
+		data:            `{"key":"value"}`,
+		wantType:        v1beta1.CloudPubSubSourcePublish,
+		wantSource:      defaultSource,
+		wantID:          "id",
+		wantContentType: cev2.ApplicationJSON,
+		wantData:        `{"key":"value"}`,
+	}, {
+		name:            "unwrap binary data",
+		mapping:         `{"unwrapData":true}`,
+		data:            "\x00\x01binary",
+		wantType:        v1beta1.CloudPubSubSourcePublish,
+		wantSource:      defaultSource,
+		wantID:          "id",
+		wantContentType: "application/octet-stream",
+		wantData:        "\x00\x01binary",
+	}, {
+		name:       "invalid source",
+		mapping:    `{"source":"${attributes.source}"}`,
+		attributes: map[string]string{"source": "%zz"},
+		data:       `{}`,
+		wantErr:    true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := WithProjectKey(context.Background(), "testproject")
+			ctx = WithTopicKey(ctx, "testtopic")
+			ctx = WithSubscriptionKey(ctx, "testsubscription")
+			msg := &pubsub.Message{
+				ID:         "id",
+				Data:       []byte(tc.data),
+				Attributes: tc.attributes,
+			}
+			event, err := convertOne(ctx, msg, CloudPubSub)
+			if err != nil {
+				t.Fatalf("conversion failed: %v", err)
+			}
+			envelope := string(event.Data())
+
+			mapper, err := NewMapper(CloudPubSub, `{"eventMapping":`+tc.mapping+`}`)
+			if err != nil {
+				t.Fatalf("NewMapper failed: %v", err)
+			}
+			if err := mapper(msg, event); (err != nil) != tc.wantErr {
+				t.Fatalf("mapper got error %v, want error=%v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if event.Type() != tc.wantType {
+				t.Errorf("Type %q != %q", event.Type(), tc.wantType)
+			}
+			if event.Source() != tc.wantSource {
+				t.Errorf("Source %q != %q", event.Source(), tc.wantSource)
+			}
+			if event.Subject() != tc.wantSubject {
+				t.Errorf("Subject %q != %q", event.Subject(), tc.wantSubject)
+			}
+			if event.ID() != tc.wantID {
+				t.Errorf("ID %q != %q", event.ID(), tc.wantID)
+			}
+			if diff := cmp.Diff(tc.wantExtensions, event.Extensions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected extensions (-want +got) %s", diff)
+			}
+			if event.DataContentType() != tc.wantContentType {
+				t.Errorf("DataContentType %q != %q", event.DataContentType(), tc.wantContentType)
+			}
+			wantData := tc.wantData
+			if wantData == "" {
+				wantData = envelope
+			}
+			if string(event.Data()) != wantData {
+				t.Errorf("Data %q != %q", event.Data(), wantData)
+			}
+		})
+	}
+}
+
+func TestNewMapper(t *testing.T) {
+	tests := []struct {
+		name          string
+		converterType ConverterType
+		options       string
+		wantMapper    bool
+		wantErr       bool
+	}{{
+		name:          "no options",
+		converterType: CloudPubSub,
+	}, {
+		name:          "no mapping",
+		converterType: CloudPubSub,
+		options:       `{}`,
+	}, {
+		name:          "mapping",
+		converterType: CloudPubSub,
+		options:       `{"eventMapping":{"unwrapData":true}}`,
+		wantMapper:    true,
+	}, {
+		name:          "invalid options",
+		converterType: CloudPubSub,
+		options:       `{"eventMapping":`,
+		wantErr:       true,
+	}, {
+		name:          "filter options",
+		converterType: CloudStorage,
+		options:       `{"eventTypes":["com.google.cloud.storage.object.finalize"]}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mapper, err := NewMapper(tc.converterType, tc.options)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewMapper got error %v, want error=%v", err, tc.wantErr)
+			}
+			if (mapper != nil) != tc.wantMapper {
+				t.Errorf("NewMapper got mapper=%t, want mapper=%t", mapper != nil, tc.wantMapper)
+			}
+		})
+	}
+}
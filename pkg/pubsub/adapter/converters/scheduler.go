@@ -19,6 +19,7 @@ package converters
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler/resources"
@@ -28,11 +29,6 @@ import (
 )
 
 func convertCloudScheduler(ctx context.Context, msg *pubsub.Message) (*cev2.Event, error) {
-	event := cev2.NewEvent(cev2.VersionV1)
-	event.SetID(msg.ID)
-	event.SetTime(msg.PublishTime)
-	event.SetType(v1beta1.CloudSchedulerSourceExecute)
-
 	// Set the source and subject if it comes as an attribute.
 	// We added this attributes so that we could identify the scheduler.
 	// We should remove them here.
@@ -44,12 +40,23 @@ func convertCloudScheduler(ctx context.Context, msg *pubsub.Message) (*cev2.Even
 	if !ok {
 		return nil, errors.New("received event did not have schedulerName")
 	}
+	return NewCloudSchedulerEvent(msg.ID, msg.PublishTime, jobName, schedulerName, msg.Data)
+}
+
+// NewCloudSchedulerEvent creates the event of an execution of the job jobName of the
+// CloudSchedulerSource schedulerName. It is shared by the Pub/Sub and the HTTP deliveries
+// of CloudSchedulerSources, so that both send the same events.
+func NewCloudSchedulerEvent(id string, t time.Time, jobName, schedulerName string, data []byte) (*cev2.Event, error) {
+	event := cev2.NewEvent(cev2.VersionV1)
+	event.SetID(id)
+	event.SetTime(t)
+	event.SetType(v1beta1.CloudSchedulerSourceExecute)
 
 	parentName := resources.ExtractParentName(jobName)
 	event.SetSource(v1beta1.CloudSchedulerSourceEventSource(parentName, schedulerName))
 	event.SetSubject(resources.ExtractJobID(jobName))
 
-	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+	if err := event.SetData(cev2.ApplicationJSON, data); err != nil {
 		return nil, err
 	}
 	return &event, nil
@@ -83,7 +83,7 @@ func TestConvertCloudSchedulerSource(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 
-			gotEvent, err := NewPubSubConverter().Convert(context.Background(), test.message, CloudScheduler)
+			gotEvent, err := convertOne(context.Background(), test.message, CloudScheduler)
 
 			if test.wantErr != "" || err != nil {
 				var gotErr string
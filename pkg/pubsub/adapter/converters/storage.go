@@ -19,10 +19,13 @@ package converters
 import (
 	"cloud.google.com/go/pubsub"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	cev2 "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"regexp"
+	"strings"
 )
 
 var (
@@ -68,8 +71,106 @@ func convertCloudStorage(ctx context.Context, msg *pubsub.Message) (*cev2.Event,
 		return nil, errors.New("received event did not have eventType")
 	}
 
-	if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
-		return nil, err
+	// Custom attributes of the notification are set as extensions. The attributes set by Cloud
+	// Storage are camel-cased, so they don't match and are not duplicated as extensions.
+	for key, val := range msg.Attributes {
+		if v1beta1.CustomAttributeKeyRegexp.MatchString(key) {
+			event.SetExtension(key, val)
+		}
+	}
+
+	// Notifications with the NONE payload format have no data.
+	if len(msg.Data) > 0 {
+		if err := event.SetData(cev2.ApplicationJSON, msg.Data); err != nil {
+			return nil, err
+		}
 	}
 	return &event, nil
 }
+
+// storageFilter selects the events of the objects whose names match the options
+// of a CloudStorageSource.
+type storageFilter struct {
+	suffix  string
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newStorageFilter(options string) (Filter, error) {
+	var opts v1beta1.CloudStorageSourceAdapterOptions
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal adapter options: %w", err)
+	}
+	f := &storageFilter{suffix: opts.ObjectNameSuffix}
+	if opts.ObjectNameFilter != nil {
+		var err error
+		if f.include, err = compileObjectNamePatterns(opts.ObjectNameFilter.Include); err != nil {
+			return nil, err
+		}
+		if f.exclude, err = compileObjectNamePatterns(opts.ObjectNameFilter.Exclude); err != nil {
+			return nil, err
+		}
+	}
+	return f.matches, nil
+}
+
+// matches returns whether the name of the object of the event, which is the
+// subject of the event, matches the filter.
+func (f *storageFilter) matches(event *cev2.Event) bool {
+	name := event.Subject()
+	if !strings.HasSuffix(name, f.suffix) {
+		return false
+	}
+	if len(f.include) > 0 && !matchesAny(f.include, name) {
+		return false
+	}
+	return !matchesAny(f.exclude, name)
+}
+
+func matchesAny(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func compileObjectNamePatterns(patterns []v1beta1.ObjectNamePattern) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		expr := p.Regex
+		if p.Glob != "" {
+			expr = globToRegexp(p.Glob)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile object name pattern: %w", err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// globToRegexp converts a glob pattern to a regular expression matching the whole
+// object name. '**' matches any sequence of characters, '*' matches any sequence
+// of characters except '/' and '?' matches any character except '/'.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package converters
 
 import (
+	"bytes"
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	cev2 "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 )
 
@@ -38,9 +41,10 @@ var (
 func TestConvertCloudStorageSource(t *testing.T) {
 
 	tests := []struct {
-		name    string
-		message *pubsub.Message
-		wantErr bool
+		name           string
+		message        *pubsub.Message
+		wantExtensions map[string]interface{}
+		wantErr        bool
 	}{{
 		name: "no attributes",
 		message: &pubsub.Message{
@@ -101,11 +105,25 @@ func TestConvertCloudStorageSource(t *testing.T) {
 				"objectId":  objectId,
 			},
 		},
+	}, {
+		name: "no payload with custom attributes",
+		message: &pubsub.Message{
+			ID:          "id",
+			PublishTime: storagePublishTime,
+			Attributes: map[string]string{
+				"bucketId":      bucket,
+				"eventType":     eventType,
+				"objectId":      objectId,
+				"payloadFormat": "NONE",
+				"team":          "storage",
+			},
+		},
+		wantExtensions: map[string]interface{}{"team": "storage"},
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			gotEvent, err := NewPubSubConverter().Convert(context.Background(), test.message, CloudStorage)
+			gotEvent, err := convertOne(context.Background(), test.message, CloudStorage)
 
 			if err != nil {
 				if !test.wantErr {
@@ -130,7 +148,88 @@ func TestConvertCloudStorageSource(t *testing.T) {
 				if gotEvent.DataSchema() != storageSchemaUrl {
 					t.Errorf("DataSchema %q != %q", gotEvent.DataSchema(), storageSchemaUrl)
 				}
+				if !reflect.DeepEqual(gotEvent.Extensions(), test.wantExtensions) {
+					t.Errorf("Extensions %v != %v", gotEvent.Extensions(), test.wantExtensions)
+				}
+				if !bytes.Equal(gotEvent.Data(), test.message.Data) {
+					t.Errorf("Data %q != %q", gotEvent.Data(), test.message.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestStorageFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		pass    []string
+		drop    []string
+	}{{
+		name:    "suffix",
+		options: `{"objectNameSuffix":".jpg"}`,
+		pass:    []string{"myfile.jpg", "images/myfile.jpg"},
+		drop:    []string{"myfile.png", "myfile.jpg.tmp"},
+	}, {
+		name:    "include glob",
+		options: `{"objectNameFilter":{"include":[{"glob":"images/*.jpg"},{"glob":"thumbnails/**"}]}}`,
+		pass:    []string{"images/myfile.jpg", "thumbnails/small/myfile.png"},
+		drop:    []string{"myfile.jpg", "images/2020/myfile.jpg", "images/myfile.jpeg"},
+	}, {
+		name:    "include regex",
+		options: `{"objectNameFilter":{"include":[{"regex":"^logs/[0-9]+\\.txt$"}]}}`,
+		pass:    []string{"logs/123.txt"},
+		drop:    []string{"logs/abc.txt", "logs/123.txt.gz"},
+	}, {
+		name:    "exclude",
+		options: `{"objectNameSuffix":".jpg","objectNameFilter":{"exclude":[{"glob":"tmp/**"},{"regex":"draft"}]}}`,
+		pass:    []string{"images/myfile.jpg"},
+		drop:    []string{"tmp/images/myfile.jpg", "images/draft-myfile.jpg", "images/myfile.png"},
+	}, {
+		name:    "glob special characters",
+		options: `{"objectNameFilter":{"include":[{"glob":"images/file?.(1).jpg"}]}}`,
+		pass:    []string{"images/file1.(1).jpg"},
+		drop:    []string{"images/file1.11.jpg", "images/file/.(1).jpg"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewFilter(CloudStorage, tc.options)
+			if err != nil {
+				t.Fatalf("NewFilter failed: %v", err)
+			}
+			event := cev2.NewEvent()
+			for _, name := range tc.pass {
+				event.SetSubject(name)
+				if !filter(&event) {
+					t.Errorf("Filter dropped the event of object %q", name)
+				}
+			}
+			for _, name := range tc.drop {
+				event.SetSubject(name)
+				if filter(&event) {
+					t.Errorf("Filter passed the event of object %q", name)
+				}
 			}
 		})
 	}
 }
+
+func TestNewFilter(t *testing.T) {
+	if filter, err := NewFilter(CloudStorage, ""); filter != nil || err != nil {
+		t.Errorf("NewFilter without options got (%p, %v), want (nil, nil)", filter, err)
+	}
+	for _, tc := range []struct {
+		name          string
+		converterType ConverterType
+		options       string
+	}{
+		{"invalid options", CloudStorage, `{"objectNameSuffix":`},
+		{"invalid regex", CloudStorage, `{"objectNameFilter":{"include":[{"regex":"(.*"}]}}`},
+		{"invalid build options", CloudBuild, `{"buildStatuses":"FAILURE"}`},
+		{"unsupported converter type", CloudScheduler, `{"objectNameSuffix":".jpg"}`},
+	} {
+		if _, err := NewFilter(tc.converterType, tc.options); err == nil {
+			t.Errorf("NewFilter with %s got nil error", tc.name)
+		}
+	}
+}
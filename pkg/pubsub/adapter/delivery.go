@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+)
+
+const (
+	// maxBackoff is the max backoff before a failed message is nacked, the same as the max
+	// backoff of the retry policy of a Pub/Sub subscription.
+	maxBackoff = 600 * time.Second
+
+	// The extensions set on the events sent to the dead letter sink.
+	deadLetterDestExtension = "knativeerrordest"
+	deadLetterCodeExtension = "knativeerrorcode"
+	deadLetterDataExtension = "knativeerrordata"
+)
+
+// DeliveryArgs configures the delivery of the events the adapter fails to deliver, as set by
+// the delivery spec of the PullSubscription.
+type DeliveryArgs struct {
+	// Retry is the number of times a failed event is retried.
+	Retry int
+
+	// Linear is whether the backoff between retries grows linearly instead of exponentially.
+	Linear bool
+
+	// BackoffDelay is the backoff before the first retry.
+	BackoffDelay time.Duration
+
+	// DeadLetterSinkURI is the URI where to send the events to once their retries are exhausted.
+	// If empty, the events are dropped.
+	DeadLetterSinkURI string
+}
+
+// retriesExhausted returns whether the event of a message which failed to be delivered on the
+// given attempt shouldn't be retried anymore.
+func (d *DeliveryArgs) retriesExhausted(attempt int) bool {
+	return attempt > d.Retry
+}
+
+// backoff returns the backoff before retrying the event of a message which failed to be delivered
+// on the given attempt.
+func (d *DeliveryArgs) backoff(attempt int) time.Duration {
+	if d.BackoffDelay <= 0 || attempt < 1 {
+		return 0
+	}
+	factor := int64(attempt)
+	if !d.Linear {
+		// Avoid overflowing for large numbers of attempts.
+		if attempt > 32 {
+			return maxBackoff
+		}
+		factor = int64(1) << (attempt - 1)
+	}
+	if factor > int64(maxBackoff/d.BackoffDelay) {
+		return maxBackoff
+	}
+	return d.BackoffDelay * time.Duration(factor)
+}
+
+// deliveryError is returned when an event fails to be delivered to an address.
+type deliveryError struct {
+	address string
+	// statusCode is the status code the address responded with, or 0 if it didn't respond.
+	statusCode int
+	err        error
+}
+
+func (e *deliveryError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("failed to deliver event to %s: %v", e.address, e.err)
+	}
+	return fmt.Sprintf("failed to deliver event to %s: HTTP status code %d", e.address, e.statusCode)
+}
+
+func (e *deliveryError) Unwrap() error {
+	return e.err
+}
+
+// failureTracker counts the failed delivery attempts of the messages. Pub/Sub only populates the
+// delivery attempt of the messages of subscriptions with a dead letter policy, otherwise we fall
+// back to the number of times the adapter has failed to deliver the message.
+type failureTracker struct {
+	mux      sync.Mutex
+	failures map[string]int
+}
+
+func newFailureTracker() *failureTracker {
+	return &failureTracker{failures: make(map[string]int)}
+}
+
+// attempt records a failed delivery attempt of the message and returns its number.
+func (t *failureTracker) attempt(msg *pubsub.Message) int {
+	if msg.DeliveryAttempt != nil {
+		return *msg.DeliveryAttempt
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.failures[msg.ID]++
+	return t.failures[msg.ID]
+}
+
+// forget stops tracking the message once it is acked.
+func (t *failureTracker) forget(msg *pubsub.Message) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	delete(t.failures, msg.ID)
+}
@@ -0,0 +1,415 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/atomic"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+)
+
+const (
+	testProjectID = "test-project"
+	testTopic     = "test-topic"
+	testSub       = "test-sub"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery DeliveryArgs
+		attempt  int
+		want     time.Duration
+	}{{
+		name:     "no backoff delay",
+		delivery: DeliveryArgs{Retry: 3},
+		attempt:  2,
+		want:     0,
+	}, {
+		name:     "exponential first attempt",
+		delivery: DeliveryArgs{Retry: 3, BackoffDelay: time.Second},
+		attempt:  1,
+		want:     time.Second,
+	}, {
+		name:     "exponential third attempt",
+		delivery: DeliveryArgs{Retry: 3, BackoffDelay: time.Second},
+		attempt:  3,
+		want:     4 * time.Second,
+	}, {
+		name:     "exponential capped",
+		delivery: DeliveryArgs{Retry: 100, BackoffDelay: time.Second},
+		attempt:  20,
+		want:     maxBackoff,
+	}, {
+		name:     "exponential many attempts",
+		delivery: DeliveryArgs{Retry: 100, BackoffDelay: time.Second},
+		attempt:  90,
+		want:     maxBackoff,
+	}, {
+		name:     "linear third attempt",
+		delivery: DeliveryArgs{Retry: 3, Linear: true, BackoffDelay: time.Second},
+		attempt:  3,
+		want:     3 * time.Second,
+	}, {
+		name:     "linear capped",
+		delivery: DeliveryArgs{Retry: 1000, Linear: true, BackoffDelay: time.Minute},
+		attempt:  11,
+		want:     maxBackoff,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.delivery.backoff(tc.attempt); got != tc.want {
+				t.Errorf("backoff(%d) = %v, want %v", tc.attempt, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeliveryRetriesExhausted(t *testing.T) {
+	delivery := DeliveryArgs{Retry: 2}
+	for attempt, want := range map[int]bool{1: false, 2: false, 3: true, 4: true} {
+		if got := delivery.retriesExhausted(attempt); got != want {
+			t.Errorf("retriesExhausted(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestFailureTracker(t *testing.T) {
+	tracker := newFailureTracker()
+	msg := &pubsub.Message{ID: "id"}
+	for want := 1; want <= 3; want++ {
+		if got := tracker.attempt(msg); got != want {
+			t.Errorf("attempt() = %d, want %d", got, want)
+		}
+	}
+	tracker.forget(msg)
+	if got := tracker.attempt(msg); got != 1 {
+		t.Errorf("attempt() after forget = %d, want 1", got)
+	}
+
+	deliveryAttempt := 7
+	if got := tracker.attempt(&pubsub.Message{ID: "other", DeliveryAttempt: &deliveryAttempt}); got != deliveryAttempt {
+		t.Errorf("attempt() with delivery attempt = %d, want %d", got, deliveryAttempt)
+	}
+}
+
+func TestAdapterDelivery(t *testing.T) {
+	tests := []struct {
+		name string
+		// retry is the number of retries of the delivery args, if any.
+		retry *int
+		// deadLetter is whether the delivery args have a dead letter sink.
+		deadLetter   bool
+		wantAttempts int64
+		wantBackoffs []time.Duration
+		wantDead     bool
+	}{{
+		name:         "retries exhausted, sent to dead letter sink",
+		retry:        intPtr(2),
+		deadLetter:   true,
+		wantAttempts: 3,
+		wantBackoffs: []time.Duration{time.Second, 2 * time.Second},
+		wantDead:     true,
+	}, {
+		name:         "no retry, sent to dead letter sink",
+		retry:        intPtr(0),
+		deadLetter:   true,
+		wantAttempts: 1,
+		wantDead:     true,
+	}, {
+		name:         "retries exhausted, dropped",
+		retry:        intPtr(1),
+		wantAttempts: 2,
+		wantBackoffs: []time.Duration{time.Second},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c, close := testPubsubClient(ctx, t)
+			defer close()
+			topic, sub := createTestSubscription(ctx, t, c, testTopic, testSub)
+
+			var attempts atomic.Int64
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Inc()
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer sink.Close()
+			deadCh := make(chan *event.Event, 1)
+			deadLetterSink := newTestSink(t, deadCh)
+			defer deadLetterSink.Close()
+
+			args := &AdapterArgs{
+				TopicID: testTopic,
+				SinkURI: sink.URL,
+			}
+			if tc.retry != nil {
+				args.Delivery = &DeliveryArgs{Retry: *tc.retry, BackoffDelay: time.Second}
+				if tc.deadLetter {
+					args.Delivery.DeadLetterSinkURI = deadLetterSink.URL
+				}
+			}
+			a := newTestAdapter(ctx, sub, DeadLetterSubscription{}, args)
+			var mux sync.Mutex
+			var backoffs []time.Duration
+			a.delayNack = func(d time.Duration) {
+				mux.Lock()
+				defer mux.Unlock()
+				backoffs = append(backoffs, d)
+			}
+			go a.Start(ctx)
+
+			publishTestEvent(ctx, t, topic)
+
+			gotDead := nextEventWithTimeout(deadCh)
+			if tc.wantDead {
+				if gotDead == nil {
+					t.Fatal("Dead letter sink didn't receive the event")
+				}
+				if diff := cmp.Diff(sink.URL, gotDead.Extensions()[deadLetterDestExtension]); diff != "" {
+					t.Errorf("Unexpected %s extension (-want,+got): %v", deadLetterDestExtension, diff)
+				}
+				if diff := cmp.Diff("500", gotDead.Extensions()[deadLetterCodeExtension]); diff != "" {
+					t.Errorf("Unexpected %s extension (-want,+got): %v", deadLetterCodeExtension, diff)
+				}
+			} else if gotDead != nil {
+				t.Errorf("Dead letter sink received an unexpected event: %v", gotDead)
+			}
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Errorf("Unexpected delivery attempts, got %d, want %d", got, tc.wantAttempts)
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			if diff := cmp.Diff(tc.wantBackoffs, backoffs); diff != "" {
+				t.Errorf("Unexpected backoffs (-want,+got): %v", diff)
+			}
+		})
+	}
+}
+
+// splitConverter converts a message to two events, with the IDs of the event of the message
+// suffixed with -0 and -1.
+type splitConverter struct{}
+
+func (splitConverter) Convert(ctx context.Context, msg *pubsub.Message, converterType converters.ConverterType) ([]*event.Event, error) {
+	events, err := converters.NewPubSubConverter().Convert(ctx, msg, converterType)
+	if err != nil {
+		return nil, err
+	}
+	first, second := events[0].Clone(), events[0].Clone()
+	first.SetID(first.ID() + "-0")
+	second.SetID(second.ID() + "-1")
+	return []*event.Event{&first, &second}, nil
+}
+
+func TestAdapterDeliveryOfSeveralEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, close := testPubsubClient(ctx, t)
+	defer close()
+	topic, sub := createTestSubscription(ctx, t, c, testTopic, testSub)
+
+	var mux sync.Mutex
+	var delivered []string
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+		if err != nil {
+			t.Errorf("failed to convert the request to an event: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mux.Lock()
+		delivered = append(delivered, e.ID())
+		mux.Unlock()
+		// The second event is rejected.
+		if e.ID() == "id-1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+	deadCh := make(chan *event.Event, 1)
+	deadLetterSink := newTestSink(t, deadCh)
+	defer deadLetterSink.Close()
+
+	a := NewAdapter(ctx, testProjectID, "testnamespace", "testname", "testresourcegroup", sub, DeadLetterSubscription{},
+		http.DefaultClient, splitConverter{}, &fakeStatsReporter{}, &AdapterArgs{
+			TopicID:  testTopic,
+			SinkURI:  sink.URL,
+			Delivery: &DeliveryArgs{Retry: 0, DeadLetterSinkURI: deadLetterSink.URL},
+		})
+	go a.Start(ctx)
+
+	publishTestEvent(ctx, t, topic)
+
+	gotDead := nextEventWithTimeout(deadCh)
+	if gotDead == nil {
+		t.Fatal("Dead letter sink didn't receive the event")
+	}
+	if diff := cmp.Diff("id-1", gotDead.ID()); diff != "" {
+		t.Errorf("Unexpected dead lettered event ID (-want,+got): %v", diff)
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if diff := cmp.Diff([]string{"id-0", "id-1"}, delivered); diff != "" {
+		t.Errorf("Unexpected delivered events (-want,+got): %v", diff)
+	}
+}
+
+func TestAdapterReceiveDeadLetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, close := testPubsubClient(ctx, t)
+	defer close()
+	_, sub := createTestSubscription(ctx, t, c, testTopic, testSub)
+	deadLetterTopic, deadLetterSub := createTestSubscription(ctx, t, c, testTopic+"-dl", testSub+"-dl")
+
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Sink received an unexpected event")
+	}))
+	defer sink.Close()
+	deadCh := make(chan *event.Event, 1)
+	deadLetterSink := newTestSink(t, deadCh)
+	defer deadLetterSink.Close()
+
+	a := newTestAdapter(ctx, sub, DeadLetterSubscription{Subscription: deadLetterSub}, &AdapterArgs{
+		TopicID:  testTopic,
+		SinkURI:  sink.URL,
+		Delivery: &DeliveryArgs{Retry: 200, DeadLetterSinkURI: deadLetterSink.URL},
+	})
+	go a.Start(ctx)
+
+	// Pub/Sub forwards the messages whose delivery attempts exceed the max delivery attempts of the
+	// dead letter policy to the dead letter topic as they are.
+	publishTestEvent(ctx, t, deadLetterTopic)
+
+	gotDead := nextEventWithTimeout(deadCh)
+	if gotDead == nil {
+		t.Fatal("Dead letter sink didn't receive the event")
+	}
+	if diff := cmp.Diff("id", gotDead.ID()); diff != "" {
+		t.Errorf("Unexpected event ID (-want,+got): %v", diff)
+	}
+}
+
+func testPubsubClient(ctx context.Context, t *testing.T) (*pubsub.Client, func()) {
+	t.Helper()
+	srv := pstest.NewServer()
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial test pubsub connection: %v", err)
+	}
+	close := func() {
+		srv.Close()
+		conn.Close()
+	}
+	c, err := pubsub.NewClient(ctx, testProjectID, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create test pubsub client: %v", err)
+	}
+	return c, close
+}
+
+func createTestSubscription(ctx context.Context, t *testing.T, c *pubsub.Client, topicID, subID string) (*pubsub.Topic, *pubsub.Subscription) {
+	t.Helper()
+	topic, err := c.CreateTopic(ctx, topicID)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	sub, err := c.CreateSubscription(ctx, subID, pubsub.SubscriptionConfig{
+		Topic: topic,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	return topic, sub
+}
+
+func newTestAdapter(ctx context.Context, sub *pubsub.Subscription, deadLetterSub DeadLetterSubscription, args *AdapterArgs) *Adapter {
+	return NewAdapter(ctx, testProjectID, "testnamespace", "testname", "testresourcegroup", sub, deadLetterSub,
+		http.DefaultClient, converters.NewPubSubConverter(), &fakeStatsReporter{}, args)
+}
+
+// newTestSink starts a server which sends the events it receives to the channel.
+func newTestSink(t *testing.T, eventCh chan<- *event.Event) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+		if err != nil {
+			t.Errorf("failed to convert the request to an event: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		eventCh <- e
+		w.WriteHeader(http.StatusAccepted)
+	}))
+}
+
+func publishTestEvent(ctx context.Context, t *testing.T, topic *pubsub.Topic) {
+	t.Helper()
+	res := topic.Publish(ctx, &pubsub.Message{
+		Data: []byte(`{"hello":"world"}`),
+		Attributes: map[string]string{
+			"ce-specversion": "1.0",
+			"ce-id":          "id",
+			"ce-source":      "source",
+			"ce-type":        "type",
+		},
+	})
+	if _, err := res.Get(ctx); err != nil {
+		t.Fatalf("failed to publish the event: %v", err)
+	}
+}
+
+func nextEventWithTimeout(eventCh <-chan *event.Event) *event.Event {
+	select {
+	case <-time.After(5 * time.Second):
+		return nil
+	case got := <-eventCh:
+		return got
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type fakeStatsReporter struct{}
+
+func (r *fakeStatsReporter) ReportEventCount(args *ReportArgs, responseCode int) error {
+	return nil
+}
+
+func (r *fakeStatsReporter) ReportEventDropped(args *ReportArgs) error {
+	return nil
+}
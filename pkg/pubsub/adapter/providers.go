@@ -18,12 +18,20 @@ type ResourceGroup string
 type SinkURI string
 type TransformerURI string
 type SubscriptionID string
+type DeadLetterSubscriptionID string
+
+// DeadLetterSubscription is the subscription of the dead letter topic of the PullSubscription. Its
+// Subscription is nil if the PullSubscription has no dead letter sink.
+type DeadLetterSubscription struct {
+	*pubsub.Subscription
+}
 
 // AdapterSet provides an adapter with a PubSub client and HTTP client.
 var AdapterSet wire.ProviderSet = wire.NewSet(
 	NewAdapter,
 	clients.NewPubsubClient,
 	NewPubSubSubscription,
+	NewPubSubDeadLetterSubscription,
 	converters.NewPubSubConverter,
 	NewStatsReporter,
 	clients.NewHTTPClient,
@@ -32,3 +40,10 @@ var AdapterSet wire.ProviderSet = wire.NewSet(
 func NewPubSubSubscription(ctx context.Context, client *pubsub.Client, subscriptionID SubscriptionID) *pubsub.Subscription {
 	return client.Subscription(string(subscriptionID))
 }
+
+func NewPubSubDeadLetterSubscription(ctx context.Context, client *pubsub.Client, subscriptionID DeadLetterSubscriptionID) DeadLetterSubscription {
+	if subscriptionID == "" {
+		return DeadLetterSubscription{}
+	}
+	return DeadLetterSubscription{Subscription: client.Subscription(string(subscriptionID))}
+}
@@ -37,6 +37,14 @@ var (
 		stats.UnitDimensionless,
 	)
 
+	// droppedEventCountM is a counter which records the number of events
+	// dropped by the filter of the receive adapter.
+	droppedEventCountM = stats.Int64(
+		"dropped_event_count",
+		"Number of events dropped by the filter",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -60,6 +68,8 @@ type ReportArgs struct {
 type StatsReporter interface {
 	// ReportEventCount captures the event count. It records one per call.
 	ReportEventCount(args *ReportArgs, responseCode int) error
+	// ReportEventDropped captures the dropped event count. It records one per call.
+	ReportEventDropped(args *ReportArgs) error
 }
 
 var _ StatsReporter = (*reporter)(nil)
@@ -94,35 +104,57 @@ func (r *reporter) ReportEventCount(args *ReportArgs, responseCode int) error {
 	return nil
 }
 
+func (r *reporter) ReportEventDropped(args *ReportArgs) error {
+	ctx, err := r.generateEventTag(args)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, droppedEventCountM.M(1))
+	return nil
+}
+
 func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
+	ctx, err := r.generateEventTag(args)
+	if err != nil {
+		return nil, err
+	}
+	return tag.New(
+		ctx,
+		tag.Insert(responseCodeKey, strconv.Itoa(responseCode)),
+		tag.Insert(responseCodeClassKey, metrics.ResponseCodeClass(responseCode)))
+}
+
+func (r *reporter) generateEventTag(args *ReportArgs) (context.Context, error) {
 	return tag.New(
 		emptyContext,
 		tag.Insert(namespaceKey, r.namespace),
 		tag.Insert(eventSourceKey, args.EventSource),
 		tag.Insert(eventTypeKey, args.EventType),
 		tag.Insert(nameKey, r.name),
-		tag.Insert(resourceGroupKey, r.resourceGroup),
-		tag.Insert(responseCodeKey, strconv.Itoa(responseCode)),
-		tag.Insert(responseCodeClassKey, metrics.ResponseCodeClass(responseCode)))
+		tag.Insert(resourceGroupKey, r.resourceGroup))
 }
 
 func (r *reporter) register() error {
-	tagKeys := []tag.Key{
+	eventTagKeys := []tag.Key{
 		namespaceKey,
 		eventSourceKey,
 		eventTypeKey,
 		nameKey,
-		resourceGroupKey,
-		responseCodeKey,
-		responseCodeClassKey}
+		resourceGroupKey}
 
-	// Create view to see our measurements.
+	// Create views to see our measurements.
 	return metrics.RegisterResourceView(
 		&view.View{
 			Description: eventCountM.Description(),
 			Measure:     eventCountM,
 			Aggregation: view.Count(),
-			TagKeys:     tagKeys,
+			TagKeys:     append(eventTagKeys, responseCodeKey, responseCodeClassKey),
+		},
+		&view.View{
+			Description: droppedEventCountM.Description(),
+			Measure:     droppedEventCountM,
+			Aggregation: view.Count(),
+			TagKeys:     eventTagKeys,
 		},
 	)
 }
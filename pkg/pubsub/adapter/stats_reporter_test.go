@@ -55,6 +55,14 @@ func TestStatsReporter(t *testing.T) {
 		return r.ReportEventCount(args, http.StatusAccepted)
 	})
 	metricstest.CheckCountData(t, "event_count", wantTags, 2)
+
+	// test ReportEventDropped
+	expectSuccess(t, func() error {
+		return r.ReportEventDropped(args)
+	})
+	delete(wantTags, metricskey.LabelResponseCode)
+	delete(wantTags, metricskey.LabelResponseCodeClass)
+	metricstest.CheckCountData(t, "dropped_event_count", wantTags, 1)
 }
 
 func expectSuccess(t *testing.T, f func() error) {
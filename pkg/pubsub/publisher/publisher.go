@@ -30,6 +30,8 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/eventing/pkg/logging"
 
+	"github.com/google/knative-gcp/pkg/utils/batch"
+
 	cev2 "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 
@@ -78,8 +80,8 @@ func (p *Publisher) Start(ctx context.Context) error {
 
 // ServeHTTP implements net/http Publisher interface method.
 // 1. Performs basic validation of the request.
-// 2. Converts the request to an event.
-// 3. Sends the event to pubsub.
+// 2. Converts the request to an event, or to events in the batched content mode.
+// 3. Sends the events to pubsub.
 func (p *Publisher) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
 	ctx := request.Context()
 	p.logger.Debug("Serving http", zap.Any("headers", request.Header))
@@ -88,6 +90,11 @@ func (p *Publisher) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.
 		return
 	}
 
+	if batch.IsBatch(request) {
+		p.serveBatch(ctx, response, request)
+		return
+	}
+
 	event, err := p.toEvent(request)
 	if err != nil {
 		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
@@ -110,6 +117,31 @@ func (p *Publisher) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.
 	response.WriteHeader(statusCode)
 }
 
+// serveBatch publishes the events of a request in the batched content mode
+// and responds with the result of each event.
+func (p *Publisher) serveBatch(ctx context.Context, response nethttp.ResponseWriter, request *nethttp.Request) {
+	events, err := batch.ReadEvents(response, request)
+	if err != nil {
+		p.logger.Debug("Failed to read batch", zap.Error(err))
+		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	results := batch.SendAll(events, nil, func(event *cev2.Event) (int, error) {
+		if res := p.Publish(ctx, event); !cev2.IsACK(res) {
+			msg := fmt.Sprintf("Error publishing to PubSub. event: %+v, err: %v.", event, res)
+			p.logger.Error(msg)
+			return nethttp.StatusInternalServerError, errors.New(msg)
+		}
+		return nethttp.StatusAccepted, nil
+	})
+	if err := batch.WriteResults(response, results); err != nil {
+		p.logger.Warn("Failed to write batch results", zap.Error(err))
+	}
+}
+
 // Publish publishes an incoming event to a pubsub topic.
 func (p *Publisher) Publish(ctx context.Context, event *cev2.Event) protocol.Result {
 	dt := extensions.FromSpanContext(trace.FromContext(ctx).SpanContext())
@@ -158,6 +158,9 @@ func (r *Reconciler) reconcileDecouplingTopicAndSubscription(ctx context.Context
 	subConfig := pubsub.SubscriptionConfig{
 		Topic:  topic,
 		Labels: labels,
+		// Message ordering can't be changed after the subscription is created, so the ordering
+		// key annotation of the broker is immutable.
+		EnableMessageOrdering: b.OrderingKeyAttribute() != "",
 		//TODO(grantr): configure these settings?
 		// AckDeadline
 		// RetentionDuration
@@ -35,6 +35,7 @@ import (
 	. "knative.dev/pkg/reconciler/testing"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1alpha1/resource"
 	brokerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/broker"
 	"github.com/google/knative-gcp/pkg/reconciler"
@@ -60,13 +61,18 @@ var (
 	brokerFinalizerUpdatedEvent = Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-broker" finalizers`)
 	brokerReconciledEvent       = Eventf(corev1.EventTypeNormal, "BrokerReconciled", `Broker reconciled: "testnamespace/test-broker"`)
 	brokerFinalizedEvent        = Eventf(corev1.EventTypeNormal, "BrokerFinalized", `Broker finalized: "testnamespace/test-broker"`)
-	ingressServiceName          = brokercellresources.Name(resources.DefaultBrokerCellName, brokercellresources.IngressName)
+	ingressServiceName          = brokercellresources.Name(brokerv1beta1.DefaultBrokerCellName, brokercellresources.IngressName)
 
 	brokerAddress = &apis.URL{
 		Scheme: "http",
 		Host:   fmt.Sprintf("%s.%s.svc.%s", ingressServiceName, systemNS, utils.GetClusterDomainName()),
 		Path:   fmt.Sprintf("/%s/%s", testNS, brokerName),
 	}
+	prodBrokerAddress = &apis.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s.%s.svc.%s", brokercellresources.Name("prod", brokercellresources.IngressName), systemNS, utils.GetClusterDomainName()),
+		Path:   fmt.Sprintf("/%s/%s", testNS, brokerName),
+	}
 )
 
 func init() {
@@ -136,8 +142,54 @@ func TestAllCases(t *testing.T) {
 				WithBrokerClass(brokerv1beta1.BrokerClass),
 				WithBrokerUID(testUID),
 				WithBrokerSetDefaults),
-			NewBrokerCell(resources.DefaultBrokerCellName, systemNS,
+			NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, systemNS,
+				WithBrokerCellReady,
+				WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				WithBrokerCellSetDefaults),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerReadyURI(brokerAddress),
+				WithBrokerDataPlaneReady,
+				WithBrokerSetDefaults,
+			),
+		}},
+		WantEvents: []string{
+			brokerFinalizerUpdatedEvent,
+			Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-bkr_testnamespace_test-broker_abc123"`),
+			Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-bkr_testnamespace_test-broker_abc123"`),
+			brokerReconciledEvent,
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, brokerFinalizerName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			TopicExists("cre-bkr_testnamespace_test-broker_abc123"),
+			SubscriptionExists("cre-bkr_testnamespace_test-broker_abc123"),
+		},
+	}, {
+		Name: "Broker handler stopped in the data plane, broker is created",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerSetDefaults),
+			NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, systemNS,
 				WithBrokerCellReady,
+				WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{
+					ProbedPods: 2,
+					UnhealthyBrokers: []inteventsv1alpha1.HandlerStatus{{
+						Namespace: testNS,
+						Name:      brokerName,
+						Message:   "Handler stopped in pods fanout-1, fanout-2: subscription does not exist",
+					}},
+				}),
 				WithBrokerCellSetDefaults),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
@@ -145,6 +197,7 @@ func TestAllCases(t *testing.T) {
 				WithBrokerClass(brokerv1beta1.BrokerClass),
 				WithBrokerUID(testUID),
 				WithBrokerReadyURI(brokerAddress),
+				WithBrokerDataPlaneFailed("HandlerStopped", "Handler stopped in pods fanout-1, fanout-2: subscription does not exist"),
 				WithBrokerSetDefaults,
 			),
 		}},
@@ -173,7 +226,7 @@ func TestAllCases(t *testing.T) {
 				WithBrokerUID(testUID),
 				WithBrokerSetDefaults,
 			),
-			NewBrokerCell(resources.DefaultBrokerCellName, systemNS,
+			NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, systemNS,
 				WithBrokerCellIngressFailed("", ""),
 				WithBrokerCellSetDefaults,
 			),
@@ -184,6 +237,7 @@ func TestAllCases(t *testing.T) {
 				WithBrokerUID(testUID),
 				WithBrokerReadyURI(brokerAddress),
 				WithBrokerBrokerCellUnknown("BrokerCellNotReady", "Brokercell knative-testing/default is not ready"),
+				WithBrokerDataPlaneUnknown("DataPlaneNotProbed", "The data plane of brokercell knative-testing/default hasn't been probed"),
 				WithBrokerSetDefaults,
 			),
 		}},
@@ -227,7 +281,7 @@ func TestAllCases(t *testing.T) {
 				),
 			},
 		},
-		WantCreates:             []runtime.Object{resources.CreateBrokerCell(nil) /*Currently brokercell doesn't require broker information*/},
+		WantCreates:             []runtime.Object{resources.CreateBrokerCell(NewBroker(brokerName, testNS, WithBrokerSetDefaults))},
 		SkipNamespaceValidation: true, // The brokercell resource is created in a different namespace (system namespace) than the broker
 		WantEvents: []string{
 			brokerFinalizerUpdatedEvent,
@@ -254,11 +308,12 @@ func TestAllCases(t *testing.T) {
 					WithBrokerUID(testUID),
 					WithBrokerReadyURI(brokerAddress),
 					WithBrokerBrokerCellUnknown("BrokerCellNotReady", "Brokercell knative-testing/default is not ready"),
+					WithBrokerDataPlaneUnknown("DataPlaneNotProbed", "The data plane of brokercell knative-testing/default hasn't been probed"),
 					WithBrokerSetDefaults,
 				),
 			},
 		},
-		WantCreates:             []runtime.Object{resources.CreateBrokerCell(nil) /*Currently brokercell doesn't require broker information*/},
+		WantCreates:             []runtime.Object{resources.CreateBrokerCell(NewBroker(brokerName, testNS, WithBrokerSetDefaults))},
 		SkipNamespaceValidation: true, // The brokercell resource is created in a different namespace (system namespace) than the broker
 		WantEvents: []string{
 			brokerFinalizerUpdatedEvent,
@@ -277,6 +332,91 @@ func TestAllCases(t *testing.T) {
 			TopicExists("cre-bkr_testnamespace_test-broker_abc123"),
 			SubscriptionExists("cre-bkr_testnamespace_test-broker_abc123"),
 		},
+	}, {
+		Name: "Create broker with brokercell annotation, the selected brokercell is used",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerCellAnnotation("prod"),
+				WithBrokerSetDefaults,
+			),
+			NewBrokerCell("prod", systemNS,
+				WithBrokerCellReady,
+				WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				WithBrokerCellSetDefaults),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithBrokerUID(testUID),
+					WithBrokerCellAnnotation("prod"),
+					WithBrokerReadyURI(prodBrokerAddress),
+					WithBrokerDataPlaneReady,
+					WithBrokerSetDefaults,
+				),
+			},
+		},
+		WantEvents: []string{
+			brokerFinalizerUpdatedEvent,
+			Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-bkr_testnamespace_test-broker_abc123"`),
+			Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-bkr_testnamespace_test-broker_abc123"`),
+			brokerReconciledEvent,
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, brokerFinalizerName),
+		},
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			TopicExists("cre-bkr_testnamespace_test-broker_abc123"),
+			SubscriptionExists("cre-bkr_testnamespace_test-broker_abc123"),
+		},
+	}, {
+		Name: "Create broker with brokercell annotation, the selected brokercell doesn't exist",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(brokerv1beta1.BrokerClass),
+				WithBrokerUID(testUID),
+				WithBrokerCellAnnotation("prod"),
+				WithBrokerSetDefaults,
+			),
+			NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, systemNS,
+				WithBrokerCellReady,
+				WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				WithBrokerCellSetDefaults),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithBrokerUID(testUID),
+					WithBrokerCellAnnotation("prod"),
+					WithInitBrokerConditions,
+					WithBrokerBrokerCellFailed("BrokerCellNotFound", "Brokercell knative-testing/prod doesn't exist"),
+					WithBrokerSetDefaults,
+				),
+			},
+		},
+		WantEvents: []string{
+			brokerFinalizerUpdatedEvent,
+			Eventf(corev1.EventTypeWarning, "InternalError", `failed to reconcile broker: brokercell reconcile failed: brokercell.internal.events.cloud.google.com "prod" not found`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, brokerFinalizerName),
+		},
+		WantErr: true,
+		OtherTestData: map[string]interface{}{
+			"pre": []PubsubAction{},
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			NoTopicsExist(),
+			NoSubscriptionsExist(),
+		},
 	}}
 
 	defer logtesting.ClearAll()
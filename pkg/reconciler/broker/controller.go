@@ -30,6 +30,7 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
@@ -93,17 +94,21 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	bcInformer.Informer().AddEventHandler(controller.HandleAll(
 		func(obj interface{}) {
-			if _, ok := obj.(*inteventsv1alpha1.BrokerCell); ok {
-				// TODO(#866) Only select brokers that point to this brokercell by label selector once the
-				// webhook assigns the brokercell label, i.e.,
-				// r.brokerLister.List(labels.SelectorFromSet(map[string]string{"brokercell":bc.Name, "brokercellns":bc.Namespace}))
+			if bc, ok := obj.(*inteventsv1alpha1.BrokerCell); ok {
+				if bc.Namespace != system.Namespace() {
+					return
+				}
 				brokers, err := brokerInformer.Lister().List(labels.Everything())
 				if err != nil {
 					r.Logger.Error("Failed to list brokers", zap.Error(err))
 					return
 				}
+				// Brokers created before the webhook assigned the brokercell label don't have it,
+				// so the brokers of the brokercell can't be listed with a label selector.
 				for _, broker := range brokers {
-					impl.Enqueue(broker)
+					if broker.BrokerCellName() == bc.Name {
+						impl.Enqueue(broker)
+					}
 				}
 			}
 		},
@@ -31,20 +31,23 @@ import (
 	"knative.dev/pkg/system"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
-	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	brokercellresources "github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 )
 
-// ensureBrokerCellExists creates a BrokerCell if it doesn't exist, and update broker status based on brokercell status.
+// ensureBrokerCellExists creates the default BrokerCell if the broker uses it and it doesn't exist, and update broker status
+// based on brokercell status. The other BrokerCells are created by the operator, so a broker can't make the controller
+// run the data plane of an arbitrary BrokerCell.
 func (r *Reconciler) ensureBrokerCellExists(ctx context.Context, b *brokerv1beta1.Broker) error {
-	var bc *inteventsv1alpha1.BrokerCell
-	var err error
-	// TODO(#866) Get brokercell based on the label (or annotation) on the broker.
-	bc, err = r.brokerCellLister.BrokerCells(system.Namespace()).Get(resources.DefaultBrokerCellName)
-
+	bc, err := r.brokerCellLister.BrokerCells(system.Namespace()).Get(b.BrokerCellName())
 	if err != nil && !apierrs.IsNotFound(err) {
 		logging.FromContext(ctx).Error("Error reconciling brokercell", zap.String("namespace", b.Namespace), zap.String("broker", b.Name), zap.Error(err))
-		b.Status.MarkBrokerCelllUnknown("BrokerCellUnknown", "Failed to get brokercell %s/%s", bc.Namespace, bc.Name)
+		b.Status.MarkBrokerCelllUnknown("BrokerCellUnknown", "Failed to get brokercell %s/%s", system.Namespace(), b.BrokerCellName())
+		return err
+	}
+
+	if apierrs.IsNotFound(err) && b.BrokerCellName() != brokerv1beta1.DefaultBrokerCellName {
+		b.Status.MarkBrokerCelllFailed("BrokerCellNotFound", "Brokercell %s/%s doesn't exist", system.Namespace(), b.BrokerCellName())
 		return err
 	}
 
@@ -77,6 +80,7 @@ func (r *Reconciler) ensureBrokerCellExists(ctx context.Context, b *brokerv1beta
 	} else {
 		b.Status.MarkBrokerCelllUnknown("BrokerCellNotReady", "Brokercell %s/%s is not ready", bc.Namespace, bc.Name)
 	}
+	propagateDataPlaneStatus(b, bc)
 
 	//TODO(#1019) Use the IngressTemplate of brokercell.
 	ingressServiceName := brokercellresources.Name(bc.Name, brokercellresources.IngressName)
@@ -88,3 +92,18 @@ func (r *Reconciler) ensureBrokerCellExists(ctx context.Context, b *brokerv1beta
 
 	return nil
 }
+
+// propagateDataPlaneStatus updates the data plane condition of the broker with the health of its
+// handlers reported in the brokercell status.
+func propagateDataPlaneStatus(b *brokerv1beta1.Broker, bc *intv1alpha1.BrokerCell) {
+	dp := bc.Status.DataPlane
+	if dp == nil {
+		b.Status.MarkDataPlaneUnknown("DataPlaneNotProbed", "The data plane of brokercell %s/%s hasn't been probed", bc.Namespace, bc.Name)
+		return
+	}
+	if hs := dp.UnhealthyBroker(b.Namespace, b.Name); hs != nil {
+		b.Status.MarkDataPlaneFailed("HandlerStopped", "%s", hs.Message)
+		return
+	}
+	b.Status.MarkDataPlaneReady()
+}
@@ -24,16 +24,13 @@ import (
 	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 )
 
-// Right now we only support one brokercell in the system namespace in the cluster.
-// TODO(#866) Delete hard-coded brokercell once we can dynamically assign brokercell to brokers.
-const DefaultBrokerCellName = "default"
-
+// CreateBrokerCell creates the BrokerCell of the Broker in the system namespace. The
+// BrokerCell is garbage collected when it no longer has Brokers.
 func CreateBrokerCell(b *v1beta1.Broker) *inteventsv1alpha1.BrokerCell {
-	// TODO(#866) Get brokercell from the label (or annotation) from the broker.
 	return &inteventsv1alpha1.BrokerCell{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   system.Namespace(),
-			Name:        DefaultBrokerCellName,
+			Name:        b.BrokerCellName(),
 			Annotations: map[string]string{inteventsv1alpha1.CreatorKey: inteventsv1alpha1.Creator},
 		},
 	}
@@ -19,10 +19,40 @@ package resources
 import (
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
+
+	"github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 )
 
-// This is already tested in broker_test.go, this test is just to make coverage tool happy.
-func TestBrokerCellCreation(t *testing.T) {
-	CreateBrokerCell(nil)
+func TestCreateBrokerCell(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{{
+		name: "default brokercell",
+		want: v1beta1.DefaultBrokerCellName,
+	}, {
+		name:        "selected brokercell",
+		annotations: map[string]string{v1beta1.BrokerCellAnnotation: "prod"},
+		want:        "prod",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &v1beta1.Broker{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			bc := CreateBrokerCell(b)
+			if bc.Name != tc.want {
+				t.Errorf("BrokerCell name got %q, want %q", bc.Name, tc.want)
+			}
+			if bc.Namespace != system.Namespace() {
+				t.Errorf("BrokerCell namespace got %q, want %q", bc.Namespace, system.Namespace())
+			}
+			if bc.Annotations[inteventsv1alpha1.CreatorKey] != inteventsv1alpha1.Creator {
+				t.Errorf("BrokerCell annotations got %v, want creator annotation", bc.Annotations)
+			}
+		})
+	}
 }
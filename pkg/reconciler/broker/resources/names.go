@@ -60,3 +60,11 @@ func GenerateRetryTopicName(t *brokerv1beta1.Trigger) string {
 func GenerateRetrySubscriptionName(t *brokerv1beta1.Trigger) string {
 	return naming.TruncatedPubsubResourceName("cre-tgr", t.Namespace, t.Name, t.UID)
 }
+
+// GenerateRetryDeadLetterTopicName generates a deterministic name for the topic
+// the retry subscription of a Trigger forwards the messages to once their
+// delivery attempts are exhausted. If the topic name would be longer than
+// allowed by PubSub, the Trigger name is truncated to fit.
+func GenerateRetryDeadLetterTopicName(t *brokerv1beta1.Trigger) string {
+	return naming.TruncatedPubsubResourceName("cre-tgr-dl", t.Namespace, t.Name, t.UID)
+}
@@ -18,11 +18,17 @@ package brokercell
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/logging"
 
@@ -33,21 +39,22 @@ import (
 	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/utils/volume"
+	"github.com/google/knative-gcp/pkg/utils"
 )
 
 const (
-	configFailed = "BrokerTargetsConfigFailed"
+	configFailed                = "BrokerTargetsConfigFailed"
+	deadLetterSinkResolveFailed = "DeadLetterSinkResolveFailed"
 )
 
-func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.BrokerCell) error {
-	// TODO(#866) Only select brokers that point to this brokercell by label selector once the
-	// webhook assigns the brokercell label, i.e.,
-	// r.brokerLister.List(labels.SelectorFromSet(map[string]string{"brokercell":bc.Name, "brokercellns":bc.Namespace}))
-	brokers, err := r.brokerLister.List(labels.Everything())
+// reconcileConfig reconciles the broker targets configmap of the brokercell, and returns the
+// targets in it.
+func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.BrokerCell) (config.ReadonlyTargets, error) {
+	brokers, err := r.listBrokers(bc)
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to list brokers", zap.Error(err))
 		bc.Status.MarkTargetsConfigFailed(configFailed, "failed to list brokers: %v", err)
-		return err
+		return nil, err
 	}
 	// Start with a fresh config and add brokers/triggers into it. This approach is straightforward and reliable,
 	// however not efficient if there are too many triggers. If performance becomes an issue, we can consider
@@ -61,21 +68,94 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, bc *intv1alpha1.Broker
 		if err != nil {
 			logging.FromContext(ctx).Error("Failed to list triggers", zap.String("Broker", broker.Name), zap.Error(err))
 			bc.Status.MarkTargetsConfigFailed(configFailed, "failed to list triggers for broker %v: %v", broker.Name, err)
-			return err
+			return nil, err
 		}
-		r.addToConfig(ctx, broker, triggers, brokerTargets)
+		r.addToConfig(ctx, bc, broker, triggers, brokerTargets)
 	}
 	if err := r.updateTargetsConfig(ctx, bc, brokerTargets); err != nil {
 		logging.FromContext(ctx).Error("Failed to update broker targets configmap", zap.Error(err))
 		bc.Status.MarkTargetsConfigFailed(configFailed, "failed to update configmap: %v", err)
-		return err
+		return nil, err
+	}
+	if err := r.reconcileTargetCerts(ctx, bc, brokerTargets); err != nil {
+		logging.FromContext(ctx).Error("Failed to update target certificates secret", zap.Error(err))
+		bc.Status.MarkTargetsConfigFailed(configFailed, "failed to update target certificates secret: %v", err)
+		return nil, err
 	}
 	bc.Status.MarkTargetsConfigReady()
-	return nil
+	return brokerTargets, nil
+}
+
+// resolveDeliverySpec converts the delivery spec of the given trigger into the delivery spec of
+// its target. The fields set in the trigger's delivery annotation override the broker's delivery
+// spec. A dead letter sink that cannot be resolved leaves the target without a delivery spec so
+// that events keep being retried instead of being dropped.
+func (r *Reconciler) resolveDeliverySpec(ctx context.Context, bc *intv1alpha1.BrokerCell, b *brokerv1beta1.Broker, t *brokerv1beta1.Trigger, triggerDelivery *eventingduckv1beta1.DeliverySpec) *config.DeliverySpec {
+	delivery := mergeDeliverySpecs(b.Spec.Delivery, triggerDelivery)
+	if delivery == nil {
+		return nil
+	}
+	ds := &config.DeliverySpec{}
+	if delivery.Retry != nil {
+		ds.Retry = *delivery.Retry
+	}
+	if delivery.BackoffPolicy != nil && *delivery.BackoffPolicy == eventingduckv1beta1.BackoffPolicyLinear {
+		ds.BackoffPolicy = config.BackoffPolicy_LINEAR
+	}
+	if delivery.BackoffDelay != nil {
+		// The broker's backoff delay is only validated by the eventing webhook, which doesn't
+		// validate it as a duration. Ignore it rather than failing the whole config.
+		if d, err := utils.ParseISO8601Duration(*delivery.BackoffDelay); err != nil {
+			logging.FromContext(ctx).Warn("Ignoring invalid backoff delay", zap.String("Trigger", t.Name), zap.Error(err))
+		} else {
+			ds.BackoffDelay = ptypes.DurationProto(d)
+		}
+	}
+	if dls := delivery.DeadLetterSink; dls != nil {
+		if dls.Ref != nil && dls.Ref.Namespace == "" {
+			// To call URIFromDestinationV1, dest.Ref must have a Namespace.
+			// We will use the Namespace of the Trigger as the Namespace of dest.Ref.
+			dls = dls.DeepCopy()
+			dls.Ref.Namespace = t.Namespace
+		}
+		uri, err := r.uriResolver.URIFromDestinationV1(*dls, t)
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to get the dead letter sink's URI", zap.String("Trigger", t.Name), zap.Error(err))
+			r.Recorder.Eventf(bc, corev1.EventTypeWarning, deadLetterSinkResolveFailed, "Unable to get the dead letter sink's URI for trigger %s/%s: %v", t.Namespace, t.Name, err)
+			return nil
+		}
+		ds.DeadLetter = uri.String()
+	}
+	return ds
+}
+
+// mergeDeliverySpecs returns the broker's delivery spec overridden by the fields set in the
+// trigger's delivery spec.
+func mergeDeliverySpecs(broker, trigger *eventingduckv1beta1.DeliverySpec) *eventingduckv1beta1.DeliverySpec {
+	if trigger == nil {
+		return broker
+	}
+	if broker == nil {
+		return trigger
+	}
+	merged := broker.DeepCopy()
+	if trigger.DeadLetterSink != nil {
+		merged.DeadLetterSink = trigger.DeadLetterSink
+	}
+	if trigger.Retry != nil {
+		merged.Retry = trigger.Retry
+	}
+	if trigger.BackoffPolicy != nil {
+		merged.BackoffPolicy = trigger.BackoffPolicy
+	}
+	if trigger.BackoffDelay != nil {
+		merged.BackoffDelay = trigger.BackoffDelay
+	}
+	return merged
 }
 
 // addToConfig reconstructs the data entry for the given broker and add it to targets-config.
-func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, triggers []*brokerv1beta1.Trigger, brokerTargets config.Targets) {
+func (r *Reconciler) addToConfig(ctx context.Context, bc *intv1alpha1.BrokerCell, b *brokerv1beta1.Broker, triggers []*brokerv1beta1.Trigger, brokerTargets config.Targets) {
 	// TODO Maybe get rid of BrokerMutation and add Delete() and Upsert(broker) methods to TargetsConfig. Now we always
 	//  delete or update the entire broker entry and we don't need partial updates per trigger.
 	// The code can be simplified to r.targetsConfig.Upsert(brokerConfigEntry)
@@ -90,6 +170,20 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 			Topic:        brokerresources.GenerateDecouplingTopicName(b),
 			Subscription: brokerresources.GenerateDecouplingSubscriptionName(b),
 		})
+		m.SetOrderingKeyAttribute(b.OrderingKeyAttribute())
+		allowedIdentities := b.AllowedIdentities()
+		if len(r.env.IngressAuthIssuers) > 0 {
+			// The fanout and retry send the replies of the Triggers to the Broker.
+			allowedIdentities = append(allowedIdentities, r.dataPlaneIdentity(bc))
+		}
+		m.SetAllowedIdentities(allowedIdentities)
+		m.SetRateLimits(rateLimitConfig(b.RateLimit()), rateLimitConfig(b.SourceRateLimit()))
+		if schemas, err := b.EventSchemas(); err != nil {
+			// The webhook rejects invalid schemas.
+			logging.FromContext(ctx).Error("Invalid broker event schemas", zap.String("Broker", b.Name), zap.Error(err))
+		} else {
+			m.SetEventSchemas(eventSchemasConfig(schemas))
+		}
 		if b.Status.IsReady() {
 			m.SetState(config.State_READY)
 		} else {
@@ -99,6 +193,38 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 		// Insert each Trigger to the config.
 		for _, t := range triggers {
 			if t.Spec.Broker == b.Name {
+				filters, err := t.Filters()
+				if err != nil {
+					// The webhook rejects invalid filters. Leave the trigger out rather
+					// than delivering events it did not ask for.
+					logging.FromContext(ctx).Error("Invalid trigger filters", zap.String("Trigger", t.Name), zap.Error(err))
+					continue
+				}
+				delivery, err := t.DeliverySpec()
+				if err != nil {
+					// The webhook rejects invalid delivery specs as well.
+					logging.FromContext(ctx).Error("Invalid trigger delivery spec", zap.String("Trigger", t.Name), zap.Error(err))
+					continue
+				}
+				reply, err := t.ReplySpec()
+				if err != nil {
+					// The webhook rejects invalid reply specs as well.
+					logging.FromContext(ctx).Error("Invalid trigger reply spec", zap.String("Trigger", t.Name), zap.Error(err))
+					continue
+				}
+				transport, err := t.TransportSpec()
+				if err != nil {
+					// The webhook rejects invalid transport specs as well.
+					logging.FromContext(ctx).Error("Invalid trigger transport spec", zap.String("Trigger", t.Name), zap.Error(err))
+					continue
+				}
+				replyURI := t.Status.ReplyURI()
+				if reply != nil && reply.Destination != nil && replyURI == nil {
+					// Leave the trigger out rather than sending its replies to the wrong
+					// destination until the trigger reconciler resolves the reply destination.
+					logging.FromContext(ctx).Info("Trigger reply destination is not resolved yet", zap.String("Trigger", t.Name))
+					continue
+				}
 				target := &config.Target{
 					Id:        string(t.UID),
 					Name:      t.Name,
@@ -113,6 +239,15 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 				if t.Spec.Filter != nil && t.Spec.Filter.Attributes != nil {
 					target.FilterAttributes = t.Spec.Filter.Attributes
 				}
+				target.Filters = resources.MakeTargetFilters(filters)
+				target.DeliverySpec = r.resolveDeliverySpec(ctx, bc, b, t, delivery)
+				if reply != nil {
+					target.DropReplies = reply.Drop
+					if reply.Destination != nil {
+						target.ReplyAddress = replyURI.String()
+					}
+				}
+				target.Transport = transportConfig(t.Namespace, transport)
 				// TODO(#939) May need to use "data plane readiness" for trigger in stead of the
 				//  overall status, see https://github.com/google/knative-gcp/issues/939#issuecomment-644337937
 				if t.Status.IsReady() {
@@ -126,7 +261,69 @@ func (r *Reconciler) addToConfig(ctx context.Context, b *brokerv1beta1.Broker, t
 	})
 }
 
-//TODO all this stuff should be in a configmap variant of the config object
+// TODO all this stuff should be in a configmap variant of the config object
+// rateLimitConfig converts a Broker rate limit to its representation in the targets config.
+func rateLimitConfig(l *brokerv1beta1.RateLimit) *config.RateLimit {
+	if l == nil {
+		return nil
+	}
+	return &config.RateLimit{
+		EventsPerSecond: l.EventsPerSecond,
+		Burst:           int32(l.Burst),
+	}
+}
+
+// transportConfig converts a Trigger transport spec to its representation in the targets config.
+// The client certificate is named after the Secret in the Trigger's namespace, see
+// reconcileTargetCerts.
+func transportConfig(namespace string, ts *brokerv1beta1.TransportSpec) *config.Transport {
+	if ts == nil {
+		return nil
+	}
+	t := &config.Transport{H2C: ts.H2C}
+	if ts.MaxIdleConnsPerHost != nil {
+		t.MaxIdleConnsPerHost = *ts.MaxIdleConnsPerHost
+	}
+	// The webhook rejects invalid durations.
+	if ts.IdleConnTimeout != nil {
+		if d, err := utils.ParseISO8601Duration(*ts.IdleConnTimeout); err == nil {
+			t.IdleConnTimeout = ptypes.DurationProto(d)
+		}
+	}
+	if ts.KeepAlive != nil {
+		if d, err := utils.ParseISO8601Duration(*ts.KeepAlive); err == nil {
+			t.KeepAlive = ptypes.DurationProto(d)
+		}
+	}
+	if ts.TLS != nil {
+		t.Tls = &config.TransportTLS{CaBundle: ts.TLS.CABundle}
+		if ts.TLS.ClientCertificateSecret != "" {
+			t.Tls.ClientCertificate = resources.TargetCertName(namespace, ts.TLS.ClientCertificateSecret)
+		}
+	}
+	return t
+}
+
+func eventSchemasConfig(s *brokerv1beta1.EventSchemas) *config.EventSchemas {
+	if s == nil {
+		return nil
+	}
+	toStrings := func(raw map[string]json.RawMessage) map[string]string {
+		if len(raw) == 0 {
+			return nil
+		}
+		m := make(map[string]string, len(raw))
+		for k, v := range raw {
+			m[k] = string(v)
+		}
+		return m
+	}
+	return &config.EventSchemas{
+		Types:       toStrings(s.Types),
+		DataSchemas: toStrings(s.DataSchemas),
+	}
+}
+
 func (r *Reconciler) updateTargetsConfig(ctx context.Context, bc *intv1alpha1.BrokerCell, brokerTargets config.Targets) error {
 	desired, err := resources.MakeTargetsConfig(bc, brokerTargets)
 	if err != nil {
@@ -145,6 +342,63 @@ func (r *Reconciler) updateTargetsConfig(ctx context.Context, bc *intv1alpha1.Br
 	return err
 }
 
+// reconcileTargetCerts copies the client certificates of the targets from the Secrets in their
+// Triggers' namespaces into the target certificates Secret of the brokercell, which is mounted
+// by the fanout and retry pods. A Trigger can thus only present the certificates of its own
+// namespace. The Secret is only created once a target has a client certificate.
+func (r *Reconciler) reconcileTargetCerts(ctx context.Context, bc *intv1alpha1.BrokerCell, brokerTargets config.ReadonlyTargets) error {
+	data := make(map[string][]byte)
+	var err error
+	brokerTargets.RangeAllTargets(func(t *config.Target) bool {
+		if t.Transport == nil || t.Transport.Tls == nil || t.Transport.Tls.ClientCertificate == "" {
+			return true
+		}
+		cert := t.Transport.Tls.ClientCertificate
+		if _, ok := data[cert+".crt"]; ok {
+			return true
+		}
+		name := strings.TrimPrefix(cert, t.Namespace+".")
+		var secret *corev1.Secret
+		secret, err = r.secretLister.Secrets(t.Namespace).Get(name)
+		if apierrs.IsNotFound(err) {
+			// The target keeps failing the TLS handshake until the Secret is created, which
+			// enqueues the brokercell again.
+			logging.FromContext(ctx).Warn("Client certificate secret not found", zap.String("namespace", t.Namespace), zap.String("secret", name))
+			err = nil
+			return true
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to get secret %s/%s: %w", t.Namespace, name, err)
+			return false
+		}
+		crt, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		if secret.Type != corev1.SecretTypeTLS || len(crt) == 0 || len(key) == 0 {
+			logging.FromContext(ctx).Warn("Client certificate secret is not a valid TLS secret", zap.String("namespace", t.Namespace), zap.String("secret", name))
+			return true
+		}
+		data[cert+".crt"] = crt
+		data[cert+".key"] = key
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	desired := resources.MakeTargetCertsSecret(bc, data)
+	if len(data) == 0 {
+		// Keep an existing Secret up to date, so that removed certificates are not presented.
+		if _, err := r.secretLister.Secrets(desired.Namespace).Get(desired.Name); apierrs.IsNotFound(err) {
+			return nil
+		}
+	}
+	handlerFuncs := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { r.refreshPodVolume(ctx, bc) },
+		UpdateFunc: func(oldObj, newObj interface{}) { r.refreshPodVolume(ctx, bc) },
+	}
+	_, err = r.secretRec.ReconcileSecret(bc, desired, handlerFuncs)
+	return err
+}
+
 func (r *Reconciler) refreshPodVolume(ctx context.Context, bc *intv1alpha1.BrokerCell) {
 	if err := volume.UpdateVolumeGeneration(r.KubeClientSet, r.podLister, bc.Namespace, resources.CommonLabels(bc.Name)); err != nil {
 		// Failing to update the annotation on the data plane pods means there
@@ -19,13 +19,18 @@ package brokercell
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	hpav2beta2listers "k8s.io/client-go/listers/autoscaling/v2beta2"
@@ -33,12 +38,17 @@ import (
 	"knative.dev/eventing/pkg/logging"
 	"knative.dev/eventing/pkg/reconciler/names"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 
+	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	"github.com/google/knative-gcp/pkg/broker/config"
 	bcreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
 	brokerlisters "github.com/google/knative-gcp/pkg/client/listers/broker/v1beta1"
+	metadataClient "github.com/google/knative-gcp/pkg/gclient/metadata"
 	"github.com/google/knative-gcp/pkg/reconciler"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+	"github.com/google/knative-gcp/pkg/utils"
 )
 
 type envConfig struct {
@@ -48,6 +58,10 @@ type envConfig struct {
 	ServiceAccountName string `envconfig:"SERVICE_ACCOUNT" default:"broker"`
 	IngressPort        int    `envconfig:"INGRESS_PORT" default:"8080"`
 	MetricsPort        int    `envconfig:"METRICS_PORT" default:"9090"`
+	// IngressAuthIssuers are the OIDC issuers trusted by the ingress to authenticate
+	// callers. If empty, the ingress accepts requests from any caller.
+	IngressAuthIssuers  []string `envconfig:"INGRESS_AUTH_ISSUERS"`
+	IngressAuthAudience string   `envconfig:"INGRESS_AUTH_AUDIENCE"`
 }
 
 type listers struct {
@@ -59,6 +73,7 @@ type listers struct {
 	endpointsLister  corev1listers.EndpointsLister
 	deploymentLister appsv1listers.DeploymentLister
 	podLister        corev1listers.PodLister
+	secretLister     corev1listers.SecretLister
 }
 
 // NewReconciler creates a new BrokerCell reconciler.
@@ -83,6 +98,11 @@ func NewReconciler(base *reconciler.Base, ls listers) (*Reconciler, error) {
 		Lister:     ls.configMapLister,
 		Recorder:   base.Recorder,
 	}
+	secretRec := &reconciler.SecretReconciler{
+		KubeClient: base.KubeClientSet,
+		Lister:     ls.secretLister,
+		Recorder:   base.Recorder,
+	}
 	r := &Reconciler{
 		Base:          base,
 		env:           env,
@@ -90,6 +110,7 @@ func NewReconciler(base *reconciler.Base, ls listers) (*Reconciler, error) {
 		svcRec:        svcRec,
 		deploymentRec: deploymentRec,
 		cmRec:         cmRec,
+		secretRec:     secretRec,
 	}
 	return r, nil
 }
@@ -103,6 +124,14 @@ type Reconciler struct {
 	svcRec        *reconciler.ServiceReconciler
 	deploymentRec *reconciler.DeploymentReconciler
 	cmRec         *reconciler.ConfigMapReconciler
+	secretRec     *reconciler.SecretReconciler
+
+	// uriResolver resolves the dead letter sinks of brokers.
+	uriResolver *resolver.URIResolver
+
+	// projectID is the project of the subscriptions whose backlog the fanout and retry are
+	// scaled on. If empty, it is read from the metadata server.
+	projectID string
 
 	env envConfig
 }
@@ -125,10 +154,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 	}
 
 	bc.Status.InitializeConditions()
+	// Default the components which the webhook didn't, e.g. of brokercells created before the
+	// components were configurable.
+	bc.SetDefaults(ctx)
 
 	// Reconcile broker targets configmap first so that data plane pods are guaranteed to have the configmap volume
 	// mount available.
-	if err := r.reconcileConfig(ctx, bc); err != nil {
+	targets, err := r.reconcileConfig(ctx, bc)
+	if err != nil {
 		return err
 	}
 
@@ -158,7 +191,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 	hostName := names.ServiceHostName(endpoints.GetName(), endpoints.GetNamespace())
 	bc.Status.IngressTemplate = fmt.Sprintf("http://%s/{namespace}/{name}", hostName)
 
-	// Reconcile fanout deployment and HPA.
+	// Reconcile fanout deployment and its autoscaling.
 	fd, err := r.deploymentRec.ReconcileDeployment(bc, resources.MakeFanoutDeployment(r.makeFanoutArgs(bc)))
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to reconcile fanout deployment", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
@@ -166,15 +199,23 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 		return err
 	}
 
-	fanoutHPA := resources.MakeHorizontalPodAutoscaler(fd, r.makeFanoutHPAArgs(bc))
-	if err := r.reconcileAutoscaling(ctx, bc, fanoutHPA); err != nil {
-		logging.FromContext(ctx).Error("Failed to reconcile fanout HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
-		bc.Status.MarkFanoutFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile fanout HorizontalPodAutoscaler: %v", err)
-		return err
+	if args := r.makeFanoutScaledObjectArgs(bc, targets); args != nil {
+		if err := r.reconcileScaledObject(ctx, bc, fd, *args); err != nil {
+			logging.FromContext(ctx).Error("Failed to reconcile fanout ScaledObject", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+			bc.Status.MarkFanoutFailed("ScaledObjectFailed", "Failed to reconcile fanout ScaledObject: %v", err)
+			return err
+		}
+	} else {
+		fanoutHPA := resources.MakeHorizontalPodAutoscaler(fd, r.makeFanoutHPAArgs(bc))
+		if err := r.reconcileAutoscaling(ctx, bc, fanoutHPA); err != nil {
+			logging.FromContext(ctx).Error("Failed to reconcile fanout HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+			bc.Status.MarkFanoutFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile fanout HorizontalPodAutoscaler: %v", err)
+			return err
+		}
 	}
 	bc.Status.PropagateFanoutAvailability(fd)
 
-	// Reconcile retry deployment and HPA.
+	// Reconcile retry deployment and its autoscaling.
 	rd, err := r.deploymentRec.ReconcileDeployment(bc, resources.MakeRetryDeployment(r.makeRetryArgs(bc)))
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to reconcile retry deployment", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
@@ -182,11 +223,19 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 		return err
 	}
 
-	retryHPA := resources.MakeHorizontalPodAutoscaler(rd, r.makeRetryHPAArgs(bc))
-	if err := r.reconcileAutoscaling(ctx, bc, retryHPA); err != nil {
-		logging.FromContext(ctx).Error("Failed to reconcile retry HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
-		bc.Status.MarkRetryFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile retry HorizontalPodAutoscaler: %v", err)
-		return err
+	if args := r.makeRetryScaledObjectArgs(bc, targets); args != nil {
+		if err := r.reconcileScaledObject(ctx, bc, rd, *args); err != nil {
+			logging.FromContext(ctx).Error("Failed to reconcile retry ScaledObject", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+			bc.Status.MarkRetryFailed("ScaledObjectFailed", "Failed to reconcile retry ScaledObject: %v", err)
+			return err
+		}
+	} else {
+		retryHPA := resources.MakeHorizontalPodAutoscaler(rd, r.makeRetryHPAArgs(bc))
+		if err := r.reconcileAutoscaling(ctx, bc, retryHPA); err != nil {
+			logging.FromContext(ctx).Error("Failed to reconcile retry HPA", zap.Any("namespace", bc.Namespace), zap.Any("name", bc.Name), zap.Error(err))
+			bc.Status.MarkRetryFailed("HorizontalPodAutoscalerFailed", "Failed to reconcile retry HorizontalPodAutoscaler: %v", err)
+			return err
+		}
 	}
 	bc.Status.PropagateRetryAvailability(rd)
 
@@ -199,16 +248,12 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, bc *intv1alpha1.BrokerCe
 // internal.events.cloud.google.com/creator: googlecloud), and
 // 2. there is no brokers pointing to it
 func (r *Reconciler) shouldGC(ctx context.Context, bc *intv1alpha1.BrokerCell) bool {
-	// TODO use the constants in #1132 once it's merged
 	// We only garbage collect brokercells that were automatically created by the GCP broker controller.
-	if bc.GetAnnotations()["internal.events.cloud.google.com/creator"] != "googlecloud" {
+	if bc.GetAnnotations()[intv1alpha1.CreatorKey] != intv1alpha1.Creator {
 		return false
 	}
 
-	// TODO(#866) Only select brokers that point to this brokercell by label selector once the
-	// webhook assigns the brokercell label, i.e.,
-	// r.brokerLister.List(labels.SelectorFromSet(map[string]string{"brokercell":bc.Name, "brokercellns":bc.Namespace}))
-	brokers, err := r.brokerLister.List(labels.Everything())
+	brokers, err := r.listBrokers(bc)
 	if err != nil {
 		logging.FromContext(ctx).Error("Failed to list brokers, skipping garbage collection logic", zap.String("brokercell", bc.Name), zap.String("Namespace", bc.Namespace))
 		return false
@@ -217,6 +262,23 @@ func (r *Reconciler) shouldGC(ctx context.Context, bc *intv1alpha1.BrokerCell) b
 	return len(brokers) == 0
 }
 
+// listBrokers lists the brokers assigned to the brokercell.
+func (r *Reconciler) listBrokers(bc *intv1alpha1.BrokerCell) ([]*brokerv1beta1.Broker, error) {
+	// Brokers created before the webhook assigned the brokercell label don't have it, so the
+	// brokers are selected by their brokercell name rather than by a label selector.
+	brokers, err := r.brokerLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var assigned []*brokerv1beta1.Broker
+	for _, b := range brokers {
+		if b.BrokerCellName() == bc.Name {
+			assigned = append(assigned, b)
+		}
+	}
+	return assigned, nil
+}
+
 func (r *Reconciler) delete(ctx context.Context, bc *intv1alpha1.BrokerCell) pkgreconciler.Event {
 	if err := r.RunClientSet.InternalV1alpha1().BrokerCells(bc.Namespace).Delete(bc.Name, nil); err != nil {
 		return fmt.Errorf("failed to garbage collect brokercell: %w", err)
@@ -226,82 +288,141 @@ func (r *Reconciler) delete(ctx context.Context, bc *intv1alpha1.BrokerCell) pkg
 
 func (r *Reconciler) makeIngressArgs(bc *intv1alpha1.BrokerCell) resources.IngressArgs {
 	return resources.IngressArgs{
-		Args: resources.Args{
-			ComponentName:      resources.IngressName,
-			BrokerCell:         bc,
-			Image:              r.env.IngressImage,
-			ServiceAccountName: r.env.ServiceAccountName,
-			MetricsPort:        r.env.MetricsPort,
-		},
-		Port: r.env.IngressPort,
+		Args:         r.makeArgs(bc, resources.IngressName, r.env.IngressImage, bc.Spec.Components.Ingress),
+		Port:         r.env.IngressPort,
+		AuthIssuers:  r.env.IngressAuthIssuers,
+		AuthAudience: r.env.IngressAuthAudience,
 	}
 }
 
 func (r *Reconciler) makeIngressHPAArgs(bc *intv1alpha1.BrokerCell) resources.AutoscalingArgs {
-	return resources.AutoscalingArgs{
-		ComponentName:     resources.IngressName,
-		BrokerCell:        bc,
-		AvgCPUUtilization: 95,
-		AvgMemoryUsage:    "700Mi",
-		MaxReplicas:       10,
-	}
+	return makeHPAArgs(bc, resources.IngressName, bc.Spec.Components.Ingress)
 }
 
 func (r *Reconciler) makeFanoutArgs(bc *intv1alpha1.BrokerCell) resources.FanoutArgs {
 	return resources.FanoutArgs{
-		Args: resources.Args{
-			ComponentName:      resources.FanoutName,
-			BrokerCell:         bc,
-			Image:              r.env.FanoutImage,
-			ServiceAccountName: r.env.ServiceAccountName,
-			MetricsPort:        r.env.MetricsPort,
-		},
+		Args:               r.makeArgs(bc, resources.FanoutName, r.env.FanoutImage, bc.Spec.Components.Fanout),
+		ReplyTokenAudience: r.replyTokenAudience(),
 	}
 }
 
 func (r *Reconciler) makeFanoutHPAArgs(bc *intv1alpha1.BrokerCell) resources.AutoscalingArgs {
-	return resources.AutoscalingArgs{
-		ComponentName:     resources.FanoutName,
-		BrokerCell:        bc,
-		AvgCPUUtilization: 95,
-		// The limit we set is 3000Mi which is mostly used to prevent surging
-		// memory usage causing OOM.
-		// Here we only set half of the limit so that in case of surging memory
-		// usage, HPA could have enough time to kick in.
-		// See: https://github.com/google/knative-gcp/issues/1265
-		AvgMemoryUsage: "1500Mi",
-		MaxReplicas:    10,
-	}
+	return makeHPAArgs(bc, resources.FanoutName, bc.Spec.Components.Fanout)
 }
 
 func (r *Reconciler) makeRetryArgs(bc *intv1alpha1.BrokerCell) resources.RetryArgs {
 	return resources.RetryArgs{
-		Args: resources.Args{
-			ComponentName:      resources.RetryName,
-			BrokerCell:         bc,
-			Image:              r.env.RetryImage,
-			ServiceAccountName: r.env.ServiceAccountName,
-			MetricsPort:        r.env.MetricsPort,
-		},
+		Args:               r.makeArgs(bc, resources.RetryName, r.env.RetryImage, bc.Spec.Components.Retry),
+		ReplyTokenAudience: r.replyTokenAudience(),
 	}
 }
 
 func (r *Reconciler) makeRetryHPAArgs(bc *intv1alpha1.BrokerCell) resources.AutoscalingArgs {
+	return makeHPAArgs(bc, resources.RetryName, bc.Spec.Components.Retry)
+}
+
+// makeFanoutScaledObjectArgs makes the arguments to scale the fanout on the backlog of the
+// decouple subscriptions of the brokers, or returns nil if the fanout is scaled by its HPA.
+func (r *Reconciler) makeFanoutScaledObjectArgs(bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) *resources.ScaledObjectArgs {
+	var subscriptions []string
+	targets.RangeBrokers(func(b *config.Broker) bool {
+		subscriptions = append(subscriptions, b.DecoupleQueue.GetSubscription())
+		return true
+	})
+	return makeScaledObjectArgs(bc, resources.FanoutName, bc.Spec.Components.Fanout, subscriptions)
+}
+
+// makeRetryScaledObjectArgs makes the arguments to scale the retry on the backlog of the retry
+// subscriptions of the triggers, or returns nil if the retry is scaled by its HPA.
+func (r *Reconciler) makeRetryScaledObjectArgs(bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) *resources.ScaledObjectArgs {
+	var subscriptions []string
+	targets.RangeAllTargets(func(t *config.Target) bool {
+		subscriptions = append(subscriptions, t.RetryQueue.GetSubscription())
+		return true
+	})
+	return makeScaledObjectArgs(bc, resources.RetryName, bc.Spec.Components.Retry, subscriptions)
+}
+
+// replyTokenAudience returns the audience of the token the fanout and retry send with replies to
+// the ingress, or an empty string if the ingress doesn't authenticate its callers.
+func (r *Reconciler) replyTokenAudience() string {
+	if len(r.env.IngressAuthIssuers) == 0 {
+		return ""
+	}
+	return r.env.IngressAuthAudience
+}
+
+// dataPlaneIdentity returns the identity of the fanout and retry of the BrokerCell, which is
+// allowed to send replies to its Brokers if the ingress authenticates its callers.
+func (r *Reconciler) dataPlaneIdentity(bc *intv1alpha1.BrokerCell) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", bc.Namespace, r.env.ServiceAccountName)
+}
+
+// makeArgs makes the deployment arguments of the component from its parameters in the
+// defaulted BrokerCell spec.
+func (r *Reconciler) makeArgs(bc *intv1alpha1.BrokerCell, componentName, image string, params *intv1alpha1.ComponentParameters) resources.Args {
+	return resources.Args{
+		ComponentName:      componentName,
+		BrokerCell:         bc,
+		Image:              image,
+		ServiceAccountName: r.env.ServiceAccountName,
+		MetricsPort:        r.env.MetricsPort,
+		Resources:          params.Resources,
+		Env:                params.Env,
+		NodeSelector:       params.NodeSelector,
+		Tolerations:        params.Tolerations,
+		Affinity:           params.Affinity,
+		PodAnnotations:     params.PodAnnotations,
+	}
+}
+
+// makeScaledObjectArgs makes the backlog autoscaling arguments of the component from its
+// parameters in the defaulted BrokerCell spec. The subscriptions are sorted so that the
+// ScaledObject doesn't change with the iteration order of the targets. It returns nil if the
+// component doesn't have backlog autoscaling, or if it doesn't have subscriptions yet, as there
+// is no backlog to scale on. The component is then scaled by its HPA, so that it always has an
+// autoscaler.
+func makeScaledObjectArgs(bc *intv1alpha1.BrokerCell, componentName string, params *intv1alpha1.ComponentParameters, subscriptions []string) *resources.ScaledObjectArgs {
+	if params.BacklogAutoscaling == nil || len(subscriptions) == 0 {
+		return nil
+	}
+	sort.Strings(subscriptions)
+	return &resources.ScaledObjectArgs{
+		ComponentName:           componentName,
+		BrokerCell:              bc,
+		MinReplicas:             *params.MinReplicas,
+		MaxReplicas:             *params.MaxReplicas,
+		PollingInterval:         *params.BacklogAutoscaling.PollingInterval,
+		CooldownPeriod:          *params.BacklogAutoscaling.CooldownPeriod,
+		SubscriptionSize:        *params.BacklogAutoscaling.SubscriptionSize,
+		OldestUnackedMessageAge: *params.BacklogAutoscaling.OldestUnackedMessageAge,
+		Subscriptions:           subscriptions,
+	}
+}
+
+// makeHPAArgs makes the autoscaling arguments of the component from its parameters in the
+// defaulted BrokerCell spec.
+func makeHPAArgs(bc *intv1alpha1.BrokerCell, componentName string, params *intv1alpha1.ComponentParameters) resources.AutoscalingArgs {
+	// A component with backlog autoscaling but without subscriptions can have 0 minReplicas,
+	// but an HPA can't scale a deployment to zero.
+	minReplicas := *params.MinReplicas
+	if minReplicas < 1 {
+		minReplicas = 1
+	}
 	return resources.AutoscalingArgs{
-		ComponentName:     resources.RetryName,
+		ComponentName:     componentName,
 		BrokerCell:        bc,
-		AvgCPUUtilization: 95,
-		// The limit we set is 3000Mi which is mostly used to prevent surging
-		// memory usage causing OOM.
-		// Here we only set half of the limit so that in case of surging memory
-		// usage, HPA could have enough time to kick in.
-		// See: https://github.com/google/knative-gcp/issues/1265
-		AvgMemoryUsage: "1500Mi",
-		MaxReplicas:    10,
+		AvgCPUUtilization: *params.AvgCPUUtilization,
+		AvgMemoryUsage:    params.AvgMemoryUsage.String(),
+		MinReplicas:       minReplicas,
+		MaxReplicas:       *params.MaxReplicas,
 	}
 }
 
 func (r *Reconciler) reconcileAutoscaling(ctx context.Context, bc *intv1alpha1.BrokerCell, desired *hpav2beta2.HorizontalPodAutoscaler) error {
+	if err := r.deleteScaledObject(ctx, bc, desired.Namespace, desired.Spec.ScaleTargetRef.Name, *desired.Spec.MinReplicas); err != nil {
+		return err
+	}
 	existing, err := r.hpaLister.HorizontalPodAutoscalers(desired.Namespace).Get(desired.Name)
 	if apierrs.IsNotFound(err) {
 		existing, err = r.KubeClientSet.AutoscalingV2beta2().HorizontalPodAutoscalers(desired.Namespace).Create(desired)
@@ -329,3 +450,109 @@ func (r *Reconciler) reconcileAutoscaling(ctx context.Context, bc *intv1alpha1.B
 	}
 	return nil
 }
+
+// reconcileScaledObject scales the deployment on the backlog of the subscriptions with a KEDA
+// ScaledObject, authenticated by the TriggerAuthentication of the brokercell. The HPA made when
+// the deployment is scaled on its CPU and memory usage is deleted as KEDA manages its own HPA.
+func (r *Reconciler) reconcileScaledObject(ctx context.Context, bc *intv1alpha1.BrokerCell, deployment *appsv1.Deployment, args resources.ScaledObjectArgs) error {
+	hpaName := resources.HorizontalPodAutoscalerName(deployment.Name)
+	if _, err := r.hpaLister.HorizontalPodAutoscalers(deployment.Namespace).Get(hpaName); err == nil {
+		err := r.KubeClientSet.AutoscalingV2beta2().HorizontalPodAutoscalers(deployment.Namespace).Delete(hpaName, &metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		r.Recorder.Eventf(bc, corev1.EventTypeNormal, "HorizontalPodAutoscalerDeleted", "Deleted HPA %s/%s", deployment.Namespace, hpaName)
+	} else if !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	// Get the project from the metadata server if it isn't set.
+	projectID, err := utils.ProjectID(r.projectID, metadataClient.NewDefaultMetadataClient())
+	if err != nil {
+		return fmt.Errorf("failed to get the project of the subscriptions: %w", err)
+	}
+	args.ProjectID = projectID
+	if err := r.reconcileKedaObject(bc, resources.MakeTriggerAuthentication(bc)); err != nil {
+		return err
+	}
+	return r.reconcileKedaObject(bc, resources.MakeScaledObject(deployment, args))
+}
+
+// reconcileKedaObject creates the KEDA object, or updates its spec if it changed.
+func (r *Reconciler) reconcileKedaObject(bc *intv1alpha1.BrokerCell, desired *unstructured.Unstructured) error {
+	gvr, _ := meta.UnsafeGuessKindToResource(desired.GroupVersionKind())
+	objects := r.DynamicClientSet.Resource(gvr).Namespace(desired.GetNamespace())
+	existing, err := objects.Get(desired.GetName(), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		_, err = objects.Create(desired, metav1.CreateOptions{})
+		if apierrs.IsAlreadyExists(err) {
+			return nil
+		}
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, desired.GetKind()+"Created", "Created %s %s/%s", desired.GetKind(), desired.GetNamespace(), desired.GetName())
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	// The spec is compared with DeepEqual rather than DeepDerivative, so that the fields which
+	// aren't in the desired spec are removed.
+	if !equality.Semantic.DeepEqual(desired.Object["spec"], existing.Object["spec"]) {
+		existing.Object["spec"] = desired.Object["spec"]
+		_, err := objects.Update(existing, metav1.UpdateOptions{})
+		if err == nil {
+			r.Recorder.Eventf(bc, corev1.EventTypeNormal, desired.GetKind()+"Updated", "Updated %s %s/%s", desired.GetKind(), desired.GetNamespace(), desired.GetName())
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteScaledObject deletes the ScaledObject of the deployment made when the deployment was
+// scaled on its backlog. The HPA made by KEDA for the ScaledObject tells whether it exists. KEDA
+// leaves the deployment of a deleted ScaledObject with its current replicas, possibly zero, so
+// the deployment is scaled back up to minReplicas.
+func (r *Reconciler) deleteScaledObject(ctx context.Context, bc *intv1alpha1.BrokerCell, namespace, deploymentName string, minReplicas int32) error {
+	_, err := r.hpaLister.HorizontalPodAutoscalers(namespace).Get(resources.KedaHorizontalPodAutoscalerName(deploymentName))
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(resources.ScaledObjectGVK)
+	err = r.DynamicClientSet.Resource(gvr).Namespace(namespace).Delete(deploymentName, &metav1.DeleteOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		r.Recorder.Eventf(bc, corev1.EventTypeNormal, "ScaledObjectDeleted", "Deleted ScaledObject %s/%s", namespace, deploymentName)
+	}
+	return r.restoreReplicas(bc, namespace, deploymentName, minReplicas)
+}
+
+// restoreReplicas scales the deployment up to minReplicas if it has fewer replicas.
+func (r *Reconciler) restoreReplicas(bc *intv1alpha1.BrokerCell, namespace, deploymentName string, minReplicas int32) error {
+	d, err := r.deploymentLister.Deployments(namespace).Get(deploymentName)
+	if err != nil {
+		return err
+	}
+	// The replicas of a deployment default to 1.
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	if replicas >= minReplicas {
+		return nil
+	}
+	// Don't modify the informers copy.
+	d = d.DeepCopy()
+	d.Spec.Replicas = &minReplicas
+	if _, err := r.KubeClientSet.AppsV1().Deployments(namespace).Update(d); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(bc, corev1.EventTypeNormal, "DeploymentScaled", "Scaled deployment %s/%s to %d replicas", namespace, deploymentName, minReplicas)
+	return nil
+}
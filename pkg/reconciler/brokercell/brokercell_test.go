@@ -20,36 +20,54 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 
+	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/resolver"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
+	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
+	"github.com/google/knative-gcp/pkg/broker/handler"
+	fakeclientset "github.com/google/knative-gcp/pkg/client/clientset/versioned/fake"
 	bcreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/testingdata"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 const (
 	testNS         = "testnamespace"
 	brokerCellName = "test-brokercell"
+	testProject    = "test-project"
 	targetsCMName = "broker-targets"
 	targetsCMKey  = "targets"
 )
@@ -139,7 +157,7 @@ func TestAllCases(t *testing.T) {
 			Objects: []runtime.Object{
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
-				NewBroker("broker", testNS, WithBrokerSetDefaults),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
 			},
 			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("update", "configmaps")},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
@@ -152,7 +170,7 @@ func TestAllCases(t *testing.T) {
 			WantEvents:  []string{configmapUpdateFailedEvent},
 			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: testingdata.Config(t,
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-				NewBroker("broker", testNS, WithBrokerSetDefaults))}},
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults))}},
 			WantErr:     true,
 		},
 		{
@@ -680,7 +698,7 @@ func TestAllCases(t *testing.T) {
 			Objects: []runtime.Object{
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
 				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-				NewBroker("broker", testNS, WithBrokerSetDefaults),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
 				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS),
 				NewDeployment(brokerCellName+"-brokercell-ingress", testNS,
@@ -713,7 +731,7 @@ func TestAllCases(t *testing.T) {
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				{Object: testingdata.Config(t,
 					NewBrokerCell(brokerCellName, testNS,WithBrokerCellSetDefaults),
-					NewBroker("broker", testNS, WithBrokerSetDefaults))},
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults))},
 				{Object: testingdata.IngressDeployment(t)},
 				{Object: testingdata.IngressHPA(t)},
 				{Object: testingdata.IngressService(t)},
@@ -806,6 +824,241 @@ func TestAllCases(t *testing.T) {
 				brokerCellReconciledEvent,
 			},
 		},
+		{
+			Name: "BrokerCell with ingress parameters, ingress deployment and HPA updated",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(ingressParameters),
+					WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.FanoutHPA(t),
+				testingdata.RetryHPA(t),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: withIngressParameters(testingdata.IngressDeploymentWithStatus(t))},
+				{Object: withIngressHPAParameters(testingdata.IngressHPA(t))},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(ingressParameters),
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				ingressDeploymentUpdatedEvent,
+				ingressHPAUpdatedEvent,
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "BrokerCell with fanout backlog autoscaling, fanout HPA replaced by ScaledObject",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellSetDefaults),
+				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.FanoutHPA(t),
+				testingdata.RetryHPA(t),
+			},
+			WantCreates: []runtime.Object{
+				fanoutTriggerAuthentication(),
+				fanoutScaledObject(t, NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				{
+					Name: testingdata.FanoutHPA(t).Name,
+					ActionImpl: clientgotesting.ActionImpl{
+						Namespace: testNS,
+						Verb:      "delete",
+						Resource:  hpav2beta2.SchemeGroupVersion.WithResource("horizontalpodautoscalers"),
+					},
+				},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "HorizontalPodAutoscalerDeleted", "Deleted HPA testnamespace/test-brokercell-brokercell-fanout-hpa"),
+				Eventf(corev1.EventTypeNormal, "TriggerAuthenticationCreated", "Created TriggerAuthentication testnamespace/test-brokercell-brokercell-keda-auth"),
+				Eventf(corev1.EventTypeNormal, "ScaledObjectCreated", "Created ScaledObject testnamespace/test-brokercell-brokercell-fanout"),
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "BrokerCell with fanout backlog autoscaling, ScaledObject is up to date",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellSetDefaults),
+				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.RetryHPA(t),
+				fanoutTriggerAuthentication(),
+				fanoutScaledObject(t, NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "BrokerCell with fanout backlog autoscaling, ScaledObject with other fields is updated",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellSetDefaults),
+				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.RetryHPA(t),
+				fanoutTriggerAuthentication(),
+				withStaleSpecField(fanoutScaledObject(t, NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults))),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: fanoutScaledObject(t, NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults))},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "ScaledObjectUpdated", "Updated ScaledObject testnamespace/test-brokercell-brokercell-fanout"),
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "BrokerCell with fanout backlog autoscaling without brokers, fanout keeps its HPA",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellSetDefaults),
+				testingdata.EmptyConfig(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				testingdata.FanoutDeploymentWithStatus(t),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				testingdata.FanoutHPA(t),
+				testingdata.RetryHPA(t),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellComponents(fanoutBacklogParameters),
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				brokerCellReconciledEvent,
+			},
+		},
+		{
+			Name: "BrokerCell without backlog autoscaling, fanout ScaledObject replaced by HPA and replicas restored",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
+				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				testingdata.IngressDeploymentWithStatus(t),
+				testingdata.IngressServiceWithStatus(t),
+				withReplicas(testingdata.FanoutDeploymentWithStatus(t), 0),
+				testingdata.RetryDeploymentWithStatus(t),
+				testingdata.IngressHPA(t),
+				fanoutKedaHPA(t),
+				testingdata.RetryHPA(t),
+				fanoutTriggerAuthentication(),
+				fanoutScaledObject(t, NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+			},
+			WantCreates: []runtime.Object{
+				testingdata.FanoutHPA(t),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: withReplicas(testingdata.FanoutDeploymentWithStatus(t), 1)},
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				{
+					Name: testingdata.FanoutDeployment(t).Name,
+					ActionImpl: clientgotesting.ActionImpl{
+						Namespace: testNS,
+						Verb:      "delete",
+						Resource:  resources.KedaSchemeGroupVersion.WithResource("scaledobjects"),
+					},
+				},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellReady,
+					WithIngressTemplate("http://test-brokercell-brokercell-ingress.testnamespace.svc.cluster.local/{namespace}/{name}"),
+					WithBrokerCellSetDefaults,
+				)},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "ScaledObjectDeleted", "Deleted ScaledObject testnamespace/test-brokercell-brokercell-fanout"),
+				Eventf(corev1.EventTypeNormal, "DeploymentScaled", "Scaled deployment testnamespace/test-brokercell-brokercell-fanout to 1 replicas"),
+				fanoutHPACreatedEvent,
+				brokerCellReconciledEvent,
+			},
+		},
 		{
 			Name: "googlecloud created BrokerCell shouldn't be gc'ed because there are brokers",
 			Key:  testKey,
@@ -814,8 +1067,8 @@ func TestAllCases(t *testing.T) {
 					WithBrokerCellAnnotations(creatorAnnotation),
 					WithBrokerCellSetDefaults),
 				testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-					NewBroker("broker", testNS, WithBrokerSetDefaults)),
-				NewBroker("broker", testNS, WithBrokerSetDefaults),
+					NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults)),
+				NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults),
 				NewEndpoints(brokerCellName+"-brokercell-ingress", testNS,
 					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
 				testingdata.IngressDeploymentWithStatus(t),
@@ -860,6 +1113,29 @@ func TestAllCases(t *testing.T) {
 			WantEvents: []string{brokerCellGCFailedEvent},
 			WantErr:    true,
 		},
+		{
+			Name: "googlecloud created BrokerCell is gc'ed if there are only brokers of other brokercells",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBrokerCell(brokerCellName, testNS,
+					WithBrokerCellAnnotations(creatorAnnotation),
+					WithBrokerCellSetDefaults,
+					WithInitBrokerCellConditions,
+				),
+				NewBroker("broker", testNS, WithBrokerSetDefaults),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				{
+					Name: brokerCellName,
+					ActionImpl: clientgotesting.ActionImpl{
+						Namespace: testNS,
+						Verb:      "delete",
+						Resource:  intv1alpha1.SchemeGroupVersion.WithResource("brokercells"),
+					},
+				},
+			},
+			WantEvents: []string{brokerCellGCEvent},
+		},
 		{
 			Name:    "googlecloud created BrokerCell is gc'ed successfully",
 			Key:     testKey,
@@ -895,16 +1171,121 @@ func TestAllCases(t *testing.T) {
 			endpointsLister:  testingListers.GetEndpointsLister(),
 			deploymentLister: testingListers.GetDeploymentLister(),
 			podLister:        testingListers.GetPodLister(),
+			secretLister:     testingListers.GetSecretLister(),
 		}
 
 		r, err := NewReconciler(base, ls)
 		if err != nil {
 			t.Fatalf("Failed to created BrokerCell reconciler: %v", err)
 		}
+		ctx = addressable.WithDuck(ctx)
+		r.uriResolver = resolver.NewURIResolver(ctx, func(types.NamespacedName) {})
+		r.projectID = testProject
 		return bcreconciler.NewReconciler(ctx, r.Logger, r.RunClientSet, testingListers.GetBrokerCellLister(), r.Recorder, r)
 	}))
 }
 
+var (
+	ingressMinReplicas int32 = 2
+	ingressMaxReplicas int32 = 20
+	ingressParameters        = intv1alpha1.ComponentsParametersSpec{
+		Ingress: &intv1alpha1.ComponentParameters{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			MinReplicas:    &ingressMinReplicas,
+			MaxReplicas:    &ingressMaxReplicas,
+			NodeSelector:   map[string]string{"pool": "ingress"},
+			Tolerations:    []corev1.Toleration{{Key: "dedicated", Value: "ingress", Effect: corev1.TaintEffectNoSchedule}},
+			PodAnnotations: map[string]string{"team": "events"},
+			Env: []corev1.EnvVar{
+				{Name: "CONFIG_LOGGING_NAME", Value: "config-logging-ingress"},
+				{Name: "GODEBUG", Value: "http2debug=1"},
+			},
+		},
+	}
+)
+
+var (
+	fanoutMinReplicas       int32 = 0
+	fanoutBacklogParameters       = intv1alpha1.ComponentsParametersSpec{
+		Fanout: &intv1alpha1.ComponentParameters{
+			MinReplicas:        &fanoutMinReplicas,
+			BacklogAutoscaling: &intv1alpha1.BacklogAutoscaling{},
+		},
+	}
+)
+
+// fanoutScaledObject makes the ScaledObject of the fanout deployment with
+// fanoutBacklogParameters, which scales on the decouple subscription of the broker.
+func fanoutScaledObject(t *testing.T, b *brokerv1beta1.Broker) *unstructured.Unstructured {
+	bc := NewBrokerCell(brokerCellName, testNS, WithBrokerCellComponents(fanoutBacklogParameters), WithBrokerCellSetDefaults)
+	return resources.MakeScaledObject(testingdata.FanoutDeployment(t), resources.ScaledObjectArgs{
+		ComponentName:           resources.FanoutName,
+		BrokerCell:              bc,
+		MinReplicas:             0,
+		MaxReplicas:             10,
+		PollingInterval:         15,
+		CooldownPeriod:          120,
+		SubscriptionSize:        100,
+		OldestUnackedMessageAge: 300,
+		ProjectID:               testProject,
+		Subscriptions:           []string{brokerresources.GenerateDecouplingSubscriptionName(b)},
+	})
+}
+
+// withStaleSpecField adds a field which the reconciler doesn't set to the spec of the
+// ScaledObject.
+func withStaleSpecField(so *unstructured.Unstructured) *unstructured.Unstructured {
+	so.Object["spec"].(map[string]interface{})["advanced"] = map[string]interface{}{
+		"restoreToOriginalReplicaCount": true,
+	}
+	return so
+}
+
+// fanoutKedaHPA makes the HPA which KEDA creates for the ScaledObject of the fanout deployment.
+func fanoutKedaHPA(t *testing.T) *hpav2beta2.HorizontalPodAutoscaler {
+	hpa := testingdata.FanoutHPA(t)
+	hpa.Name = resources.KedaHorizontalPodAutoscalerName(testingdata.FanoutDeployment(t).Name)
+	return hpa
+}
+
+// withReplicas sets the replicas of the deployment.
+func withReplicas(d *appsv1.Deployment, replicas int32) *appsv1.Deployment {
+	d.Spec.Replicas = &replicas
+	return d
+}
+
+// fanoutTriggerAuthentication makes the TriggerAuthentication of the ScaledObject of the fanout
+// deployment with fanoutBacklogParameters.
+func fanoutTriggerAuthentication() *unstructured.Unstructured {
+	return resources.MakeTriggerAuthentication(NewBrokerCell(brokerCellName, testNS, WithBrokerCellComponents(fanoutBacklogParameters), WithBrokerCellSetDefaults))
+}
+
+// withIngressParameters applies ingressParameters to the ingress deployment.
+func withIngressParameters(d *appsv1.Deployment) *appsv1.Deployment {
+	spec := &d.Spec.Template.Spec
+	d.Spec.Template.Annotations = map[string]string{"team": "events"}
+	spec.NodeSelector = map[string]string{"pool": "ingress"}
+	spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Value: "ingress", Effect: corev1.TaintEffectNoSchedule}}
+	container := &spec.Containers[0]
+	container.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+	for i := range container.Env {
+		if container.Env[i].Name == "CONFIG_LOGGING_NAME" {
+			container.Env[i].Value = "config-logging-ingress"
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: "GODEBUG", Value: "http2debug=1"})
+	return d
+}
+
+// withIngressHPAParameters applies ingressParameters to the ingress HPA.
+func withIngressHPAParameters(hpa *hpav2beta2.HorizontalPodAutoscaler) *hpav2beta2.HorizontalPodAutoscaler {
+	hpa.Spec.MinReplicas = &ingressMinReplicas
+	hpa.Spec.MaxReplicas = ingressMaxReplicas
+	return hpa
+}
+
 func emptyHPASpec(template *hpav2beta2.HorizontalPodAutoscaler) *hpav2beta2.HorizontalPodAutoscaler {
 	template.Spec = hpav2beta2.HorizontalPodAutoscalerSpec{}
 	return template
@@ -918,9 +1299,29 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 	bc := NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)
 	objects:= []runtime.Object{
 		bc,
-		NewBroker("broker", testNS, WithBrokerSetDefaults),
+		NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults, WithBrokerOrderingKey("partitionkey"), WithBrokerRateLimits("100", "2.5"),
+			WithBrokerEventSchemas(`{"types":{"com.example.order":{"required":["id"]}}}`)),
 		NewTrigger("trigger1", testNS, "broker", WithTriggerSetDefaults),
-		NewTrigger("trigger2", testNS, "broker", WithTriggerSetDefaults),
+		NewTrigger("trigger2", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerFiltersAnnotation(`[{"prefix":{"type":"com.example."}},{"any":[{"suffix":{"subject":".png"}},{"expression":"priority > 3"}]}]`)),
+		NewTrigger("trigger3", testNS, "broker", WithTriggerSetDefaults, WithTriggerFiltersAnnotation(`{"invalid"}`)),
+		NewTrigger("trigger4", testNS, "broker", WithTriggerSetDefaults, WithTriggerDeliveryAnnotation(`{"retry":"invalid"}`)),
+		NewTrigger("trigger5", testNS, "broker", WithTriggerSetDefaults, WithTriggerReplyAnnotation(`{"drop":true}`)),
+		NewTrigger("trigger6", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerReplyAnnotation(`{"destination":{"uri":"http://other-broker.example.com"}}`),
+			WithTriggerReplyResolvedURI("http://other-broker.example.com")),
+		NewTrigger("trigger7", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerReplyAnnotation(`{"destination":{"uri":"http://other-broker.example.com"}}`)),
+		NewTrigger("trigger8", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerTransportAnnotation(`{"maxIdleConnsPerHost":10,"idleConnTimeout":"PT90S","keepAlive":"PT15S","tls":{"clientCertificateSecret":"mesh"}}`)),
+		// The broker of another brokercell and its triggers are not in the config.
+		NewBroker("other-broker", testNS, WithBrokerCellAnnotation("other"), WithBrokerSetDefaults),
+		NewTrigger("other-trigger", testNS, "other-broker", WithTriggerSetDefaults),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: testNS},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+		},
 	}
 	ctx, _ := SetupFakeContext(t)
 	cmw := configmap.NewStaticWatcher()
@@ -936,6 +1337,7 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 		endpointsLister:  testingListers.GetEndpointsLister(),
 		deploymentLister: testingListers.GetDeploymentLister(),
 		podLister:        testingListers.GetPodLister(),
+		secretLister:     testingListers.GetSecretLister(),
 	}
 	r, err := NewReconciler(base, ls)
 	if err != nil {
@@ -944,9 +1346,16 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 	// here we only want to test the functionality of the reconcileConfig that it should create a brokerTargets config successfully
 	r.reconcileConfig(ctx, bc)
 	wantMap := testingdata.Config(t, NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults),
-		NewBroker("broker", testNS, WithBrokerSetDefaults),
-		NewTrigger("trigger1", testNS, "broker", WithTriggerSetDefaults),
-		NewTrigger("trigger2", testNS, "broker", WithTriggerSetDefaults))
+		NewBroker("broker", testNS, WithBrokerCellAnnotation(brokerCellName), WithBrokerSetDefaults, WithBrokerOrderingKey("partitionkey"), WithBrokerRateLimits("100", "2.5"),
+			WithBrokerEventSchemas(`{"types":{"com.example.order":{"required":["id"]}}}`)),
+		objects[2].(*brokerv1beta1.Trigger),
+		objects[3].(*brokerv1beta1.Trigger),
+		objects[4].(*brokerv1beta1.Trigger),
+		objects[5].(*brokerv1beta1.Trigger),
+		objects[6].(*brokerv1beta1.Trigger),
+		objects[7].(*brokerv1beta1.Trigger),
+		objects[8].(*brokerv1beta1.Trigger),
+		objects[9].(*brokerv1beta1.Trigger))
 	gotMap, err := client.CoreV1().ConfigMaps(testNS).Get(resources.Name(bc.Name, targetsCMName),metav1.GetOptions{})
 	if err != nil {
 		t.Fatalf("Failed to get ConfigMap from client: %v", err)
@@ -968,4 +1377,215 @@ func TestBrokerTargetsReconcileConfig(t *testing.T) {
 	if diff := cmp.Diff(wantBrokerTargets.String(), gotBrokerTargets.String()); diff != "" {
 		t.Fatalf("Unexpected brokerTargets in ConfigMap(-want, +got): %s", diff)
 	}
+	// the client certificate of trigger8 is copied into the target certificates secret
+	wantSecret := resources.MakeTargetCertsSecret(bc, map[string][]byte{
+		testNS + ".mesh.crt": []byte("cert"),
+		testNS + ".mesh.key": []byte("key"),
+	})
+	gotSecret, err := client.CoreV1().Secrets(testNS).Get(wantSecret.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get Secret from client: %v", err)
+	}
+	if diff := cmp.Diff(wantSecret, gotSecret); diff != "" {
+		t.Fatalf("Unexpected target certificates Secret (-want, +got): %s", diff)
+	}
+}
+
+func TestResolveDeliverySpec(t *testing.T) {
+	setReconcilerEnv()
+	deadLetterURI, _ := apis.ParseURL("http://dead-letter.example.com")
+	retry := int32(3)
+	linear := eventingduckv1beta1.BackoffPolicyLinear
+	invalidDelay := "10s"
+	tests := []struct {
+		name    string
+		broker  *brokerv1beta1.Broker
+		trigger *brokerv1beta1.Trigger
+		want    *config.DeliverySpec
+	}{{
+		name:   "no delivery spec",
+		broker: NewBroker("broker", testNS),
+	}, {
+		name: "retry only",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			Retry: &retry,
+		})),
+		want: &config.DeliverySpec{Retry: 3},
+	}, {
+		name: "dead letter sink uri",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{URI: deadLetterURI},
+			Retry:          &retry,
+		})),
+		want: &config.DeliverySpec{DeadLetter: "http://dead-letter.example.com", Retry: 3},
+	}, {
+		name: "dead letter sink not found",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{Ref: &duckv1.KReference{
+				APIVersion: "serving.knative.dev/v1",
+				Kind:       "Service",
+				Name:       "missing",
+			}},
+			Retry: &retry,
+		})),
+	}, {
+		name: "backoff policy",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			BackoffPolicy: &linear,
+		})),
+		want: &config.DeliverySpec{BackoffPolicy: config.BackoffPolicy_LINEAR},
+	}, {
+		name: "invalid broker backoff delay is ignored",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			Retry:        &retry,
+			BackoffDelay: &invalidDelay,
+		})),
+		want: &config.DeliverySpec{Retry: 3},
+	}, {
+		name:   "trigger delivery spec",
+		broker: NewBroker("broker", testNS),
+		trigger: NewTrigger("trigger", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerDeliveryAnnotation(`{"backoffDelay":"PT0.5S"}`)),
+		want: &config.DeliverySpec{BackoffDelay: ptypes.DurationProto(500 * time.Millisecond)},
+	}, {
+		name: "trigger delivery spec overrides broker",
+		broker: NewBroker("broker", testNS, WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{
+			DeadLetterSink: &duckv1.Destination{URI: deadLetterURI},
+			Retry:          &retry,
+		})),
+		trigger: NewTrigger("trigger", testNS, "broker", WithTriggerSetDefaults,
+			WithTriggerDeliveryAnnotation(`{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT2M"}`)),
+		want: &config.DeliverySpec{
+			DeadLetter:    "http://dead-letter.example.com",
+			Retry:         1,
+			BackoffPolicy: config.BackoffPolicy_LINEAR,
+			BackoffDelay:  ptypes.DurationProto(2 * time.Minute),
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)
+			ctx, _ := SetupFakeContext(t)
+			ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+			ctx = addressable.WithDuck(ctx)
+			base := reconciler.NewBase(ctx, controllerAgentName, configmap.NewStaticWatcher())
+			r := &Reconciler{
+				Base:        base,
+				uriResolver: resolver.NewURIResolver(ctx, func(types.NamespacedName) {}),
+			}
+			trigger := tc.trigger
+			if trigger == nil {
+				trigger = NewTrigger("trigger", testNS, "broker", WithTriggerSetDefaults)
+			}
+			brokerTargets := memory.NewEmptyTargets()
+			r.addToConfig(ctx, bc, tc.broker, []*brokerv1beta1.Trigger{trigger}, brokerTargets)
+
+			target, ok := brokerTargets.GetTarget(testNS, "broker", "trigger")
+			if !ok {
+				t.Fatal("target not found in the broker targets config")
+			}
+			if diff := cmp.Diff(tc.want, target.DeliverySpec, protocmp.Transform()); diff != "" {
+				t.Errorf("Unexpected target delivery spec (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDataPlaneProber(t *testing.T) {
+	bc := NewBrokerCell(brokerCellName, testNS, WithBrokerCellSetDefaults)
+	pod := func(name, component string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNS,
+				Labels:    resources.Labels(brokerCellName, component),
+			},
+			Status: corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+		}
+	}
+
+	targets := memory.NewEmptyTargets()
+	targets.MutateBroker("ns", "broker", func(m config.BrokerMutation) {
+		m.SetState(config.State_READY)
+		m.UpsertTargets(&config.Target{Namespace: "ns", Broker: "broker", Name: "trigger", State: config.State_READY})
+	})
+	targets.MutateBroker("ns", "notready", func(m config.BrokerMutation) {
+		m.SetState(config.State_UNKNOWN)
+	})
+	targets.MutateBroker("ns", "other", func(m config.BrokerMutation) {
+		m.SetState(config.State_READY)
+	})
+	cm, err := resources.MakeTargetsConfig(bc, targets)
+	if err != nil {
+		t.Fatalf("Failed to make the targets config: %v", err)
+	}
+
+	testingListers := NewListers([]runtime.Object{
+		bc,
+		cm,
+		pod("fanout-1", resources.FanoutName, corev1.PodRunning),
+		pod("fanout-2", resources.FanoutName, corev1.PodRunning),
+		pod("fanout-3", resources.FanoutName, corev1.PodPending),
+		pod("retry-1", resources.RetryName, corev1.PodRunning),
+		pod("retry-2", resources.RetryName, corev1.PodRunning),
+	})
+	client := fakeclientset.NewSimpleClientset(bc)
+	p := newDataPlaneProber(client, testingListers.GetBrokerCellLister(), testingListers.GetConfigMapLister(), testingListers.GetPodLister())
+	statuses := map[string]*handler.PoolStatus{
+		"fanout-1": {Handlers: map[string]handler.HandlerStatus{
+			"ns/broker":     {Alive: false, Error: "subscription does not exist"},
+			"ns/notready":   {Alive: false},
+			"ns/other":      {Alive: true},
+			"ns/unassigned": {Alive: false},
+		}},
+		"fanout-2": {Handlers: map[string]handler.HandlerStatus{
+			"ns/broker": {Alive: false},
+			"ns/other":  {Alive: true},
+		}},
+		"retry-1": {Handlers: map[string]handler.HandlerStatus{
+			"ns/broker/trigger": {Alive: true},
+		}},
+	}
+	p.probePod = func(_ context.Context, pod *corev1.Pod) (*handler.PoolStatus, error) {
+		if pod.Name == "fanout-3" {
+			t.Errorf("Pending pod %s was probed", pod.Name)
+		}
+		if status, ok := statuses[pod.Name]; ok {
+			return status, nil
+		}
+		return nil, fmt.Errorf("pod %s is unavailable", pod.Name)
+	}
+
+	ctx := logtesting.TestContextWithLogger(t)
+	p.probeAll(ctx)
+	got, err := client.InternalV1alpha1().BrokerCells(testNS).Get(brokerCellName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the brokercell: %v", err)
+	}
+	want := &intv1alpha1.DataPlaneStatus{
+		ProbedPods: 3,
+		UnhealthyBrokers: []intv1alpha1.HandlerStatus{{
+			Namespace: "ns",
+			Name:      "broker",
+			Message:   "Handler stopped in pods fanout-1, fanout-2: subscription does not exist",
+		}},
+	}
+	if diff := cmp.Diff(want, got.Status.DataPlane); diff != "" {
+		t.Errorf("Unexpected data plane status (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(bc.Status.Status, got.Status.Status); diff != "" {
+		t.Errorf("Unexpected change of the conditions of the brokercell (-want, +got): %s", diff)
+	}
+
+	// The status isn't updated if the health of the handlers didn't change.
+	updatedListers := NewListers([]runtime.Object{got})
+	p.brokerCellLister = updatedListers.GetBrokerCellLister()
+	client.ClearActions()
+	p.probeAll(ctx)
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" {
+			t.Errorf("Unexpected update of the brokercell status: %v", action)
+		}
+	}
 }
@@ -20,6 +20,7 @@ package brokercell
 
 import (
 	"context"
+	"os"
 
 	"go.uber.org/zap"
 
@@ -29,9 +30,12 @@ import (
 	brokercellinformer "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell"
 	hpainformer "github.com/google/knative-gcp/pkg/client/injection/kube/informers/autoscaling/v2beta2/horizontalpodautoscaler"
 	v1alpha1brokercell "github.com/google/knative-gcp/pkg/client/injection/reconciler/intevents/v1alpha1/brokercell"
+	metadataClient "github.com/google/knative-gcp/pkg/gclient/metadata"
 	"github.com/google/knative-gcp/pkg/reconciler"
-	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+	"github.com/google/knative-gcp/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/logging"
@@ -39,9 +43,12 @@ import (
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
 )
 
 const (
@@ -66,6 +73,7 @@ func NewController(
 		endpointsLister:  endpointsinformer.Get(ctx).Lister(),
 		deploymentLister: deploymentinformer.Get(ctx).Lister(),
 		podLister:        podinformer.Get(ctx).Lister(),
+		secretLister:     secretinformer.Get(ctx).Lister(),
 	}
 
 	base := reconciler.NewBase(ctx, controllerAgentName, cmw)
@@ -73,31 +81,60 @@ func NewController(
 	if err != nil {
 		logger.Fatal("Failed to create BrokerCell reconciler", zap.Error(err))
 	}
+	// If there is an error, the projectID will be empty. The reconciler will retry to get the
+	// projectID when it reconciles the backlog autoscaling of a brokercell.
+	r.projectID, err = utils.ProjectID(os.Getenv(utils.ProjectIDEnvKey), metadataClient.NewDefaultMetadataClient())
+	if err != nil {
+		logger.Error("Failed to get project ID", zap.Error(err))
+	}
 	impl := v1alpha1brokercell.NewImpl(ctx, r)
 
+	brokerLister := brokerinformer.Get(ctx).Lister()
+	triggerLister := triggerinformer.Get(ctx).Lister()
+	// enqueueBrokerCell enqueues the brokercell of the broker.
+	enqueueBrokerCell := func(obj interface{}) {
+		if b, ok := unwrapTombstone(obj).(*brokerv1beta1.Broker); ok {
+			impl.EnqueueKey(types.NamespacedName{Namespace: system.Namespace(), Name: b.BrokerCellName()})
+		}
+	}
+	// enqueueTriggerBrokerCell enqueues the brokercell of the broker of the trigger.
+	enqueueTriggerBrokerCell := func(namespace, brokerName string) {
+		b, err := brokerLister.Brokers(namespace).Get(brokerName)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to get broker", zap.Error(err))
+			return
+		}
+		enqueueBrokerCell(b)
+	}
+
+	// The dead letter sinks are tracked by the triggers which use them.
+	r.uriResolver = resolver.NewURIResolver(ctx, func(key types.NamespacedName) {
+		t, err := triggerLister.Triggers(key.Namespace).Get(key.Name)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to get trigger", zap.Error(err))
+			return
+		}
+		enqueueTriggerBrokerCell(t.Namespace, t.Spec.Broker)
+	})
+
 	logger.Info("Setting up event handlers.")
 
 	brokercellinformer.Get(ctx).Informer().AddEventHandlerWithResyncPeriod(controller.HandleAll(impl.Enqueue), reconciler.DefaultResyncPeriod)
 
-	// Watch brokers and triggers to invoke configmap update immediately.
-	brokerinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
-		func(obj interface{}) {
-			if b, ok := obj.(*brokerv1beta1.Broker); ok {
-				// TODO(#866) Select the brokercell that's associated with the given broker.
-				impl.EnqueueKey(types.NamespacedName{Namespace: b.Namespace, Name: brokerresources.DefaultBrokerCellName})
-			}
+	// Watch brokers and triggers to invoke configmap update immediately. A broker which moved to
+	// another brokercell also has to be removed from the configmap of its previous brokercell.
+	brokerinformer.Get(ctx).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueBrokerCell,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			enqueueBrokerCell(oldObj)
+			enqueueBrokerCell(newObj)
 		},
-	))
+		DeleteFunc: enqueueBrokerCell,
+	})
 	triggerinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
 		func(obj interface{}) {
-			if t, ok := obj.(*brokerv1beta1.Trigger); ok {
-				b, err := brokerinformer.Get(ctx).Lister().Brokers(t.Namespace).Get(t.Spec.Broker)
-				if err != nil {
-					logging.FromContext(ctx).Error("Failed to get broker", zap.Error(err))
-					return
-				}
-				// TODO(#866) Select the brokercell that's associated with the given broker.
-				impl.EnqueueKey(types.NamespacedName{Namespace: b.Namespace, Name: brokerresources.DefaultBrokerCellName})
+			if t, ok := unwrapTombstone(obj).(*brokerv1beta1.Trigger); ok {
+				enqueueTriggerBrokerCell(t.Namespace, t.Spec.Broker)
 			}
 		},
 	))
@@ -111,11 +148,45 @@ func NewController(
 	hpainformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
 	// 4. Watch the broker targets configmap.
 	configmapinformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
+	// 5. Watch the target certificates secret, and the client certificate secrets of triggers.
+	secretinformer.Get(ctx).Informer().AddEventHandler(handleResourceUpdate(impl))
+	secretinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+		func(obj interface{}) {
+			s, ok := unwrapTombstone(obj).(*corev1.Secret)
+			if !ok || s.Type != corev1.SecretTypeTLS {
+				return
+			}
+			triggers, err := triggerLister.Triggers(s.Namespace).List(labels.Everything())
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to list triggers", zap.Error(err))
+				return
+			}
+			for _, t := range triggers {
+				if ts, err := t.TransportSpec(); err == nil && ts != nil && ts.TLS != nil && ts.TLS.ClientCertificateSecret == s.Name {
+					enqueueTriggerBrokerCell(t.Namespace, t.Spec.Broker)
+				}
+			}
+		},
+	))
+
+	// The health of the handlers of the data plane pods doesn't change any watched object, so the
+	// pods are probed periodically apart from the reconciliation of the brokercells.
+	prober := newDataPlaneProber(base.RunClientSet, brokercellinformer.Get(ctx).Lister(), ls.configMapLister, ls.podLister)
+	go prober.Run(ctx)
 
 	return impl
 }
 
 // handleResourceUpdate returns an event handler for resources created by brokercell such as the ingress deployment.
+// unwrapTombstone returns the last known state of a deleted object whose deletion was missed
+// by the informer, or the object itself.
+func unwrapTombstone(obj interface{}) interface{} {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return d.Obj
+	}
+	return obj
+}
+
 func handleResourceUpdate(impl *controller.Impl) cache.ResourceEventHandler {
 	// Since resources created by brokercell live in the same namespace as the brokercell, we use an
 	// empty namespaceLabel so that the same namespace of the given object is used to enqueue.
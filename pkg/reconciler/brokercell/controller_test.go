@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
@@ -30,17 +31,22 @@ import (
 	"knative.dev/pkg/system"
 	tracingconfig "knative.dev/pkg/tracing/config"
 
+	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+
 	// Fake injection informers
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/trigger/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell/fake"
 	_ "github.com/google/knative-gcp/pkg/client/injection/kube/informers/autoscaling/v2beta2/horizontalpodautoscaler/fake"
+	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/conditions/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	_ "knative.dev/pkg/injection/clients/dynamicclient/fake"
 )
 
 func TestNew(t *testing.T) {
@@ -77,6 +83,18 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestUnwrapTombstone(t *testing.T) {
+	b := &brokerv1beta1.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "broker"}}
+	for _, obj := range []interface{}{
+		b,
+		cache.DeletedFinalStateUnknown{Key: "ns/broker", Obj: b},
+	} {
+		if got := unwrapTombstone(obj); got != b {
+			t.Errorf("unwrapTombstone(%#v) = %#v, want %#v", obj, got, b)
+		}
+	}
+}
+
 func setReconcilerEnv() {
 	_ = os.Setenv("BROKER_CELL_INGRESS_IMAGE", "ingress")
 	_ = os.Setenv("BROKER_CELL_FANOUT_IMAGE", "fanout")
@@ -0,0 +1,274 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/eventing/pkg/logging"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/handler"
+	clientset "github.com/google/knative-gcp/pkg/client/clientset/versioned"
+	intlisters "github.com/google/knative-gcp/pkg/client/listers/intevents/v1alpha1"
+	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+)
+
+const (
+	// dataPlaneProbePeriod is the period to probe the data plane pods of the brokercells, as the
+	// health of their handlers doesn't change any object the controller watches.
+	dataPlaneProbePeriod = 30 * time.Second
+	// podProbeTimeout is the timeout to get the status of the handlers of a pod.
+	podProbeTimeout = 5 * time.Second
+)
+
+// podProber gets the status of the handlers of a fanout or retry pod.
+type podProber func(ctx context.Context, pod *corev1.Pod) (*handler.PoolStatus, error)
+
+// newPodProber returns a podProber which gets the status of the handlers of the pod from its
+// health checker with the client.
+func newPodProber(client *http.Client) podProber {
+	return func(ctx context.Context, pod *corev1.Pod) (*handler.PoolStatus, error) {
+		url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(handler.DefaultHealthCheckPort)) + handler.StatusPath
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("pod responded with status code %d", resp.StatusCode)
+		}
+		var status handler.PoolStatus
+		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			return nil, err
+		}
+		return &status, nil
+	}
+}
+
+// dataPlaneProber periodically probes the fanout and retry pods of the brokercells for the
+// status of their handlers, and updates the data plane status of the brokercells. It runs apart
+// from the reconciler so that the brokercells aren't reconciled only to be probed, and a slow
+// pod doesn't hold up the reconciliation of the brokercells.
+type dataPlaneProber struct {
+	client           clientset.Interface
+	brokerCellLister intlisters.BrokerCellLister
+	configMapLister  corev1listers.ConfigMapLister
+	podLister        corev1listers.PodLister
+
+	// probePod gets the status of the handlers of the data plane pods.
+	probePod podProber
+}
+
+func newDataPlaneProber(client clientset.Interface, brokerCellLister intlisters.BrokerCellLister, configMapLister corev1listers.ConfigMapLister, podLister corev1listers.PodLister) *dataPlaneProber {
+	return &dataPlaneProber{
+		client:           client,
+		brokerCellLister: brokerCellLister,
+		configMapLister:  configMapLister,
+		podLister:        podLister,
+		probePod:         newPodProber(&http.Client{Timeout: podProbeTimeout}),
+	}
+}
+
+// Run probes the brokercells every dataPlaneProbePeriod until the context is done.
+func (p *dataPlaneProber) Run(ctx context.Context) {
+	wait.Until(func() { p.probeAll(ctx) }, dataPlaneProbePeriod, ctx.Done())
+}
+
+// probeAll updates the data plane status of all the brokercells.
+func (p *dataPlaneProber) probeAll(ctx context.Context) {
+	bcs, err := p.brokerCellLister.List(labels.Everything())
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list brokercells", zap.Error(err))
+		return
+	}
+	for _, bc := range bcs {
+		if bc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := p.updateDataPlaneStatus(ctx, bc); err != nil {
+			logging.FromContext(ctx).Error("Failed to update the data plane status of brokercell", zap.String("namespace", bc.Namespace), zap.String("name", bc.Name), zap.Error(err))
+		}
+	}
+}
+
+// updateDataPlaneStatus probes the pods of the brokercell with the targets of its configmap, and
+// updates the status of the brokercell if the health of the handlers changed. A brokercell whose
+// configmap hasn't been created yet has no handlers to probe.
+func (p *dataPlaneProber) updateDataPlaneStatus(ctx context.Context, bc *intv1alpha1.BrokerCell) error {
+	cm, err := p.configMapLister.ConfigMaps(bc.Namespace).Get(resources.TargetsConfigName(bc.Name))
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	targets, err := resources.ParseTargetsConfig(cm)
+	if err != nil {
+		return err
+	}
+	dp := p.dataPlaneStatus(ctx, bc, targets)
+	if equality.Semantic.DeepEqual(dp, bc.Status.DataPlane) {
+		return nil
+	}
+	// Don't modify the informers copy.
+	bc = bc.DeepCopy()
+	bc.Status.SetDataPlaneStatus(dp)
+	_, err = p.client.InternalV1alpha1().BrokerCells(bc.Namespace).UpdateStatus(bc)
+	return err
+}
+
+// stoppedHandler collects the pods in which the handler of a broker or a trigger has stopped.
+type stoppedHandler struct {
+	namespace string
+	name      string
+	pods      []string
+	err       string
+}
+
+func (h *stoppedHandler) status() intv1alpha1.HandlerStatus {
+	sort.Strings(h.pods)
+	message := fmt.Sprintf("Handler stopped in pods %s", strings.Join(h.pods, ", "))
+	if h.err != "" {
+		message += ": " + h.err
+	}
+	return intv1alpha1.HandlerStatus{Namespace: h.namespace, Name: h.name, Message: message}
+}
+
+// dataPlaneStatus probes the fanout and retry pods of the brokercell for the status of their
+// handlers, and returns the brokers and triggers whose handlers have stopped. Only the handlers
+// of ready brokers and triggers are considered, as the data plane doesn't start the others. The
+// pods which can't be probed are skipped.
+func (p *dataPlaneProber) dataPlaneStatus(ctx context.Context, bc *intv1alpha1.BrokerCell, targets config.ReadonlyTargets) *intv1alpha1.DataPlaneStatus {
+	dp := &intv1alpha1.DataPlaneStatus{}
+
+	brokers := make(map[string]*stoppedHandler)
+	for _, status := range p.probePods(ctx, bc, resources.FanoutName) {
+		dp.ProbedPods++
+		for key, hs := range status.handlers {
+			b, ok := targets.GetBrokerByKey(key)
+			if hs.Alive || !ok || b.State != config.State_READY {
+				continue
+			}
+			addStoppedHandler(brokers, key, b.Namespace, b.Name, status.pod, hs)
+		}
+	}
+	triggers := make(map[string]*stoppedHandler)
+	for _, status := range p.probePods(ctx, bc, resources.RetryName) {
+		dp.ProbedPods++
+		for key, hs := range status.handlers {
+			t, ok := targets.GetTargetByKey(key)
+			if hs.Alive || !ok || t.State != config.State_READY {
+				continue
+			}
+			addStoppedHandler(triggers, key, t.Namespace, t.Name, status.pod, hs)
+		}
+	}
+	dp.UnhealthyBrokers = handlerStatuses(brokers)
+	dp.UnhealthyTriggers = handlerStatuses(triggers)
+	return dp
+}
+
+// podStatus is the status of the handlers of a probed pod.
+type podStatus struct {
+	pod      string
+	handlers map[string]handler.HandlerStatus
+}
+
+// probePods probes the running pods of the component of the brokercell concurrently.
+func (p *dataPlaneProber) probePods(ctx context.Context, bc *intv1alpha1.BrokerCell, componentName string) []podStatus {
+	pods, err := p.podLister.Pods(bc.Namespace).List(labels.SelectorFromSet(resources.Labels(bc.Name, componentName)))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list pods", zap.String("component", componentName), zap.Error(err))
+		return nil
+	}
+	var (
+		mux      sync.Mutex
+		wg       sync.WaitGroup
+		statuses []podStatus
+	)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer wg.Done()
+			status, err := p.probePod(ctx, pod)
+			if err != nil {
+				logging.FromContext(ctx).Debug("Failed to probe pod", zap.String("pod", pod.Name), zap.Error(err))
+				return
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			statuses = append(statuses, podStatus{pod: pod.Name, handlers: status.Handlers})
+		}(pod)
+	}
+	wg.Wait()
+	return statuses
+}
+
+func addStoppedHandler(handlers map[string]*stoppedHandler, key, namespace, name, pod string, hs handler.HandlerStatus) {
+	h, ok := handlers[key]
+	if !ok {
+		h = &stoppedHandler{namespace: namespace, name: name}
+		handlers[key] = h
+	}
+	h.pods = append(h.pods, pod)
+	if h.err == "" {
+		h.err = hs.Error
+	}
+}
+
+// handlerStatuses returns the status of the stopped handlers sorted by their keys, so that the
+// status of the brokercell doesn't change with the order of the probes.
+func handlerStatuses(handlers map[string]*stoppedHandler) []intv1alpha1.HandlerStatus {
+	if len(handlers) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(handlers))
+	for key := range handlers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	statuses := make([]intv1alpha1.HandlerStatus, 0, len(keys))
+	for _, key := range keys {
+		statuses = append(statuses, handlers[key].status())
+	}
+	return statuses
+}
@@ -19,6 +19,7 @@ package resources
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/kmeta"
 
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
@@ -45,22 +46,44 @@ type Args struct {
 	Image              string
 	ServiceAccountName string
 	MetricsPort        int
+	// Resources are the compute resources of the container.
+	Resources corev1.ResourceRequirements
+	// Env are the environment variables which override the ones of the container with the
+	// same names, or are added to them.
+	Env []corev1.EnvVar
+	// NodeSelector, Tolerations and Affinity constrain the nodes of the pods.
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+	Affinity     *corev1.Affinity
+	// PodAnnotations are added to the pods.
+	PodAnnotations map[string]string
 }
 
 // IngressArgs are the arguments to create a Broker's ingress Deployment.
 type IngressArgs struct {
 	Args
 	Port int
+	// AuthIssuers are the OIDC issuers trusted to authenticate callers of the
+	// ingress. If empty, callers are not authenticated.
+	AuthIssuers []string
+	// AuthAudience is the audience the tokens of callers must be issued for.
+	AuthAudience string
 }
 
 // FanoutArgs are the arguments to create a Broker's fanout Deployment.
 type FanoutArgs struct {
 	Args
+	// ReplyTokenAudience is the audience of the service account token sent with replies to
+	// the ingress. If empty, replies are sent without a token.
+	ReplyTokenAudience string
 }
 
 // RetryArgs are the arguments to create a Broker's retry Deployment.
 type RetryArgs struct {
 	Args
+	// ReplyTokenAudience is the audience of the service account token sent with replies to
+	// the ingress. If empty, replies are sent without a token.
+	ReplyTokenAudience string
 }
 
 // AutoscalingArgs are the arguments to create HPA for deployments.
@@ -69,6 +92,7 @@ type AutoscalingArgs struct {
 	BrokerCell        *intv1alpha1.BrokerCell
 	AvgCPUUtilization int32
 	AvgMemoryUsage    string
+	MinReplicas       int32
 	MaxReplicas       int32
 }
 
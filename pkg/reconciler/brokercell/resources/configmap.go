@@ -19,10 +19,13 @@ package resources
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/proto"
 	"knative.dev/pkg/kmeta"
 
+	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/config"
+	"github.com/google/knative-gcp/pkg/broker/config/memory"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -39,7 +42,7 @@ func MakeTargetsConfig(bc *intv1alpha1.BrokerCell, brokerTargets config.Targets)
 	}
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            Name(bc.Name, targetsCMName),
+			Name:            TargetsConfigName(bc.Name),
 			Namespace:       bc.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(bc)},
 			Labels:          Labels(bc.Name, "broker-targets"),
@@ -49,3 +52,45 @@ func MakeTargetsConfig(bc *intv1alpha1.BrokerCell, brokerTargets config.Targets)
 		Data: map[string]string{"targets.txt": brokerTargets.String()},
 	}, nil
 }
+
+// TargetsConfigName returns the name of the broker targets configmap of the brokercell.
+func TargetsConfigName(brokerCellName string) string {
+	return Name(brokerCellName, targetsCMName)
+}
+
+// ParseTargetsConfig returns the targets in the broker targets configmap.
+func ParseTargetsConfig(cm *corev1.ConfigMap) (config.ReadonlyTargets, error) {
+	var val config.TargetsConfig
+	if err := proto.Unmarshal(cm.BinaryData[targetsCMKey], &val); err != nil {
+		return nil, fmt.Errorf("error deserializing targets config: %w", err)
+	}
+	return memory.NewTargets(&val), nil
+}
+
+// MakeTargetFilters converts the advanced filters of a trigger to the filters in the targets config.
+func MakeTargetFilters(filters []brokerv1beta1.TriggerFilter) []*config.Filter {
+	if len(filters) == 0 {
+		return nil
+	}
+	out := make([]*config.Filter, 0, len(filters))
+	for i := range filters {
+		out = append(out, makeTargetFilter(&filters[i]))
+	}
+	return out
+}
+
+func makeTargetFilter(f *brokerv1beta1.TriggerFilter) *config.Filter {
+	out := &config.Filter{
+		Exact:      f.Exact,
+		Prefix:     f.Prefix,
+		Suffix:     f.Suffix,
+		Exists:     f.Exists,
+		Any:        MakeTargetFilters(f.Any),
+		All:        MakeTargetFilters(f.All),
+		Expression: f.Expression,
+	}
+	if f.Not != nil {
+		out.Not = makeTargetFilter(f.Not)
+	}
+	return out
+}
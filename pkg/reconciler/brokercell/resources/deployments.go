@@ -18,23 +18,44 @@ package resources
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/google/knative-gcp/pkg/broker/handler"
+	"github.com/google/knative-gcp/pkg/broker/handler/processors/deliver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/system"
 )
 
+const (
+	// targetCertsVolumeName is the name of the volume with the client certificates presented to
+	// targets. See MakeTargetCertsSecret.
+	targetCertsVolumeName = "target-certs"
+
+	// replyTokenVolumeName is the name of the volume with the service account token sent with
+	// replies to the ingress.
+	replyTokenVolumeName = "reply-token"
+	replyTokenDir        = "/var/run/cloud-run-events/reply-token"
+	// replyTokenExpirationSeconds is the requested lifetime of the reply token. The kubelet
+	// rotates the token before it expires.
+	replyTokenExpirationSeconds int64 = 3600
+)
+
 // MakeIngressDeployment creates the ingress Deployment object.
 func MakeIngressDeployment(args IngressArgs) *appsv1.Deployment {
 	container := containerTemplate(args.Args)
 	// Decorate the container template with ingress port.
 	container.Env = append(container.Env, corev1.EnvVar{Name: "PORT", Value: strconv.Itoa(args.Port)})
 	container.Ports = append(container.Ports, corev1.ContainerPort{Name: "http", ContainerPort: int32(args.Port)})
+	if len(args.AuthIssuers) > 0 {
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "AUTH_ISSUERS", Value: strings.Join(args.AuthIssuers, ",")},
+			corev1.EnvVar{Name: "AUTH_AUDIENCE", Value: args.AuthAudience},
+		)
+	}
 	container.ReadinessProbe = &corev1.Probe{
 		Handler: corev1.Handler{
 			HTTPGet: &corev1.HTTPGetAction{
@@ -62,30 +83,12 @@ func MakeIngressDeployment(args IngressArgs) *appsv1.Deployment {
 		SuccessThreshold:    1,
 		TimeoutSeconds:      5,
 	}
-	container.Resources = corev1.ResourceRequirements{
-		Limits: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("1000Mi"),
-		},
-		Requests: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("500Mi"),
-			corev1.ResourceCPU:    resource.MustParse("1000m"),
-		},
-	}
 	return deploymentTemplate(args.Args, []corev1.Container{container})
 }
 
 // MakeFanoutDeployment creates the fanout Deployment object.
 func MakeFanoutDeployment(args FanoutArgs) *appsv1.Deployment {
 	container := containerTemplate(args.Args)
-	container.Resources = corev1.ResourceRequirements{
-		Limits: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("3000Mi"),
-		},
-		Requests: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("500Mi"),
-			corev1.ResourceCPU:    resource.MustParse("1500m"),
-		},
-	}
 	container.Ports = append(container.Ports,
 		corev1.ContainerPort{
 			Name:          "http-health",
@@ -110,21 +113,12 @@ func MakeFanoutDeployment(args FanoutArgs) *appsv1.Deployment {
 		SuccessThreshold:    1,
 		TimeoutSeconds:      5,
 	}
-	return deploymentTemplate(args.Args, []corev1.Container{container})
+	return mountReplyToken(mountTargetCerts(deploymentTemplate(args.Args, []corev1.Container{container}), args.BrokerCell.Name), args.ReplyTokenAudience)
 }
 
 // MakeRetryDeployment creates the retry Deployment object.
 func MakeRetryDeployment(args RetryArgs) *appsv1.Deployment {
 	container := containerTemplate(args.Args)
-	container.Resources = corev1.ResourceRequirements{
-		Limits: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("3000Mi"),
-		},
-		Requests: corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("500Mi"),
-			corev1.ResourceCPU:    resource.MustParse("1000m"),
-		},
-	}
 	container.Ports = append(container.Ports,
 		corev1.ContainerPort{
 			Name:          "http-health",
@@ -145,11 +139,67 @@ func MakeRetryDeployment(args RetryArgs) *appsv1.Deployment {
 		SuccessThreshold:    1,
 		TimeoutSeconds:      5,
 	}
-	return deploymentTemplate(args.Args, []corev1.Container{container})
+	return mountReplyToken(mountTargetCerts(deploymentTemplate(args.Args, []corev1.Container{container}), args.BrokerCell.Name), args.ReplyTokenAudience)
+}
+
+// mountReplyToken mounts a projected service account token for the audience of the ingress into
+// the containers of the deployment, and sets REPLY_TOKEN_PATH so that the token is sent with
+// replies to the ingress. Nothing is mounted if the audience is empty.
+func mountReplyToken(d *appsv1.Deployment, audience string) *appsv1.Deployment {
+	if audience == "" {
+		return d
+	}
+	expirationSeconds := replyTokenExpirationSeconds
+	spec := &d.Spec.Template.Spec
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: replyTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+					Audience:          audience,
+					ExpirationSeconds: &expirationSeconds,
+					Path:              "token",
+				},
+			}},
+		}},
+	})
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      replyTokenVolumeName,
+			MountPath: replyTokenDir,
+			ReadOnly:  true,
+		})
+		spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
+			Name:  "REPLY_TOKEN_PATH",
+			Value: replyTokenDir + "/token",
+		})
+	}
+	return d
+}
+
+// mountTargetCerts mounts the client certificates presented to targets into the containers of
+// the deployment. The Secret is optional, as it's only created for targets with client
+// certificates.
+func mountTargetCerts(d *appsv1.Deployment, brokerCellName string) *appsv1.Deployment {
+	spec := &d.Spec.Template.Spec
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name:         targetCertsVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: Name(brokerCellName, targetCertsSecretName), Optional: &optionalSecretVolume}},
+	})
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      targetCertsVolumeName,
+			MountPath: deliver.DefaultTargetCertsDir,
+		})
+	}
+	return d
 }
 
 // deploymentTemplate creates a template for data plane deployments.
 func deploymentTemplate(args Args, containers []corev1.Container) *appsv1.Deployment {
+	for i := range containers {
+		containers[i].Env = overrideEnv(containers[i].Env, args.Env)
+	}
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       args.BrokerCell.Namespace,
@@ -160,9 +210,15 @@ func deploymentTemplate(args Args, containers []corev1.Container) *appsv1.Deploy
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: Labels(args.BrokerCell.Name, args.ComponentName)},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: Labels(args.BrokerCell.Name, args.ComponentName)},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      Labels(args.BrokerCell.Name, args.ComponentName),
+					Annotations: args.PodAnnotations,
+				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: args.ServiceAccountName,
+					NodeSelector:       args.NodeSelector,
+					Tolerations:        args.Tolerations,
+					Affinity:           args.Affinity,
 					Volumes: []corev1.Volume{
 						{
 							Name:         "broker-config",
@@ -183,8 +239,9 @@ func deploymentTemplate(args Args, containers []corev1.Container) *appsv1.Deploy
 // containerTemplate returns a common template for broker data plane containers.
 func containerTemplate(args Args) corev1.Container {
 	return corev1.Container{
-		Image: args.Image,
-		Name:  args.ComponentName,
+		Image:     args.Image,
+		Name:      args.ComponentName,
+		Resources: args.Resources,
 		Env: []corev1.EnvVar{
 			{
 				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
@@ -234,3 +291,21 @@ func containerTemplate(args Args) corev1.Container {
 		},
 	}
 }
+
+// overrideEnv replaces the environment variables with the overrides of the same names, and
+// appends the other overrides.
+func overrideEnv(env, overrides []corev1.EnvVar) []corev1.EnvVar {
+	for _, o := range overrides {
+		replaced := false
+		for i := range env {
+			if env[i].Name == o.Name {
+				env[i] = o
+				replaced = true
+			}
+		}
+		if !replaced {
+			env = append(env, o)
+		}
+	}
+	return env
+}
@@ -25,13 +25,17 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
+// HorizontalPodAutoscalerName returns the name of the HPA of the deployment.
+func HorizontalPodAutoscalerName(deploymentName string) string {
+	return deploymentName + "-hpa"
+}
+
 // MakeHorizontalPodAutoscaler makes an HPA for the given arguments.
 func MakeHorizontalPodAutoscaler(deployment *appsv1.Deployment, args AutoscalingArgs) *hpav2beta2.HorizontalPodAutoscaler {
-	var one int32 = 1
 	memQuantity := resource.MustParse(args.AvgMemoryUsage)
 	return &hpav2beta2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            deployment.Name + "-hpa",
+			Name:            HorizontalPodAutoscalerName(deployment.Name),
 			Namespace:       deployment.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(args.BrokerCell)},
 			Labels:          Labels(args.BrokerCell.Name, args.ComponentName),
@@ -43,7 +47,7 @@ func MakeHorizontalPodAutoscaler(deployment *appsv1.Deployment, args Autoscaling
 				Name:       deployment.Name,
 			},
 			MaxReplicas: args.MaxReplicas,
-			MinReplicas: &one,
+			MinReplicas: &args.MinReplicas,
 			Metrics: []hpav2beta2.MetricSpec{
 				{
 					Type: hpav2beta2.ResourceMetricSourceType,
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+)
+
+const (
+	triggerAuthenticationName = "keda-auth"
+
+	numUndeliveredMessagesMetric  = "pubsub.googleapis.com/subscription/num_undelivered_messages"
+	oldestUnackedMessageAgeMetric = "pubsub.googleapis.com/subscription/oldest_unacked_message_age"
+	// alignmentPeriodSeconds is the sampling period of the Pub/Sub subscription metrics.
+	alignmentPeriodSeconds = "60"
+)
+
+var (
+	// KedaSchemeGroupVersion is the group version of KEDA v2, whose gcp-stackdriver scaler
+	// aggregates the metrics of all the subscriptions of a component. The ScaledObjects of the
+	// PullSubscriptions still use KEDA v1.
+	KedaSchemeGroupVersion = schema.GroupVersion{Group: "keda.sh", Version: "v1alpha1"}
+	// ScaledObjectGVK is the GroupVersionKind of the KEDA v2 ScaledObjects.
+	ScaledObjectGVK = KedaSchemeGroupVersion.WithKind("ScaledObject")
+	// TriggerAuthenticationGVK is the GroupVersionKind of the KEDA v2 TriggerAuthentications.
+	TriggerAuthenticationGVK = KedaSchemeGroupVersion.WithKind("TriggerAuthentication")
+)
+
+// ScaledObjectArgs are the arguments to create a KEDA ScaledObject for a deployment.
+type ScaledObjectArgs struct {
+	ComponentName string
+	BrokerCell    *intv1alpha1.BrokerCell
+	MinReplicas   int32
+	MaxReplicas   int32
+	// PollingInterval, CooldownPeriod and OldestUnackedMessageAge are in seconds.
+	PollingInterval         int32
+	CooldownPeriod          int32
+	SubscriptionSize        int32
+	OldestUnackedMessageAge int32
+	// ProjectID is the project of the subscriptions.
+	ProjectID string
+	// Subscriptions are the Pub/Sub subscriptions whose backlog the deployment is scaled on.
+	Subscriptions []string
+}
+
+// KedaHorizontalPodAutoscalerName returns the name of the HPA which KEDA creates for the
+// ScaledObject of the deployment.
+func KedaHorizontalPodAutoscalerName(deploymentName string) string {
+	return "keda-hpa-" + deploymentName
+}
+
+// MakeTriggerAuthentication makes the KEDA TriggerAuthentication of the ScaledObjects of the
+// BrokerCell. KEDA reads the backlog of the subscriptions with the GCP workload identity of its
+// operator, so that the data plane doesn't need credentials for KEDA.
+func MakeTriggerAuthentication(bc *intv1alpha1.BrokerCell) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": KedaSchemeGroupVersion.String(),
+			"kind":       TriggerAuthenticationGVK.Kind,
+			"metadata": map[string]interface{}{
+				"namespace":       bc.Namespace,
+				"name":            Name(bc.Name, triggerAuthenticationName),
+				"labels":          kedaLabels(bc.Name, triggerAuthenticationName),
+				"ownerReferences": kedaOwnerReferences(bc),
+			},
+			"spec": map[string]interface{}{
+				"podIdentity": map[string]interface{}{
+					"provider": "gcp",
+				},
+			},
+		},
+	}
+}
+
+// MakeScaledObject makes a KEDA ScaledObject which scales the deployment on the backlog of the
+// subscriptions. The number of undelivered messages of the subscriptions is summed, and the
+// deployment has SubscriptionSize of them per replica. The age of the oldest unacknowledged
+// message of the subscriptions is scaled to OldestUnackedMessageAge, so that a small but stuck
+// backlog still adds replicas. The ScaledObject has the same name as the deployment.
+func MakeScaledObject(deployment *appsv1.Deployment, args ScaledObjectArgs) *unstructured.Unstructured {
+	// Using Unstructured instead of adding the Keda dependency, see the ScaledObjects of the
+	// PullSubscriptions.
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": KedaSchemeGroupVersion.String(),
+			"kind":       ScaledObjectGVK.Kind,
+			"metadata": map[string]interface{}{
+				"namespace":       deployment.Namespace,
+				"name":            deployment.Name,
+				"labels":          kedaLabels(args.BrokerCell.Name, args.ComponentName),
+				"ownerReferences": kedaOwnerReferences(args.BrokerCell),
+			},
+			"spec": map[string]interface{}{
+				"scaleTargetRef": map[string]interface{}{
+					"name": deployment.Name,
+				},
+				"minReplicaCount": int64(args.MinReplicas),
+				"maxReplicaCount": int64(args.MaxReplicas),
+				"pollingInterval": int64(args.PollingInterval),
+				"cooldownPeriod":  int64(args.CooldownPeriod),
+				"triggers": []interface{}{
+					aggregateTrigger(args, numUndeliveredMessagesMetric, "AverageValue", "sum", args.SubscriptionSize),
+					aggregateTrigger(args, oldestUnackedMessageAgeMetric, "Value", "max", args.OldestUnackedMessageAge),
+				},
+			},
+		},
+	}
+}
+
+// aggregateTrigger makes a trigger on the metric of all the subscriptions, aggregated with the
+// reducer.
+func aggregateTrigger(args ScaledObjectArgs, metric, metricType, reducer string, target int32) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "gcp-stackdriver",
+		"metricType": metricType,
+		"authenticationRef": map[string]interface{}{
+			"name": Name(args.BrokerCell.Name, triggerAuthenticationName),
+		},
+		"metadata": map[string]interface{}{
+			"projectId":              args.ProjectID,
+			"filter":                 subscriptionsFilter(metric, args.Subscriptions),
+			"targetValue":            strconv.Itoa(int(target)),
+			"alignmentPeriodSeconds": alignmentPeriodSeconds,
+			"alignmentAligner":       "max",
+			"alignmentReducer":       reducer,
+		},
+	}
+}
+
+// kedaLabels returns the labels of the KEDA object of the component as unstructured.
+func kedaLabels(brokerCellName, componentName string) map[string]interface{} {
+	labels := make(map[string]interface{})
+	for k, v := range Labels(brokerCellName, componentName) {
+		labels[k] = v
+	}
+	return labels
+}
+
+// kedaOwnerReferences returns the owner references of the KEDA objects of the BrokerCell as
+// unstructured.
+func kedaOwnerReferences(bc *intv1alpha1.BrokerCell) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"apiVersion":         bc.GetGroupVersionKind().GroupVersion().String(),
+			"kind":               bc.GetGroupVersionKind().Kind,
+			"blockOwnerDeletion": true,
+			"controller":         true,
+			"name":               bc.Name,
+			"uid":                string(bc.UID),
+		},
+	}
+}
+
+// subscriptionsFilter returns the Cloud Monitoring filter of the time series of the metric of
+// the subscriptions.
+func subscriptionsFilter(metric string, subscriptions []string) string {
+	ids := make([]string, len(subscriptions))
+	for i, s := range subscriptions {
+		ids[i] = strconv.Quote(s)
+	}
+	return fmt.Sprintf(`metric.type=%q AND resource.type="pubsub_subscription" AND resource.label.subscription_id=one_of(%s)`,
+		metric, strings.Join(ids, ","))
+}
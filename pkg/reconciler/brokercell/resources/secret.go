@@ -0,0 +1,49 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+
+	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
+)
+
+const targetCertsSecretName = "target-certs"
+
+// TargetCertName is the name of the client certificate of the Secret in the namespace presented
+// to targets, in the targets config and the target certificates Secret. Namespaces can't contain
+// dots, so the certificates of Secrets in different namespaces never have the same name.
+func TargetCertName(namespace, secret string) string {
+	return namespace + "." + secret
+}
+
+// MakeTargetCertsSecret creates the Secret with the client certificates presented to the targets
+// of the brokercell. The certificate and key of the client certificate <name> are its <name>.crt
+// and <name>.key entries.
+func MakeTargetCertsSecret(bc *intv1alpha1.BrokerCell, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            Name(bc.Name, targetCertsSecretName),
+			Namespace:       bc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(bc)},
+			Labels:          Labels(bc.Name, targetCertsSecretName),
+		},
+		Data: data,
+	}
+}
@@ -19,12 +19,15 @@ package testingdata
 import (
 	"testing"
 
+	"github.com/golang/protobuf/ptypes"
+
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	intv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/broker/config"
 	"github.com/google/knative-gcp/pkg/broker/config/memory"
 	brokerresources "github.com/google/knative-gcp/pkg/reconciler/broker/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/brokercell/resources"
+	"github.com/google/knative-gcp/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -42,6 +45,29 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 	// construct triggers config
 	targets := make(map[string]*config.Target, len(triggers))
 	for _, t := range triggers {
+		filters, err := t.Filters()
+		if err != nil {
+			// Triggers with invalid filters are left out of the config.
+			continue
+		}
+		if _, err := t.DeliverySpec(); err != nil {
+			// So are triggers with invalid delivery specs.
+			continue
+		}
+		reply, err := t.ReplySpec()
+		if err != nil {
+			// And triggers with invalid reply specs.
+			continue
+		}
+		if reply != nil && reply.Destination != nil && t.Status.ReplyURI() == nil {
+			// And triggers with unresolved reply destinations.
+			continue
+		}
+		ts, err := t.TransportSpec()
+		if err != nil {
+			// And triggers with invalid transport specs.
+			continue
+		}
 		state := config.State_UNKNOWN
 		if t.Status.IsReady() {
 			state = config.State_READY
@@ -62,7 +88,15 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 			},
 			State:            state,
 			FilterAttributes: filterAttributes,
+			Filters:          resources.MakeTargetFilters(filters),
+		}
+		if reply != nil {
+			target.DropReplies = reply.Drop
+			if reply.Destination != nil {
+				target.ReplyAddress = t.Status.ReplyURI().String()
+			}
 		}
+		target.Transport = transport(t.Namespace, ts)
 
 		targets[t.Name] = target
 	}
@@ -81,8 +115,13 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 			Topic:        brokerresources.GenerateDecouplingTopicName(broker),
 			Subscription: brokerresources.GenerateDecouplingSubscriptionName(broker),
 		},
-		Targets: targets,
-		State:   state,
+		Targets:              targets,
+		State:                state,
+		OrderingKeyAttribute: broker.OrderingKeyAttribute(),
+		AllowedIdentities:    broker.AllowedIdentities(),
+		RateLimit:            rateLimit(broker.RateLimit()),
+		SourceRateLimit:      rateLimit(broker.SourceRateLimit()),
+		EventSchemas:         eventSchemas(broker),
 	}
 	bt := &config.TargetsConfig{
 		Brokers: map[string]*config.Broker{
@@ -93,3 +132,56 @@ func Config(t *testing.T, bc *intv1alpha1.BrokerCell, broker *brokerv1beta1.Brok
 	cm, _ := resources.MakeTargetsConfig(bc, brokerTargets)
 	return cm
 }
+
+func rateLimit(l *brokerv1beta1.RateLimit) *config.RateLimit {
+	if l == nil {
+		return nil
+	}
+	return &config.RateLimit{EventsPerSecond: l.EventsPerSecond, Burst: int32(l.Burst)}
+}
+
+func transport(namespace string, ts *brokerv1beta1.TransportSpec) *config.Transport {
+	if ts == nil {
+		return nil
+	}
+	t := &config.Transport{H2C: ts.H2C}
+	if ts.MaxIdleConnsPerHost != nil {
+		t.MaxIdleConnsPerHost = *ts.MaxIdleConnsPerHost
+	}
+	if ts.IdleConnTimeout != nil {
+		d, _ := utils.ParseISO8601Duration(*ts.IdleConnTimeout)
+		t.IdleConnTimeout = ptypes.DurationProto(d)
+	}
+	if ts.KeepAlive != nil {
+		d, _ := utils.ParseISO8601Duration(*ts.KeepAlive)
+		t.KeepAlive = ptypes.DurationProto(d)
+	}
+	if ts.TLS != nil {
+		t.Tls = &config.TransportTLS{CaBundle: ts.TLS.CABundle}
+		if ts.TLS.ClientCertificateSecret != "" {
+			t.Tls.ClientCertificate = resources.TargetCertName(namespace, ts.TLS.ClientCertificateSecret)
+		}
+	}
+	return t
+}
+
+func eventSchemas(b *brokerv1beta1.Broker) *config.EventSchemas {
+	s, _ := b.EventSchemas()
+	if s == nil {
+		return nil
+	}
+	schemas := &config.EventSchemas{}
+	for k, v := range s.Types {
+		if schemas.Types == nil {
+			schemas.Types = make(map[string]string)
+		}
+		schemas.Types[k] = string(v)
+	}
+	for k, v := range s.DataSchemas {
+		if schemas.DataSchemas == nil {
+			schemas.DataSchemas = make(map[string]string)
+		}
+		schemas.DataSchemas[k] = string(v)
+	}
+	return schemas
+}
@@ -18,11 +18,11 @@ package auditlogs
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 
 	"cloud.google.com/go/logging/logadmin"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
@@ -32,35 +32,28 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	cloudauditlogssourcereconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudauditlogssource"
 	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1beta1"
-	glogadmin "github.com/google/knative-gcp/pkg/gclient/logging/logadmin"
-	gpubsub "github.com/google/knative-gcp/pkg/gclient/pubsub"
 	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs/resources"
-	"github.com/google/knative-gcp/pkg/reconciler/identity"
-	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	"github.com/google/knative-gcp/pkg/reconciler/events/logsink"
 )
 
 const (
 	resourceGroup = "cloudauditlogssources.events.cloud.google.com"
-	publisherRole = "roles/pubsub.publisher"
 
-	deletePubSubFailed           = "PubSubDeleteFailed"
-	deleteSinkFailed             = "SinkDeleteFailed"
-	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
 	reconciledFailedReason       = "SinkReconcileFailed"
 	reconciledPubSubFailedReason = "PubSubReconcileFailed"
 	reconciledSuccessReason      = "CloudAuditLogsSourceReconciled"
+	scopeNotAllowedReason        = "ScopeNotAllowed"
 	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
 )
 
 type Reconciler struct {
-	*intevents.PubSubBase
-	// identity reconciler for reconciling workload identity.
-	*identity.Identity
-	auditLogsSourceLister  listers.CloudAuditLogsSourceLister
-	logadminClientProvider glogadmin.CreateFn
-	pubsubClientProvider   gpubsub.CreateFn
+	*logsink.Base
+	auditLogsSourceLister listers.CloudAuditLogsSourceLister
 	// serviceAccountLister for reading serviceAccounts.
 	serviceAccountLister corev1listers.ServiceAccountLister
+	// allowedScopes holds the allowedScopes of the namespaces, updated from the scopesConfigName
+	// ConfigMap.
+	allowedScopes atomic.Value
 }
 
 // Check that our Reconciler implements Interface.
@@ -98,108 +91,37 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, s *v1beta1.CloudAuditLog
 }
 
 func (c *Reconciler) reconcileSink(ctx context.Context, s *v1beta1.CloudAuditLogsSource) (string, error) {
-	sink, err := c.ensureSinkCreated(ctx, s)
-	if err != nil {
-		s.Status.MarkSinkNotReady("SinkCreateFailed", "failed to ensure creation of logging sink: %s", err.Error())
-		return "", err
-	}
-	err = c.ensureSinkIsPublisher(ctx, s, sink)
-	if err != nil {
-		s.Status.MarkSinkNotReady("SinkNotPublisher", "failed to ensure sink has pubsub.publisher permission on source topic: %s", err.Error())
-		return "", err
+	parent := resources.GenerateSinkParent(s)
+	if !c.scopeAllowed(s) {
+		s.Status.MarkSinkNotReady(scopeNotAllowedReason, "%s is not an allowed scope of namespace %s", parent, s.Namespace)
+		return "", fmt.Errorf("%s is not an allowed scope of namespace %s in the %s ConfigMap", parent, s.Namespace, scopesConfigName)
 	}
-	return sink.ID, nil
-}
-
-func (c *Reconciler) ensureSinkCreated(ctx context.Context, s *v1beta1.CloudAuditLogsSource) (*logadmin.Sink, error) {
 	sinkID := s.Status.StackdriverSink
 	if sinkID == "" {
 		sinkID = resources.GenerateSinkName(s)
 	}
-	logadminClient, err := c.logadminClientProvider(ctx, s.Status.ProjectID)
-	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
-		return nil, err
-	}
-	sink, err := logadminClient.Sink(ctx, sinkID)
-	if status.Code(err) == codes.NotFound {
-		filterBuilder := resources.FilterBuilder{}
-		filterBuilder.WithServiceName(s.Spec.ServiceName).WithMethodName(s.Spec.MethodName)
-		if s.Spec.ResourceName != "" {
-			filterBuilder.WithResourceName(s.Spec.ResourceName)
-		}
-		sink = &logadmin.Sink{
-			ID:          sinkID,
-			Destination: resources.GenerateTopicResourceName(s),
-			Filter:      filterBuilder.GetFilterQuery(),
-		}
-		sink, err = logadminClient.CreateSinkOpt(ctx, sink, logadmin.SinkOptions{UniqueWriterIdentity: true})
-		// Handle AlreadyExists in-case of a race between another create call.
-		if status.Code(err) == codes.AlreadyExists {
-			sink, err = logadminClient.Sink(ctx, sinkID)
-		}
-	}
-	return sink, err
-}
-
-// Ensures that the sink has been granted the pubsub.publisher role on the source topic.
-func (c *Reconciler) ensureSinkIsPublisher(ctx context.Context, s *v1beta1.CloudAuditLogsSource, sink *logadmin.Sink) error {
-	pubsubClient, err := c.pubsubClientProvider(ctx, s.Status.ProjectID)
-	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to create PubSub client", zap.Error(err))
-		return err
-	}
-	topicIam := pubsubClient.Topic(s.Status.TopicID).IAM()
-	topicPolicy, err := topicIam.Policy(ctx)
-	if err != nil {
-		return err
-	}
-	if !topicPolicy.HasRole(sink.WriterIdentity, publisherRole) {
-		topicPolicy.Add(sink.WriterIdentity, publisherRole)
-		if err = topicIam.SetPolicy(ctx, topicPolicy); err != nil {
-			return err
-		}
-		logging.FromContext(ctx).Desugar().Debug(
-			"Granted the Stackdriver Sink writer identity roles/pubsub.publisher on PubSub Topic.",
-			zap.String("writerIdentity", sink.WriterIdentity),
-			zap.String("topicID", s.Status.TopicID))
-	}
-	return nil
-}
-
-// deleteSink looks at status.SinkID and if non-empty will delete the
-// previously created stackdriver sink.
-func (c *Reconciler) deleteSink(ctx context.Context, s *v1beta1.CloudAuditLogsSource) error {
-	if s.Status.StackdriverSink == "" {
-		return nil
-	}
-	logadminClient, err := c.logadminClientProvider(ctx, s.Status.ProjectID)
-	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
-		return err
-	}
-	if err = logadminClient.DeleteSink(ctx, s.Status.StackdriverSink); status.Code(err) != codes.NotFound {
-		return err
-	}
-	return nil
+	filterBuilder := resources.FilterBuilder{}
+	filterBuilder.WithServiceName(s.Spec.ServiceName).
+		WithMethodName(s.Spec.MethodName).
+		WithMethodNames(s.Spec.MethodNames...).
+		WithResourceName(s.Spec.ResourceName).
+		WithResourceNamePrefix(s.Spec.ResourceNamePrefix).
+		WithPrincipalEmails(s.Spec.PrincipalEmails...).
+		WithSeverity(s.Spec.Severity).
+		WithAdvancedFilter(s.Spec.AdvancedFilter)
+	return c.ReconcileSink(ctx, s, &s.Status, parent, &logadmin.Sink{
+		ID:          sinkID,
+		Destination: resources.GenerateTopicResourceName(s),
+		Filter:      filterBuilder.GetFilterQuery(),
+		// Sinks of folders and organizations aggregate the logs of their child projects.
+		IncludeChildren: s.Spec.Scope == v1beta1.CloudAuditLogsSourceScopeFolder ||
+			s.Spec.Scope == v1beta1.CloudAuditLogsSourceScopeOrganization,
+	})
 }
 
 func (c *Reconciler) FinalizeKind(ctx context.Context, s *v1beta1.CloudAuditLogsSource) reconciler.Event {
-	// If k8s ServiceAccount exists, binds to the default GCP ServiceAccount, and it only has one ownerReference,
-	// remove the corresponding GCP ServiceAccount iam policy binding.
-	// No need to delete k8s ServiceAccount, it will be automatically handled by k8s Garbage Collection.
-	if s.Spec.ServiceAccountName != "" {
-		if err := c.Identity.DeleteWorkloadIdentity(ctx, s.Spec.Project, s); err != nil {
-			return reconciler.NewEvent(corev1.EventTypeWarning, deleteWorkloadIdentityFailed, "Failed to delete CloudAuditLogsSource workload identity: %s", err.Error())
-		}
-	}
-
-	if err := c.deleteSink(ctx, s); err != nil {
-		return reconciler.NewEvent(corev1.EventTypeWarning, deleteSinkFailed, "Failed to delete Stackdriver sink: %s", err.Error())
-	}
-
-	if err := c.PubSubBase.DeletePubSub(ctx, s); err != nil {
-		return reconciler.NewEvent(corev1.EventTypeWarning, deletePubSubFailed, "Failed to delete CloudAuditLogsSource PubSub: %s", err.Error())
+	if err := c.Finalize(ctx, s, resources.GenerateSinkParent(s), s.Status.StackdriverSink); err != nil {
+		return err
 	}
 	s.Status.StackdriverSink = ""
 	return nil
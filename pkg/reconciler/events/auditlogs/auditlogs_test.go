@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgotesting "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -42,6 +43,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	inteventsv1beta1 "github.com/google/knative-gcp/pkg/apis/intevents/v1beta1"
 	"github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudauditlogssource"
 	testiam "github.com/google/knative-gcp/pkg/gclient/iam/testing"
@@ -50,6 +52,7 @@ import (
 	testingMetadataClient "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
 	gpubsub "github.com/google/knative-gcp/pkg/gclient/pubsub/testing"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/events/logsink"
 	"github.com/google/knative-gcp/pkg/reconciler/identity"
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
@@ -66,6 +69,9 @@ const (
 	testMethodName  = "test-method"
 	testFilter      = `protoPayload.methodName="test-method" AND protoPayload.serviceName="test-service" AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`
 
+	testOrganizationID     = "123456789"
+	testOrganizationParent = "organizations/" + testOrganizationID
+
 	sinkName = "sink"
 	sinkDNS  = sinkName + ".mynamespace.svc.cluster.local"
 
@@ -1154,6 +1160,234 @@ func TestAllCases(t *testing.T) {
 				WithCloudAuditLogsSourceSetDefaults,
 			),
 		}},
+	}, {
+		Name: "sink created with filters",
+		Objects: []runtime.Object{
+			NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceMethodName(""),
+				WithCloudAuditLogsSourceMethodNames(testMethodName, "other-method"),
+				WithCloudAuditLogsSourceResourceNamePrefix("projects/_/buckets/prod-"),
+				WithCloudAuditLogsSourcePrincipalEmails("alice@example.com"),
+				WithCloudAuditLogsSourceSeverity("NOTICE"),
+				WithCloudAuditLogsSourceAdvancedFilter("protoPayload.status.code!=0"),
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+			NewTopic(sourceName, testNS,
+				WithTopicSpec(inteventsv1beta1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				WithTopicReady(testTopicID),
+				WithTopicAddress(testTopicURI),
+				WithTopicProjectID(testProject),
+				WithTopicSetDefaults,
+			),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+				WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret: &secret,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: newSinkDestination(),
+						},
+					},
+					AdapterType: string(converters.CloudAuditLogs),
+				})),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID: testSinkID,
+					Filter: `(protoPayload.methodName="test-method" OR protoPayload.methodName="other-method")` +
+						` AND protoPayload.serviceName="test-service"` +
+						` AND protoPayload.resourceName=~"^projects/_/buckets/prod-"` +
+						` AND protoPayload.authenticationInfo.principalEmail="alice@example.com"` +
+						` AND severity>=NOTICE` +
+						` AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"` +
+						` AND (protoPayload.status.code!=0)`,
+					Destination: testTopicResource,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudAuditLogsSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceMethodName(""),
+				WithCloudAuditLogsSourceMethodNames(testMethodName, "other-method"),
+				WithCloudAuditLogsSourceResourceNamePrefix("projects/_/buckets/prod-"),
+				WithCloudAuditLogsSourcePrincipalEmails("alice@example.com"),
+				WithCloudAuditLogsSourceSeverity("NOTICE"),
+				WithCloudAuditLogsSourceAdvancedFilter("protoPayload.status.code!=0"),
+				WithCloudAuditLogsSourceProjectID(testProject),
+				WithCloudAuditLogsSourceSubscriptionID(SubscriptionID),
+				WithInitCloudAuditLogsSourceConditions,
+				WithCloudAuditLogsSourceTopicReady(testTopicID),
+				WithCloudAuditLogsSourcePullSubscriptionReady(),
+				WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				WithCloudAuditLogsSourceSinkReady,
+				WithCloudAuditLogsSourceSinkID(testSinkID),
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "organization sink created",
+		Objects: []runtime.Object{
+			NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+			NewTopic(sourceName, testNS,
+				WithTopicSpec(inteventsv1beta1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				WithTopicReady(testTopicID),
+				WithTopicAddress(testTopicURI),
+				WithTopicProjectID(testProject),
+				WithTopicSetDefaults,
+			),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+				WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret: &secret,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: newSinkDestination(),
+						},
+					},
+					AdapterType: string(converters.CloudAuditLogs),
+				})),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"sinkParent": testOrganizationParent,
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:              testSinkID,
+					Filter:          testFilter,
+					Destination:     testTopicResource,
+					IncludeChildren: true,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudAuditLogsSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithCloudAuditLogsSourceProjectID(testProject),
+				WithCloudAuditLogsSourceSubscriptionID(SubscriptionID),
+				WithInitCloudAuditLogsSourceConditions,
+				WithCloudAuditLogsSourceTopicReady(testTopicID),
+				WithCloudAuditLogsSourcePullSubscriptionReady(),
+				WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				WithCloudAuditLogsSourceSinkReady,
+				WithCloudAuditLogsSourceSinkID(testSinkID),
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "organization scope not allowed",
+		Objects: []runtime.Object{
+			NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+			NewTopic(sourceName, testNS,
+				WithTopicSpec(inteventsv1beta1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				WithTopicReady(testTopicID),
+				WithTopicAddress(testTopicURI),
+				WithTopicProjectID(testProject),
+				WithTopicSetDefaults,
+			),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+				WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret: &secret,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: newSinkDestination(),
+						},
+					},
+					AdapterType: string(converters.CloudAuditLogs),
+				})),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"sinkParent":    testOrganizationParent,
+			"allowedScopes": allowedScopes{"other": sets.NewString(testOrganizationParent)},
+			"expectedSinks": map[string]*logadmin.Sink{testSinkID: nil},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Sink failed with: %s is not an allowed scope of namespace %s in the %s ConfigMap",
+				testOrganizationParent, testNS, scopesConfigName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithCloudAuditLogsSourceProjectID(testProject),
+				WithCloudAuditLogsSourceSubscriptionID(SubscriptionID),
+				WithInitCloudAuditLogsSourceConditions,
+				WithCloudAuditLogsSourceTopicReady(testTopicID),
+				WithCloudAuditLogsSourcePullSubscriptionReady(),
+				WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				WithCloudAuditLogsSourceSetDefaults,
+				WithCloudAuditLogsSourceSinkNotReady(scopeNotAllowedReason, "%s is not an allowed scope of namespace %s", testOrganizationParent, testNS),
+			),
+		}},
 	}, {
 		Name: "sink exists",
 		Objects: []runtime.Object{
@@ -1272,7 +1506,7 @@ func TestAllCases(t *testing.T) {
 			},
 		},
 		WantEvents: []string{
-			Eventf(corev1.EventTypeWarning, deleteSinkFailed, "Failed to delete Stackdriver sink: delete-sink-induced-error"),
+			Eventf(corev1.EventTypeWarning, logsink.DeleteSinkFailed, "Failed to delete Stackdriver sink: delete-sink-induced-error"),
 		},
 		WantStatusUpdates: nil,
 	}, {
@@ -1338,6 +1572,73 @@ func TestAllCases(t *testing.T) {
 				Name: sourceName,
 			},
 		},
+	}, {
+		Name: "organization sink delete succeeds",
+		Objects: []runtime.Object{
+			NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithCloudAuditLogsSourceProjectID(testProject),
+				WithInitCloudAuditLogsSourceConditions,
+				WithCloudAuditLogsSourceTopicReady(testTopicID),
+				WithCloudAuditLogsSourcePullSubscriptionReady(),
+				WithCloudAuditLogsSourceSinkURI(calSinkURL),
+				WithCloudAuditLogsSourceSinkReady,
+				WithCloudAuditLogsSourceSinkID(testSinkID),
+				WithCloudAuditLogsSourceDeletionTimestamp,
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+			NewTopic(sourceName, testNS,
+				WithTopicReady(testTopicID),
+				WithTopicAddress(testTopicURI),
+				WithTopicProjectID(testProject),
+				WithTopicSetDefaults,
+			),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"sinkParent": testOrganizationParent,
+			"existingSinks": []logadmin.Sink{{
+				ID:              testSinkID,
+				Filter:          testFilter,
+				Destination:     testTopicResource,
+				IncludeChildren: true,
+			}},
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: nil,
+			},
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudAuditLogsSource(sourceName, testNS,
+				WithCloudAuditLogsSourceUID(sourceUID),
+				WithCloudAuditLogsSourceMethodName(testMethodName),
+				WithCloudAuditLogsSourceServiceName(testServiceName),
+				WithCloudAuditLogsSourceSink(sinkGVK, sinkName),
+				WithCloudAuditLogsSourceScope(v1beta1.CloudAuditLogsSourceScopeOrganization, testOrganizationID),
+				WithInitCloudAuditLogsSourceConditions,
+				WithCloudAuditLogsSourceSinkReady,
+				WithCloudAuditLogsSourceTopicFailed("TopicDeleted", fmt.Sprintf("Successfully deleted Topic: %s", sourceName)),
+				WithCloudAuditLogsSourcePullSubscriptionFailed("PullSubscriptionDeleted", fmt.Sprintf("Successfully deleted PullSubscription: %s", sourceName)),
+				WithCloudAuditLogsSourceDeletionTimestamp,
+				WithCloudAuditLogsSourceSetDefaults,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
 	}, {
 		Name: "delete succeeds, sink does not exist",
 		Objects: []runtime.Object{
@@ -1402,36 +1703,47 @@ func TestAllCases(t *testing.T) {
 	for _, tt := range table {
 		t.Run(tt.Name, func(t *testing.T) {
 			logadminClientProvider := glogadmintesting.TestClientCreator(tt.OtherTestData["logadmin"])
+			sinkParent := testProject
+			if parent, ok := tt.OtherTestData["sinkParent"]; ok {
+				sinkParent = parent.(string)
+			}
 			if existingSinks := tt.OtherTestData["existingSinks"]; existingSinks != nil {
-				createSinks(t, logadminClientProvider, existingSinks.([]logadmin.Sink))
+				createSinks(t, logadminClientProvider, sinkParent, existingSinks.([]logadmin.Sink))
 			}
 			tt.Test(t, MakeFactory(
 				func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
 					r := &Reconciler{
-						PubSubBase: intevents.NewPubSubBase(ctx,
-							&intevents.PubSubBaseArgs{
-								ControllerAgentName: controllerAgentName,
-								ReceiveAdapterName:  receiveAdapterName,
-								ReceiveAdapterType:  string(converters.CloudAuditLogs),
-								ConfigWatcher:       cmw,
-							}),
-						Identity:               identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
-						auditLogsSourceLister:  listers.GetCloudAuditLogsSourceLister(),
-						logadminClientProvider: logadminClientProvider,
-						pubsubClientProvider:   gpubsub.TestClientCreator(testData["pubsub"]),
-						serviceAccountLister:   listers.GetServiceAccountLister(),
+						Base: &logsink.Base{
+							PubSubBase: intevents.NewPubSubBase(ctx,
+								&intevents.PubSubBaseArgs{
+									ControllerAgentName: controllerAgentName,
+									ReceiveAdapterName:  receiveAdapterName,
+									ReceiveAdapterType:  string(converters.CloudAuditLogs),
+									ConfigWatcher:       cmw,
+								}),
+							Identity:               identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
+							LogadminClientProvider: logadminClientProvider,
+							PubsubClientProvider:   gpubsub.TestClientCreator(testData["pubsub"]),
+						},
+						auditLogsSourceLister: listers.GetCloudAuditLogsSourceLister(),
+						serviceAccountLister:  listers.GetServiceAccountLister(),
+					}
+					scopes := allowedScopes{testNS: sets.NewString(testOrganizationParent)}
+					if s, ok := testData["allowedScopes"]; ok {
+						scopes = s.(allowedScopes)
 					}
+					r.allowedScopes.Store(scopes)
 					return cloudauditlogssource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudAuditLogsSourceLister(), r.Recorder, r)
 				}))
 			if expectedSinks := tt.OtherTestData["expectedSinks"]; expectedSinks != nil {
-				expectSinks(t, logadminClientProvider, expectedSinks.(map[string]*logadmin.Sink))
+				expectSinks(t, logadminClientProvider, sinkParent, expectedSinks.(map[string]*logadmin.Sink))
 			}
 		})
 	}
 }
 
-func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks []logadmin.Sink) {
-	logadminClient, err := clientProvider(context.Background(), testProject)
+func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, parent string, sinks []logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), parent)
 	if err != nil {
 		t.Fatalf("failed to create logadmin client during setup: %s", err)
 	}
@@ -1440,8 +1752,8 @@ func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks []logadm
 	}
 }
 
-func expectSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks map[string]*logadmin.Sink) {
-	logadminClient, err := clientProvider(context.Background(), testProject)
+func expectSinks(t *testing.T, clientProvider glogadmin.CreateFn, parent string, sinks map[string]*logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), parent)
 	if err != nil {
 		t.Fatalf("failed to create logadmin client during verification: %s", err)
 	}
@@ -22,6 +22,7 @@ import (
 
 	"knative.dev/pkg/injection"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 	serviceaccountinformers "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
 	"knative.dev/pkg/configmap"
@@ -31,6 +32,7 @@ import (
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	"github.com/google/knative-gcp/pkg/reconciler/events/logsink"
 	"github.com/google/knative-gcp/pkg/reconciler/identity"
 	"github.com/google/knative-gcp/pkg/reconciler/identity/iam"
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
@@ -76,18 +78,20 @@ func newController(
 	serviceAccountInformer := serviceaccountinformers.Get(ctx)
 
 	r := &Reconciler{
-		PubSubBase: intevents.NewPubSubBase(ctx,
-			&intevents.PubSubBaseArgs{
-				ControllerAgentName: controllerAgentName,
-				ReceiveAdapterName:  receiveAdapterName,
-				ReceiveAdapterType:  string(converters.CloudAuditLogs),
-				ConfigWatcher:       cmw,
-			}),
-		Identity:               identity.NewIdentity(ctx, ipm, gcpas),
-		auditLogsSourceLister:  cloudauditlogssourceInformer.Lister(),
-		logadminClientProvider: glogadmin.NewClient,
-		pubsubClientProvider:   gpubsub.NewClient,
-		serviceAccountLister:   serviceAccountInformer.Lister(),
+		Base: &logsink.Base{
+			PubSubBase: intevents.NewPubSubBase(ctx,
+				&intevents.PubSubBaseArgs{
+					ControllerAgentName: controllerAgentName,
+					ReceiveAdapterName:  receiveAdapterName,
+					ReceiveAdapterType:  string(converters.CloudAuditLogs),
+					ConfigWatcher:       cmw,
+				}),
+			Identity:               identity.NewIdentity(ctx, ipm, gcpas),
+			LogadminClientProvider: glogadmin.NewClient,
+			PubsubClientProvider:   gpubsub.NewClient,
+		},
+		auditLogsSourceLister: cloudauditlogssourceInformer.Lister(),
+		serviceAccountLister:  serviceAccountInformer.Lister(),
 	}
 	impl := cloudauditlogssourcereconciler.NewImpl(ctx, r)
 
@@ -110,5 +114,10 @@ func newController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Sources wait for their scope to be allowed, so they are resynced when it changes.
+	cmw.Watch(scopesConfigName, r.UpdateFromScopesConfigMap, func(*corev1.ConfigMap) {
+		impl.GlobalResync(cloudauditlogssourceInformer.Informer())
+	})
+
 	return impl
 }
@@ -2,25 +2,33 @@ package resources
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
 const (
-	keyPrefix   = "protoPayload"
-	methodKey   = keyPrefix + ".methodName"
-	serviceKey  = keyPrefix + ".serviceName"
-	resourceKey = keyPrefix + ".resourceName"
-	typeKey     = keyPrefix + ".\x22@type\x22"
-	typeValue   = "type.googleapis.com/google.cloud.audit.AuditLog"
+	keyPrefix         = "protoPayload"
+	methodKey         = keyPrefix + ".methodName"
+	serviceKey        = keyPrefix + ".serviceName"
+	resourceKey       = keyPrefix + ".resourceName"
+	principalEmailKey = keyPrefix + ".authenticationInfo.principalEmail"
+	typeKey           = keyPrefix + ".\x22@type\x22"
+	typeValue         = "type.googleapis.com/google.cloud.audit.AuditLog"
+	severityKey       = "severity"
 )
 
 // Stackdriver query builder for querying audit logs. Currently
-// supports querying by the AuditLog serviceName, methodName, and
-// resourceName.
+// supports querying by the AuditLog serviceName, methodName(s),
+// resourceName or its prefix, principal emails, the minimum severity
+// of the log entries and an advanced filter.
 type FilterBuilder struct {
-	serviceName  string
-	methodName   string
-	resourceName string
+	serviceName        string
+	methodNames        []string
+	resourceName       string
+	resourceNamePrefix string
+	principalEmails    []string
+	severity           string
+	advancedFilter     string
 }
 
 func (fb *FilterBuilder) WithServiceName(serviceName string) *FilterBuilder {
@@ -29,7 +37,16 @@ func (fb *FilterBuilder) WithServiceName(serviceName string) *FilterBuilder {
 }
 
 func (fb *FilterBuilder) WithMethodName(methodName string) *FilterBuilder {
-	fb.methodName = methodName
+	if methodName != "" {
+		fb.methodNames = append(fb.methodNames, methodName)
+	}
+	return fb
+}
+
+func (fb *FilterBuilder) WithMethodNames(methodNames ...string) *FilterBuilder {
+	for _, methodName := range methodNames {
+		fb.WithMethodName(methodName)
+	}
 	return fb
 }
 
@@ -38,10 +55,30 @@ func (fb *FilterBuilder) WithResourceName(resourceName string) *FilterBuilder {
 	return fb
 }
 
+func (fb *FilterBuilder) WithResourceNamePrefix(resourceNamePrefix string) *FilterBuilder {
+	fb.resourceNamePrefix = resourceNamePrefix
+	return fb
+}
+
+func (fb *FilterBuilder) WithPrincipalEmails(principalEmails ...string) *FilterBuilder {
+	fb.principalEmails = append(fb.principalEmails, principalEmails...)
+	return fb
+}
+
+func (fb *FilterBuilder) WithSeverity(severity string) *FilterBuilder {
+	fb.severity = severity
+	return fb
+}
+
+func (fb *FilterBuilder) WithAdvancedFilter(advancedFilter string) *FilterBuilder {
+	fb.advancedFilter = advancedFilter
+	return fb
+}
+
 func (fb *FilterBuilder) GetFilterQuery() string {
 	var filters []string
-	if fb.methodName != "" {
-		filters = append(filters, filter{methodKey, fb.methodName}.String())
+	if len(fb.methodNames) > 0 {
+		filters = append(filters, anyOf(methodKey, fb.methodNames))
 	}
 
 	if fb.serviceName != "" {
@@ -52,11 +89,39 @@ func (fb *FilterBuilder) GetFilterQuery() string {
 		filters = append(filters, filter{resourceKey, fb.resourceName}.String())
 	}
 
+	if fb.resourceNamePrefix != "" {
+		filters = append(filters, fmt.Sprintf("%s=~%q", resourceKey, "^"+regexp.QuoteMeta(fb.resourceNamePrefix)))
+	}
+
+	if len(fb.principalEmails) > 0 {
+		filters = append(filters, anyOf(principalEmailKey, fb.principalEmails))
+	}
+
+	if fb.severity != "" {
+		filters = append(filters, fmt.Sprintf("%s>=%s", severityKey, fb.severity))
+	}
+
 	filters = append(filters, filter{typeKey, typeValue}.String())
+
+	if fb.advancedFilter != "" {
+		filters = append(filters, "("+fb.advancedFilter+")")
+	}
 	filter := strings.Join(filters, " AND ")
 	return filter
 }
 
+// anyOf returns the filter matching any of the values of the key.
+func anyOf(key string, values []string) string {
+	if len(values) == 1 {
+		return filter{key, values[0]}.String()
+	}
+	filters := make([]string, 0, len(values))
+	for _, value := range values {
+		filters = append(filters, filter{key, value}.String())
+	}
+	return "(" + strings.Join(filters, " OR ") + ")"
+}
+
 type filter struct {
 	key   string
 	value string
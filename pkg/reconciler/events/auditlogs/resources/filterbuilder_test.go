@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetFilterQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(fb *FilterBuilder)
+		want  string
+	}{{
+		name: "service and method",
+		build: func(fb *FilterBuilder) {
+			fb.WithServiceName("storage.googleapis.com").WithMethodName("storage.buckets.create")
+		},
+		want: `protoPayload.methodName="storage.buckets.create" AND protoPayload.serviceName="storage.googleapis.com" AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`,
+	}, {
+		name: "resource name",
+		build: func(fb *FilterBuilder) {
+			fb.WithServiceName("storage.googleapis.com").WithMethodName("storage.buckets.create").
+				WithResourceName("projects/_/buckets/bucket")
+		},
+		want: `protoPayload.methodName="storage.buckets.create" AND protoPayload.serviceName="storage.googleapis.com" AND protoPayload.resourceName="projects/_/buckets/bucket" AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`,
+	}, {
+		name: "all conditions",
+		build: func(fb *FilterBuilder) {
+			fb.WithServiceName("storage.googleapis.com").
+				WithMethodNames("storage.setIamPermissions", "SetIamPolicy").
+				WithResourceNamePrefix("projects/_/buckets/prod.").
+				WithPrincipalEmails("alice@example.com", "bob@example.com").
+				WithSeverity("NOTICE").
+				WithAdvancedFilter(`protoPayload.status.code!=0 OR labels.env="prod"`)
+		},
+		want: `(protoPayload.methodName="storage.setIamPermissions" OR protoPayload.methodName="SetIamPolicy")` +
+			` AND protoPayload.serviceName="storage.googleapis.com"` +
+			` AND protoPayload.resourceName=~"^projects/_/buckets/prod\\."` +
+			` AND (protoPayload.authenticationInfo.principalEmail="alice@example.com" OR protoPayload.authenticationInfo.principalEmail="bob@example.com")` +
+			` AND severity>=NOTICE` +
+			` AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"` +
+			` AND (protoPayload.status.code!=0 OR labels.env="prod")`,
+	}, {
+		name: "empty values",
+		build: func(fb *FilterBuilder) {
+			fb.WithServiceName("storage.googleapis.com").WithMethodName("").WithMethodNames("storage.buckets.create").
+				WithResourceName("").WithResourceNamePrefix("").WithSeverity("").WithAdvancedFilter("")
+		},
+		want: `protoPayload.methodName="storage.buckets.create" AND protoPayload.serviceName="storage.googleapis.com" AND protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fb := &FilterBuilder{}
+			tc.build(fb)
+			if diff := cmp.Diff(tc.want, fb.GetFilterQuery()); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
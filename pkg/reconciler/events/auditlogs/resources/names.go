@@ -41,3 +41,17 @@ func GenerateTopicResourceName(s *v1beta1.CloudAuditLogsSource) string {
 func GenerateSinkName(s *v1beta1.CloudAuditLogsSource) string {
 	return naming.TruncatedLoggingSinkResourceName("cre-src", s.Namespace, s.Name, s.UID)
 }
+
+// GenerateSinkParent generates the parent of the Stackdriver sink of an
+// CloudAuditLogsSource, which is its project unless the source has a folder or
+// organization scope.
+func GenerateSinkParent(s *v1beta1.CloudAuditLogsSource) string {
+	switch s.Spec.Scope {
+	case v1beta1.CloudAuditLogsSourceScopeFolder:
+		return "folders/" + s.Spec.ScopeID
+	case v1beta1.CloudAuditLogsSourceScopeOrganization:
+		return "organizations/" + s.Spec.ScopeID
+	default:
+		return s.Status.ProjectID
+	}
+}
@@ -70,3 +70,32 @@ func TestGenerateSinkName(t *testing.T) {
 		t.Errorf("unexpected (-want, +got) = %v", diff)
 	}
 }
+
+func TestGenerateSinkParent(t *testing.T) {
+	tests := []struct {
+		scope   string
+		scopeID string
+		want    string
+	}{
+		{"", "", "project"},
+		{v1beta1.CloudAuditLogsSourceScopeProject, "", "project"},
+		{v1beta1.CloudAuditLogsSourceScopeFolder, "123", "folders/123"},
+		{v1beta1.CloudAuditLogsSourceScopeOrganization, "456", "organizations/456"},
+	}
+	for _, tc := range tests {
+		got := GenerateSinkParent(&v1beta1.CloudAuditLogsSource{
+			Spec: v1beta1.CloudAuditLogsSourceSpec{
+				Scope:   tc.scope,
+				ScopeID: tc.scopeID,
+			},
+			Status: v1beta1.CloudAuditLogsSourceStatus{
+				PubSubStatus: duckv1beta1.PubSubStatus{
+					ProjectID: "project",
+				},
+			},
+		})
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("unexpected (-want, +got) for scope %q = %v", tc.scope, diff)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/reconciler/events/auditlogs/resources"
+)
+
+// scopesConfigName is the name of the ConfigMap with the folders and organizations in which the
+// CloudAuditLogsSources of each namespace may create sinks. Its keys are namespaces, and its
+// values are YAML lists of sink parents, e.g. "folders/123" or "organizations/456". Keys
+// starting with an underscore, like _example, are ignored.
+const scopesConfigName = "config-auditlogs-scopes"
+
+// allowedScopes are the sink parents allowed for each namespace.
+type allowedScopes map[string]sets.String
+
+// newAllowedScopesFromConfigMap parses the allowed scopes in the ConfigMap.
+func newAllowedScopesFromConfigMap(cm *corev1.ConfigMap) (allowedScopes, error) {
+	scopes := make(allowedScopes, len(cm.Data))
+	for namespace, value := range cm.Data {
+		if strings.HasPrefix(namespace, "_") {
+			continue
+		}
+		var parents []string
+		if err := yaml.Unmarshal([]byte(value), &parents); err != nil {
+			return nil, fmt.Errorf("failed to parse the scopes of namespace %q: %w", namespace, err)
+		}
+		for _, p := range parents {
+			if !strings.HasPrefix(p, "folders/") && !strings.HasPrefix(p, "organizations/") {
+				return nil, fmt.Errorf("scope %q of namespace %q is not a folder or organization", p, namespace)
+			}
+		}
+		scopes[namespace] = sets.NewString(parents...)
+	}
+	return scopes, nil
+}
+
+// allows returns whether the sources of the namespace may create sinks in the parent.
+func (a allowedScopes) allows(namespace, parent string) bool {
+	return a[namespace].Has(parent)
+}
+
+// UpdateFromScopesConfigMap updates the allowed scopes from the ConfigMap. An invalid ConfigMap
+// keeps the previous allowed scopes.
+func (c *Reconciler) UpdateFromScopesConfigMap(cm *corev1.ConfigMap) {
+	scopes, err := newAllowedScopesFromConfigMap(cm)
+	if err != nil {
+		c.Logger.Errorw("Failed to parse the allowed scopes", zap.Error(err))
+		return
+	}
+	c.allowedScopes.Store(scopes)
+}
+
+// scopeAllowed returns whether the source may create a sink in its folder or organization.
+// Sources of projects are always allowed.
+func (c *Reconciler) scopeAllowed(s *v1beta1.CloudAuditLogsSource) bool {
+	switch s.Spec.Scope {
+	case v1beta1.CloudAuditLogsSourceScopeFolder, v1beta1.CloudAuditLogsSourceScopeOrganization:
+		scopes, _ := c.allowedScopes.Load().(allowedScopes)
+		return scopes.allows(s.Namespace, resources.GenerateSinkParent(s))
+	default:
+		return true
+	}
+}
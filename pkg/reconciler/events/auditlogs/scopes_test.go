@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlogs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestNewAllowedScopesFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    map[string]string
+		want    allowedScopes
+		wantErr bool
+	}{{
+		name: "empty",
+		want: allowedScopes{},
+	}, {
+		name: "namespaces",
+		data: map[string]string{
+			"_example": "my-namespace: |\n  - folders/1\n",
+			"ns1":      "- folders/123\n- organizations/456\n",
+			"ns2":      "[]",
+		},
+		want: allowedScopes{
+			"ns1": sets.NewString("folders/123", "organizations/456"),
+			"ns2": sets.NewString(),
+		},
+	}, {
+		name:    "project",
+		data:    map[string]string{"ns1": "- projects/my-project\n"},
+		wantErr: true,
+	}, {
+		name:    "not a list",
+		data:    map[string]string{"ns1": "folders/123"},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := newAllowedScopesFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("newAllowedScopesFromConfigMap() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("newAllowedScopesFromConfigMap() (-want,+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowedScopesAllows(t *testing.T) {
+	scopes := allowedScopes{"ns1": sets.NewString("folders/123")}
+	if !scopes.allows("ns1", "folders/123") {
+		t.Error("folders/123 is not allowed in ns1")
+	}
+	if scopes.allows("ns1", "organizations/123") {
+		t.Error("organizations/123 is allowed in ns1")
+	}
+	if scopes.allows("ns2", "folders/123") {
+		t.Error("folders/123 is allowed in ns2")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firestore
+
+import (
+	"context"
+
+	"cloud.google.com/go/logging/logadmin"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	cloudfirestoresourcereconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudfirestoresource"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/reconciler/events/firestore/resources"
+	"github.com/google/knative-gcp/pkg/reconciler/events/logsink"
+)
+
+const (
+	resourceGroup = "cloudfirestoresources.events.cloud.google.com"
+
+	reconciledFailedReason       = "SinkReconcileFailed"
+	reconciledPubSubFailedReason = "PubSubReconcileFailed"
+	reconciledSuccessReason      = "CloudFirestoreSourceReconciled"
+	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
+)
+
+type Reconciler struct {
+	*logsink.Base
+	firestoreSourceLister listers.CloudFirestoreSourceLister
+	// serviceAccountLister for reading serviceAccounts.
+	serviceAccountLister corev1listers.ServiceAccountLister
+}
+
+// Check that our Reconciler implements Interface.
+var _ cloudfirestoresourcereconciler.Interface = (*Reconciler)(nil)
+
+func (c *Reconciler) ReconcileKind(ctx context.Context, s *v1beta1.CloudFirestoreSource) reconciler.Event {
+	ctx = logging.WithLogger(ctx, c.Logger.With(zap.Any("firestoresource", s)))
+
+	s.Status.InitializeConditions()
+	s.Status.ObservedGeneration = s.Generation
+
+	// If ServiceAccountName is provided, reconcile workload identity.
+	if s.Spec.ServiceAccountName != "" {
+		if _, err := c.Identity.ReconcileWorkloadIdentity(ctx, s.Spec.Project, s); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, workloadIdentityFailed, "Failed to reconcile CloudFirestoreSource workload identity: %s", err.Error())
+		}
+	}
+
+	topic := resources.GenerateTopicName(s)
+	t, ps, err := c.PubSubBase.ReconcilePubSub(ctx, s, topic, resourceGroup)
+	if err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+	}
+	c.Logger.Debugf("Reconciled: PubSub: %+v PullSubscription: %+v", t, ps)
+
+	sink, err := c.reconcileSink(ctx, s)
+	if err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Sink failed with: %s", err.Error())
+	}
+	s.Status.StackdriverSink = sink
+	s.Status.MarkSinkReady()
+	c.Logger.Debugf("Reconciled Stackdriver sink: %+v", sink)
+
+	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudFirestoreSource reconciled: "%s/%s"`, s.Namespace, s.Name)
+}
+
+func (c *Reconciler) reconcileSink(ctx context.Context, s *v1beta1.CloudFirestoreSource) (string, error) {
+	sinkID := s.Status.StackdriverSink
+	if sinkID == "" {
+		sinkID = resources.GenerateSinkName(s)
+	}
+	return c.ReconcileSink(ctx, s, &s.Status, s.Status.ProjectID, &logadmin.Sink{
+		ID:          sinkID,
+		Destination: resources.GenerateTopicResourceName(s),
+		Filter:      resources.GenerateSinkFilter(s),
+	})
+}
+
+func (c *Reconciler) FinalizeKind(ctx context.Context, s *v1beta1.CloudFirestoreSource) reconciler.Event {
+	if err := c.Finalize(ctx, s, s.Status.ProjectID, s.Status.StackdriverSink); err != nil {
+		return err
+	}
+	s.Status.StackdriverSink = ""
+	return nil
+}
@@ -0,0 +1,632 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/logging/logadmin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	. "knative.dev/pkg/reconciler/testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	inteventsv1beta1 "github.com/google/knative-gcp/pkg/apis/intevents/v1beta1"
+	"github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudfirestoresource"
+	glogadmin "github.com/google/knative-gcp/pkg/gclient/logging/logadmin"
+	glogadmintesting "github.com/google/knative-gcp/pkg/gclient/logging/logadmin/testing"
+	testingMetadataClient "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
+	gpubsub "github.com/google/knative-gcp/pkg/gclient/pubsub/testing"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/events/logsink"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+)
+
+const (
+	sourceName   = "test-firestore"
+	sourceUID    = "test-firestore-uid"
+	testNS       = "testnamespace"
+	testProject  = "test-project-id"
+	testTopicURI = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
+
+	testDocument       = "users/{userId}"
+	testAdapterOptions = `{"document":"users/{userId}","eventTypes":["com.google.cloud.firestore.document.create"]}`
+	testFilter         = `protoPayload.serviceName="firestore.googleapis.com" AND ` +
+		`(protoPayload.methodName="google.firestore.v1.Firestore.Commit" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.BatchWrite" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.CreateDocument" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.UpdateDocument" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.DeleteDocument") AND ` +
+		`protoPayload.resourceName="projects/test-project-id/databases/(default)" AND ` +
+		`protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog" AND ` +
+		`NOT protoPayload.status.code>0`
+
+	sinkName = "sink"
+	sinkDNS  = sinkName + ".mynamespace.svc.cluster.local"
+
+	failedToReconcileTopicMsg                  = `Topic has not yet been reconciled`
+	failedToReconcilePullSubscriptionMsg       = `PullSubscription has not yet been reconciled`
+	failedToCreateSinkMsg                      = `failed to ensure creation of logging sink`
+	failedToPropagatePullSubscriptionStatusMsg = `Failed to propagate PullSubscription status`
+)
+
+var (
+	trueVal  = true
+	falseVal = false
+
+	sinkGVK = metav1.GroupVersionKind{
+		Group:   "testing.cloud.google.com",
+		Version: "v1beta1",
+		Kind:    "Sink",
+	}
+
+	testTopicID       = fmt.Sprintf("cre-src_%s_%s_%s", testNS, sourceName, sourceUID)
+	testSinkID        = fmt.Sprintf("cre-src_%s_%s_%s", testNS, sourceName, sourceUID)
+	testTopicResource = fmt.Sprintf("pubsub.googleapis.com/projects/%s/topics/%s", testProject, testTopicID)
+
+	secret = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "google-cloud-key",
+		},
+		Key: "key.json",
+	}
+
+	sinkURI = apis.HTTP(sinkDNS)
+)
+
+func sourceOwnerRef(name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         "events.cloud.google.com/v1beta1",
+		Kind:               "CloudFirestoreSource",
+		Name:               name,
+		UID:                uid,
+		Controller:         &trueVal,
+		BlockOwnerDeletion: &trueVal,
+	}
+}
+
+func patchFinalizers(namespace, name string, add bool) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	var fname string
+	if add {
+		fname = fmt.Sprintf("%q", resourceGroup)
+	}
+	patch := `{"metadata":{"finalizers":[` + fname + `],"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func newSinkDestination() duckv1.Destination {
+	return duckv1.Destination{
+		Ref: &duckv1.KReference{
+			APIVersion: "testing.cloud.google.com/v1beta1",
+			Kind:       "Sink",
+			Name:       sinkName,
+		},
+	}
+}
+
+func newReadyTopic() *inteventsv1beta1.Topic {
+	return NewTopic(sourceName, testNS,
+		WithTopicSpec(inteventsv1beta1.TopicSpec{
+			Topic:             testTopicID,
+			PropagationPolicy: "CreateDelete",
+			EnablePublisher:   &falseVal,
+		}),
+		WithTopicReady(testTopicID),
+		WithTopicAddress(testTopicURI),
+		WithTopicProjectID(testProject),
+		WithTopicSetDefaults,
+	)
+}
+
+func newPullSubscription(opts ...PullSubscriptionOption) *inteventsv1beta1.PullSubscription {
+	opts = append(opts, WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+		Topic: testTopicID,
+		PubSubSpec: duckv1beta1.PubSubSpec{
+			Secret: &secret,
+			SourceSpec: duckv1.SourceSpec{
+				Sink: newSinkDestination(),
+			},
+		},
+		AdapterType:    string(converters.CloudFirestore),
+		AdapterOptions: testAdapterOptions,
+	}))
+	return NewPullSubscription(sourceName, testNS, opts...)
+}
+
+func TestAllCases(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		Key:  "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "topic created, not yet been reconciled",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceTopicUnknown("TopicNotConfigured", failedToReconcileTopicMsg),
+				WithCloudFirestoreSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		}},
+		WantCreates: []runtime.Object{
+			NewTopic(sourceName, testNS,
+				WithTopicSpec(inteventsv1beta1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				WithTopicLabels(map[string]string{
+					"receive-adapter":                     receiveAdapterName,
+					"events.cloud.google.com/source-name": sourceName,
+				}),
+				WithTopicOwnerReferences([]metav1.OwnerReference{sourceOwnerRef(sourceName, sourceUID)}),
+				WithTopicAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithTopicSetDefaults,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: Topic %q has not yet been reconciled", sourceName),
+		},
+	}, {
+		Name: "topic exists and is ready, pullsubscription created with adapter options",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudFirestoreSourceSetDefaults,
+				WithCloudFirestoreSourcePullSubscriptionUnknown("PullSubscriptionNotConfigured", failedToReconcilePullSubscriptionMsg),
+			),
+		}},
+		WantCreates: []runtime.Object{
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret: &secret,
+					},
+					AdapterType:    string(converters.CloudFirestore),
+					AdapterOptions: testAdapterOptions,
+				}),
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				WithPullSubscriptionLabels(map[string]string{
+					"receive-adapter":                     receiveAdapterName,
+					"events.cloud.google.com/source-name": sourceName,
+				}),
+				WithPullSubscriptionAnnotations(map[string]string{
+					"metrics-resource-group":          resourceGroup,
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithPullSubscriptionOwnerReferences([]metav1.OwnerReference{sourceOwnerRef(sourceName, sourceUID)}),
+				WithPullSubscriptionDefaultGCPAuth,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, `Reconcile PubSub failed with: %s: PullSubscription %q has not yet been reconciled`, failedToPropagatePullSubscriptionStatusMsg, sourceName),
+		},
+	}, {
+		Name: "topic exists and ready, pullsubscription exists and the status of pullsubscription is unknown",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionUnknown()),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionUnknown("", ""),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s: the status of PullSubscription %q is Unknown", failedToPropagatePullSubscriptionStatusMsg, sourceName),
+		},
+	}, {
+		Name: "create sink fails",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"logadmin": glogadmintesting.TestClientConfiguration{
+				CreateSinkErr: errors.New("create-client-induced-error"),
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Sink failed with: create-client-induced-error"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithCloudFirestoreSourceSubscriptionID(SubscriptionID),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionReady(),
+				WithCloudFirestoreSourceSinkURI(sinkURI),
+				WithCloudFirestoreSourceSetDefaults,
+				WithCloudFirestoreSourceSinkNotReady("SinkCreateFailed", "%s: %s", failedToCreateSinkMsg, "create-client-induced-error"),
+			),
+		}},
+	}, {
+		Name: "sink created",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:          testSinkID,
+					Filter:      testFilter,
+					Destination: testTopicResource,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudFirestoreSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithCloudFirestoreSourceSubscriptionID(SubscriptionID),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionReady(),
+				WithCloudFirestoreSourceSinkURI(sinkURI),
+				WithCloudFirestoreSourceSinkReady,
+				WithCloudFirestoreSourceSinkID(testSinkID),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "sink exists",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"existingSinks": []logadmin.Sink{{
+				ID:          testSinkID,
+				Filter:      testFilter,
+				Destination: testTopicResource,
+			}},
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:          testSinkID,
+					Filter:      testFilter,
+					Destination: testTopicResource,
+				}},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudFirestoreSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceEventTypes(v1beta1.CloudFirestoreSourceCreate),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithCloudFirestoreSourceSubscriptionID(SubscriptionID),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionReady(),
+				WithCloudFirestoreSourceSinkURI(sinkURI),
+				WithCloudFirestoreSourceSinkReady,
+				WithCloudFirestoreSourceSinkID(testSinkID),
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "sink delete fails",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionReady(),
+				WithCloudFirestoreSourceSinkURI(sinkURI),
+				WithCloudFirestoreSourceSinkReady,
+				WithCloudFirestoreSourceSinkID(testSinkID),
+				WithCloudFirestoreSourceDeletionTimestamp,
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"existingSinks": []logadmin.Sink{{
+				ID:          testSinkID,
+				Filter:      testFilter,
+				Destination: testTopicResource,
+			}},
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: {
+					ID:          testSinkID,
+					Filter:      testFilter,
+					Destination: testTopicResource,
+				}},
+			"logadmin": glogadmintesting.TestClientConfiguration{
+				DeleteSinkErr: errors.New("delete-sink-induced-error"),
+			},
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, logsink.DeleteSinkFailed, "Failed to delete Stackdriver sink: delete-sink-induced-error"),
+		},
+		WantStatusUpdates: nil,
+	}, {
+		Name: "sink delete succeeds",
+		Objects: []runtime.Object{
+			NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithCloudFirestoreSourceProjectID(testProject),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceTopicReady(testTopicID),
+				WithCloudFirestoreSourcePullSubscriptionReady(),
+				WithCloudFirestoreSourceSinkURI(sinkURI),
+				WithCloudFirestoreSourceSinkReady,
+				WithCloudFirestoreSourceSinkID(testSinkID),
+				WithCloudFirestoreSourceDeletionTimestamp,
+				WithCloudFirestoreSourceSetDefaults,
+			),
+			newReadyTopic(),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"existingSinks": []logadmin.Sink{{
+				ID:          testSinkID,
+				Filter:      testFilter,
+				Destination: testTopicResource,
+			}},
+			"expectedSinks": map[string]*logadmin.Sink{
+				testSinkID: nil,
+			},
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudFirestoreSource(sourceName, testNS,
+				WithCloudFirestoreSourceUID(sourceUID),
+				WithCloudFirestoreSourceSink(sinkGVK, sinkName),
+				WithCloudFirestoreSourceDocument(testDocument),
+				WithInitCloudFirestoreSourceConditions,
+				WithCloudFirestoreSourceSinkReady,
+				WithCloudFirestoreSourceTopicFailed("TopicDeleted", fmt.Sprintf("Successfully deleted Topic: %s", sourceName)),
+				WithCloudFirestoreSourcePullSubscriptionFailed("PullSubscriptionDeleted", fmt.Sprintf("Successfully deleted PullSubscription: %s", sourceName)),
+				WithCloudFirestoreSourceDeletionTimestamp,
+				WithCloudFirestoreSourceSetDefaults,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
+	}}
+
+	defer logtesting.ClearAll()
+	for _, tt := range table {
+		t.Run(tt.Name, func(t *testing.T) {
+			logadminClientProvider := glogadmintesting.TestClientCreator(tt.OtherTestData["logadmin"])
+			if existingSinks := tt.OtherTestData["existingSinks"]; existingSinks != nil {
+				createSinks(t, logadminClientProvider, existingSinks.([]logadmin.Sink))
+			}
+			tt.Test(t, MakeFactory(
+				func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
+					r := &Reconciler{
+						Base: &logsink.Base{
+							PubSubBase: intevents.NewPubSubBase(ctx,
+								&intevents.PubSubBaseArgs{
+									ControllerAgentName: controllerAgentName,
+									ReceiveAdapterName:  receiveAdapterName,
+									ReceiveAdapterType:  string(converters.CloudFirestore),
+									ConfigWatcher:       cmw,
+								}),
+							Identity:               identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
+							LogadminClientProvider: logadminClientProvider,
+							PubsubClientProvider:   gpubsub.TestClientCreator(testData["pubsub"]),
+						},
+						firestoreSourceLister: listers.GetCloudFirestoreSourceLister(),
+						serviceAccountLister:  listers.GetServiceAccountLister(),
+					}
+					return cloudfirestoresource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudFirestoreSourceLister(), r.Recorder, r)
+				}))
+			if expectedSinks := tt.OtherTestData["expectedSinks"]; expectedSinks != nil {
+				expectSinks(t, logadminClientProvider, expectedSinks.(map[string]*logadmin.Sink))
+			}
+		})
+	}
+}
+
+func createSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks []logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), testProject)
+	if err != nil {
+		t.Fatalf("failed to create logadmin client during setup: %s", err)
+	}
+	for _, sink := range sinks {
+		logadminClient.CreateSink(context.Background(), &sink)
+	}
+}
+
+func expectSinks(t *testing.T, clientProvider glogadmin.CreateFn, sinks map[string]*logadmin.Sink) {
+	logadminClient, err := clientProvider(context.Background(), testProject)
+	if err != nil {
+		t.Fatalf("failed to create logadmin client during verification: %s", err)
+	}
+	for sinkID, sink := range sinks {
+		actual, err := logadminClient.Sink(context.Background(), sinkID)
+		if err != nil && !(status.Code(err) == codes.NotFound && sink == nil) {
+			t.Errorf("failed to get expected sink %s: %v", sinkID, err)
+		}
+		if diff := cmp.Diff(sink, actual, cmpopts.IgnoreFields(logadmin.Sink{}, "WriterIdentity")); diff != "" {
+			t.Log("Unexpected difference in sink:")
+			t.Log(diff)
+			t.Fail()
+		}
+	}
+}
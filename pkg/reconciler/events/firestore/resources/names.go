@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resources contains helpers for Firestore source resources.
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/utils/naming"
+)
+
+// firestoreWriteMethods are the Firestore methods which write documents.
+var firestoreWriteMethods = []string{
+	"google.firestore.v1.Firestore.Commit",
+	"google.firestore.v1.Firestore.BatchWrite",
+	"google.firestore.v1.Firestore.CreateDocument",
+	"google.firestore.v1.Firestore.UpdateDocument",
+	"google.firestore.v1.Firestore.DeleteDocument",
+}
+
+// GenerateTopicName generates a topic name for the Firestore source. This
+// refers to the underlying Pub/Sub topic, and not our Topic resource.
+func GenerateTopicName(s *v1beta1.CloudFirestoreSource) string {
+	return naming.TruncatedPubsubResourceName("cre-src", s.Namespace, s.Name, s.UID)
+}
+
+// GenerateTopicResourceName generates the resource name for the topic used by
+// a CloudFirestoreSource.
+func GenerateTopicResourceName(s *v1beta1.CloudFirestoreSource) string {
+	return fmt.Sprintf("pubsub.googleapis.com/projects/%s/topics/%s", s.Status.ProjectID, s.Status.TopicID)
+}
+
+// GenerateSinkName generates a Stackdriver sink resource name for a
+// CloudFirestoreSource.
+func GenerateSinkName(s *v1beta1.CloudFirestoreSource) string {
+	return naming.TruncatedLoggingSinkResourceName("cre-src", s.Namespace, s.Name, s.UID)
+}
+
+// GenerateSinkFilter generates the filter of the Stackdriver sink of a
+// CloudFirestoreSource, which selects the Data Access audit logs of the
+// successful document writes to its database. The document path and event
+// types are filtered by the receive adapter, so that changing them does not
+// require recreating the sink.
+func GenerateSinkFilter(s *v1beta1.CloudFirestoreSource) string {
+	methods := make([]string, 0, len(firestoreWriteMethods))
+	for _, method := range firestoreWriteMethods {
+		methods = append(methods, fmt.Sprintf("protoPayload.methodName=%q", method))
+	}
+	filters := []string{
+		`protoPayload.serviceName="firestore.googleapis.com"`,
+		"(" + strings.Join(methods, " OR ") + ")",
+		fmt.Sprintf("protoPayload.resourceName=%q", fmt.Sprintf("projects/%s/databases/%s", s.Status.ProjectID, s.Spec.Database)),
+		`protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog"`,
+		"NOT protoPayload.status.code>0",
+	}
+	return strings.Join(filters, " AND ")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateTopicName(t *testing.T) {
+	want := "cre-src_mynamespace_myname_uid"
+	got := GenerateTopicName(&v1beta1.CloudFirestoreSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myname",
+			Namespace: "mynamespace",
+			UID:       "uid",
+		},
+	})
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateTopicResourceName(t *testing.T) {
+	want := "pubsub.googleapis.com/projects/project/topics/topic"
+	got := GenerateTopicResourceName(&v1beta1.CloudFirestoreSource{
+		Status: v1beta1.CloudFirestoreSourceStatus{
+			PubSubStatus: duckv1beta1.PubSubStatus{
+				ProjectID: "project",
+				TopicID:   "topic",
+			},
+		},
+	})
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateSinkName(t *testing.T) {
+	want := "cre-src_mynamespace_myname_uid"
+	got := GenerateSinkName(&v1beta1.CloudFirestoreSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myname",
+			Namespace: "mynamespace",
+			UID:       "uid",
+		},
+	})
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateSinkFilter(t *testing.T) {
+	want := `protoPayload.serviceName="firestore.googleapis.com" AND ` +
+		`(protoPayload.methodName="google.firestore.v1.Firestore.Commit" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.BatchWrite" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.CreateDocument" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.UpdateDocument" OR ` +
+		`protoPayload.methodName="google.firestore.v1.Firestore.DeleteDocument") AND ` +
+		`protoPayload.resourceName="projects/project/databases/(default)" AND ` +
+		`protoPayload."@type"="type.googleapis.com/google.cloud.audit.AuditLog" AND ` +
+		`NOT protoPayload.status.code>0`
+	got := GenerateSinkFilter(&v1beta1.CloudFirestoreSource{
+		Spec: v1beta1.CloudFirestoreSourceSpec{
+			Database: "(default)",
+			Document: "users/{userId}",
+		},
+		Status: v1beta1.CloudFirestoreSourceStatus{
+			PubSubStatus: duckv1beta1.PubSubStatus{
+				ProjectID: "project",
+			},
+		},
+	})
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logsink contains the parts of the reconcilers of sources whose log
+// entries are exported to their topic by a Cloud Logging sink, such as the
+// CloudAuditLogsSource and the CloudFirestoreSource.
+package logsink
+
+import (
+	"context"
+
+	"cloud.google.com/go/logging/logadmin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	duck "github.com/google/knative-gcp/pkg/duck/v1beta1"
+	glogadmin "github.com/google/knative-gcp/pkg/gclient/logging/logadmin"
+	gpubsub "github.com/google/knative-gcp/pkg/gclient/pubsub"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+)
+
+const (
+	publisherRole = "roles/pubsub.publisher"
+
+	DeletePubSubFailed           = "PubSubDeleteFailed"
+	DeleteSinkFailed             = "SinkDeleteFailed"
+	DeleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
+)
+
+// SinkStatus is the status of a source with a sink.
+type SinkStatus interface {
+	MarkSinkNotReady(reason, messageFormat string, messageA ...interface{})
+}
+
+// Base reconciles the Pub/Sub resources, the workload identity and the sink
+// of a source.
+type Base struct {
+	*intevents.PubSubBase
+	// identity reconciler for reconciling workload identity.
+	*identity.Identity
+	LogadminClientProvider glogadmin.CreateFn
+	PubsubClientProvider   gpubsub.CreateFn
+}
+
+// ReconcileSink ensures that the sink of the source exists in the parent, and
+// that its writer identity may publish to the topic of the source. The sink is
+// created as desired if it does not exist. It returns the ID of the sink.
+func (b *Base) ReconcileSink(ctx context.Context, s duck.PubSubable, sinkStatus SinkStatus, parent string, desired *logadmin.Sink) (string, error) {
+	sink, err := b.ensureSinkCreated(ctx, parent, desired)
+	if err != nil {
+		sinkStatus.MarkSinkNotReady("SinkCreateFailed", "failed to ensure creation of logging sink: %s", err.Error())
+		return "", err
+	}
+	err = b.ensureSinkIsPublisher(ctx, s, sink)
+	if err != nil {
+		sinkStatus.MarkSinkNotReady("SinkNotPublisher", "failed to ensure sink has pubsub.publisher permission on source topic: %s", err.Error())
+		return "", err
+	}
+	return sink.ID, nil
+}
+
+func (b *Base) ensureSinkCreated(ctx context.Context, parent string, desired *logadmin.Sink) (*logadmin.Sink, error) {
+	logadminClient, err := b.LogadminClientProvider(ctx, parent)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
+		return nil, err
+	}
+	sink, err := logadminClient.Sink(ctx, desired.ID)
+	if status.Code(err) == codes.NotFound {
+		sink, err = logadminClient.CreateSinkOpt(ctx, desired, logadmin.SinkOptions{UniqueWriterIdentity: true})
+		// Handle AlreadyExists in-case of a race between another create call.
+		if status.Code(err) == codes.AlreadyExists {
+			sink, err = logadminClient.Sink(ctx, desired.ID)
+		}
+	}
+	return sink, err
+}
+
+// Ensures that the sink has been granted the pubsub.publisher role on the source topic.
+func (b *Base) ensureSinkIsPublisher(ctx context.Context, s duck.PubSubable, sink *logadmin.Sink) error {
+	st := s.PubSubStatus()
+	pubsubClient, err := b.PubsubClientProvider(ctx, st.ProjectID)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create PubSub client", zap.Error(err))
+		return err
+	}
+	topicIam := pubsubClient.Topic(st.TopicID).IAM()
+	topicPolicy, err := topicIam.Policy(ctx)
+	if err != nil {
+		return err
+	}
+	if !topicPolicy.HasRole(sink.WriterIdentity, publisherRole) {
+		topicPolicy.Add(sink.WriterIdentity, publisherRole)
+		if err = topicIam.SetPolicy(ctx, topicPolicy); err != nil {
+			return err
+		}
+		logging.FromContext(ctx).Desugar().Debug(
+			"Granted the Stackdriver Sink writer identity roles/pubsub.publisher on PubSub Topic.",
+			zap.String("writerIdentity", sink.WriterIdentity),
+			zap.String("topicID", st.TopicID))
+	}
+	return nil
+}
+
+// deleteSink deletes the previously created sink, if any.
+func (b *Base) deleteSink(ctx context.Context, parent, sinkID string) error {
+	if sinkID == "" {
+		return nil
+	}
+	logadminClient, err := b.LogadminClientProvider(ctx, parent)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create LogAdmin client", zap.Error(err))
+		return err
+	}
+	if err = logadminClient.DeleteSink(ctx, sinkID); status.Code(err) != codes.NotFound {
+		return err
+	}
+	return nil
+}
+
+// Finalize deletes the workload identity, the sink in the parent and the
+// Pub/Sub resources of the source.
+func (b *Base) Finalize(ctx context.Context, s duck.PubSubable, parent, sinkID string) reconciler.Event {
+	kind := s.GetGroupVersionKind().Kind
+	// If k8s ServiceAccount exists, binds to the default GCP ServiceAccount, and it only has one ownerReference,
+	// remove the corresponding GCP ServiceAccount iam policy binding.
+	// No need to delete k8s ServiceAccount, it will be automatically handled by k8s Garbage Collection.
+	if s.IdentitySpec().ServiceAccountName != "" {
+		if err := b.Identity.DeleteWorkloadIdentity(ctx, s.PubSubSpec().Project, s); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, DeleteWorkloadIdentityFailed, "Failed to delete %s workload identity: %s", kind, err.Error())
+		}
+	}
+
+	if err := b.deleteSink(ctx, parent, sinkID); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, DeleteSinkFailed, "Failed to delete Stackdriver sink: %s", err.Error())
+	}
+
+	if err := b.PubSubBase.DeletePubSub(ctx, s); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, DeletePubSubFailed, "Failed to delete %s PubSub: %s", kind, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	cloudmonitoringalertsourcereconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudmonitoringalertsource"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1beta1"
+	gmonitoring "github.com/google/knative-gcp/pkg/gclient/monitoring"
+	"github.com/google/knative-gcp/pkg/reconciler/events/monitoring/resources"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+)
+
+const (
+	resourceGroup = "cloudmonitoringalertsources.events.cloud.google.com"
+
+	// pubsubChannelType is the type of the notification channels which publish
+	// to a Pub/Sub topic.
+	pubsubChannelType = "pubsub"
+	// topicLabel is the label of Pub/Sub notification channels with their topic.
+	topicLabel = "topic"
+	// notificationChannelsMask is the field mask of the notification channels
+	// of alert policies.
+	notificationChannelsMask = "notification_channels"
+
+	deleteNotificationChannelFailed = "NotificationChannelDeleteFailed"
+	deletePubSubFailed              = "PubSubDeleteFailed"
+	deleteWorkloadIdentityFailed    = "WorkloadIdentityDeleteFailed"
+	reconciledFailedReason          = "NotificationChannelReconcileFailed"
+	reconciledPubSubFailedReason    = "PubSubReconcileFailed"
+	reconciledSuccessReason         = "CloudMonitoringAlertSourceReconciled"
+	workloadIdentityFailed          = "WorkloadIdentityReconcileFailed"
+)
+
+// Reconciler is the controller implementation for Cloud Monitoring notification channels.
+type Reconciler struct {
+	*intevents.PubSubBase
+	// identity reconciler for reconciling workload identity.
+	*identity.Identity
+	// monitoringAlertSourceLister for reading CloudMonitoringAlertSources.
+	monitoringAlertSourceLister listers.CloudMonitoringAlertSourceLister
+
+	createClientFn gmonitoring.CreateFn
+}
+
+// Check that our Reconciler implements Interface.
+var _ cloudmonitoringalertsourcereconciler.Interface = (*Reconciler)(nil)
+
+func (r *Reconciler) ReconcileKind(ctx context.Context, s *v1beta1.CloudMonitoringAlertSource) reconciler.Event {
+	ctx = logging.WithLogger(ctx, r.Logger.With(zap.Any("monitoringalertsource", s)))
+
+	s.Status.InitializeConditions()
+	s.Status.ObservedGeneration = s.Generation
+
+	// If ServiceAccountName is provided, reconcile workload identity.
+	if s.Spec.ServiceAccountName != "" {
+		if _, err := r.Identity.ReconcileWorkloadIdentity(ctx, s.Spec.Project, s); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, workloadIdentityFailed, "Failed to reconcile CloudMonitoringAlertSource workload identity: %s", err.Error())
+		}
+	}
+
+	topic := resources.GenerateTopicName(s)
+	t, ps, err := r.PubSubBase.ReconcilePubSub(ctx, s, topic, resourceGroup)
+	if err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+	}
+	r.Logger.Debugf("Reconciled: PubSub: %+v PullSubscription: %+v", t, ps)
+
+	channel, err := r.reconcileNotificationChannel(ctx, s)
+	if err != nil {
+		s.Status.MarkNotificationChannelNotReady(reconciledFailedReason, "Failed to reconcile CloudMonitoringAlertSource notification channel: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: %s", err.Error())
+	}
+	s.Status.MarkNotificationChannelReady(channel)
+	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, s.Namespace, s.Name)
+}
+
+// reconcileNotificationChannel ensures that the notification channel of the
+// source exists, and that it is a notification channel of exactly the alert
+// policies of the spec. It returns the name of the notification channel.
+func (r *Reconciler) reconcileNotificationChannel(ctx context.Context, s *v1beta1.CloudMonitoringAlertSource) (string, error) {
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create Cloud Monitoring client", zap.Error(err))
+		return "", err
+	}
+	defer client.Close()
+
+	channel, err := r.ensureNotificationChannelCreated(ctx, client, s)
+	if err != nil {
+		return "", err
+	}
+	// Record the channel before updating the alert policies, so that it is
+	// deleted even if the source is deleted before the policies are updated.
+	s.Status.NotificationChannel = channel
+
+	desired := make(map[string]bool, len(s.Spec.AlertPolicies))
+	for _, policyID := range s.Spec.AlertPolicies {
+		desired[policyID] = true
+		if err := r.addNotificationChannel(ctx, client, resources.GenerateAlertPolicyName(s, policyID), channel); err != nil {
+			return "", err
+		}
+	}
+	for _, policyID := range s.Status.AlertPolicies {
+		if desired[policyID] {
+			continue
+		}
+		err := r.removeNotificationChannel(ctx, client, resources.GenerateAlertPolicyName(s, policyID), channel)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return "", err
+		}
+	}
+	s.Status.AlertPolicies = append([]string(nil), s.Spec.AlertPolicies...)
+	return channel, nil
+}
+
+// ensureNotificationChannelCreated returns the name of the notification channel
+// in the status of the source, creating a new one if there is none.
+func (r *Reconciler) ensureNotificationChannelCreated(ctx context.Context, client gmonitoring.Client, s *v1beta1.CloudMonitoringAlertSource) (string, error) {
+	if s.Status.NotificationChannel != "" {
+		channel, err := client.GetNotificationChannel(ctx, &monitoringpb.GetNotificationChannelRequest{Name: s.Status.NotificationChannel})
+		if err == nil {
+			return channel.GetName(), nil
+		}
+		if status.Code(err) != codes.NotFound {
+			logging.FromContext(ctx).Desugar().Error("Failed to get CloudMonitoringAlertSource notification channel", zap.String("channel", s.Status.NotificationChannel), zap.Error(err))
+			return "", err
+		}
+		// The channel has been deleted out of band, so the alert policies
+		// need to be updated with its replacement.
+		s.Status.AlertPolicies = nil
+	}
+	channel, err := client.CreateNotificationChannel(ctx, &monitoringpb.CreateNotificationChannelRequest{
+		Name: resources.GenerateProjectName(s),
+		NotificationChannel: &monitoringpb.NotificationChannel{
+			Type:        pubsubChannelType,
+			DisplayName: resources.GenerateNotificationChannelDisplayName(s),
+			Labels: map[string]string{
+				topicLabel: resources.GenerateTopicResourceName(s),
+			},
+		},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudMonitoringAlertSource notification channel", zap.Error(err))
+		return "", err
+	}
+	logging.FromContext(ctx).Desugar().Debug("Created CloudMonitoringAlertSource notification channel", zap.String("channel", channel.GetName()))
+	return channel.GetName(), nil
+}
+
+// addNotificationChannel adds the notification channel to the alert policy, if
+// it is not already one of its notification channels.
+func (r *Reconciler) addNotificationChannel(ctx context.Context, client gmonitoring.Client, policyName, channel string) error {
+	policy, err := client.GetAlertPolicy(ctx, &monitoringpb.GetAlertPolicyRequest{Name: policyName})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to get alert policy", zap.String("policy", policyName), zap.Error(err))
+		return err
+	}
+	for _, c := range policy.NotificationChannels {
+		if c == channel {
+			return nil
+		}
+	}
+	return r.updateNotificationChannels(ctx, client, policyName, append(policy.NotificationChannels, channel))
+}
+
+// removeNotificationChannel removes the notification channel from the alert
+// policy, if it is one of its notification channels.
+func (r *Reconciler) removeNotificationChannel(ctx context.Context, client gmonitoring.Client, policyName, channel string) error {
+	policy, err := client.GetAlertPolicy(ctx, &monitoringpb.GetAlertPolicyRequest{Name: policyName})
+	if err != nil {
+		return err
+	}
+	channels := make([]string, 0, len(policy.NotificationChannels))
+	for _, c := range policy.NotificationChannels {
+		if c != channel {
+			channels = append(channels, c)
+		}
+	}
+	if len(channels) == len(policy.NotificationChannels) {
+		return nil
+	}
+	return r.updateNotificationChannels(ctx, client, policyName, channels)
+}
+
+// updateNotificationChannels sets the notification channels of the alert policy.
+func (r *Reconciler) updateNotificationChannels(ctx context.Context, client gmonitoring.Client, policyName string, channels []string) error {
+	_, err := client.UpdateAlertPolicy(ctx, &monitoringpb.UpdateAlertPolicyRequest{
+		AlertPolicy: &monitoringpb.AlertPolicy{
+			Name:                 policyName,
+			NotificationChannels: channels,
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{notificationChannelsMask}},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to update the notification channels of alert policy", zap.String("policy", policyName), zap.Error(err))
+	}
+	return err
+}
+
+// deleteNotificationChannel looks at status.NotificationChannel and if
+// non-empty will delete the previously created notification channel. The
+// channel is forcibly deleted, which removes it from the alert policies.
+func (r *Reconciler) deleteNotificationChannel(ctx context.Context, s *v1beta1.CloudMonitoringAlertSource) error {
+	if s.Status.NotificationChannel == "" {
+		return nil
+	}
+	client, err := r.createClientFn(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create Cloud Monitoring client", zap.Error(err))
+		return err
+	}
+	defer client.Close()
+
+	err = client.DeleteNotificationChannel(ctx, &monitoringpb.DeleteNotificationChannelRequest{
+		Name:  s.Status.NotificationChannel,
+		Force: true,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		logging.FromContext(ctx).Desugar().Error("Failed to delete CloudMonitoringAlertSource notification channel", zap.String("channel", s.Status.NotificationChannel), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) FinalizeKind(ctx context.Context, s *v1beta1.CloudMonitoringAlertSource) reconciler.Event {
+	// If k8s ServiceAccount exists, binds to the default GCP ServiceAccount, and it only has one ownerReference,
+	// remove the corresponding GCP ServiceAccount iam policy binding.
+	// No need to delete k8s ServiceAccount, it will be automatically handled by k8s Garbage Collection.
+	if s.Spec.ServiceAccountName != "" {
+		if err := r.Identity.DeleteWorkloadIdentity(ctx, s.Spec.Project, s); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, deleteWorkloadIdentityFailed, "Failed to delete CloudMonitoringAlertSource workload identity: %s", err.Error())
+		}
+	}
+
+	if err := r.deleteNotificationChannel(ctx, s); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deleteNotificationChannelFailed, "Failed to delete CloudMonitoringAlertSource notification channel: %s", err.Error())
+	}
+
+	if err := r.PubSubBase.DeletePubSub(ctx, s); err != nil {
+		return reconciler.NewEvent(corev1.EventTypeWarning, deletePubSubFailed, "Failed to delete CloudMonitoringAlertSource PubSub: %s", err.Error())
+	}
+	s.Status.NotificationChannel = ""
+	s.Status.AlertPolicies = nil
+	return nil
+}
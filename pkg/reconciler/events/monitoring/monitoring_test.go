@@ -0,0 +1,631 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	. "knative.dev/pkg/reconciler/testing"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	inteventsv1beta1 "github.com/google/knative-gcp/pkg/apis/intevents/v1beta1"
+	"github.com/google/knative-gcp/pkg/client/injection/reconciler/events/v1beta1/cloudmonitoringalertsource"
+	testingMetadataClient "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
+	gmonitoring "github.com/google/knative-gcp/pkg/gclient/monitoring/testing"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/identity"
+	"github.com/google/knative-gcp/pkg/reconciler/intevents"
+	. "github.com/google/knative-gcp/pkg/reconciler/testing"
+)
+
+const (
+	sourceName   = "test-monitoring"
+	sourceUID    = "test-monitoring-uid"
+	testNS       = "testnamespace"
+	testProject  = "test-project-id"
+	testTopicURI = "http://" + sourceName + "-topic." + testNS + ".svc.cluster.local"
+
+	testPolicyID  = "12345"
+	otherPolicyID = "67890"
+	testChannel   = "projects/" + testProject + "/notificationChannels/111"
+
+	sinkName = "sink"
+	sinkDNS  = sinkName + ".mynamespace.svc.cluster.local"
+
+	failedToReconcileTopicMsg                  = `Topic has not yet been reconciled`
+	failedToReconcilePullSubscriptionMsg       = `PullSubscription has not yet been reconciled`
+	failedToReconcileChannelMsg                = `Failed to reconcile CloudMonitoringAlertSource notification channel`
+	failedToPropagatePullSubscriptionStatusMsg = `Failed to propagate PullSubscription status`
+)
+
+var (
+	trueVal  = true
+	falseVal = false
+
+	sinkGVK = metav1.GroupVersionKind{
+		Group:   "testing.cloud.google.com",
+		Version: "v1beta1",
+		Kind:    "Sink",
+	}
+
+	testTopicID = fmt.Sprintf("cre-src_%s_%s_%s", testNS, sourceName, sourceUID)
+
+	secret = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "google-cloud-key",
+		},
+		Key: "key.json",
+	}
+
+	sinkURI = apis.HTTP(sinkDNS)
+)
+
+func sourceOwnerRef(name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         "events.cloud.google.com/v1beta1",
+		Kind:               "CloudMonitoringAlertSource",
+		Name:               name,
+		UID:                uid,
+		Controller:         &trueVal,
+		BlockOwnerDeletion: &trueVal,
+	}
+}
+
+func patchFinalizers(namespace, name string, add bool) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	var fname string
+	if add {
+		fname = fmt.Sprintf("%q", resourceGroup)
+	}
+	patch := `{"metadata":{"finalizers":[` + fname + `],"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func newSinkDestination() duckv1.Destination {
+	return duckv1.Destination{
+		Ref: &duckv1.KReference{
+			APIVersion: "testing.cloud.google.com/v1beta1",
+			Kind:       "Sink",
+			Name:       sinkName,
+		},
+	}
+}
+
+func newReadyTopic() *inteventsv1beta1.Topic {
+	return NewTopic(sourceName, testNS,
+		WithTopicSpec(inteventsv1beta1.TopicSpec{
+			Topic:             testTopicID,
+			PropagationPolicy: "CreateDelete",
+			EnablePublisher:   &falseVal,
+		}),
+		WithTopicReady(testTopicID),
+		WithTopicAddress(testTopicURI),
+		WithTopicProjectID(testProject),
+		WithTopicSetDefaults,
+	)
+}
+
+func newPullSubscription(opts ...PullSubscriptionOption) *inteventsv1beta1.PullSubscription {
+	opts = append(opts, WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+		Topic: testTopicID,
+		PubSubSpec: duckv1beta1.PubSubSpec{
+			Secret: &secret,
+			SourceSpec: duckv1.SourceSpec{
+				Sink: newSinkDestination(),
+			},
+		},
+		AdapterType: string(converters.CloudMonitoring),
+	}))
+	return NewPullSubscription(sourceName, testNS, opts...)
+}
+
+func TestAllCases(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		Key:  "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "topic created, not yet been reconciled",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceTopicUnknown("TopicNotConfigured", failedToReconcileTopicMsg),
+				WithCloudMonitoringAlertSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+		WantCreates: []runtime.Object{
+			NewTopic(sourceName, testNS,
+				WithTopicSpec(inteventsv1beta1.TopicSpec{
+					Topic:             testTopicID,
+					PropagationPolicy: "CreateDelete",
+					EnablePublisher:   &falseVal,
+				}),
+				WithTopicLabels(map[string]string{
+					"receive-adapter":                     receiveAdapterName,
+					"events.cloud.google.com/source-name": sourceName,
+				}),
+				WithTopicOwnerReferences([]metav1.OwnerReference{sourceOwnerRef(sourceName, sourceUID)}),
+				WithTopicAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithTopicSetDefaults,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: Topic %q has not yet been reconciled", sourceName),
+		},
+	}, {
+		Name: "topic exists and is ready, pullsubscription created",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourceAnnotations(map[string]string{
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithCloudMonitoringAlertSourceSetDefaults,
+				WithCloudMonitoringAlertSourcePullSubscriptionUnknown("PullSubscriptionNotConfigured", failedToReconcilePullSubscriptionMsg),
+			),
+		}},
+		WantCreates: []runtime.Object{
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+					Topic: testTopicID,
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret: &secret,
+					},
+					AdapterType: string(converters.CloudMonitoring),
+				}),
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				WithPullSubscriptionLabels(map[string]string{
+					"receive-adapter":                     receiveAdapterName,
+					"events.cloud.google.com/source-name": sourceName,
+				}),
+				WithPullSubscriptionAnnotations(map[string]string{
+					"metrics-resource-group":          resourceGroup,
+					duckv1beta1.ClusterNameAnnotation: testingMetadataClient.FakeClusterName,
+				}),
+				WithPullSubscriptionOwnerReferences([]metav1.OwnerReference{sourceOwnerRef(sourceName, sourceUID)}),
+				WithPullSubscriptionDefaultGCPAuth,
+			),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, `Reconcile PubSub failed with: %s: PullSubscription %q has not yet been reconciled`, failedToPropagatePullSubscriptionStatusMsg, sourceName),
+		},
+	}, {
+		Name: "topic exists and ready, pullsubscription exists and the status of pullsubscription is unknown",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionUnknown()),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionUnknown("", ""),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s: the status of PullSubscription %q is Unknown", failedToPropagatePullSubscriptionStatusMsg, sourceName),
+		},
+	}, {
+		Name: "create notification channel fails",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				CreateNotificationChannelErr: errors.New("create-channel-induced-error"),
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: create-channel-induced-error"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithCloudMonitoringAlertSourceSubscriptionID(SubscriptionID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceSetDefaults,
+				WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason, "%s: %s", failedToReconcileChannelMsg, "create-channel-induced-error"),
+			),
+		}},
+	}, {
+		Name: "notification channel created and added to alert policies",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID, otherPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				CreateNotificationChannelName: testChannel,
+				AlertPolicies: map[string]*monitoringpb.AlertPolicy{
+					// The channel is already a notification channel of the other policy.
+					testPolicyName(otherPolicyID): {
+						Name:                 testPolicyName(otherPolicyID),
+						NotificationChannels: []string{testChannel},
+					},
+				},
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID, otherPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithCloudMonitoringAlertSourceSubscriptionID(SubscriptionID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID, otherPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "notification channel exists, alert policy removed",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceNotificationChannel(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID, otherPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				AlertPolicies: map[string]*monitoringpb.AlertPolicy{
+					testPolicyName(otherPolicyID): {
+						Name:                 testPolicyName(otherPolicyID),
+						NotificationChannels: []string{testChannel},
+					},
+				},
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithCloudMonitoringAlertSourceSubscriptionID(SubscriptionID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "notification channel deleted out of band, recreated",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceNotificationChannel("projects/"+testProject+"/notificationChannels/deleted"),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				GetNotificationChannelErr:     status.Error(codes.NotFound, "channel not found"),
+				CreateNotificationChannelName: testChannel,
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudMonitoringAlertSource reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithCloudMonitoringAlertSourceSubscriptionID(SubscriptionID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+	}, {
+		Name: "update alert policy fails",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			newPullSubscription(WithPullSubscriptionReady(sinkURI)),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				CreateNotificationChannelName: testChannel,
+				UpdateAlertPolicyErr:          errors.New("update-policy-induced-error"),
+			},
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, true),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile NotificationChannel failed with: update-policy-induced-error"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithCloudMonitoringAlertSourceSubscriptionID(SubscriptionID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannel(testChannel),
+				WithCloudMonitoringAlertSourceSetDefaults,
+				WithCloudMonitoringAlertSourceNotificationChannelNotReady(reconciledFailedReason, "%s: %s", failedToReconcileChannelMsg, "update-policy-induced-error"),
+			),
+		}},
+	}, {
+		Name: "notification channel delete fails",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceDeletionTimestamp,
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		OtherTestData: map[string]interface{}{
+			"monitoring": gmonitoring.TestClientData{
+				DeleteNotificationChannelErr: errors.New("delete-channel-induced-error"),
+			},
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, deleteNotificationChannelFailed, "Failed to delete CloudMonitoringAlertSource notification channel: delete-channel-induced-error"),
+		},
+		WantStatusUpdates: nil,
+	}, {
+		Name: "notification channel delete succeeds",
+		Objects: []runtime.Object{
+			NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceProjectID(testProject),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceTopicReady(testTopicID),
+				WithCloudMonitoringAlertSourcePullSubscriptionReady(),
+				WithCloudMonitoringAlertSourceSinkURI(sinkURI),
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceStatusAlertPolicies(testPolicyID),
+				WithCloudMonitoringAlertSourceDeletionTimestamp,
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+			newReadyTopic(),
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionReady(sinkURI),
+			),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewCloudMonitoringAlertSource(sourceName, testNS,
+				WithCloudMonitoringAlertSourceUID(sourceUID),
+				WithCloudMonitoringAlertSourceSink(sinkGVK, sinkName),
+				WithCloudMonitoringAlertSourceAlertPolicies(testPolicyID),
+				WithInitCloudMonitoringAlertSourceConditions,
+				WithCloudMonitoringAlertSourceNotificationChannelReady(testChannel),
+				WithCloudMonitoringAlertSourceNotificationChannel(""),
+				WithCloudMonitoringAlertSourceTopicFailed("TopicDeleted", fmt.Sprintf("Successfully deleted Topic: %s", sourceName)),
+				WithCloudMonitoringAlertSourcePullSubscriptionFailed("PullSubscriptionDeleted", fmt.Sprintf("Successfully deleted PullSubscription: %s", sourceName)),
+				WithCloudMonitoringAlertSourceDeletionTimestamp,
+				WithCloudMonitoringAlertSourceSetDefaults,
+			),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "topics"}},
+				Name: sourceName,
+			},
+			{ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS, Verb: "delete", Resource: schema.GroupVersionResource{Group: "internal.events.cloud.google.com", Version: "v1beta1", Resource: "pullsubscriptions"}},
+				Name: sourceName,
+			},
+		},
+	}}
+
+	defer logtesting.ClearAll()
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
+		r := &Reconciler{
+			PubSubBase: intevents.NewPubSubBase(ctx,
+				&intevents.PubSubBaseArgs{
+					ControllerAgentName: controllerAgentName,
+					ReceiveAdapterName:  receiveAdapterName,
+					ReceiveAdapterType:  string(converters.CloudMonitoring),
+					ConfigWatcher:       cmw,
+				}),
+			Identity:                    identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
+			monitoringAlertSourceLister: listers.GetCloudMonitoringAlertSourceLister(),
+			createClientFn:              gmonitoring.TestClientCreator(testData["monitoring"]),
+		}
+		return cloudmonitoringalertsource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudMonitoringAlertSourceLister(), r.Recorder, r)
+	}))
+}
+
+// testPolicyName returns the name of the alert policy of the test project with the ID.
+func testPolicyName(policyID string) string {
+	return fmt.Sprintf("projects/%s/alertPolicies/%s", testProject, policyID)
+}
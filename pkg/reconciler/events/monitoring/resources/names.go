@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resources contains helpers for Cloud Monitoring alert source resources.
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/utils/naming"
+)
+
+// GenerateTopicName generates a topic name for the Cloud Monitoring alert
+// source. This refers to the underlying Pub/Sub topic, and not our Topic
+// resource.
+func GenerateTopicName(s *v1beta1.CloudMonitoringAlertSource) string {
+	return naming.TruncatedPubsubResourceName("cre-src", s.Namespace, s.Name, s.UID)
+}
+
+// GenerateTopicResourceName generates the name of the topic used by a
+// CloudMonitoringAlertSource, in the format of the topic label of Pub/Sub
+// notification channels.
+func GenerateTopicResourceName(s *v1beta1.CloudMonitoringAlertSource) string {
+	return fmt.Sprintf("projects/%s/topics/%s", s.Status.ProjectID, s.Status.TopicID)
+}
+
+// GenerateNotificationChannelDisplayName generates the display name of the
+// notification channel of a CloudMonitoringAlertSource.
+func GenerateNotificationChannelDisplayName(s *v1beta1.CloudMonitoringAlertSource) string {
+	return fmt.Sprintf("CloudMonitoringAlertSource %s/%s", s.Namespace, s.Name)
+}
+
+// GenerateProjectName generates the name of the project of a
+// CloudMonitoringAlertSource, which is the parent of its notification channel.
+func GenerateProjectName(s *v1beta1.CloudMonitoringAlertSource) string {
+	return "projects/" + s.Status.ProjectID
+}
+
+// GenerateAlertPolicyName generates the name of an alert policy of a
+// CloudMonitoringAlertSource from its ID.
+func GenerateAlertPolicyName(s *v1beta1.CloudMonitoringAlertSource, policyID string) string {
+	return fmt.Sprintf("projects/%s/alertPolicies/%s", s.Status.ProjectID, policyID)
+}
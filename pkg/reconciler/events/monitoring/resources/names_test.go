@@ -0,0 +1,86 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+var source = &v1beta1.CloudMonitoringAlertSource{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "myname",
+		Namespace: "mynamespace",
+		UID:       "uid",
+	},
+	Status: v1beta1.CloudMonitoringAlertSourceStatus{
+		PubSubStatus: duckv1beta1.PubSubStatus{
+			ProjectID: "project",
+			TopicID:   "topic",
+		},
+	},
+}
+
+func TestGenerateTopicName(t *testing.T) {
+	want := "cre-src_mynamespace_myname_uid"
+	got := GenerateTopicName(source)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateTopicResourceName(t *testing.T) {
+	want := "projects/project/topics/topic"
+	got := GenerateTopicResourceName(source)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateNotificationChannelDisplayName(t *testing.T) {
+	want := "CloudMonitoringAlertSource mynamespace/myname"
+	got := GenerateNotificationChannelDisplayName(source)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateProjectName(t *testing.T) {
+	want := "projects/project"
+	got := GenerateProjectName(source)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestGenerateAlertPolicyName(t *testing.T) {
+	want := "projects/project/alertPolicies/12345"
+	got := GenerateAlertPolicyName(source, "12345")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
@@ -19,12 +19,17 @@ package scheduler
 import (
 	"context"
 
+	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
 	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
 
 	"github.com/google/knative-gcp/pkg/apis/configs/gcpauth"
 	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
 	"github.com/google/knative-gcp/pkg/reconciler"
+	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/identity"
 	"github.com/google/knative-gcp/pkg/reconciler/identity/iam"
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
@@ -52,6 +57,15 @@ const (
 	receiveAdapterName = "cloudschedulersource.events.cloud.google.com"
 )
 
+type envConfig struct {
+	// IngressURL is the URL of the shared scheduler ingress which the jobs of the
+	// CloudSchedulerSources with HTTP delivery call. It must be reachable by Cloud Scheduler.
+	IngressURL string `envconfig:"SCHEDULER_INGRESS_URL"`
+	// IngressServiceAccount is the email of the Google service account whose OIDC tokens the
+	// jobs of the CloudSchedulerSources with HTTP delivery present to the ingress.
+	IngressServiceAccount string `envconfig:"SCHEDULER_INGRESS_SERVICE_ACCOUNT"`
+}
+
 type Constructor injection.ControllerConstructor
 
 // NewConstructor creates a constructor to make a CloudSchedulerSource controller.
@@ -72,6 +86,13 @@ func newController(
 	cloudschedulersourceInformer := cloudschedulersourceinformers.Get(ctx)
 	serviceAccountInformer := serviceaccountinformers.Get(ctx)
 
+	logger := logging.FromContext(ctx).Named(controllerAgentName).Desugar()
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		logger.Fatal("Failed to process env var", zap.Error(err))
+	}
+
 	c := &Reconciler{
 		PubSubBase: intevents.NewPubSubBase(ctx,
 			&intevents.PubSubBaseArgs{
@@ -82,9 +103,14 @@ func newController(
 			}),
 		Identity:        identity.NewIdentity(ctx, ipm, gcpas),
 		schedulerLister: cloudschedulersourceInformer.Lister(),
-		createClientFn:  gscheduler.NewClient,
+		ingress: resources.Ingress{
+			URL:            env.IngressURL,
+			ServiceAccount: env.IngressServiceAccount,
+		},
+		createClientFn: gscheduler.NewClient,
 	}
 	impl := cloudschedulersourcereconciler.NewImpl(ctx, c)
+	c.uriResolver = resolver.NewURIResolver(ctx, impl.EnqueueKey)
 
 	c.Logger.Info("Setting up event handlers")
 	cloudschedulersourceInformer.Informer().AddEventHandlerWithResyncPeriod(controller.HandleAll(impl.Enqueue), reconciler.DefaultResyncPeriod)
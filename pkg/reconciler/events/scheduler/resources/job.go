@@ -0,0 +1,240 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/utils"
+)
+
+// The values Cloud Scheduler uses for the job options which are not set.
+const (
+	defaultMinBackoffDuration = 5 * time.Second
+	defaultMaxBackoffDuration = time.Hour
+	defaultMaxDoublings       = 5
+	defaultAttemptDeadline    = 3 * time.Minute
+	defaultTimeZone           = "Etc/UTC"
+)
+
+// Ingress is the shared scheduler ingress which the jobs of the CloudSchedulerSources with HTTP
+// delivery call.
+type Ingress struct {
+	// URL is the base URL of the ingress, which is also the audience of the OIDC tokens of the jobs.
+	URL string
+	// ServiceAccount is the email of the Google service account of the OIDC tokens of the jobs.
+	ServiceAccount string
+}
+
+// MakeJob generates the Cloud Scheduler job named jobName which publishes the scheduler's data to topic.
+// The job options which are not set in the spec are set to the Cloud Scheduler defaults.
+func MakeJob(scheduler *v1beta1.CloudSchedulerSource, topic, jobName string) (*schedulerpb.Job, error) {
+	job, err := makeJob(scheduler, jobName)
+	if err != nil {
+		return nil, err
+	}
+	job.Target = &schedulerpb.Job_PubsubTarget{
+		PubsubTarget: &schedulerpb.PubsubTarget{
+			TopicName: GeneratePubSubTargetTopic(scheduler, topic),
+			Data:      []byte(scheduler.Spec.Data),
+			// Add our jobName, and schedulerName as customAttributes.
+			Attributes: map[string]string{
+				v1beta1.CloudSchedulerSourceJobName: jobName,
+				v1beta1.CloudSchedulerSourceName:    scheduler.GetName(),
+			},
+		},
+	}
+	return job, nil
+}
+
+// MakeHTTPJob generates the Cloud Scheduler job named jobName which posts the scheduler's data to
+// the ingress, authenticated with an OIDC token of the ingress service account.
+// The job options which are not set in the spec are set to the Cloud Scheduler defaults.
+func MakeHTTPJob(scheduler *v1beta1.CloudSchedulerSource, ingress Ingress, jobName string) (*schedulerpb.Job, error) {
+	job, err := makeJob(scheduler, jobName)
+	if err != nil {
+		return nil, err
+	}
+	job.Target = &schedulerpb.Job_HttpTarget{
+		HttpTarget: &schedulerpb.HttpTarget{
+			Uri:        GenerateIngressURI(scheduler, ingress.URL),
+			HttpMethod: schedulerpb.HttpMethod_POST,
+			Body:       []byte(scheduler.Spec.Data),
+			AuthorizationHeader: &schedulerpb.HttpTarget_OidcToken{
+				OidcToken: &schedulerpb.OidcToken{
+					ServiceAccountEmail: ingress.ServiceAccount,
+					Audience:            ingress.URL,
+				},
+			},
+		},
+	}
+	return job, nil
+}
+
+// makeJob generates the Cloud Scheduler job named jobName without its target.
+func makeJob(scheduler *v1beta1.CloudSchedulerSource, jobName string) (*schedulerpb.Job, error) {
+	retryConfig, err := makeRetryConfig(scheduler.Spec.RetryConfig)
+	if err != nil {
+		return nil, err
+	}
+	attemptDeadline := ptypes.DurationProto(defaultAttemptDeadline)
+	if scheduler.Spec.AttemptDeadline != nil {
+		if attemptDeadline, err = durationProto(*scheduler.Spec.AttemptDeadline); err != nil {
+			return nil, err
+		}
+	}
+	return &schedulerpb.Job{
+		Name:            jobName,
+		Schedule:        scheduler.Spec.Schedule,
+		TimeZone:        scheduler.Spec.TimeZone,
+		RetryConfig:     retryConfig,
+		AttemptDeadline: attemptDeadline,
+	}, nil
+}
+
+func makeRetryConfig(rc *v1beta1.SchedulerRetryConfig) (*schedulerpb.RetryConfig, error) {
+	config := defaultRetryConfig()
+	if rc == nil {
+		return config, nil
+	}
+	config.RetryCount = rc.RetryCount
+	if rc.MaxDoublings != nil {
+		config.MaxDoublings = *rc.MaxDoublings
+	}
+	for _, d := range []struct {
+		value *string
+		field **duration.Duration
+	}{
+		{rc.MaxRetryDuration, &config.MaxRetryDuration},
+		{rc.MinBackoffDuration, &config.MinBackoffDuration},
+		{rc.MaxBackoffDuration, &config.MaxBackoffDuration},
+	} {
+		if d.value == nil {
+			continue
+		}
+		p, err := durationProto(*d.value)
+		if err != nil {
+			return nil, err
+		}
+		*d.field = p
+	}
+	return config, nil
+}
+
+func defaultRetryConfig() *schedulerpb.RetryConfig {
+	return &schedulerpb.RetryConfig{
+		MaxRetryDuration:   ptypes.DurationProto(0),
+		MinBackoffDuration: ptypes.DurationProto(defaultMinBackoffDuration),
+		MaxBackoffDuration: ptypes.DurationProto(defaultMaxBackoffDuration),
+		MaxDoublings:       defaultMaxDoublings,
+	}
+}
+
+func durationProto(s string) (*duration.Duration, error) {
+	d, err := utils.ParseISO8601Duration(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse duration: %w", err)
+	}
+	return ptypes.DurationProto(d), nil
+}
+
+// JobUpdateMask returns the paths of the fields of the existing job which differ from the desired
+// job, in the format of the update mask of an UpdateJobRequest. It returns nil if the existing job
+// is up to date. The options which are not set in either job are compared as the Cloud Scheduler
+// defaults, so that the defaults filled in by Cloud Scheduler are not reported as drift.
+func JobUpdateMask(existing, desired *schedulerpb.Job) []string {
+	var paths []string
+	if existing.GetSchedule() != desired.GetSchedule() {
+		paths = append(paths, "schedule")
+	}
+	if timeZone(existing) != timeZone(desired) {
+		paths = append(paths, "time_zone")
+	}
+	if !proto.Equal(existing.GetPubsubTarget(), desired.GetPubsubTarget()) {
+		paths = append(paths, "pubsub_target")
+	}
+	if !proto.Equal(httpTarget(existing), httpTarget(desired)) {
+		paths = append(paths, "http_target")
+	}
+	if !proto.Equal(retryConfig(existing), retryConfig(desired)) {
+		paths = append(paths, "retry_config")
+	}
+	if !proto.Equal(attemptDeadline(existing), attemptDeadline(desired)) {
+		paths = append(paths, "attempt_deadline")
+	}
+	return paths
+}
+
+func timeZone(job *schedulerpb.Job) string {
+	switch tz := job.GetTimeZone(); tz {
+	case "", "UTC":
+		return defaultTimeZone
+	default:
+		return tz
+	}
+}
+
+// httpTarget returns the HTTP target of the job without its headers, as Cloud Scheduler adds
+// headers such as the User-Agent to the HTTP targets of jobs.
+func httpTarget(job *schedulerpb.Job) *schedulerpb.HttpTarget {
+	t := job.GetHttpTarget()
+	if t == nil {
+		return nil
+	}
+	return &schedulerpb.HttpTarget{
+		Uri:                 t.Uri,
+		HttpMethod:          t.HttpMethod,
+		Body:                t.Body,
+		AuthorizationHeader: t.AuthorizationHeader,
+	}
+}
+
+func retryConfig(job *schedulerpb.Job) *schedulerpb.RetryConfig {
+	config := defaultRetryConfig()
+	rc := job.GetRetryConfig()
+	if rc == nil {
+		return config
+	}
+	config.RetryCount = rc.RetryCount
+	if rc.MaxRetryDuration != nil {
+		config.MaxRetryDuration = rc.MaxRetryDuration
+	}
+	if rc.MinBackoffDuration != nil {
+		config.MinBackoffDuration = rc.MinBackoffDuration
+	}
+	if rc.MaxBackoffDuration != nil {
+		config.MaxBackoffDuration = rc.MaxBackoffDuration
+	}
+	if rc.MaxDoublings != 0 {
+		config.MaxDoublings = rc.MaxDoublings
+	}
+	return config
+}
+
+func attemptDeadline(job *schedulerpb.Job) *duration.Duration {
+	if d := job.GetAttemptDeadline(); d != nil {
+		return d
+	}
+	return ptypes.DurationProto(defaultAttemptDeadline)
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+
+	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+const testJobName = "projects/project/locations/location/jobs/cre-scheduler-uid"
+
+var testIngress = Ingress{
+	URL:            "https://scheduler.example.com",
+	ServiceAccount: "scheduler@project.iam.gserviceaccount.com",
+}
+
+func newScheduler(spec v1beta1.CloudSchedulerSourceSpec) *v1beta1.CloudSchedulerSource {
+	return &v1beta1.CloudSchedulerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scheduler",
+			Namespace: "namespace",
+			UID:       "uid",
+		},
+		Spec: spec,
+		Status: v1beta1.CloudSchedulerSourceStatus{
+			PubSubStatus: duckv1beta1.PubSubStatus{
+				ProjectID: "project",
+			},
+		},
+	}
+}
+
+func TestMakeJob(t *testing.T) {
+	target := &schedulerpb.Job_PubsubTarget{
+		PubsubTarget: &schedulerpb.PubsubTarget{
+			TopicName: "projects/project/topics/topic",
+			Data:      []byte("data"),
+			Attributes: map[string]string{
+				v1beta1.CloudSchedulerSourceJobName: testJobName,
+				v1beta1.CloudSchedulerSourceName:    "scheduler",
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		spec v1beta1.CloudSchedulerSourceSpec
+		want *schedulerpb.Job
+	}{{
+		name: "defaults",
+		spec: v1beta1.CloudSchedulerSourceSpec{
+			Schedule: "* * * * *",
+			Data:     "data",
+		},
+		want: &schedulerpb.Job{
+			Name:     testJobName,
+			Target:   target,
+			Schedule: "* * * * *",
+			RetryConfig: &schedulerpb.RetryConfig{
+				MaxRetryDuration:   ptypes.DurationProto(0),
+				MinBackoffDuration: ptypes.DurationProto(5 * time.Second),
+				MaxBackoffDuration: ptypes.DurationProto(time.Hour),
+				MaxDoublings:       5,
+			},
+			AttemptDeadline: ptypes.DurationProto(3 * time.Minute),
+		},
+	}, {
+		name: "job options",
+		spec: v1beta1.CloudSchedulerSourceSpec{
+			Schedule: "* * * * *",
+			Data:     "data",
+			TimeZone: "America/New_York",
+			RetryConfig: &v1beta1.SchedulerRetryConfig{
+				RetryCount:         3,
+				MaxRetryDuration:   ptr.String("PT1H"),
+				MinBackoffDuration: ptr.String("PT10S"),
+				MaxBackoffDuration: ptr.String("PT10M"),
+				MaxDoublings:       ptr.Int32(2),
+			},
+			AttemptDeadline: ptr.String("PT5M"),
+		},
+		want: &schedulerpb.Job{
+			Name:     testJobName,
+			Target:   target,
+			Schedule: "* * * * *",
+			TimeZone: "America/New_York",
+			RetryConfig: &schedulerpb.RetryConfig{
+				RetryCount:         3,
+				MaxRetryDuration:   ptypes.DurationProto(time.Hour),
+				MinBackoffDuration: ptypes.DurationProto(10 * time.Second),
+				MaxBackoffDuration: ptypes.DurationProto(10 * time.Minute),
+				MaxDoublings:       2,
+			},
+			AttemptDeadline: ptypes.DurationProto(5 * time.Minute),
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MakeJob(newScheduler(tc.spec), "topic", testJobName)
+			if err != nil {
+				t.Fatalf("MakeJob failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMakeHTTPJob(t *testing.T) {
+	got, err := MakeHTTPJob(newScheduler(v1beta1.CloudSchedulerSourceSpec{
+		Schedule: "* * * * *",
+		Data:     "data",
+		Delivery: v1beta1.SchedulerDeliveryHTTP,
+	}), testIngress, testJobName)
+	if err != nil {
+		t.Fatalf("MakeHTTPJob failed: %v", err)
+	}
+	want := &schedulerpb.Job{
+		Name: testJobName,
+		Target: &schedulerpb.Job_HttpTarget{
+			HttpTarget: &schedulerpb.HttpTarget{
+				Uri:        "https://scheduler.example.com/namespace/scheduler",
+				HttpMethod: schedulerpb.HttpMethod_POST,
+				Body:       []byte("data"),
+				AuthorizationHeader: &schedulerpb.HttpTarget_OidcToken{
+					OidcToken: &schedulerpb.OidcToken{
+						ServiceAccountEmail: "scheduler@project.iam.gserviceaccount.com",
+						Audience:            "https://scheduler.example.com",
+					},
+				},
+			},
+		},
+		Schedule: "* * * * *",
+		RetryConfig: &schedulerpb.RetryConfig{
+			MaxRetryDuration:   ptypes.DurationProto(0),
+			MinBackoffDuration: ptypes.DurationProto(5 * time.Second),
+			MaxBackoffDuration: ptypes.DurationProto(time.Hour),
+			MaxDoublings:       5,
+		},
+		AttemptDeadline: ptypes.DurationProto(3 * time.Minute),
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeJobInvalidDuration(t *testing.T) {
+	scheduler := newScheduler(v1beta1.CloudSchedulerSourceSpec{AttemptDeadline: ptr.String("5m")})
+	if _, err := MakeJob(scheduler, "topic", testJobName); err == nil {
+		t.Error("MakeJob with an invalid attempt deadline got nil error")
+	}
+}
+
+func TestJobUpdateMask(t *testing.T) {
+	desired, err := MakeJob(newScheduler(v1beta1.CloudSchedulerSourceSpec{
+		Schedule: "* * * * *",
+		Data:     "data",
+	}), "topic", testJobName)
+	if err != nil {
+		t.Fatalf("MakeJob failed: %v", err)
+	}
+	// existing returns the job as returned by Cloud Scheduler, which fills in the defaults of the
+	// retry config and time zone but not of the attempt deadline of Pub/Sub jobs.
+	existing := func(f func(*schedulerpb.Job)) *schedulerpb.Job {
+		job := &schedulerpb.Job{
+			Name:     testJobName,
+			Target:   desired.Target,
+			Schedule: "* * * * *",
+			TimeZone: "Etc/UTC",
+			RetryConfig: &schedulerpb.RetryConfig{
+				MaxRetryDuration:   ptypes.DurationProto(0),
+				MinBackoffDuration: ptypes.DurationProto(5 * time.Second),
+				MaxBackoffDuration: ptypes.DurationProto(time.Hour),
+				MaxDoublings:       5,
+			},
+			State: schedulerpb.Job_ENABLED,
+		}
+		if f != nil {
+			f(job)
+		}
+		return job
+	}
+	tests := []struct {
+		name     string
+		existing *schedulerpb.Job
+		want     []string
+	}{{
+		name:     "up to date",
+		existing: existing(nil),
+	}, {
+		name: "unset options",
+		existing: existing(func(job *schedulerpb.Job) {
+			job.TimeZone = ""
+			job.RetryConfig = nil
+		}),
+	}, {
+		name: "schedule changed",
+		existing: existing(func(job *schedulerpb.Job) {
+			job.Schedule = "0 * * * *"
+		}),
+		want: []string{"schedule"},
+	}, {
+		name: "data changed",
+		existing: existing(func(job *schedulerpb.Job) {
+			job.Target = &schedulerpb.Job_PubsubTarget{
+				PubsubTarget: &schedulerpb.PubsubTarget{
+					TopicName:  desired.GetPubsubTarget().TopicName,
+					Data:       []byte("other-data"),
+					Attributes: desired.GetPubsubTarget().Attributes,
+				},
+			}
+		}),
+		want: []string{"pubsub_target"},
+	}, {
+		name: "options changed",
+		existing: existing(func(job *schedulerpb.Job) {
+			job.TimeZone = "America/New_York"
+			job.RetryConfig.RetryCount = 2
+			job.AttemptDeadline = ptypes.DurationProto(time.Minute)
+		}),
+		want: []string{"time_zone", "retry_config", "attempt_deadline"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := JobUpdateMask(tc.existing, desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPJobUpdateMask(t *testing.T) {
+	desired, err := MakeHTTPJob(newScheduler(v1beta1.CloudSchedulerSourceSpec{
+		Schedule: "* * * * *",
+		Data:     "data",
+	}), testIngress, testJobName)
+	if err != nil {
+		t.Fatalf("MakeHTTPJob failed: %v", err)
+	}
+	// existing returns the job as returned by Cloud Scheduler, which adds headers to the HTTP target.
+	existing := func(f func(*schedulerpb.HttpTarget)) *schedulerpb.Job {
+		job := proto.Clone(desired).(*schedulerpb.Job)
+		job.GetHttpTarget().Headers = map[string]string{"User-Agent": "Google-Cloud-Scheduler"}
+		if f != nil {
+			f(job.GetHttpTarget())
+		}
+		return job
+	}
+	tests := []struct {
+		name     string
+		existing *schedulerpb.Job
+		want     []string
+	}{{
+		name:     "up to date",
+		existing: existing(nil),
+	}, {
+		name: "data changed",
+		existing: existing(func(target *schedulerpb.HttpTarget) {
+			target.Body = []byte("other-data")
+		}),
+		want: []string{"http_target"},
+	}, {
+		name: "service account changed",
+		existing: existing(func(target *schedulerpb.HttpTarget) {
+			target.AuthorizationHeader = &schedulerpb.HttpTarget_OidcToken{
+				OidcToken: &schedulerpb.OidcToken{
+					ServiceAccountEmail: "other@project.iam.gserviceaccount.com",
+					Audience:            testIngress.URL,
+				},
+			}
+		}),
+		want: []string{"http_target"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := JobUpdateMask(tc.existing, desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -55,3 +55,9 @@ func GenerateTopicName(scheduler *v1beta1.CloudSchedulerSource) string {
 func GeneratePubSubTargetTopic(scheduler *v1beta1.CloudSchedulerSource, topic string) string {
 	return fmt.Sprintf("projects/%s/topics/%s", scheduler.Status.ProjectID, topic)
 }
+
+// GenerateIngressURI generates the URI of the scheduler on the shared scheduler ingress with the
+// base URL, like this: BASE_URL/NAMESPACE/NAME.
+func GenerateIngressURI(scheduler *v1beta1.CloudSchedulerSource, baseURL string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(baseURL, "/"), scheduler.Namespace, scheduler.Name)
+}
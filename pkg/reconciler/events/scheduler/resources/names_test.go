@@ -91,3 +91,18 @@ func TestGeneratePubSubTargetTopic(t *testing.T) {
 		t.Errorf("unexpected (-want, +got) = %v", diff)
 	}
 }
+
+func TestGenerateIngressURI(t *testing.T) {
+	scheduler := &v1beta1.CloudSchedulerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myname",
+			Namespace: "mynamespace",
+		},
+	}
+	want := "https://scheduler.example.com/mynamespace/myname"
+	for _, baseURL := range []string{"https://scheduler.example.com", "https://scheduler.example.com/"} {
+		if got := GenerateIngressURI(scheduler, baseURL); got != want {
+			t.Errorf("GenerateIngressURI(%q) = %q, want %q", baseURL, got, want)
+		}
+	}
+}
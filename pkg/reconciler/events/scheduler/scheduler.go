@@ -18,13 +18,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 
 	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 
@@ -42,13 +45,16 @@ import (
 const (
 	resourceGroup = "cloudschedulersources.events.cloud.google.com"
 
-	deleteJobFailed              = "JobDeleteFailed"
-	deletePubSubFailed           = "PubSubDeleteFailed"
-	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
-	reconciledPubSubFailedReason = "PubSubReconcileFailed"
-	reconciledFailedReason       = "JobReconcileFailed"
-	reconciledSuccessReason      = "CloudSchedulerSourceReconciled"
-	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
+	deleteJobFailed                    = "JobDeleteFailed"
+	deletePubSubFailed                 = "PubSubDeleteFailed"
+	deleteWorkloadIdentityFailed       = "WorkloadIdentityDeleteFailed"
+	reconciledPubSubFailedReason       = "PubSubReconcileFailed"
+	reconciledHTTPDeliveryFailedReason = "HTTPDeliveryReconcileFailed"
+	reconciledFailedReason             = "JobReconcileFailed"
+	reconciledSuccessReason            = "CloudSchedulerSourceReconciled"
+	workloadIdentityFailed             = "WorkloadIdentityReconcileFailed"
+	httpDeliveryNotConfiguredReason    = "HTTPDeliveryNotConfigured"
+	sinkNotResolvedReason              = "SinkNotResolved"
 )
 
 // Reconciler is the controller implementation for Google Cloud Scheduler Jobs.
@@ -58,6 +64,10 @@ type Reconciler struct {
 	*identity.Identity
 	// schedulerLister for reading schedulers.
 	schedulerLister listers.CloudSchedulerSourceLister
+	// uriResolver resolves the sinks of schedulers with HTTP delivery.
+	uriResolver *resolver.URIResolver
+	// ingress is the shared scheduler ingress called by the jobs of schedulers with HTTP delivery.
+	ingress resources.Ingress
 
 	createClientFn gscheduler.CreateFn
 }
@@ -78,15 +88,26 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, scheduler *v1beta1.Cloud
 		}
 	}
 
-	topic := resources.GenerateTopicName(scheduler)
-	_, _, err := r.PubSubBase.ReconcilePubSub(ctx, scheduler, topic, resourceGroup)
-	if err != nil {
-		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+	// With HTTP delivery, the job calls the shared scheduler ingress, so the scheduler needs
+	// neither a Topic nor a PullSubscription.
+	var topic string
+	if scheduler.Spec.IsHTTPDelivery() {
+		if err := r.reconcileHTTPDelivery(ctx, scheduler); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, reconciledHTTPDeliveryFailedReason, "Reconcile HTTP delivery failed with: %s", err.Error())
+		}
+	} else {
+		topic = resources.GenerateTopicName(scheduler)
+		if _, _, err := r.PubSubBase.ReconcilePubSub(ctx, scheduler, topic, resourceGroup); err != nil {
+			return reconciler.NewEvent(corev1.EventTypeWarning, reconciledPubSubFailedReason, "Reconcile PubSub failed with: %s", err.Error())
+		}
 	}
 
+	if err := r.reconcileProjectID(ctx, scheduler); err != nil {
+		scheduler.Status.MarkJobNotReady(reconciledFailedReason, "Failed to reconcile CloudSchedulerSource job: %s", err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: %s", err.Error())
+	}
 	jobName := resources.GenerateJobName(scheduler)
-	err = r.reconcileJob(ctx, scheduler, topic, jobName)
-	if err != nil {
+	if err := r.reconcileJob(ctx, scheduler, topic, jobName); err != nil {
 		scheduler.Status.MarkJobNotReady(reconciledFailedReason, "Failed to reconcile CloudSchedulerSource job: %s", err.Error())
 		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: %s", err.Error())
 	}
@@ -94,17 +115,47 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, scheduler *v1beta1.Cloud
 	return reconciler.NewEvent(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, scheduler.Namespace, scheduler.Name)
 }
 
-func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1beta1.CloudSchedulerSource, topic, jobName string) error {
-	if scheduler.Status.ProjectID == "" {
-		projectID, err := utils.ProjectID(scheduler.Spec.Project, metadataClient.NewDefaultMetadataClient())
-		if err != nil {
-			logging.FromContext(ctx).Desugar().Error("Failed to find project id", zap.Error(err))
-			return err
-		}
-		// Set the projectID in the status.
-		scheduler.Status.ProjectID = projectID
+// reconcileHTTPDelivery checks that the shared scheduler ingress is configured and resolves the
+// sink of a scheduler with HTTP delivery, which the ingress sends the events to.
+func (r *Reconciler) reconcileHTTPDelivery(ctx context.Context, scheduler *v1beta1.CloudSchedulerSource) error {
+	if r.ingress.URL == "" || r.ingress.ServiceAccount == "" {
+		scheduler.Status.MarkJobNotReady(httpDeliveryNotConfiguredReason, "The scheduler ingress is not configured")
+		return errors.New("the scheduler ingress is not configured")
+	}
+
+	// To call URIFromDestinationV1(), dest.Ref must have a Namespace. If there is
+	// no Namespace defined in dest.Ref, we will use the Namespace of the scheduler
+	// as the Namespace of dest.Ref.
+	destination := *scheduler.Spec.Sink.DeepCopy()
+	if destination.Ref != nil && destination.Ref.Namespace == "" {
+		destination.Ref.Namespace = scheduler.Namespace
+	}
+	sinkURI, err := r.uriResolver.URIFromDestinationV1(destination, scheduler)
+	if err != nil {
+		scheduler.Status.SinkURI = nil
+		scheduler.Status.MarkJobNotReady(sinkNotResolvedReason, "Failed to resolve the sink: %s", err.Error())
+		return err
+	}
+	scheduler.Status.SinkURI = sinkURI
+	scheduler.Status.MarkHTTPDelivery()
+	return nil
+}
+
+func (r *Reconciler) reconcileProjectID(ctx context.Context, scheduler *v1beta1.CloudSchedulerSource) error {
+	if scheduler.Status.ProjectID != "" {
+		return nil
+	}
+	projectID, err := utils.ProjectID(scheduler.Spec.Project, metadataClient.NewDefaultMetadataClient())
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to find project id", zap.Error(err))
+		return err
 	}
+	// Set the projectID in the status.
+	scheduler.Status.ProjectID = projectID
+	return nil
+}
 
+func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1beta1.CloudSchedulerSource, topic, jobName string) error {
 	client, err := r.createClientFn(ctx)
 	if err != nil {
 		logging.FromContext(ctx).Desugar().Error("Failed to create CloudSchedulerSource client", zap.Error(err))
@@ -112,33 +163,28 @@ func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1beta1.CloudS
 	}
 	defer client.Close()
 
+	var desired *schedulerpb.Job
+	if scheduler.Spec.IsHTTPDelivery() {
+		desired, err = resources.MakeHTTPJob(scheduler, r.ingress, jobName)
+	} else {
+		desired, err = resources.MakeJob(scheduler, topic, jobName)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to generate CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
+		return err
+	}
+
 	// Check if the job exists.
-	_, err = client.GetJob(ctx, &schedulerpb.GetJobRequest{Name: jobName})
+	job, err := client.GetJob(ctx, &schedulerpb.GetJobRequest{Name: jobName})
 	if err != nil {
 		if st, ok := gstatus.FromError(err); !ok {
 			logging.FromContext(ctx).Desugar().Error("Failed from CloudSchedulerSource client while retrieving CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
 			return err
 		} else if st.Code() == codes.NotFound {
 			// Create the job as it does not exist. For creation, we need a parent, extract it from the jobName.
-			parent := resources.ExtractParentName(jobName)
-			// Add our jobName, and schedulerName as customAttributes.
-			customAttributes := map[string]string{
-				v1beta1.CloudSchedulerSourceJobName: jobName,
-				v1beta1.CloudSchedulerSourceName:    scheduler.GetName(),
-			}
-			_, err = client.CreateJob(ctx, &schedulerpb.CreateJobRequest{
-				Parent: parent,
-				Job: &schedulerpb.Job{
-					Name: jobName,
-					Target: &schedulerpb.Job_PubsubTarget{
-						PubsubTarget: &schedulerpb.PubsubTarget{
-							TopicName:  resources.GeneratePubSubTargetTopic(scheduler, topic),
-							Data:       []byte(scheduler.Spec.Data),
-							Attributes: customAttributes,
-						},
-					},
-					Schedule: scheduler.Spec.Schedule,
-				},
+			job, err = client.CreateJob(ctx, &schedulerpb.CreateJobRequest{
+				Parent: resources.ExtractParentName(jobName),
+				Job:    desired,
 			})
 			if err != nil {
 				logging.FromContext(ctx).Desugar().Error("Failed to create CloudSchedulerSource job", zap.String("jobName", jobName), zap.Error(err))
@@ -148,8 +194,33 @@ func (r *Reconciler) reconcileJob(ctx context.Context, scheduler *v1beta1.CloudS
 			logging.FromContext(ctx).Desugar().Error("Failed from CloudSchedulerSource client while retrieving CloudSchedulerSource job", zap.String("jobName", jobName), zap.Any("errorCode", st.Code()), zap.Error(err))
 			return err
 		}
+	} else if paths := resources.JobUpdateMask(job, desired); len(paths) > 0 {
+		// Update the fields of the existing job which drifted from the spec.
+		job, err = client.UpdateJob(ctx, &schedulerpb.UpdateJobRequest{
+			Job:        desired,
+			UpdateMask: &field_mask.FieldMask{Paths: paths},
+		})
+		if err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to update CloudSchedulerSource job", zap.String("jobName", jobName), zap.Strings("paths", paths), zap.Error(err))
+			return err
+		}
 	}
-	return nil
+	return r.reconcileJobState(ctx, client, scheduler, job)
+}
+
+// reconcileJobState pauses or resumes the job to match spec.paused.
+func (r *Reconciler) reconcileJobState(ctx context.Context, client gscheduler.Client, scheduler *v1beta1.CloudSchedulerSource, job *schedulerpb.Job) error {
+	var err error
+	switch {
+	case scheduler.Spec.Paused && job.GetState() != schedulerpb.Job_PAUSED:
+		_, err = client.PauseJob(ctx, &schedulerpb.PauseJobRequest{Name: job.GetName()})
+	case !scheduler.Spec.Paused && job.GetState() == schedulerpb.Job_PAUSED:
+		_, err = client.ResumeJob(ctx, &schedulerpb.ResumeJobRequest{Name: job.GetName()})
+	}
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to change the state of CloudSchedulerSource job", zap.String("jobName", job.GetName()), zap.Bool("paused", scheduler.Spec.Paused), zap.Error(err))
+	}
+	return err
 }
 
 // deleteJob looks at the status.JobName and if non-empty,
@@ -197,6 +268,9 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, scheduler *v1beta1.CloudS
 		return reconciler.NewEvent(corev1.EventTypeWarning, deleteJobFailed, "Failed to delete CloudSchedulerSource job: %s", err.Error())
 	}
 
+	if scheduler.Spec.IsHTTPDelivery() {
+		return nil
+	}
 	if err := r.PubSubBase.DeletePubSub(ctx, scheduler); err != nil {
 		return reconciler.NewEvent(corev1.EventTypeWarning, deletePubSubFailed, "Failed to delete CloudSchedulerSource PubSub: %s", err.Error())
 	}
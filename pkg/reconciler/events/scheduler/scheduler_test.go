@@ -27,14 +27,19 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
 
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 	. "knative.dev/pkg/reconciler/testing"
@@ -47,6 +52,7 @@ import (
 	testingMetadataClient "github.com/google/knative-gcp/pkg/gclient/metadata/testing"
 	gscheduler "github.com/google/knative-gcp/pkg/gclient/scheduler/testing"
 	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/reconciler/events/scheduler/resources"
 	"github.com/google/knative-gcp/pkg/reconciler/identity"
 	"github.com/google/knative-gcp/pkg/reconciler/intevents"
 	. "github.com/google/knative-gcp/pkg/reconciler/testing"
@@ -99,6 +105,11 @@ var (
 	}
 
 	gServiceAccount = "test123@test123.iam.gserviceaccount.com"
+
+	testIngress = resources.Ingress{
+		URL:            "https://scheduler.example.com",
+		ServiceAccount: "scheduler@" + testProject + ".iam.gserviceaccount.com",
+	}
 )
 
 func init() {
@@ -143,6 +154,7 @@ func newSink() *unstructured.Unstructured {
 			"status": map[string]interface{}{
 				"address": map[string]interface{}{
 					"hostname": sinkDNS,
+					"url":      sinkURI.String(),
 				},
 			},
 		},
@@ -159,6 +171,52 @@ func newSinkDestination() duckv1.Destination {
 	}
 }
 
+// existingJob returns the Cloud Scheduler job of the test CloudSchedulerSource as returned by
+// Cloud Scheduler, with the given schedule and state.
+func existingJob(schedule string, state schedulerpb.Job_State) *schedulerpb.Job {
+	return &schedulerpb.Job{
+		Name: jobName,
+		Target: &schedulerpb.Job_PubsubTarget{
+			PubsubTarget: &schedulerpb.PubsubTarget{
+				TopicName: fmt.Sprintf("projects/%s/topics/%s", testProject, testTopicID),
+				Data:      []byte(testData),
+				Attributes: map[string]string{
+					schedulerv1beta1.CloudSchedulerSourceJobName: jobName,
+					schedulerv1beta1.CloudSchedulerSourceName:    schedulerName,
+				},
+			},
+		},
+		Schedule: schedule,
+		TimeZone: "Etc/UTC",
+		State:    state,
+	}
+}
+
+// existingHTTPJob returns the Cloud Scheduler job of the test CloudSchedulerSource with HTTP
+// delivery as returned by Cloud Scheduler, with the given schedule.
+func existingHTTPJob(schedule string) *schedulerpb.Job {
+	return &schedulerpb.Job{
+		Name: jobName,
+		Target: &schedulerpb.Job_HttpTarget{
+			HttpTarget: &schedulerpb.HttpTarget{
+				Uri:        testIngress.URL + "/" + testNS + "/" + schedulerName,
+				HttpMethod: schedulerpb.HttpMethod_POST,
+				Headers:    map[string]string{"User-Agent": "Google-Cloud-Scheduler"},
+				Body:       []byte(testData),
+				AuthorizationHeader: &schedulerpb.HttpTarget_OidcToken{
+					OidcToken: &schedulerpb.OidcToken{
+						ServiceAccountEmail: testIngress.ServiceAccount,
+						Audience:            testIngress.URL,
+					},
+				},
+			},
+		},
+		Schedule: schedule,
+		TimeZone: "Etc/UTC",
+		State:    schedulerpb.Job_ENABLED,
+	}
+}
+
 // TODO add a unit test for successfully creating a k8s service account, after issue https://github.com/google/knative-gcp/issues/657 gets solved.
 func TestAllCases(t *testing.T) {
 	schedulerSinkURL := sinkURI
@@ -1084,6 +1142,13 @@ func TestAllCases(t *testing.T) {
 				),
 				newSink(),
 			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobResp: existingJob(onceAMinuteSchedule, schedulerpb.Job_ENABLED),
+					// The job is up to date, so it must not be updated.
+					UpdateJobErr: errors.New("update-job-induced-error"),
+				},
+			},
 			Key: testNS + "/" + schedulerName,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewCloudSchedulerSource(schedulerName, testNS,
@@ -1108,6 +1173,340 @@ func TestAllCases(t *testing.T) {
 				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
 				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, testNS, schedulerName),
 			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job exists with a drifted schedule, update job fails",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				NewTopic(schedulerName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(schedulerName, testNS,
+					WithPullSubscriptionReady(sinkURI),
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobResp:   existingJob("0 * * * *", schedulerpb.Job_ENABLED),
+					UpdateJobErr: errors.New("update-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					WithCloudSchedulerSourcePullSubscriptionReady,
+					WithCloudSchedulerSourceSubscriptionID(SubscriptionID),
+					WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: %s", failedToReconcileJobMsg, "update-job-induced-error")),
+					WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: update-job-induced-error"),
+			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job exists and is enabled, pause job fails",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourcePaused,
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				NewTopic(schedulerName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(schedulerName, testNS,
+					WithPullSubscriptionReady(sinkURI),
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobResp:  existingJob(onceAMinuteSchedule, schedulerpb.Job_ENABLED),
+					PauseJobErr: errors.New("pause-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourcePaused,
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					WithCloudSchedulerSourcePullSubscriptionReady,
+					WithCloudSchedulerSourceSubscriptionID(SubscriptionID),
+					WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: %s", failedToReconcileJobMsg, "pause-job-induced-error")),
+					WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: pause-job-induced-error"),
+			},
+		}, {
+			Name: "topic and pullsubscription exist and ready, job exists and is paused, resume job fails",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				NewTopic(schedulerName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(schedulerName, testNS,
+					WithPullSubscriptionReady(sinkURI),
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Secret: &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+							Project: testProject,
+						},
+						AdapterType: string(converters.CloudScheduler),
+					}),
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobResp:   existingJob(onceAMinuteSchedule, schedulerpb.Job_PAUSED),
+					ResumeJobErr: errors.New("resume-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceTopicReady(testTopicID, testProject),
+					WithCloudSchedulerSourcePullSubscriptionReady,
+					WithCloudSchedulerSourceSubscriptionID(SubscriptionID),
+					WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: %s", failedToReconcileJobMsg, "resume-job-induced-error")),
+					WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: resume-job-induced-error"),
+			},
+		}, {
+			Name: "http delivery, ingress not configured",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"ingress": resources.Ingress{},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceJobNotReady(httpDeliveryNotConfiguredReason, "The scheduler ingress is not configured"),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledHTTPDeliveryFailedReason, "Reconcile HTTP delivery failed with: the scheduler ingress is not configured"),
+			},
+		}, {
+			Name: "http delivery, get job fails with grpc not found error, create job succeeds",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobErr: gstatus.Error(codes.NotFound, "get-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceHTTPDeliveryReady,
+					WithCloudSchedulerSourceProjectID(testProject),
+					WithCloudSchedulerSourceJobReady(jobName),
+					WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudSchedulerSource reconciled: "%s/%s"`, testNS, schedulerName),
+			},
+		}, {
+			Name: "http delivery, job exists with a drifted schedule, update job fails",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithCloudSchedulerSourceSetDefaults,
+				),
+				newSink(),
+			},
+			OtherTestData: map[string]interface{}{
+				"scheduler": gscheduler.TestClientData{
+					GetJobResp:   existingHTTPJob("0 * * * *"),
+					UpdateJobErr: errors.New("update-job-induced-error"),
+				},
+			},
+			Key: testNS + "/" + schedulerName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithInitCloudSchedulerSourceConditions,
+					WithCloudSchedulerSourceHTTPDeliveryReady,
+					WithCloudSchedulerSourceProjectID(testProject),
+					WithCloudSchedulerSourceJobNotReady(reconciledFailedReason, fmt.Sprintf("%s: %s", failedToReconcileJobMsg, "update-job-induced-error")),
+					WithCloudSchedulerSourceSinkURI(schedulerSinkURL),
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, true),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+				Eventf(corev1.EventTypeWarning, reconciledFailedReason, "Reconcile Job failed with: update-job-induced-error"),
+			},
 		}, {
 			Name: "scheduler job fails to delete with no-grpc error",
 			Objects: []runtime.Object{
@@ -1249,10 +1648,34 @@ func TestAllCases(t *testing.T) {
 					DeleteJobErr: gstatus.Error(codes.NotFound, "delete-job-induced-error"),
 				},
 			},
+		}, {
+			Name: "http delivery, scheduler successfully deleted",
+			Objects: []runtime.Object{
+				NewCloudSchedulerSource(schedulerName, testNS,
+					WithCloudSchedulerSourceProject(testProject),
+					WithCloudSchedulerSourceSink(sinkGVK, sinkName),
+					WithCloudSchedulerSourceLocation(location),
+					WithCloudSchedulerSourceData(testData),
+					WithCloudSchedulerSourceSchedule(onceAMinuteSchedule),
+					WithCloudSchedulerSourceHTTPDelivery,
+					WithCloudSchedulerSourceJobReady(jobName),
+					WithCloudSchedulerSourceFinalizers(resourceGroup),
+					WithCloudSchedulerSourceDeletionTimestamp,
+					WithCloudSchedulerSourceSetDefaults,
+				),
+			},
+			Key: testNS + "/" + schedulerName,
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, schedulerName, false),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", schedulerName),
+			},
 		}}
 
 	defer logtesting.ClearAll()
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher, testData map[string]interface{}) controller.Reconciler {
+		ctx = addressable.WithDuck(ctx)
 		r := &Reconciler{
 			PubSubBase: intevents.NewPubSubBase(ctx,
 				&intevents.PubSubBaseArgs{
@@ -1263,8 +1686,13 @@ func TestAllCases(t *testing.T) {
 				}),
 			Identity:        identity.NewIdentity(ctx, NoopIAMPolicyManager, NewGCPAuthTestStore(t, nil)),
 			schedulerLister: listers.GetCloudSchedulerSourceLister(),
+			ingress:         testIngress,
 			createClientFn:  gscheduler.TestClientCreator(testData["scheduler"]),
 		}
+		if ingress, ok := testData["ingress"]; ok {
+			r.ingress = ingress.(resources.Ingress)
+		}
+		r.uriResolver = resolver.NewURIResolver(ctx, func(types.NamespacedName) {})
 		return cloudschedulersource.NewReconciler(ctx, r.Logger, r.RunClientSet, listers.GetCloudSchedulerSourceLister(), r.Recorder, r)
 	}))
 
@@ -18,12 +18,17 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"reflect"
 
 	"go.uber.org/zap"
 
+	"google.golang.org/api/googleapi"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 
@@ -46,10 +51,15 @@ const (
 	deleteNotificationFailed     = "NotificationDeleteFailed"
 	deletePubSubFailed           = "PubSubDeleteFailed"
 	deleteWorkloadIdentityFailed = "WorkloadIdentityDeleteFailed"
+	notificationPermissionDenied = "NotificationPermissionDenied"
 	reconciledNotificationFailed = "NotificationReconcileFailed"
 	reconciledPubSubFailed       = "PubSubReconcileFailed"
 	reconciledSuccessReason      = "CloudStorageSourceReconciled"
 	workloadIdentityFailed       = "WorkloadIdentityReconcileFailed"
+
+	permissionDeniedMsg = "Permission denied to configure the notification of bucket %q. The Google service account " +
+		"of the CloudStorageSource needs the storage.buckets.update permission on the bucket, and the Cloud Storage " +
+		"service account of the bucket's project needs the pubsub.topics.publish permission on topic %q: %s"
 )
 
 var (
@@ -99,7 +109,10 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, storage *v1beta1.CloudSt
 	}
 
 	notification, err := r.reconcileNotification(ctx, storage)
-	if err != nil {
+	if isPermissionDenied(err) {
+		storage.Status.MarkNotificationNotReady(notificationPermissionDenied, permissionDeniedMsg, storage.Spec.Bucket, storage.Status.TopicID, err.Error())
+		return reconciler.NewEvent(corev1.EventTypeWarning, notificationPermissionDenied, permissionDeniedMsg, storage.Spec.Bucket, storage.Status.TopicID, err.Error())
+	} else if err != nil {
 		storage.Status.MarkNotificationNotReady(reconciledNotificationFailed, "Failed to reconcile CloudStorageSource notification: %s", err.Error())
 		return reconciler.NewEvent(corev1.EventTypeWarning, reconciledNotificationFailed, "Failed to reconcile CloudStorageSource notification: %s", err.Error())
 	}
@@ -144,27 +157,55 @@ func (r *Reconciler) reconcileNotification(ctx context.Context, storage *v1beta1
 		return "", err
 	}
 
-	// If the notification does exist, then return its ID.
+	desired := r.makeNotification(storage)
 	if existing, ok := notifications[storage.Status.NotificationID]; ok {
-		return existing.ID, nil
+		// If the notification is up to date, then return its ID.
+		if notificationMatches(existing, desired) {
+			return existing.ID, nil
+		}
+		// Notifications can't be updated, so replace the notification. The existing notification is
+		// deleted first, so that a failure to create the new one doesn't leave both of them behind.
+		logging.FromContext(ctx).Desugar().Debug("Replacing outdated notification", zap.Any("notification", existing))
+		if err := bucket.DeleteNotification(ctx, existing.ID); err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to delete outdated CloudStorageSource notification", zap.String("notificationId", existing.ID), zap.Error(err))
+			return "", err
+		}
 	}
 
 	// If the notification does not exist, then create it.
+	notification, err := bucket.AddNotification(ctx, desired)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to create CloudStorageSource notification", zap.Error(err))
+		return "", err
+	}
+	return notification.ID, nil
+}
 
-	nc := &Notification{
+func (r *Reconciler) makeNotification(storage *v1beta1.CloudStorageSource) *Notification {
+	payloadFormat := JSONPayload
+	if storage.Spec.PayloadFormat == v1beta1.CloudStorageSourcePayloadFormatNone {
+		payloadFormat = NoPayload
+	}
+	return &Notification{
 		TopicProjectID:   storage.Status.ProjectID,
 		TopicID:          storage.Status.TopicID,
-		PayloadFormat:    JSONPayload,
+		PayloadFormat:    payloadFormat,
 		EventTypes:       r.toCloudStorageSourceEventTypes(storage.Spec.EventTypes),
 		ObjectNamePrefix: storage.Spec.ObjectNamePrefix,
+		CustomAttributes: storage.Spec.CustomAttributes,
 	}
+}
 
-	notification, err := bucket.AddNotification(ctx, nc)
-	if err != nil {
-		logging.FromContext(ctx).Desugar().Error("Failed to create CloudStorageSource notification", zap.Error(err))
-		return "", err
-	}
-	return notification.ID, nil
+// notificationMatches returns true if the existing notification is configured as the desired one.
+func notificationMatches(existing, desired *Notification) bool {
+	return existing.TopicProjectID == desired.TopicProjectID &&
+		existing.TopicID == desired.TopicID &&
+		existing.PayloadFormat == desired.PayloadFormat &&
+		existing.ObjectNamePrefix == desired.ObjectNamePrefix &&
+		sets.NewString(existing.EventTypes...).Equal(sets.NewString(desired.EventTypes...)) &&
+		// Notifications without custom attributes have either a nil or an empty map.
+		(len(existing.CustomAttributes) == 0 && len(desired.CustomAttributes) == 0 ||
+			reflect.DeepEqual(existing.CustomAttributes, desired.CustomAttributes))
 }
 
 func (r *Reconciler) toCloudStorageSourceEventTypes(eventTypes []string) []string {
@@ -175,6 +216,19 @@ func (r *Reconciler) toCloudStorageSourceEventTypes(eventTypes []string) []strin
 	return storageTypes
 }
 
+// isPermissionDenied returns true if err is an error of the Cloud Storage API because the bucket
+// or topic permissions are missing.
+func isPermissionDenied(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusForbidden
+	}
+	if st, ok := gstatus.FromError(err); ok {
+		return st.Code() == codes.PermissionDenied
+	}
+	return false
+}
+
 // deleteNotification looks at the status.NotificationID and if non-empty,
 // hence indicating that we have created a notification successfully
 // in the CloudStorageSource, remove it.
@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/grpc/codes"
 	gstatus "google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
@@ -98,8 +100,29 @@ var (
 	}
 
 	gServiceAccount = "test123@test123.iam.gserviceaccount.com"
+
+	// replacedNotificationId is the ID of the notification which replaces an outdated one.
+	replacedNotificationId = "136"
+
+	permissionDeniedErr = &googleapi.Error{Code: http.StatusForbidden, Message: "permission-denied-induced-error"}
 )
 
+// existingNotification returns the notification of the test CloudStorageSource as returned by
+// Cloud Storage, modified by f.
+func existingNotification(f func(*storage.Notification)) *storage.Notification {
+	n := &storage.Notification{
+		ID:             notificationId,
+		TopicProjectID: testProject,
+		TopicID:        testTopicID,
+		PayloadFormat:  storage.JSONPayload,
+		EventTypes:     []string{"OBJECT_FINALIZE"},
+	}
+	if f != nil {
+		f(n)
+	}
+	return n
+}
+
 func init() {
 	// Add types to scheme
 	_ = storagev1beta1.AddToScheme(scheme.Scheme)
@@ -1059,6 +1082,316 @@ func TestAllCases(t *testing.T) {
 					WithCloudStorageSourceSetDefaults,
 				),
 			}},
+		}, {
+			Name: "notification exists and is up to date",
+			Objects: []runtime.Object{
+				NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithCloudStorageSourceNotificationID(notificationId),
+					WithCloudStorageSourceSetDefaults,
+				),
+				NewTopic(storageName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(storageName, testNS,
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: existingNotification(nil),
+						},
+						// The notification is up to date, so it must not be replaced.
+						AddNotificationErr: errors.New("bucket-add-notification-induced-error"),
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, testNS, storageName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceStatusObservedGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithInitCloudStorageSourceConditions,
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceTopicReady(testTopicID),
+					WithCloudStorageSourceProjectID(testProject),
+					WithCloudStorageSourcePullSubscriptionReady(),
+					WithCloudStorageSourceSubscriptionID(SubscriptionID),
+					WithCloudStorageSourceSinkURI(storageSinkURL),
+					WithCloudStorageSourceNotificationReady(notificationId),
+					WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		}, {
+			Name: "notification exists and is outdated, notification replaced",
+			Objects: []runtime.Object{
+				NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithCloudStorageSourceNotificationID(notificationId),
+					WithCloudStorageSourceSetDefaults,
+				),
+				NewTopic(storageName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(storageName, testNS,
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: existingNotification(func(n *storage.Notification) {
+								n.ObjectNamePrefix = "some-other-prefix"
+							}),
+						},
+						AddNotificationID: replacedNotificationId,
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeNormal, reconciledSuccessReason, `CloudStorageSource reconciled: "%s/%s"`, testNS, storageName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceStatusObservedGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithInitCloudStorageSourceConditions,
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceTopicReady(testTopicID),
+					WithCloudStorageSourceProjectID(testProject),
+					WithCloudStorageSourcePullSubscriptionReady(),
+					WithCloudStorageSourceSubscriptionID(SubscriptionID),
+					WithCloudStorageSourceSinkURI(storageSinkURL),
+					WithCloudStorageSourceNotificationReady(replacedNotificationId),
+					WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		}, {
+			Name: "notification exists and is outdated, delete notification fails",
+			Objects: []runtime.Object{
+				NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithCloudStorageSourceNotificationID(notificationId),
+					WithCloudStorageSourceSetDefaults,
+				),
+				NewTopic(storageName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(storageName, testNS,
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						Notifications: map[string]*storage.Notification{
+							notificationId: existingNotification(func(n *storage.Notification) {
+								n.PayloadFormat = storage.NoPayload
+							}),
+						},
+						DeleteErr: errors.New("delete-notification-induced-error"),
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeWarning, reconciledNotificationFailed, fmt.Sprintf("%s: %s", failedToReconcileNotificationMsg, "delete-notification-induced-error")),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceStatusObservedGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithInitCloudStorageSourceConditions,
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceTopicReady(testTopicID),
+					WithCloudStorageSourceProjectID(testProject),
+					WithCloudStorageSourcePullSubscriptionReady(),
+					WithCloudStorageSourceSubscriptionID(SubscriptionID),
+					WithCloudStorageSourceSinkURI(storageSinkURL),
+					WithCloudStorageSourceNotificationID(notificationId),
+					WithCloudStorageSourceNotificationNotReady(reconciledNotificationFailed, fmt.Sprintf("%s: %s", failedToReconcileNotificationMsg, "delete-notification-induced-error")),
+					WithCloudStorageSourceSetDefaults,
+				),
+			}},
+		}, {
+			Name: "bucket add notification fails with permission denied",
+			Objects: []runtime.Object{
+				NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithCloudStorageSourceSetDefaults,
+				),
+				NewTopic(storageName, testNS,
+					WithTopicSpec(inteventsv1beta1.TopicSpec{
+						Topic:             testTopicID,
+						PropagationPolicy: "CreateDelete",
+						Project:           testProject,
+						EnablePublisher:   &falseVal,
+					}),
+					WithTopicReady(testTopicID),
+					WithTopicAddress(testTopicURI),
+					WithTopicProjectID(testProject),
+					WithTopicSetDefaults,
+				),
+				NewPullSubscription(storageName, testNS,
+					WithPullSubscriptionSpec(inteventsv1beta1.PullSubscriptionSpec{
+						Topic: testTopicID,
+						PubSubSpec: duckv1beta1.PubSubSpec{
+							Project: testProject,
+							Secret:  &secret,
+							SourceSpec: duckv1.SourceSpec{
+								Sink: newSinkDestination(),
+							},
+						},
+						AdapterType: string(converters.CloudStorage),
+					}),
+					WithPullSubscriptionReady(sinkURI),
+				),
+				newSink(),
+			},
+			Key: testNS + "/" + storageName,
+			OtherTestData: map[string]interface{}{
+				"storage": gstorage.TestClientData{
+					BucketData: gstorage.TestBucketData{
+						AddNotificationErr: permissionDeniedErr,
+					},
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", storageName),
+				Eventf(corev1.EventTypeWarning, notificationPermissionDenied, fmt.Sprintf(permissionDeniedMsg, bucket, testTopicID, permissionDeniedErr.Error())),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, storageName, true),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewCloudStorageSource(storageName, testNS,
+					WithCloudStorageSourceProject(testProject),
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceStatusObservedGeneration(generation),
+					WithCloudStorageSourceBucket(bucket),
+					WithCloudStorageSourceSink(sinkGVK, sinkName),
+					WithCloudStorageSourceEventTypes([]string{storagev1beta1.CloudStorageSourceFinalize}),
+					WithInitCloudStorageSourceConditions,
+					WithCloudStorageSourceObjectMetaGeneration(generation),
+					WithCloudStorageSourceTopicReady(testTopicID),
+					WithCloudStorageSourceProjectID(testProject),
+					WithCloudStorageSourcePullSubscriptionReady(),
+					WithCloudStorageSourceSubscriptionID(SubscriptionID),
+					WithCloudStorageSourceSinkURI(storageSinkURL),
+					WithCloudStorageSourceNotificationNotReady(notificationPermissionDenied, fmt.Sprintf(permissionDeniedMsg, bucket, testTopicID, permissionDeniedErr.Error())),
+					WithCloudStorageSourceSetDefaults,
+				),
+			}},
 		},
 		{
 			Name: "delete fails with non grpc error",
@@ -36,6 +36,8 @@ const (
 	serviceUpdated    = "ServiceUpdated"
 	configMapCreated  = "ConfigMapCreated"
 	configMapUpdated  = "ConfigMapUpdated"
+	secretCreated     = "SecretCreated"
+	secretUpdated     = "SecretUpdated"
 )
 
 type ServiceReconciler struct {
@@ -57,6 +59,12 @@ type ConfigMapReconciler struct {
 	Recorder   record.EventRecorder
 }
 
+type SecretReconciler struct {
+	KubeClient kubernetes.Interface
+	Lister     corev1listers.SecretLister
+	Recorder   record.EventRecorder
+}
+
 // ReconcileDeployment reconciles the K8s Deployment 'd'.
 func (r *DeploymentReconciler) ReconcileDeployment(obj runtime.Object, d *appsv1.Deployment) (*appsv1.Deployment, error) {
 	current, err := r.Lister.Deployments(d.Namespace).Get(d.Name)
@@ -158,3 +166,39 @@ func (r *ConfigMapReconciler) ReconcileConfigMap(obj runtime.Object, cm *corev1.
 	}
 	return current, err
 }
+
+// ReconcileSecret reconciles the K8s Secret 'secret'.
+func (r *SecretReconciler) ReconcileSecret(obj runtime.Object, secret *corev1.Secret, handlers ...cache.ResourceEventHandlerFuncs) (*corev1.Secret, error) {
+	current, err := r.Lister.Secrets(secret.Namespace).Get(secret.Name)
+	if apierrs.IsNotFound(err) {
+		current, err = r.KubeClient.CoreV1().Secrets(secret.Namespace).Create(secret)
+		if apierrs.IsAlreadyExists(err) {
+			return current, nil
+		}
+		if err == nil {
+			r.Recorder.Eventf(obj, corev1.EventTypeNormal, secretCreated, "Created secret %s/%s", secret.Namespace, secret.Name)
+			for _, h := range handlers {
+				h.OnAdd(current)
+			}
+		}
+		return current, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !equality.Semantic.DeepEqual(secret.Data, current.Data) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Data = secret.Data
+		res, err := r.KubeClient.CoreV1().Secrets(desired.Namespace).Update(desired)
+		if err == nil {
+			r.Recorder.Eventf(obj, corev1.EventTypeNormal, secretUpdated, "Updated secret %s/%s", res.Namespace, res.Name)
+			for _, h := range handlers {
+				h.OnUpdate(current, desired)
+			}
+		}
+		return res, err
+	}
+	return current, err
+}
@@ -38,6 +38,8 @@ const (
 	serviceUpdatedEvent    = "Normal ServiceUpdated Updated service testns/test"
 	configmapCreatedEvent  = "Normal ConfigMapCreated Created configmap testns/test"
 	configmapUpdatedEvent  = "Normal ConfigMapUpdated Updated configmap testns/test"
+	secretCreatedEvent     = "Normal SecretCreated Created secret testns/test"
+	secretUpdatedEvent     = "Normal SecretUpdated Updated secret testns/test"
 )
 
 var (
@@ -81,12 +83,23 @@ var (
 		BinaryData: map[string][]byte{"binary": {'d'}},
 	}
 
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Data:       map[string][]byte{"data": []byte("value")},
+	}
+	secretDifferentData = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Data:       map[string][]byte{"data": []byte("different value")},
+	}
+
 	deploymentCreateFailure = pkgreconcilertesting.InduceFailure("create", "deployments")
 	deploymentUpdateFailure = pkgreconcilertesting.InduceFailure("update", "deployments")
 	serviceCreateFailure    = pkgreconcilertesting.InduceFailure("create", "services")
 	serviceUpdateFailure    = pkgreconcilertesting.InduceFailure("update", "services")
 	configmapCreateFailure  = pkgreconcilertesting.InduceFailure("create", "configmaps")
 	configmapUpdateFailure  = pkgreconcilertesting.InduceFailure("update", "configmaps")
+	secretCreateFailure     = pkgreconcilertesting.InduceFailure("create", "secrets")
+	secretUpdateFailure     = pkgreconcilertesting.InduceFailure("update", "secrets")
 
 	tr = &testRunner{}
 )
@@ -324,6 +337,77 @@ func TestConfigMapReconciler(t *testing.T) {
 	}
 }
 
+func TestSecretReconciler(t *testing.T) {
+	var tests = []struct {
+		commonCase
+		in   *corev1.Secret
+		want *corev1.Secret
+	}{
+		{
+			commonCase: commonCase{
+				name:     "secret exists, nothing to do",
+				existing: []runtime.Object{secret},
+			},
+			in:   secret,
+			want: secret,
+		},
+		{
+			commonCase: commonCase{
+				name:       "secret created",
+				wantEvents: []string{secretCreatedEvent},
+			},
+			in:   secret,
+			want: secret,
+		},
+		{
+			commonCase: commonCase{
+				name:      "secret creation error",
+				reactions: []clientgotesting.ReactionFunc{secretCreateFailure},
+				wantErr:   true,
+			},
+			in: secret,
+		},
+		{
+			commonCase: commonCase{
+				name:       "secret updated - different data",
+				existing:   []runtime.Object{secretDifferentData},
+				wantEvents: []string{secretUpdatedEvent},
+			},
+			in:   secret,
+			want: secret,
+		},
+		{
+			commonCase: commonCase{
+				name:      "secret update error",
+				reactions: []clientgotesting.ReactionFunc{secretUpdateFailure},
+				existing:  []runtime.Object{secretDifferentData},
+				wantErr:   true,
+			},
+			in: secret,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr.setup(test.commonCase)
+
+			rec := SecretReconciler{
+				KubeClient: tr.client,
+				Lister:     tr.listers.GetSecretLister(),
+				Recorder:   tr.recorder,
+			}
+			out, err := rec.ReconcileSecret(obj, test.in)
+
+			tr.verify(t, test.commonCase, err)
+
+			if diff := cmp.Diff(out, test.want); diff != "" {
+				t.Errorf("Unexpected reconciler result (-got, +want): %s", diff)
+				return
+			}
+		})
+	}
+}
+
 // testRunner helps to setup resources such as fake KubeClientSet and informers, as well as verify the common test case.
 type testRunner struct {
 	client   *fake.Clientset
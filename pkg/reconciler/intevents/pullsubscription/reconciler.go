@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"time"
 
+	"cloud.google.com/go/pubsub"
 	"go.uber.org/zap"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -66,6 +68,11 @@ const (
 	// If the topic of the subscription has been deleted, the value of its topic becomes "_deleted-topic_".
 	// See https://cloud.google.com/pubsub/docs/reference/rpc/google.pubsub.v1#subscription
 	deletedTopic = "_deleted-topic_"
+
+	// The bounds of the max delivery attempts of a dead letter policy.
+	// See https://cloud.google.com/pubsub/docs/reference/rpc/google.pubsub.v1#deadletterpolicy
+	minMaxDeliveryAttempts = 5
+	maxMaxDeliveryAttempts = 100
 )
 
 // Base implements the core controller logic for pullsubscription.
@@ -138,6 +145,18 @@ func (r *Base) ReconcileKind(ctx context.Context, ps *v1beta1.PullSubscription)
 		ps.Status.TransformerURI = nil
 	}
 
+	// Dead letter sink is optional.
+	if ps.Spec.Delivery != nil && ps.Spec.Delivery.DeadLetterSink != nil {
+		deadLetterSinkURI, err := r.resolveDestination(ctx, *ps.Spec.Delivery.DeadLetterSink, ps)
+		if err != nil {
+			ps.Status.MarkNoSink("InvalidDeadLetterSink", "%s", err.Error())
+			return reconciler.NewEvent(corev1.EventTypeWarning, "InvalidDeadLetterSink", "InvalidDeadLetterSink: %s", err.Error())
+		}
+		ps.Status.DeadLetterSinkURI = deadLetterSinkURI
+	} else {
+		ps.Status.DeadLetterSinkURI = nil
+	}
+
 	subscriptionID, err := r.reconcileSubscription(ctx, ps)
 	if err != nil {
 		ps.Status.MarkNoSubscription(reconciledPubSubFailedReason, "Failed to reconcile Pub/Sub subscription: %s", err.Error())
@@ -219,6 +238,16 @@ func (r *Base) reconcileSubscription(ctx context.Context, ps *v1beta1.PullSubscr
 		subConfig.RetentionDuration = retentionDuration
 	}
 
+	// The dead letter policy forwards the messages the receive adapter fails to deliver
+	// to the dead letter topic, from which the receive adapter sends them to the dead
+	// letter sink.
+	dlp, err := r.reconcileDeadLetterTopic(ctx, client, ps, subConfig)
+	if err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to reconcile Pub/Sub dead letter topic", zap.Error(err))
+		return "", err
+	}
+	subConfig.DeadLetterPolicy = dlp
+
 	// Check if the topic of the subscription is "_deleted-topic_"
 	if subExists {
 		config, err := sub.Config(ctx)
@@ -239,6 +268,16 @@ func (r *Base) reconcileSubscription(ctx context.Context, ps *v1beta1.PullSubscr
 				logging.FromContext(ctx).Desugar().Error("Failed to create subscription", zap.Error(err))
 				return "", err
 			}
+		} else if !equality.Semantic.DeepEqual(config.DeadLetterPolicy, subConfig.DeadLetterPolicy) {
+			update := subConfig
+			if update.DeadLetterPolicy == nil {
+				// The zero value removes the dead letter policy.
+				update.DeadLetterPolicy = &pubsub.DeadLetterPolicy{}
+			}
+			if _, err := sub.Update(ctx, update); err != nil {
+				logging.FromContext(ctx).Desugar().Error("Failed to update the dead letter policy of the subscription", zap.Error(err))
+				return "", err
+			}
 		}
 	} else {
 		sub, err = client.CreateSubscription(ctx, subID, subConfig)
@@ -248,9 +287,110 @@ func (r *Base) reconcileSubscription(ctx context.Context, ps *v1beta1.PullSubscr
 		}
 	}
 	// TODO update the subscription's config if needed.
+
+	// Only delete the dead letter topic once the subscription no longer forwards to it.
+	if subConfig.DeadLetterPolicy == nil {
+		if err := r.deleteDeadLetterTopic(ctx, client, ps); err != nil {
+			logging.FromContext(ctx).Desugar().Error("Failed to delete Pub/Sub dead letter topic", zap.Error(err))
+			return "", err
+		}
+	}
 	return subID, nil
 }
 
+// reconcileDeadLetterTopic creates the dead letter topic of the PullSubscription and the subscription
+// the receive adapter pulls it from if the PullSubscription has a dead letter sink, and returns the
+// dead letter policy of its subscription. It returns a nil policy if there is no dead letter sink.
+func (r *Base) reconcileDeadLetterTopic(ctx context.Context, client gpubsub.Client, ps *v1beta1.PullSubscription, subConfig gpubsub.SubscriptionConfig) (*pubsub.DeadLetterPolicy, error) {
+	if ps.Status.DeadLetterSinkURI == nil {
+		return nil, nil
+	}
+	topicID := resources.GenerateDeadLetterTopicName(ps)
+
+	t := client.Topic(topicID)
+	topicExists, err := t.Exists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !topicExists {
+		if t, err = client.CreateTopic(ctx, topicID); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := client.Subscription(topicID)
+	subExists, err := sub.Exists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !subExists {
+		_, err := client.CreateSubscription(ctx, topicID, gpubsub.SubscriptionConfig{
+			Topic:             t,
+			AckDeadline:       subConfig.AckDeadline,
+			RetentionDuration: subConfig.RetentionDuration,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	ps.Status.DeadLetterTopicID = topicID
+
+	var retry int32
+	if ps.Spec.Delivery.Retry != nil {
+		retry = *ps.Spec.Delivery.Retry
+	}
+	return &pubsub.DeadLetterPolicy{
+		DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", ps.Status.ProjectID, topicID),
+		MaxDeliveryAttempts: maxDeliveryAttempts(retry),
+	}, nil
+}
+
+// maxDeliveryAttempts returns the max delivery attempts of the dead letter policy for the given number of
+// retries, within the bounds Pub/Sub allows. With fewer retries than the minimum, the receive adapter sends
+// the events to the dead letter sink itself once their retries are exhausted.
+func maxDeliveryAttempts(retry int32) int {
+	attempts := int(retry) + 1
+	if attempts < minMaxDeliveryAttempts {
+		return minMaxDeliveryAttempts
+	}
+	if attempts > maxMaxDeliveryAttempts {
+		return maxMaxDeliveryAttempts
+	}
+	return attempts
+}
+
+// deleteDeadLetterTopic looks at the status.DeadLetterTopicID and if non-empty, hence indicating that we
+// have created a dead letter topic for the PullSubscription, removes it along with its subscription.
+func (r *Base) deleteDeadLetterTopic(ctx context.Context, client gpubsub.Client, ps *v1beta1.PullSubscription) error {
+	if ps.Status.DeadLetterTopicID == "" {
+		return nil
+	}
+
+	sub := client.Subscription(ps.Status.DeadLetterTopicID)
+	exists, err := sub.Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := sub.Delete(ctx); err != nil {
+			return err
+		}
+	}
+
+	t := client.Topic(ps.Status.DeadLetterTopicID)
+	exists, err = t.Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := t.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	ps.Status.DeadLetterTopicID = ""
+	return nil
+}
+
 // deleteSubscription looks at the status.SubscriptionID and if non-empty,
 // hence indicating that we have created a subscription successfully
 // in the PullSubscription, remove it.
@@ -281,6 +421,10 @@ func (r *Base) deleteSubscription(ctx context.Context, ps *v1beta1.PullSubscript
 			return err
 		}
 	}
+	if err := r.deleteDeadLetterTopic(ctx, client, ps); err != nil {
+		logging.FromContext(ctx).Desugar().Error("Failed to delete Pub/Sub dead letter topic", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
@@ -316,9 +460,12 @@ func (r *Base) reconcileDataPlaneResources(ctx context.Context, ps *v1beta1.Pull
 		SubscriptionID:   ps.Status.SubscriptionID,
 		SinkURI:          ps.Status.SinkURI,
 		TransformerURI:   ps.Status.TransformerURI,
-		LoggingConfig:    loggingConfig,
-		MetricsConfig:    metricsConfig,
-		TracingConfig:    tracingConfig,
+		// The dead letter subscription has the same ID as the dead letter topic.
+		DeadLetterSinkURI:        ps.Status.DeadLetterSinkURI,
+		DeadLetterSubscriptionID: ps.Status.DeadLetterTopicID,
+		LoggingConfig:            loggingConfig,
+		MetricsConfig:            metricsConfig,
+		TracingConfig:            tracingConfig,
 	})
 
 	return f(ctx, desired, ps)
@@ -32,6 +32,13 @@ func GenerateSubscriptionName(ps *v1beta1.PullSubscription) string {
 	return naming.TruncatedPubsubResourceName(prefix, ps.Namespace, ps.Name, ps.UID)
 }
 
+// GenerateDeadLetterTopicName generates the name for the Pub/Sub topic the subscription of this PullSubscription
+// forwards undeliverable messages to. The subscription the receive adapter pulls them from has the same name.
+func GenerateDeadLetterTopicName(ps *v1beta1.PullSubscription) string {
+	prefix := getPrefix(ps) + "-dl"
+	return naming.TruncatedPubsubResourceName(prefix, ps.Namespace, ps.Name, ps.UID)
+}
+
 // GenerateReceiveAdapterName generates the name of the receive adapter to be used for this PullSubscription.
 func GenerateReceiveAdapterName(ps *v1beta1.PullSubscription) string {
 	return GenerateK8sName(ps)
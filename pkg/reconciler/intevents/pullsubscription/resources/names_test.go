@@ -77,6 +77,45 @@ func TestGenerateSubscriptionName(t *testing.T) {
 	}
 }
 
+func TestGenerateDeadLetterTopicName(t *testing.T) {
+	tests := []struct {
+		name string
+		ps   *v1beta1.PullSubscription
+		want string
+	}{{
+		name: "ps-based name",
+		ps: &v1beta1.PullSubscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myname",
+				Namespace: "mynamespace",
+				UID:       "uid",
+			},
+		},
+		want: "cre-ps-dl_mynamespace_myname_uid",
+	}, {
+		name: "channel-based name",
+		ps: &v1beta1.PullSubscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myname",
+				Namespace: "mynamespace",
+				UID:       "uid",
+				Labels: map[string]string{
+					intevents.ChannelLabelKey: "myname",
+				},
+			},
+		},
+		want: "cre-chan-dl_mynamespace_myname_uid",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := GenerateDeadLetterTopicName(test.ps)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateReceiveAdapterName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -19,9 +19,11 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"go.uber.org/zap"
 
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
@@ -37,17 +39,20 @@ import (
 )
 
 // ReceiveAdapterArgs are the arguments needed to create a PullSubscription Receive
-// Adapter. Every field is required.
+// Adapter. Every field is required, except the dead letter ones which are only set
+// when the PullSubscription has a dead letter sink.
 type ReceiveAdapterArgs struct {
-	Image            string
-	PullSubscription *v1beta1.PullSubscription
-	Labels           map[string]string
-	SubscriptionID   string
-	SinkURI          *apis.URL
-	TransformerURI   *apis.URL
-	MetricsConfig    string
-	LoggingConfig    string
-	TracingConfig    string
+	Image                    string
+	PullSubscription         *v1beta1.PullSubscription
+	Labels                   map[string]string
+	SubscriptionID           string
+	SinkURI                  *apis.URL
+	TransformerURI           *apis.URL
+	DeadLetterSinkURI        *apis.URL
+	DeadLetterSubscriptionID string
+	MetricsConfig            string
+	LoggingConfig            string
+	TracingConfig            string
 }
 
 const (
@@ -125,6 +130,9 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 		}, {
 			Name:  "ADAPTER_TYPE",
 			Value: adapterType,
+		}, {
+			Name:  "ADAPTER_OPTIONS",
+			Value: args.PullSubscription.Spec.AdapterOptions,
 		}, {
 			Name:  "SEND_MODE",
 			Value: string(mode),
@@ -159,6 +167,10 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 		}},
 	}
 
+	if delivery := args.PullSubscription.Spec.Delivery; delivery != nil {
+		receiveAdapterContainer.Env = append(receiveAdapterContainer.Env, makeDeliveryEnv(ctx, delivery, args)...)
+	}
+
 	// If there is no secret to embed, return what we have.
 	if args.PullSubscription.Spec.Secret == nil {
 		return &corev1.PodSpec{
@@ -207,6 +219,50 @@ func makeReceiveAdapterPodSpec(ctx context.Context, args *ReceiveAdapterArgs) *c
 	}
 }
 
+// makeDeliveryEnv returns the environment variables which configure the retries and the dead letter sink
+// of the receive adapter from the delivery spec of the PullSubscription. The optional variables are only
+// set if the delivery spec has them, as the receive adapter can't parse an empty backoff delay.
+func makeDeliveryEnv(ctx context.Context, delivery *eventingduckv1beta1.DeliverySpec, args *ReceiveAdapterArgs) []corev1.EnvVar {
+	var retry int32
+	if delivery.Retry != nil {
+		retry = *delivery.Retry
+	}
+	backoffPolicy := eventingduckv1beta1.BackoffPolicyExponential
+	if delivery.BackoffPolicy != nil {
+		backoffPolicy = *delivery.BackoffPolicy
+	}
+	env := []corev1.EnvVar{{
+		Name:  "RETRY",
+		Value: strconv.Itoa(int(retry)),
+	}, {
+		Name:  "BACKOFF_POLICY",
+		Value: string(backoffPolicy),
+	}}
+	if delivery.BackoffDelay != nil {
+		if d, err := utils.ParseISO8601Duration(*delivery.BackoffDelay); err != nil {
+			logging.FromContext(ctx).Warnw("Ignoring invalid backoff delay", zap.Error(err))
+		} else {
+			env = append(env, corev1.EnvVar{
+				Name:  "BACKOFF_DELAY",
+				Value: d.String(),
+			})
+		}
+	}
+	if args.DeadLetterSinkURI != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "DEAD_LETTER_SINK_URI",
+			Value: args.DeadLetterSinkURI.String(),
+		})
+	}
+	if args.DeadLetterSubscriptionID != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "DEAD_LETTER_SUBSCRIPTION_ID",
+			Value: args.DeadLetterSubscriptionID,
+		})
+	}
+	return env
+}
+
 // MakeReceiveAdapter generates (but does not insert into K8s) the Receive Adapter Deployment for
 // PullSubscriptions.
 func MakeReceiveAdapter(ctx context.Context, args *ReceiveAdapterArgs) *v1.Deployment {
@@ -18,9 +18,12 @@ package resources
 
 import (
 	"context"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kelseyhightower/envconfig"
 
 	duckv1beta1 "github.com/google/knative-gcp/pkg/apis/duck/v1beta1"
 	"github.com/google/knative-gcp/pkg/apis/intevents"
@@ -31,6 +34,8 @@ import (
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -128,6 +133,9 @@ func TestMakeMinimumReceiveAdapter(t *testing.T) {
 						}, {
 							Name:  "ADAPTER_TYPE",
 							Value: "source-adapter-type",
+						}, {
+							Name:  "ADAPTER_OPTIONS",
+							Value: "",
 						}, {
 							Name:  "SEND_MODE",
 							Value: "binary",
@@ -211,8 +219,9 @@ func TestMakeFullReceiveAdapter(t *testing.T) {
 					},
 				},
 			},
-			Topic:       "topic",
-			AdapterType: string(converters.PubSubPull),
+			Topic:          "topic",
+			AdapterType:    string(converters.PubSubPull),
+			AdapterOptions: `{"option":"value"}`,
 		},
 	}
 
@@ -287,6 +296,9 @@ func TestMakeFullReceiveAdapter(t *testing.T) {
 						}, {
 							Name:  "ADAPTER_TYPE",
 							Value: string(converters.PubSubPull),
+						}, {
+							Name:  "ADAPTER_OPTIONS",
+							Value: `{"option":"value"}`,
 						}, {
 							Name:  "SEND_MODE",
 							Value: "binary",
@@ -450,6 +462,9 @@ func TestMakeReceiveAdapterWithServiceAccount(t *testing.T) {
 						}, {
 							Name:  "ADAPTER_TYPE",
 							Value: string(converters.PubSubPull),
+						}, {
+							Name:  "ADAPTER_OPTIONS",
+							Value: "",
 						}, {
 							Name:  "SEND_MODE",
 							Value: "binary",
@@ -492,3 +507,139 @@ func TestMakeReceiveAdapterWithServiceAccount(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+// deliveryEnvNames are the names of the delivery environment variables of the receive adapter.
+var deliveryEnvNames = sets.NewString("RETRY", "BACKOFF_POLICY", "BACKOFF_DELAY", "DEAD_LETTER_SINK_URI", "DEAD_LETTER_SUBSCRIPTION_ID")
+
+// deliveryEnvConfig has the delivery fields of the envConfig of cmd/pubsub/receive_adapter.
+type deliveryEnvConfig struct {
+	Retry                  *int          `envconfig:"RETRY"`
+	BackoffPolicy          string        `envconfig:"BACKOFF_POLICY"`
+	BackoffDelay           time.Duration `envconfig:"BACKOFF_DELAY"`
+	DeadLetterSink         string        `envconfig:"DEAD_LETTER_SINK_URI"`
+	DeadLetterSubscription string        `envconfig:"DEAD_LETTER_SUBSCRIPTION_ID"`
+}
+
+func TestMakeReceiveAdapterWithDelivery(t *testing.T) {
+	retry := int32(3)
+	linear := eventingduckv1beta1.BackoffPolicyLinear
+	backoffDelay := "PT0.5S"
+	invalidBackoffDelay := "half a second"
+
+	testCases := map[string]struct {
+		delivery          *eventingduckv1beta1.DeliverySpec
+		deadLetterSinkURI *apis.URL
+		want              map[string]string
+	}{
+		"defaults": {
+			delivery: &eventingduckv1beta1.DeliverySpec{},
+			want: map[string]string{
+				"RETRY":          "0",
+				"BACKOFF_POLICY": "exponential",
+			},
+		},
+		"invalid backoff delay": {
+			delivery: &eventingduckv1beta1.DeliverySpec{
+				BackoffDelay: &invalidBackoffDelay,
+			},
+			want: map[string]string{
+				"RETRY":          "0",
+				"BACKOFF_POLICY": "exponential",
+			},
+		},
+		"retry and dead letter sink without backoff delay": {
+			delivery: &eventingduckv1beta1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					URI: apis.HTTP("dead-letter"),
+				},
+				Retry: &retry,
+			},
+			deadLetterSinkURI: apis.HTTP("dead-letter"),
+			want: map[string]string{
+				"RETRY":                       "3",
+				"BACKOFF_POLICY":              "exponential",
+				"DEAD_LETTER_SINK_URI":        "http://dead-letter",
+				"DEAD_LETTER_SUBSCRIPTION_ID": "dead-letter-subscription",
+			},
+		},
+		"dead letter sink": {
+			delivery: &eventingduckv1beta1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					URI: apis.HTTP("dead-letter"),
+				},
+				Retry:         &retry,
+				BackoffPolicy: &linear,
+				BackoffDelay:  &backoffDelay,
+			},
+			deadLetterSinkURI: apis.HTTP("dead-letter"),
+			want: map[string]string{
+				"RETRY":                       "3",
+				"BACKOFF_POLICY":              "linear",
+				"BACKOFF_DELAY":               "500ms",
+				"DEAD_LETTER_SINK_URI":        "http://dead-letter",
+				"DEAD_LETTER_SUBSCRIPTION_ID": "dead-letter-subscription",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ps := &v1beta1.PullSubscription{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testname",
+					Namespace: "testnamespace",
+				},
+				Spec: v1beta1.PullSubscriptionSpec{
+					Topic:    "topic",
+					Delivery: tc.delivery,
+				},
+			}
+			args := &ReceiveAdapterArgs{
+				Image:             "test-image",
+				PullSubscription:  ps,
+				SubscriptionID:    "sub-id",
+				SinkURI:           apis.HTTP("sink-uri"),
+				DeadLetterSinkURI: tc.deadLetterSinkURI,
+			}
+			if tc.deadLetterSinkURI != nil {
+				args.DeadLetterSubscriptionID = "dead-letter-subscription"
+			}
+			got := MakeReceiveAdapter(context.Background(), args)
+
+			env := make(map[string]string)
+			for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+				if deliveryEnvNames.Has(e.Name) {
+					env[e.Name] = e.Value
+				}
+			}
+			if diff := cmp.Diff(tc.want, env); diff != "" {
+				t.Errorf("unexpected delivery env (-want, +got) = %v", diff)
+			}
+
+			// The receive adapter must be able to process its delivery env.
+			for name, value := range env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			var delivery deliveryEnvConfig
+			if err := envconfig.Process("", &delivery); err != nil {
+				t.Errorf("failed to process the delivery env: %v", err)
+			}
+		})
+	}
+
+	t.Run("no delivery", func(t *testing.T) {
+		got := MakeReceiveAdapter(context.Background(), &ReceiveAdapterArgs{
+			Image: "test-image",
+			PullSubscription: &v1beta1.PullSubscription{
+				Spec: v1beta1.PullSubscriptionSpec{Topic: "topic"},
+			},
+			SinkURI: apis.HTTP("sink-uri"),
+		})
+		for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+			if e.Name == "RETRY" {
+				t.Errorf("unexpected env var %q without a delivery spec", e.Name)
+			}
+		}
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
@@ -92,6 +93,10 @@ var (
 
 	testSubscriptionID = fmt.Sprintf("cre-ps_%s_%s_%s", testNS, sourceName, sourceUID)
 
+	deadLetterSinkURI = apis.HTTP("dead-letter.mynamespace.svc.cluster.local")
+
+	testDeadLetterTopicID = fmt.Sprintf("cre-ps-dl_%s_%s_%s", testNS, sourceName, sourceUID)
+
 	transformerGVK = metav1.GroupVersionKind{
 		Group:   "testing.cloud.google.com",
 		Version: "v1beta1",
@@ -575,6 +580,126 @@ func TestAllCases(t *testing.T) {
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchFinalizers(testNS, sourceName, resourceGroup),
 		},
+	}, {
+		Name: "cannot get dead letter sink",
+		Objects: []runtime.Object{
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionUID(sourceUID),
+				WithPullSubscriptionObjectMetaGeneration(generation),
+				WithPullSubscriptionSpec(pubsubv1beta1.PullSubscriptionSpec{
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:    testTopicID,
+					Delivery: newDeadLetterRefDelivery(),
+				}),
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, "InvalidDeadLetterSink",
+				`InvalidDeadLetterSink: failed to get ref &ObjectReference{Kind:Sink,Namespace:testnamespace,Name:dead-letter,UID:,APIVersion:testing.cloud.google.com/v1beta1,ResourceVersion:,FieldPath:,}: sinks.testing.cloud.google.com "dead-letter" not found`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionUID(sourceUID),
+				WithPullSubscriptionObjectMetaGeneration(generation),
+				WithPullSubscriptionStatusObservedGeneration(generation),
+				WithPullSubscriptionSpec(pubsubv1beta1.PullSubscriptionSpec{
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:    testTopicID,
+					Delivery: newDeadLetterRefDelivery(),
+				}),
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				// updates
+				WithInitPullSubscriptionConditions,
+				WithPullSubscriptionMarkSink(sinkURI),
+				WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				WithPullSubscriptionTransformerURI(nil),
+				WithPullSubscriptionMarkNoSink("InvalidDeadLetterSink",
+					`failed to get ref &ObjectReference{Kind:Sink,Namespace:testnamespace,Name:dead-letter,UID:,APIVersion:testing.cloud.google.com/v1beta1,ResourceVersion:,FieldPath:,}: sinks.testing.cloud.google.com "dead-letter" not found`),
+				WithPullSubscriptionSetDefaults,
+			),
+		}},
+	}, {
+		Name: "successfully created subscription with dead letter sink",
+		Objects: []runtime.Object{
+			NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionUID(sourceUID),
+				WithPullSubscriptionObjectMetaGeneration(generation),
+				WithPullSubscriptionSpec(pubsubv1beta1.PullSubscriptionSpec{
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:    testTopicID,
+					Delivery: newDeadLetterDelivery(),
+				}),
+				WithInitPullSubscriptionConditions,
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				WithPullSubscriptionMarkSink(sinkURI),
+				WithPullSubscriptionSetDefaults,
+			),
+			newSink(),
+			newSecret(),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "PullSubscriptionReconciled", `PullSubscription reconciled: "%s/%s"`, testNS, sourceName),
+		},
+		OtherTestData: map[string]interface{}{
+			"ps": gpubsub.TestClientData{
+				TopicData: gpubsub.TestTopicData{
+					Exists: true,
+				},
+			},
+		},
+		WantCreates: []runtime.Object{
+			newDeadLetterReceiveAdapter(context.Background(), testImage),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewPullSubscription(sourceName, testNS,
+				WithPullSubscriptionUID(sourceUID),
+				WithPullSubscriptionObjectMetaGeneration(generation),
+				WithPullSubscriptionSpec(pubsubv1beta1.PullSubscriptionSpec{
+					PubSubSpec: duckv1beta1.PubSubSpec{
+						Secret:  &secret,
+						Project: testProject,
+					},
+					Topic:    testTopicID,
+					Delivery: newDeadLetterDelivery(),
+				}),
+				WithInitPullSubscriptionConditions,
+				WithPullSubscriptionProjectID(testProject),
+				WithPullSubscriptionSink(sinkGVK, sinkName),
+				WithPullSubscriptionMarkSink(sinkURI),
+				WithPullSubscriptionMarkNoTransformer("TransformerNil", "Transformer is nil"),
+				WithPullSubscriptionTransformerURI(nil),
+				WithPullSubscriptionDeadLetterSinkURI(deadLetterSinkURI),
+				WithPullSubscriptionDeadLetterTopicID(testDeadLetterTopicID),
+				// Updates
+				WithPullSubscriptionStatusObservedGeneration(generation),
+				WithPullSubscriptionMarkSubscribed(testSubscriptionID),
+				WithPullSubscriptionMarkNoDeployed(deploymentName(), testNS),
+				WithPullSubscriptionSetDefaults,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, sourceName, resourceGroup),
+		},
 	}, {
 		Name: "sink namespace empty, default to the source one",
 		Objects: []runtime.Object{
@@ -953,6 +1078,43 @@ func newReceiveAdapter(ctx context.Context, image string, transformer *apis.URL)
 	return ra
 }
 
+func newDeadLetterReceiveAdapter(ctx context.Context, image string) runtime.Object {
+	ps := newPullSubscription()
+	ps.Spec.Delivery = newDeadLetterDelivery()
+	args := &resources.ReceiveAdapterArgs{
+		Image:                    image,
+		PullSubscription:         ps,
+		Labels:                   resources.GetLabels(controllerAgentName, sourceName),
+		SubscriptionID:           testSubscriptionID,
+		SinkURI:                  sinkURI,
+		DeadLetterSinkURI:        deadLetterSinkURI,
+		DeadLetterSubscriptionID: testDeadLetterTopicID,
+	}
+	return resources.MakeReceiveAdapter(ctx, args)
+}
+
+func newDeadLetterDelivery() *eventingduckv1beta1.DeliverySpec {
+	retry := int32(3)
+	return &eventingduckv1beta1.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{
+			URI: deadLetterSinkURI,
+		},
+		Retry: &retry,
+	}
+}
+
+func newDeadLetterRefDelivery() *eventingduckv1beta1.DeliverySpec {
+	return &eventingduckv1beta1.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: "testing.cloud.google.com/v1beta1",
+				Kind:       "Sink",
+				Name:       "dead-letter",
+			},
+		},
+	}
+}
+
 func newAvailableReceiveAdapter(ctx context.Context, image string, transformer *apis.URL) runtime.Object {
 	obj := newReceiveAdapter(ctx, image, transformer)
 	ra := obj.(*v1.Deployment)
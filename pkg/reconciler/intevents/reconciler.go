@@ -151,6 +151,9 @@ func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable
 		Labels:      resources.GetLabels(psb.receiveAdapterName, name),
 		Annotations: resources.GetAnnotations(annotations, resourceGroup),
 	}
+	if ac, ok := pubsubable.(duck.AdapterConfigurable); ok {
+		args.AdapterOptions = ac.AdapterOptions()
+	}
 
 	newPS := resources.MakePullSubscription(args)
 
@@ -167,8 +170,9 @@ func (psb *PubSubBase) ReconcilePullSubscription(ctx context.Context, pubsubable
 			logging.FromContext(ctx).Desugar().Error("Failed to create PullSubscription", zap.Any("ps", newPS), zap.Error(err))
 			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, pullSubscriptionCreateFailedReason, "Creating PullSubscription failed with: %s", err.Error())
 		}
-		// Check whether the specs differ and update the PS if so.
-	} else if !equality.Semantic.DeepDerivative(newPS.Spec, ps.Spec) {
+		// Check whether the specs differ and update the PS if so. The adapter options are compared
+		// separately, as removing them isn't a semantic difference for DeepDerivative.
+	} else if !equality.Semantic.DeepDerivative(newPS.Spec, ps.Spec) || newPS.Spec.AdapterOptions != ps.Spec.AdapterOptions {
 		// Don't modify the informers copy.
 		desired := ps.DeepCopy()
 		desired.Spec = newPS.Spec
@@ -26,15 +26,16 @@ import (
 )
 
 type PullSubscriptionArgs struct {
-	Namespace   string
-	Name        string
-	Spec        *duckv1beta1.PubSubSpec
-	Owner       kmeta.OwnerRefable
-	Topic       string
-	AdapterType string
-	Mode        inteventsv1beta1.ModeType
-	Labels      map[string]string
-	Annotations map[string]string
+	Namespace      string
+	Name           string
+	Spec           *duckv1beta1.PubSubSpec
+	Owner          kmeta.OwnerRefable
+	Topic          string
+	AdapterType    string
+	AdapterOptions string
+	Mode           inteventsv1beta1.ModeType
+	Labels         map[string]string
+	Annotations    map[string]string
 }
 
 // MakePullSubscription creates the spec for, but does not create, a GCP PullSubscription
@@ -59,9 +60,10 @@ func MakePullSubscription(args *PullSubscriptionArgs) *inteventsv1beta1.PullSubs
 					Sink: args.Spec.SourceSpec.Sink,
 				},
 			},
-			Topic:       args.Topic,
-			AdapterType: args.AdapterType,
-			Mode:        args.Mode,
+			Topic:          args.Topic,
+			AdapterType:    args.AdapterType,
+			AdapterOptions: args.AdapterOptions,
+			Mode:           args.Mode,
 		},
 	}
 	if args.Spec.CloudEventOverrides != nil && args.Spec.CloudEventOverrides.Extensions != nil {
@@ -63,13 +63,14 @@ func TestMakePullSubscription(t *testing.T) {
 		},
 	}
 	args := &PullSubscriptionArgs{
-		Namespace:   source.Namespace,
-		Name:        source.Name,
-		Spec:        &source.Spec.PubSubSpec,
-		Owner:       source,
-		Topic:       "topic-abc",
-		AdapterType: "google.storage",
-		Annotations: GetAnnotations(nil, "storages.events.cloud.google.com"),
+		Namespace:      source.Namespace,
+		Name:           source.Name,
+		Spec:           &source.Spec.PubSubSpec,
+		Owner:          source,
+		Topic:          "topic-abc",
+		AdapterType:    "google.storage",
+		AdapterOptions: `{"objectNameSuffix":".jpg"}`,
+		Annotations:    GetAnnotations(nil, "storages.events.cloud.google.com"),
 		Labels: map[string]string{
 			"receive-adapter":                     "storage.events.cloud.google.com",
 			"events.cloud.google.com/source-name": source.Name,
@@ -122,8 +123,9 @@ func TestMakePullSubscription(t *testing.T) {
 					},
 				},
 			},
-			Topic:       "topic-abc",
-			AdapterType: "google.storage",
+			Topic:          "topic-abc",
+			AdapterType:    "google.storage",
+			AdapterOptions: `{"objectNameSuffix":".jpg"}`,
 		},
 	}
 
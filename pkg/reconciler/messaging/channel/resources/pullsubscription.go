@@ -55,7 +55,8 @@ func MakePullSubscription(args *PullSubscriptionArgs) *v1beta1.PullSubscription
 			Secret:  args.Secret,
 			Project: args.Project,
 		},
-		Topic: args.Topic,
+		Topic:    args.Topic,
+		Delivery: args.Subscriber.Delivery,
 	}
 
 	reply := args.Subscriber.ReplyURI
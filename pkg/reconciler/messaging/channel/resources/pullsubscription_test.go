@@ -286,3 +286,102 @@ func TestMakePullSubscription_JustReply(t *testing.T) {
 		t.Errorf("unexpected (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakePullSubscription_Delivery(t *testing.T) {
+	retry := int32(3)
+	backoffDelay := "PT1S"
+	channel := &v1beta1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "channel-name",
+			Namespace: "channel-namespace",
+			UID:       "channel-uid",
+		},
+		Spec: v1beta1.ChannelSpec{
+			Project: "eventing-name",
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "eventing-secret-name",
+				},
+				Key: "eventing-secret-key",
+			},
+		},
+		Status: v1beta1.ChannelStatus{
+			ProjectID: "project-123",
+			TopicID:   "topic-abc",
+		},
+	}
+
+	got := MakePullSubscription(&PullSubscriptionArgs{
+		Owner:   channel,
+		Name:    GeneratePullSubscriptionName("subscriber-uid"),
+		Project: channel.Status.ProjectID,
+		Topic:   channel.Status.TopicID,
+		Secret:  channel.Spec.Secret,
+		Labels: map[string]string{
+			"test-key1": "test-value1",
+			"test-key2": "test-value2",
+		},
+		Subscriber: duckv1beta1.SubscriberSpec{
+			SubscriberURI: &apis.URL{
+				Scheme: "http",
+				Path:   "/",
+				Host:   "subscriber",
+			},
+			Delivery: &duckv1beta1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					URI: &apis.URL{Scheme: "http", Host: "dead-letter", Path: "/"},
+				},
+				Retry:        &retry,
+				BackoffDelay: &backoffDelay,
+			},
+		},
+	})
+
+	yes := true
+	want := &inteventsv1beta1.PullSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "channel-namespace",
+			Name:      "cre-sub-subscriber-uid",
+			Labels: map[string]string{
+				"test-key1": "test-value1",
+				"test-key2": "test-value2",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "messaging.cloud.google.com/v1beta1",
+				Kind:               "Channel",
+				Name:               "channel-name",
+				UID:                "channel-uid",
+				Controller:         &yes,
+				BlockOwnerDeletion: &yes,
+			}},
+		},
+		Spec: inteventsv1beta1.PullSubscriptionSpec{
+			PubSubSpec: duckinteventsv1beta1.PubSubSpec{
+				Secret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "eventing-secret-name",
+					},
+					Key: "eventing-secret-key",
+				},
+				Project: "project-123",
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						URI: &apis.URL{Scheme: "http", Host: "subscriber", Path: "/"},
+					},
+				},
+			},
+			Topic: "topic-abc",
+			Delivery: &duckv1beta1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					URI: &apis.URL{Scheme: "http", Host: "dead-letter", Path: "/"},
+				},
+				Retry:        &retry,
+				BackoffDelay: &backoffDelay,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected (-want, +got) = %v", diff)
+	}
+}
@@ -180,6 +180,43 @@ func WithCloudAuditLogsSourceMethodName(methodName string) CloudAuditLogsSourceO
 	}
 }
 
+func WithCloudAuditLogsSourceMethodNames(methodNames ...string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.MethodNames = methodNames
+	}
+}
+
+func WithCloudAuditLogsSourceResourceNamePrefix(resourceNamePrefix string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.ResourceNamePrefix = resourceNamePrefix
+	}
+}
+
+func WithCloudAuditLogsSourcePrincipalEmails(principalEmails ...string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.PrincipalEmails = principalEmails
+	}
+}
+
+func WithCloudAuditLogsSourceSeverity(severity string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.Severity = severity
+	}
+}
+
+func WithCloudAuditLogsSourceAdvancedFilter(advancedFilter string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.AdvancedFilter = advancedFilter
+	}
+}
+
+func WithCloudAuditLogsSourceScope(scope, scopeID string) CloudAuditLogsSourceOption {
+	return func(s *v1beta1.CloudAuditLogsSource) {
+		s.Spec.Scope = scope
+		s.Spec.ScopeID = scopeID
+	}
+}
+
 func WithCloudAuditLogsSourceFinalizers(finalizers ...string) CloudAuditLogsSourceOption {
 	return func(s *v1beta1.CloudAuditLogsSource) {
 		s.Finalizers = finalizers
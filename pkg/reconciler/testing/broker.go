@@ -23,6 +23,7 @@ import (
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/pkg/apis"
 )
@@ -137,6 +138,22 @@ func WithBrokerBrokerCellReady(b *brokerv1beta1.Broker) {
 	b.Status.MarkBrokerCellReady()
 }
 
+func WithBrokerDataPlaneReady(b *brokerv1beta1.Broker) {
+	b.Status.MarkDataPlaneReady()
+}
+
+func WithBrokerDataPlaneFailed(reason, msg string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		b.Status.MarkDataPlaneFailed(reason, msg)
+	}
+}
+
+func WithBrokerDataPlaneUnknown(reason, msg string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		b.Status.MarkDataPlaneUnknown(reason, msg)
+	}
+}
+
 func WithBrokerSubscriptionReady(b *brokerv1beta1.Broker) {
 	b.Status.MarkSubscriptionReady()
 }
@@ -156,6 +173,57 @@ func WithBrokerClass(bc string) BrokerOption {
 	}
 }
 
+func WithBrokerOrderingKey(attr string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[brokerv1beta1.OrderingKeyAnnotation] = attr
+		b.SetAnnotations(annotations)
+	}
+}
+
+func WithBrokerRateLimits(rateLimit, sourceRateLimit string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 2)
+		}
+		annotations[brokerv1beta1.RateLimitAnnotation] = rateLimit
+		annotations[brokerv1beta1.SourceRateLimitAnnotation] = sourceRateLimit
+		b.SetAnnotations(annotations)
+	}
+}
+
+func WithBrokerEventSchemas(schemas string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[brokerv1beta1.EventSchemasAnnotation] = schemas
+		b.SetAnnotations(annotations)
+	}
+}
+
+func WithBrokerCellAnnotation(brokerCell string) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[brokerv1beta1.BrokerCellAnnotation] = brokerCell
+		b.SetAnnotations(annotations)
+	}
+}
+
+func WithBrokerDeliverySpec(ds *eventingduckv1beta1.DeliverySpec) BrokerOption {
+	return func(b *brokerv1beta1.Broker) {
+		b.Spec.Delivery = ds
+	}
+}
+
 func WithBrokerSetDefaults(b *brokerv1beta1.Broker) {
 	b.SetDefaults(context.Background())
 }
@@ -147,6 +147,18 @@ func WithTargetsCofigFailed(reason, msg string) BrokerCellOption {
 	}
 }
 
+func WithBrokerCellDataPlane(dp *intv1alpha1.DataPlaneStatus) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Status.SetDataPlaneStatus(dp)
+	}
+}
+
+func WithBrokerCellComponents(components intv1alpha1.ComponentsParametersSpec) BrokerCellOption {
+	return func(bc *intv1alpha1.BrokerCell) {
+		bc.Spec.Components = components
+	}
+}
+
 func WithBrokerCellSetDefaults(bc *intv1alpha1.BrokerCell) {
 	bc.SetDefaults(context.Background())
 }
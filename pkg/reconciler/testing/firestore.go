@@ -0,0 +1,172 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	gcpauthtesthelper "github.com/google/knative-gcp/pkg/apis/configs/gcpauth/testhelper"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+// CloudFirestoreSourceOption enables further configuration of a CloudFirestoreSource.
+type CloudFirestoreSourceOption func(*v1beta1.CloudFirestoreSource)
+
+// NewCloudFirestoreSource creates a CloudFirestoreSource with CloudFirestoreSourceOptions
+func NewCloudFirestoreSource(name, namespace string, opts ...CloudFirestoreSourceOption) *v1beta1.CloudFirestoreSource {
+	s := &v1beta1.CloudFirestoreSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func WithInitCloudFirestoreSourceConditions(s *v1beta1.CloudFirestoreSource) {
+	s.Status.InitializeConditions()
+}
+
+func WithCloudFirestoreSourceUID(uid string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.ObjectMeta.UID = types.UID(uid)
+	}
+}
+
+func WithCloudFirestoreSourceDocument(document string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Spec.Document = document
+	}
+}
+
+func WithCloudFirestoreSourceDatabase(database string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Spec.Database = database
+	}
+}
+
+func WithCloudFirestoreSourceEventTypes(eventTypes ...string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Spec.EventTypes = eventTypes
+	}
+}
+
+func WithCloudFirestoreSourceTopicFailed(reason, message string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkTopicFailed(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudFirestoreSourceTopicUnknown(reason, message string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkTopicUnknown(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudFirestoreSourceTopicReady(topicID string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkTopicReady(s.ConditionSet())
+		s.Status.TopicID = topicID
+	}
+}
+
+func WithCloudFirestoreSourcePullSubscriptionFailed(reason, message string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkPullSubscriptionFailed(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudFirestoreSourcePullSubscriptionUnknown(reason, message string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkPullSubscriptionUnknown(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudFirestoreSourcePullSubscriptionReady() CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+	}
+}
+
+func WithCloudFirestoreSourceSinkNotReady(reason, messageFmt string, messageA ...interface{}) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.MarkSinkNotReady(reason, messageFmt, messageA...)
+	}
+}
+
+func WithCloudFirestoreSourceSinkReady(s *v1beta1.CloudFirestoreSource) {
+	s.Status.MarkSinkReady()
+}
+
+func WithCloudFirestoreSourceSink(gvk metav1.GroupVersionKind, name string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Spec.Sink = duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: apiVersion(gvk),
+				Kind:       gvk.Kind,
+				Name:       name,
+			},
+		}
+	}
+}
+
+func WithCloudFirestoreSourceSinkURI(url *apis.URL) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.SinkURI = url
+	}
+}
+
+func WithCloudFirestoreSourceProjectID(projectID string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.ProjectID = projectID
+	}
+}
+
+func WithCloudFirestoreSourceSubscriptionID(subscriptionID string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.SubscriptionID = subscriptionID
+	}
+}
+
+func WithCloudFirestoreSourceSinkID(sinkID string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.Status.StackdriverSink = sinkID
+	}
+}
+
+func WithCloudFirestoreSourceDeletionTimestamp(s *v1beta1.CloudFirestoreSource) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	s.ObjectMeta.SetDeletionTimestamp(&t)
+}
+
+func WithCloudFirestoreSourceAnnotations(annotations map[string]string) CloudFirestoreSourceOption {
+	return func(s *v1beta1.CloudFirestoreSource) {
+		s.ObjectMeta.Annotations = annotations
+	}
+}
+
+func WithCloudFirestoreSourceSetDefaults(s *v1beta1.CloudFirestoreSource) {
+	s.SetDefaults(gcpauthtesthelper.ContextWithDefaults())
+}
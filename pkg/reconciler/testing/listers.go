@@ -152,6 +152,14 @@ func (l *Listers) GetCloudBuildSourceLister() eventslisters.CloudBuildSourceList
 	return eventslisters.NewCloudBuildSourceLister(l.indexerFor(&EventsV1beta1.CloudBuildSource{}))
 }
 
+func (l *Listers) GetCloudFirestoreSourceLister() eventslisters.CloudFirestoreSourceLister {
+	return eventslisters.NewCloudFirestoreSourceLister(l.indexerFor(&EventsV1beta1.CloudFirestoreSource{}))
+}
+
+func (l *Listers) GetCloudMonitoringAlertSourceLister() eventslisters.CloudMonitoringAlertSourceLister {
+	return eventslisters.NewCloudMonitoringAlertSourceLister(l.indexerFor(&EventsV1beta1.CloudMonitoringAlertSource{}))
+}
+
 func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
@@ -196,6 +204,10 @@ func (l *Listers) GetConfigMapLister() corev1listers.ConfigMapLister {
 	return corev1listers.NewConfigMapLister(l.indexerFor(&corev1.ConfigMap{}))
 }
 
+func (l *Listers) GetSecretLister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(l.indexerFor(&corev1.Secret{}))
+}
+
 func (l *Listers) GetBrokerLister() brokerlisters.BrokerLister {
 	return brokerlisters.NewBrokerLister(l.indexerFor(&brokerv1beta1.Broker{}))
 }
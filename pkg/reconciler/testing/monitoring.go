@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	gcpauthtesthelper "github.com/google/knative-gcp/pkg/apis/configs/gcpauth/testhelper"
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+)
+
+// CloudMonitoringAlertSourceOption enables further configuration of a CloudMonitoringAlertSource.
+type CloudMonitoringAlertSourceOption func(*v1beta1.CloudMonitoringAlertSource)
+
+// NewCloudMonitoringAlertSource creates a CloudMonitoringAlertSource with CloudMonitoringAlertSourceOptions
+func NewCloudMonitoringAlertSource(name, namespace string, opts ...CloudMonitoringAlertSourceOption) *v1beta1.CloudMonitoringAlertSource {
+	s := &v1beta1.CloudMonitoringAlertSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func WithInitCloudMonitoringAlertSourceConditions(s *v1beta1.CloudMonitoringAlertSource) {
+	s.Status.InitializeConditions()
+}
+
+func WithCloudMonitoringAlertSourceUID(uid string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.ObjectMeta.UID = types.UID(uid)
+	}
+}
+
+func WithCloudMonitoringAlertSourceAlertPolicies(policies ...string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Spec.AlertPolicies = policies
+	}
+}
+
+func WithCloudMonitoringAlertSourceTopicFailed(reason, message string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkTopicFailed(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudMonitoringAlertSourceTopicUnknown(reason, message string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkTopicUnknown(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudMonitoringAlertSourceTopicReady(topicID string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkTopicReady(s.ConditionSet())
+		s.Status.TopicID = topicID
+	}
+}
+
+func WithCloudMonitoringAlertSourcePullSubscriptionFailed(reason, message string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkPullSubscriptionFailed(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudMonitoringAlertSourcePullSubscriptionUnknown(reason, message string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkPullSubscriptionUnknown(s.ConditionSet(), reason, message)
+	}
+}
+
+func WithCloudMonitoringAlertSourcePullSubscriptionReady() CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkPullSubscriptionReady(s.ConditionSet())
+	}
+}
+
+func WithCloudMonitoringAlertSourceNotificationChannelNotReady(reason, messageFmt string, messageA ...interface{}) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkNotificationChannelNotReady(reason, messageFmt, messageA...)
+	}
+}
+
+func WithCloudMonitoringAlertSourceNotificationChannelReady(channel string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.MarkNotificationChannelReady(channel)
+	}
+}
+
+func WithCloudMonitoringAlertSourceSink(gvk metav1.GroupVersionKind, name string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Spec.Sink = duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: apiVersion(gvk),
+				Kind:       gvk.Kind,
+				Name:       name,
+			},
+		}
+	}
+}
+
+func WithCloudMonitoringAlertSourceSinkURI(url *apis.URL) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.SinkURI = url
+	}
+}
+
+func WithCloudMonitoringAlertSourceProjectID(projectID string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.ProjectID = projectID
+	}
+}
+
+func WithCloudMonitoringAlertSourceSubscriptionID(subscriptionID string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.SubscriptionID = subscriptionID
+	}
+}
+
+func WithCloudMonitoringAlertSourceNotificationChannel(channel string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.NotificationChannel = channel
+	}
+}
+
+func WithCloudMonitoringAlertSourceStatusAlertPolicies(policies ...string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.Status.AlertPolicies = policies
+	}
+}
+
+func WithCloudMonitoringAlertSourceDeletionTimestamp(s *v1beta1.CloudMonitoringAlertSource) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	s.ObjectMeta.SetDeletionTimestamp(&t)
+}
+
+func WithCloudMonitoringAlertSourceAnnotations(annotations map[string]string) CloudMonitoringAlertSourceOption {
+	return func(s *v1beta1.CloudMonitoringAlertSource) {
+		s.ObjectMeta.Annotations = annotations
+	}
+}
+
+func WithCloudMonitoringAlertSourceSetDefaults(s *v1beta1.CloudMonitoringAlertSource) {
+	s.SetDefaults(gcpauthtesthelper.ContextWithDefaults())
+}
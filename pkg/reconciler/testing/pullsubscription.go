@@ -148,6 +148,24 @@ func WithPullSubscriptionTransformerURI(uri *apis.URL) PullSubscriptionOption {
 	}
 }
 
+func WithPullSubscriptionDeadLetterSinkURI(uri *apis.URL) PullSubscriptionOption {
+	return func(s *v1beta1.PullSubscription) {
+		s.Status.DeadLetterSinkURI = uri
+	}
+}
+
+func WithPullSubscriptionDeadLetterTopicID(topicID string) PullSubscriptionOption {
+	return func(s *v1beta1.PullSubscription) {
+		s.Status.DeadLetterTopicID = topicID
+	}
+}
+
+func WithPullSubscriptionMarkNoSink(reason, message string) PullSubscriptionOption {
+	return func(s *v1beta1.PullSubscription) {
+		s.Status.MarkNoSink(reason, "%s", message)
+	}
+}
+
 func WithPullSubscriptionMarkNoSubscription(reason, message string) PullSubscriptionOption {
 	return func(s *v1beta1.PullSubscription) {
 		s.Status.MarkNoSubscription(reason, message)
@@ -89,6 +89,15 @@ func WithCloudSchedulerSourceData(data string) CloudSchedulerSourceOption {
 	}
 }
 
+func WithCloudSchedulerSourcePaused(s *v1beta1.CloudSchedulerSource) {
+	s.Spec.Paused = true
+}
+
+// WithCloudSchedulerSourceHTTPDelivery sets the delivery of the CloudSchedulerSource to HTTP.
+func WithCloudSchedulerSourceHTTPDelivery(s *v1beta1.CloudSchedulerSource) {
+	s.Spec.Delivery = v1beta1.SchedulerDeliveryHTTP
+}
+
 func WithCloudSchedulerSourceDeletionTimestamp(s *v1beta1.CloudSchedulerSource) {
 	t := metav1.NewTime(time.Unix(1e9, 0))
 	s.ObjectMeta.SetDeletionTimestamp(&t)
@@ -160,6 +169,19 @@ func WithCloudSchedulerSourcePullSubscriptionReady(s *v1beta1.CloudSchedulerSour
 	s.Status.MarkPullSubscriptionReady(s.ConditionSet())
 }
 
+// WithCloudSchedulerSourceHTTPDeliveryReady marks the conditions of the topic and
+// pullsubscription, which a CloudSchedulerSource with HTTP delivery doesn't have, as ready.
+func WithCloudSchedulerSourceHTTPDeliveryReady(s *v1beta1.CloudSchedulerSource) {
+	s.Status.MarkHTTPDelivery()
+}
+
+// WithCloudSchedulerSourceProjectID sets the status for project ID
+func WithCloudSchedulerSourceProjectID(projectID string) CloudSchedulerSourceOption {
+	return func(s *v1beta1.CloudSchedulerSource) {
+		s.Status.ProjectID = projectID
+	}
+}
+
 // WithCloudSchedulerSourceJobNotReady marks the condition that the
 // CloudSchedulerSource Job is not ready.
 func WithCloudSchedulerSourceJobNotReady(reason, message string) CloudSchedulerSourceOption {
@@ -126,6 +126,22 @@ func WithTriggerBrokerUnknown(reason, message string) TriggerOption {
 	}
 }
 
+func WithTriggerDataPlaneReady(t *brokerv1beta1.Trigger) {
+	t.Status.MarkDataPlaneReady()
+}
+
+func WithTriggerDataPlaneFailed(reason, message string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		t.Status.MarkDataPlaneFailed(reason, message)
+	}
+}
+
+func WithTriggerDataPlaneUnknown(reason, message string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		t.Status.MarkDataPlaneUnknown(reason, message)
+	}
+}
+
 func WithTriggerStatusSubscriberURI(uri string) TriggerOption {
 	return func(t *brokerv1beta1.Trigger) {
 		u, _ := apis.ParseURL(uri)
@@ -151,6 +167,24 @@ func WithDependencyAnnotation(dependencyAnnotation string) TriggerOption {
 	}
 }
 
+func WithTriggerFiltersAnnotation(filters string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		if t.Annotations == nil {
+			t.Annotations = make(map[string]string)
+		}
+		t.Annotations[brokerv1beta1.FiltersAnnotation] = filters
+	}
+}
+
+func WithTriggerDeliveryAnnotation(delivery string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		if t.Annotations == nil {
+			t.Annotations = make(map[string]string)
+		}
+		t.Annotations[brokerv1beta1.DeliveryAnnotation] = delivery
+	}
+}
+
 func WithTriggerDependencyReady(t *brokerv1beta1.Trigger) {
 	t.Status.MarkDependencySucceeded()
 }
@@ -183,6 +217,41 @@ func WithTriggerSubscriberResolvedUnknown(reason, message string) TriggerOption
 	}
 }
 
+func WithTriggerReplyResolvedSucceeded(t *brokerv1beta1.Trigger) {
+	t.Status.MarkReplyResolvedSucceeded(nil)
+}
+
+func WithTriggerReplyResolvedURI(uri string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		u, _ := apis.ParseURL(uri)
+		t.Status.MarkReplyResolvedSucceeded(u)
+	}
+}
+
+func WithTriggerReplyResolvedFailed(reason, message string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		t.Status.MarkReplyResolvedFailed(reason, message)
+	}
+}
+
+func WithTriggerReplyAnnotation(reply string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		if t.Annotations == nil {
+			t.Annotations = make(map[string]string)
+		}
+		t.Annotations[brokerv1beta1.ReplyAnnotation] = reply
+	}
+}
+
+func WithTriggerTransportAnnotation(transport string) TriggerOption {
+	return func(t *brokerv1beta1.Trigger) {
+		if t.Annotations == nil {
+			t.Annotations = make(map[string]string)
+		}
+		t.Annotations[brokerv1beta1.TransportAnnotation] = transport
+	}
+}
+
 func WithTriggerSubscriptionReady(t *brokerv1beta1.Trigger) {
 	t.Status.MarkSubscriptionReady()
 }
@@ -36,10 +36,13 @@ import (
 	pkgcontroller "knative.dev/pkg/controller"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	brokerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/broker"
 	triggerinformer "github.com/google/knative-gcp/pkg/client/injection/informers/broker/v1beta1/trigger"
+	brokercellinformer "github.com/google/knative-gcp/pkg/client/injection/informers/intevents/v1alpha1/brokercell"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	metadataClient "github.com/google/knative-gcp/pkg/gclient/metadata"
 	"github.com/google/knative-gcp/pkg/reconciler"
@@ -82,10 +85,11 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	}
 
 	r := &Reconciler{
-		Base:         reconciler.NewBase(ctx, controllerAgentName, cmw),
-		brokerLister: brokerinformer.Get(ctx).Lister(),
-		pubsubClient: client,
-		projectID:    projectID,
+		Base:             reconciler.NewBase(ctx, controllerAgentName, cmw),
+		brokerLister:     brokerinformer.Get(ctx).Lister(),
+		brokerCellLister: brokercellinformer.Get(ctx).Lister(),
+		pubsubClient:     client,
+		projectID:        projectID,
 	}
 
 	impl := triggerreconciler.NewImpl(ctx, r, withAgentAndFinalizer)
@@ -97,6 +101,18 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	triggerInformer.Informer().AddEventHandlerWithResyncPeriod(controller.HandleAll(impl.Enqueue), reconciler.DefaultResyncPeriod)
 
+	// enqueueTriggers enqueues the triggers of the broker.
+	enqueueTriggers := func(b *brokerv1beta1.Broker) {
+		triggers, err := triggerinformer.Get(ctx).Lister().Triggers(b.Namespace).List(labels.SelectorFromSet(map[string]string{eventing.BrokerLabelKey: b.Name}))
+		if err != nil {
+			r.Logger.Warn("Failed to list triggers", zap.String("Namespace", b.Namespace), zap.String("Broker", b.Name))
+			return
+		}
+		for _, trigger := range triggers {
+			impl.Enqueue(trigger)
+		}
+	}
+
 	// Watch brokers.
 	brokerinformer.Get(ctx).Informer().AddEventHandler(
 		cache.FilteringResourceEventHandler{
@@ -104,19 +120,33 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 			FilterFunc: filterBroker,
 			Handler: controller.HandleAll(func(obj interface{}) {
 				if b, ok := obj.(*brokerv1beta1.Broker); ok {
-					triggers, err := triggerinformer.Get(ctx).Lister().Triggers(b.Namespace).List(labels.SelectorFromSet(map[string]string{eventing.BrokerLabelKey: b.Name}))
-					if err != nil {
-						r.Logger.Warn("Failed to list triggers", zap.String("Namespace", b.Namespace), zap.String("Broker", b.Name))
-						return
-					}
-					for _, trigger := range triggers {
-						impl.Enqueue(trigger)
-					}
+					enqueueTriggers(b)
 				}
 			}),
 		},
 	)
 
+	// Watch brokercells to update the data plane condition of the triggers of their brokers.
+	brokercellinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+		func(obj interface{}) {
+			if bc, ok := obj.(*inteventsv1alpha1.BrokerCell); ok {
+				if bc.Namespace != system.Namespace() {
+					return
+				}
+				brokers, err := brokerinformer.Get(ctx).Lister().List(labels.Everything())
+				if err != nil {
+					r.Logger.Error("Failed to list brokers", zap.Error(err))
+					return
+				}
+				for _, b := range brokers {
+					if filterBroker(b) && b.BrokerCellName() == bc.Name {
+						enqueueTriggers(b)
+					}
+				}
+			}
+		},
+	))
+
 	return impl
 }
 
@@ -25,16 +25,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/eventing/pkg/duck"
 	"knative.dev/eventing/pkg/logging"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
 
 	"cloud.google.com/go/pubsub"
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	handlerctx "github.com/google/knative-gcp/pkg/broker/handler/context"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	brokerlisters "github.com/google/knative-gcp/pkg/client/listers/broker/v1beta1"
+	inteventslisters "github.com/google/knative-gcp/pkg/client/listers/intevents/v1alpha1"
 	metadataClient "github.com/google/knative-gcp/pkg/gclient/metadata"
 	"github.com/google/knative-gcp/pkg/reconciler"
 	"github.com/google/knative-gcp/pkg/reconciler/broker/resources"
@@ -53,7 +57,8 @@ const (
 type Reconciler struct {
 	*reconciler.Base
 
-	brokerLister brokerlisters.BrokerLister
+	brokerLister     brokerlisters.BrokerLister
+	brokerCellLister inteventslisters.BrokerCellLister
 
 	// Dynamic tracker to track KResources. It tracks the dependency between Triggers and Sources.
 	kresourceTracker duck.ListableTracker
@@ -106,7 +111,11 @@ func (r *Reconciler) reconcile(ctx context.Context, t *brokerv1beta1.Trigger, b
 		return err
 	}
 
-	if err := r.reconcileRetryTopicAndSubscription(ctx, t); err != nil {
+	if err := r.resolveReply(ctx, t); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRetryTopicAndSubscription(ctx, t, b); err != nil {
 		return err
 	}
 
@@ -114,6 +123,10 @@ func (r *Reconciler) reconcile(ctx context.Context, t *brokerv1beta1.Trigger, b
 		return err
 	}
 
+	if err := r.propagateDataPlaneStatus(ctx, t, b); err != nil {
+		return err
+	}
+
 	return pkgreconciler.NewEvent(corev1.EventTypeNormal, triggerReconciled, "Trigger reconciled: \"%s/%s\"", t.Namespace, t.Name)
 }
 
@@ -155,6 +168,63 @@ func (r *Reconciler) resolveSubscriber(ctx context.Context, t *brokerv1beta1.Tri
 	return nil
 }
 
+// resolveReply resolves the reply destination of the Trigger and records its URI in the status.
+func (r *Reconciler) resolveReply(ctx context.Context, t *brokerv1beta1.Trigger) error {
+	reply, err := t.ReplySpec()
+	if err != nil {
+		// The webhook rejects invalid reply specs, so this should not happen.
+		logging.FromContext(ctx).Error("Invalid reply spec", zap.Error(err))
+		t.Status.MarkReplyResolvedFailed("InvalidReplySpec", "%v", err)
+		return err
+	}
+	if reply == nil || reply.Destination == nil {
+		t.Status.MarkReplyResolvedSucceeded(nil)
+		return nil
+	}
+
+	dest := reply.Destination.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		// To call URIFromDestinationV1, dest.Ref must have a Namespace.
+		// We will use the Namespace of the Trigger as the Namespace of dest.Ref.
+		dest.Ref.Namespace = t.Namespace
+	}
+	replyURI, err := r.uriResolver.URIFromDestinationV1(*dest, t)
+	if err != nil {
+		logging.FromContext(ctx).Error("Unable to get the reply URI", zap.Error(err))
+		t.Status.MarkReplyResolvedFailed("Unable to get the reply URI", "%v", err)
+		return err
+	}
+	t.Status.MarkReplyResolvedSucceeded(replyURI)
+	return nil
+}
+
+// propagateDataPlaneStatus updates the data plane condition of the Trigger with the health of
+// its handlers reported in the status of the BrokerCell of its Broker.
+func (r *Reconciler) propagateDataPlaneStatus(ctx context.Context, t *brokerv1beta1.Trigger, b *brokerv1beta1.Broker) error {
+	bc, err := r.brokerCellLister.BrokerCells(system.Namespace()).Get(b.BrokerCellName())
+	if apierrs.IsNotFound(err) {
+		// The broker controller creates the brokercell, and the trigger is requeued when it does.
+		t.Status.MarkDataPlaneUnknown("BrokerCellNotFound", "Brokercell %s/%s does not exist", system.Namespace(), b.BrokerCellName())
+		return nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get brokercell", zap.String("brokercell", b.BrokerCellName()), zap.Error(err))
+		t.Status.MarkDataPlaneUnknown("BrokerCellUnknown", "Failed to get brokercell %s/%s: %v", system.Namespace(), b.BrokerCellName(), err)
+		return err
+	}
+	dp := bc.Status.DataPlane
+	if dp == nil {
+		t.Status.MarkDataPlaneUnknown("DataPlaneNotProbed", "The data plane of brokercell %s/%s hasn't been probed", bc.Namespace, bc.Name)
+		return nil
+	}
+	if hs := dp.UnhealthyTrigger(t.Namespace, t.Name); hs != nil {
+		t.Status.MarkDataPlaneFailed("HandlerStopped", "%s", hs.Message)
+		return nil
+	}
+	t.Status.MarkDataPlaneReady()
+	return nil
+}
+
 // hasGCPBrokerFinalizer checks if the Trigger object has a finalizer matching the one added by this controller.
 func hasGCPBrokerFinalizer(t *brokerv1beta1.Trigger) bool {
 	for _, f := range t.Finalizers {
@@ -165,7 +235,7 @@ func hasGCPBrokerFinalizer(t *brokerv1beta1.Trigger) bool {
 	return false
 }
 
-func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger) error {
+func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, trig *brokerv1beta1.Trigger, b *brokerv1beta1.Broker) error {
 	logger := logging.FromContext(ctx)
 	logger.Debug("Reconciling retry topic")
 	// get ProjectID from metadata
@@ -218,13 +288,50 @@ func (r *Reconciler) reconcileRetryTopicAndSubscription(ctx context.Context, tri
 	subConfig := pubsub.SubscriptionConfig{
 		Topic:  topic,
 		Labels: labels,
+		// Events of ordered brokers are delivered from the retry subscription in order.
+		EnableMessageOrdering: b.OrderingKeyAttribute() != "",
 		//TODO(grantr): configure these settings?
 		// AckDeadline
 		// RetentionDuration
 	}
+
+	// Pub/Sub only tracks the delivery attempts of the messages of subscriptions with a dead
+	// letter policy, which the retry handlers need to enforce the retry limit of the Trigger.
+	dlTopicID := resources.GenerateRetryDeadLetterTopicName(trig)
+	limited := hasRetryLimit(ctx, trig, b)
+	if limited {
+		dlTopic, err := pubsubReconciler.ReconcileTopic(ctx, dlTopicID, topicConfig, trig, &trig.Status)
+		if err != nil {
+			return err
+		}
+		// The subscription of the dead letter topic retains the events Pub/Sub forwards to it,
+		// e.g. because the retry handlers keep crashing while delivering them.
+		dlSubConfig := pubsub.SubscriptionConfig{
+			Topic:  dlTopic,
+			Labels: labels,
+		}
+		if _, err := pubsubReconciler.ReconcileSubscription(ctx, dlTopicID, dlSubConfig, trig, &trig.Status); err != nil {
+			return err
+		}
+		subConfig.DeadLetterPolicy = &pubsub.DeadLetterPolicy{
+			DeadLetterTopic:     dlTopic.String(),
+			MaxDeliveryAttempts: handlerctx.MaxDeliveryAttempts,
+		}
+	}
+
 	if _, err := pubsubReconciler.ReconcileSubscription(ctx, subID, subConfig, trig, &trig.Status); err != nil {
 		return err
 	}
+
+	// Only delete the dead letter topic once the retry subscription no longer forwards to it.
+	if !limited {
+		if err := multierr.Append(
+			pubsubReconciler.DeleteSubscription(ctx, dlTopicID, trig, &trig.Status),
+			pubsubReconciler.DeleteTopic(ctx, dlTopicID, trig, &trig.Status),
+		); err != nil {
+			return err
+		}
+	}
 	// TODO(grantr): this isn't actually persisted due to webhook issues.
 	//TODO uncomment when eventing webhook allows this
 	//trig.Status.SubscriptionID = sub.ID()
@@ -265,10 +372,28 @@ func (r *Reconciler) deleteRetryTopicAndSubscription(ctx context.Context, trig *
 	err = multierr.Append(nil, pubsubReconciler.DeleteTopic(ctx, topicID, trig, &trig.Status))
 	// Delete pull subscription if it exists.
 	subID := resources.GenerateRetrySubscriptionName(trig)
-	err = multierr.Append(nil, pubsubReconciler.DeleteSubscription(ctx, subID, trig, &trig.Status))
+	err = multierr.Append(err, pubsubReconciler.DeleteSubscription(ctx, subID, trig, &trig.Status))
+	// Delete the dead letter topic and its subscription if they exist.
+	dlTopicID := resources.GenerateRetryDeadLetterTopicName(trig)
+	err = multierr.Append(err, pubsubReconciler.DeleteSubscription(ctx, dlTopicID, trig, &trig.Status))
+	err = multierr.Append(err, pubsubReconciler.DeleteTopic(ctx, dlTopicID, trig, &trig.Status))
 	return err
 }
 
+// hasRetryLimit returns whether the delivery of the events of the Trigger is limited by a retry
+// limit or a dead letter sink, set on the Broker or overridden by the Trigger.
+func hasRetryLimit(ctx context.Context, t *brokerv1beta1.Trigger, b *brokerv1beta1.Broker) bool {
+	limited := func(ds *eventingduckv1beta1.DeliverySpec) bool {
+		return ds != nil && (ds.Retry != nil || ds.DeadLetterSink != nil)
+	}
+	ds, err := t.DeliverySpec()
+	if err != nil {
+		// The webhook rejects invalid delivery specs, so this should not happen.
+		logging.FromContext(ctx).Error("Invalid delivery spec", zap.Error(err))
+	}
+	return limited(ds) || limited(b.Spec.Delivery)
+}
+
 func (r *Reconciler) checkDependencyAnnotation(ctx context.Context, t *brokerv1beta1.Trigger, b *brokerv1beta1.Broker) error {
 	if dependencyAnnotation, ok := t.GetAnnotations()[v1beta1.DependencyAnnotation]; ok {
 		dependencyObjRef, err := v1beta1.GetObjRefFromDependencyAnnotation(dependencyAnnotation)
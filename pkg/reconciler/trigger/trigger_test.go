@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"testing"
 
+	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -28,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/eventing/pkg/duck"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/conditions"
@@ -36,8 +39,10 @@ import (
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/system"
 
 	brokerv1beta1 "github.com/google/knative-gcp/pkg/apis/broker/v1beta1"
+	inteventsv1alpha1 "github.com/google/knative-gcp/pkg/apis/intevents/v1alpha1"
 	"github.com/google/knative-gcp/pkg/client/injection/ducks/duck/v1alpha1/resource"
 	triggerreconciler "github.com/google/knative-gcp/pkg/client/injection/reconciler/broker/v1beta1/trigger"
 	"github.com/google/knative-gcp/pkg/reconciler"
@@ -66,12 +71,20 @@ var (
 	triggerFinalizedEvent        = Eventf(corev1.EventTypeNormal, "TriggerFinalized", `Trigger finalized: "testnamespace/test-trigger"`)
 	topicCreatedEvent            = Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-tgr_testnamespace_test-trigger_abc123"`)
 	subscriptionCreatedEvent     = Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-tgr_testnamespace_test-trigger_abc123"`)
+
 	subscriberAPIVersion         = fmt.Sprintf("%s/%s", subscriberGroup, subscriberVersion)
 	subscriberGVK                = metav1.GroupVersionKind{
 		Group:   subscriberGroup,
 		Version: subscriberVersion,
 		Kind:    subscriberKind,
 	}
+
+	deadLetterTopicCreatedEvent        = Eventf(corev1.EventTypeNormal, "TopicCreated", `Created PubSub topic "cre-tgr-dl_testnamespace_test-trigger_abc123"`)
+	deadLetterSubscriptionCreatedEvent = Eventf(corev1.EventTypeNormal, "SubscriptionCreated", `Created PubSub subscription "cre-tgr-dl_testnamespace_test-trigger_abc123"`)
+	deadLetterTopicDeletedEvent        = Eventf(corev1.EventTypeNormal, "TopicDeleted", `Deleted PubSub topic "cre-tgr-dl_testnamespace_test-trigger_abc123"`)
+	deadLetterSubscriptionDeletedEvent = Eventf(corev1.EventTypeNormal, "SubscriptionDeleted", `Deleted PubSub subscription "cre-tgr-dl_testnamespace_test-trigger_abc123"`)
+
+	retry = int32(3)
 )
 
 func init() {
@@ -224,6 +237,9 @@ func TestAllCasesTrigger(t *testing.T) {
 					WithInitBrokerConditions,
 					WithBrokerSetDefaults,
 				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithInitBrokerCellConditions,
+				),
 				makeSubscriberAddressableAsUnstructured(),
 				NewTrigger(triggerName, testNS, brokerName,
 					WithTriggerUID(testUID),
@@ -235,10 +251,12 @@ func TestAllCasesTrigger(t *testing.T) {
 					WithTriggerUID(testUID),
 					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
 					WithTriggerBrokerUnknown("Broker/", ""),
+					WithTriggerDataPlaneUnknown("DataPlaneNotProbed", fmt.Sprintf("The data plane of brokercell %s/default hasn't been probed", system.Namespace())),
 					WithTriggerSubscriptionReady,
 					WithTriggerTopicReady,
 					WithTriggerDependencyReady,
 					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedSucceeded,
 					WithTriggerStatusSubscriberURI(subscriberURI),
 					WithTriggerSetDefaults,
 				),
@@ -301,21 +319,178 @@ func TestAllCasesTrigger(t *testing.T) {
 					WithBrokerReady("url"),
 					WithBrokerSetDefaults,
 				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+					WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerDataPlaneReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				topicCreatedEvent,
+				subscriptionCreatedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			OtherTestData: map[string]interface{}{},
+			PostConditions: []func(*testing.T, *TableRow){
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
+		{
+			Name: "Trigger handler stopped in the data plane",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+					WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{
+						ProbedPods: 2,
+						UnhealthyTriggers: []inteventsv1alpha1.HandlerStatus{{
+							Namespace: testNS,
+							Name:      triggerName,
+							Message:   "Handler stopped in pods retry-1: subscription does not exist",
+						}},
+					}),
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerDataPlaneFailed("HandlerStopped", "Handler stopped in pods retry-1: subscription does not exist"),
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				topicCreatedEvent,
+				subscriptionCreatedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			OtherTestData: map[string]interface{}{},
+			PostConditions: []func(*testing.T, *TableRow){
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
+		{
+			Name: "Reply destination doesn't exist",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerReplyAnnotation(`{"destination":{"ref":{"apiVersion":"serving.knative.dev/v1","kind":"Service","name":"reply-name"}}}`),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerReplyAnnotation(`{"destination":{"ref":{"apiVersion":"serving.knative.dev/v1","kind":"Service","name":"reply-name"}}}`),
+					WithInitTriggerConditions,
+					WithTriggerBrokerReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerReplyResolvedFailed("Unable to get the reply URI", `failed to get ref &ObjectReference{Kind:Service,Namespace:testnamespace,Name:reply-name,UID:,APIVersion:serving.knative.dev/v1,ResourceVersion:,FieldPath:,}: services.serving.knative.dev "reply-name" not found`),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				Eventf(corev1.EventTypeWarning, "InternalError", `failed to get ref &ObjectReference{Kind:Service,Namespace:testnamespace,Name:reply-name,UID:,APIVersion:serving.knative.dev/v1,ResourceVersion:,FieldPath:,}: services.serving.knative.dev "reply-name" not found`),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			WantErr: true,
+		},
+		{
+			Name: "Trigger created with a reply URI",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+					WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				),
 				makeSubscriberAddressableAsUnstructured(),
 				NewTrigger(triggerName, testNS, brokerName,
 					WithTriggerUID(testUID),
 					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerReplyAnnotation(`{"destination":{"uri":"http://example.com/reply/"}}`),
 					WithTriggerSetDefaults),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewTrigger(triggerName, testNS, brokerName,
 					WithTriggerUID(testUID),
 					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerReplyAnnotation(`{"destination":{"uri":"http://example.com/reply/"}}`),
 					WithTriggerBrokerReady,
 					WithTriggerSubscriptionReady,
 					WithTriggerTopicReady,
 					WithTriggerDependencyReady,
+					WithTriggerDataPlaneReady,
 					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedURI("http://example.com/reply/"),
 					WithTriggerStatusSubscriberURI(subscriberURI),
 					WithTriggerSetDefaults,
 				),
@@ -335,6 +510,119 @@ func TestAllCasesTrigger(t *testing.T) {
 				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
 			},
 		},
+		{
+			Name: "Trigger created with a dead letter policy for the retry limit of its broker",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithBrokerDeliverySpec(&eventingduckv1beta1.DeliverySpec{Retry: &retry}),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+					WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerDataPlaneReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				topicCreatedEvent,
+				deadLetterTopicCreatedEvent,
+				deadLetterSubscriptionCreatedEvent,
+				subscriptionCreatedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			OtherTestData: map[string]interface{}{},
+			PostConditions: []func(*testing.T, *TableRow){
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr-dl_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123", "cre-tgr-dl_testnamespace_test-trigger_abc123"),
+				subscriptionHasDeadLetterPolicy("cre-tgr_testnamespace_test-trigger_abc123", &pubsub.DeadLetterPolicy{
+					DeadLetterTopic:     "projects/test-project-id/topics/cre-tgr-dl_testnamespace_test-trigger_abc123",
+					MaxDeliveryAttempts: 100,
+				}),
+			},
+		},
+		{
+			Name: "Trigger dead letter topic deleted once its broker has no retry limit",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(brokerv1beta1.BrokerClass),
+					WithInitBrokerConditions,
+					WithBrokerReady("url"),
+					WithBrokerSetDefaults,
+				),
+				NewBrokerCell(brokerv1beta1.DefaultBrokerCellName, system.Namespace(),
+					WithBrokerCellReady,
+					WithBrokerCellDataPlane(&inteventsv1alpha1.DataPlaneStatus{}),
+				),
+				makeSubscriberAddressableAsUnstructured(),
+				NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerSetDefaults),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewTrigger(triggerName, testNS, brokerName,
+					WithTriggerUID(testUID),
+					WithTriggerSubscriberRef(subscriberGVK, subscriberName, testNS),
+					WithTriggerBrokerReady,
+					WithTriggerSubscriptionReady,
+					WithTriggerTopicReady,
+					WithTriggerDependencyReady,
+					WithTriggerDataPlaneReady,
+					WithTriggerSubscriberResolvedSucceeded,
+					WithTriggerReplyResolvedSucceeded,
+					WithTriggerStatusSubscriberURI(subscriberURI),
+					WithTriggerSetDefaults,
+				),
+			}},
+			WantEvents: []string{
+				triggerFinalizerUpdatedEvent,
+				topicCreatedEvent,
+				subscriptionCreatedEvent,
+				deadLetterSubscriptionDeletedEvent,
+				deadLetterTopicDeletedEvent,
+				triggerReconciledEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(testNS, triggerName, finalizerName),
+			},
+			OtherTestData: map[string]interface{}{
+				"pre": []PubsubAction{
+					TopicAndSub("cre-tgr-dl_testnamespace_test-trigger_abc123", "cre-tgr-dl_testnamespace_test-trigger_abc123"),
+				},
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				OnlyTopics("cre-tgr_testnamespace_test-trigger_abc123"),
+				OnlySubscriptions("cre-tgr_testnamespace_test-trigger_abc123"),
+			},
+		},
 	}
 
 	defer logtesting.ClearAll()
@@ -359,6 +647,7 @@ func TestAllCasesTrigger(t *testing.T) {
 		r := &Reconciler{
 			Base:               reconciler.NewBase(ctx, controllerAgentName, cmw),
 			brokerLister:       listers.GetBrokerLister(),
+			brokerCellLister:   listers.GetBrokerCellLister(),
 			kresourceTracker:   duck.NewListableTracker(ctx, conditions.Get, func(types.NamespacedName) {}, 0),
 			addressableTracker: duck.NewListableTracker(ctx, addressable.Get, func(types.NamespacedName) {}, 0),
 			uriResolver:        resolver.NewURIResolver(ctx, func(types.NamespacedName) {}),
@@ -407,3 +696,16 @@ func patchRemoveFinalizers(namespace, name string) clientgotesting.PatchActionIm
 	action.Patch = []byte(patch)
 	return action
 }
+
+func subscriptionHasDeadLetterPolicy(id string, want *pubsub.DeadLetterPolicy) func(*testing.T, *TableRow) {
+	return func(t *testing.T, r *TableRow) {
+		c := r.OtherTestData["_psclient"].(*pubsub.Client)
+		config, err := c.Subscription(id).Config(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to get the config of subscription %q: %v", id, err)
+		}
+		if diff := cmp.Diff(want, config.DeadLetterPolicy); diff != "" {
+			t.Errorf("Unexpected dead letter policy (-want, +got): %s", diff)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/eventing/pkg/logging"
 )
@@ -33,6 +34,7 @@ const (
 	deletedTopic = "_deleted-topic_"
 	subCreated   = "SubscriptionCreated"
 	subDeleted   = "SubscriptionDeleted"
+	subUpdated   = "SubscriptionUpdated"
 )
 
 func (r *Reconciler) ReconcileSubscription(ctx context.Context, id string, subConfig pubsub.SubscriptionConfig, obj runtime.Object, updater StatusUpdater) (*pubsub.Subscription, error) {
@@ -64,6 +66,12 @@ func (r *Reconciler) ReconcileSubscription(ctx context.Context, id string, subCo
 			}
 			return r.createSubscription(ctx, id, subConfig, obj, updater)
 		}
+		if !equality.Semantic.DeepEqual(config.DeadLetterPolicy, subConfig.DeadLetterPolicy) {
+			if err := r.updateDeadLetterPolicy(ctx, sub, subConfig.DeadLetterPolicy, obj); err != nil {
+				updater.MarkSubscriptionFailed("SubscriptionUpdateFailed", "Failed to update the dead letter policy of the Pub/Sub subscription: %v", err)
+				return nil, err
+			}
+		}
 		updater.MarkSubscriptionReady()
 		return sub, nil
 	}
@@ -116,3 +124,18 @@ func (r *Reconciler) createSubscription(ctx context.Context, id string, subConfi
 	updater.MarkSubscriptionReady()
 	return sub, nil
 }
+
+func (r *Reconciler) updateDeadLetterPolicy(ctx context.Context, sub *pubsub.Subscription, dlp *pubsub.DeadLetterPolicy, obj runtime.Object) error {
+	logger := logging.FromContext(ctx)
+	if dlp == nil {
+		// The zero value removes the dead letter policy.
+		dlp = &pubsub.DeadLetterPolicy{}
+	}
+	if _, err := sub.Update(ctx, pubsub.SubscriptionConfigToUpdate{DeadLetterPolicy: dlp}); err != nil {
+		logger.Error("Failed to update the dead letter policy of the Pub/Sub subscription", zap.Error(err))
+		return err
+	}
+	logger.Info("Updated the dead letter policy of the PubSub subscription", zap.String("name", sub.ID()))
+	r.recorder.Eventf(obj, corev1.EventTypeNormal, subUpdated, "Updated PubSub subscription %q", sub.ID())
+	return nil
+}
@@ -78,6 +78,61 @@ func TestReconcileSub(t *testing.T) {
 
 }
 
+func TestReconcileSubDeadLetterPolicy(t *testing.T) {
+	dlp := &pubsub.DeadLetterPolicy{
+		DeadLetterTopic:     fmt.Sprintf("projects/%s/topics/%s", project, "test-dead-letter-topic"),
+		MaxDeliveryAttempts: 100,
+	}
+	tests := []struct {
+		testCase
+		wantErr bool
+	}{{
+		testCase: testCase{
+			name:             "new sub created with dead letter policy",
+			pre:              []reconcilertesting.PubsubAction{reconcilertesting.Topic(topic)},
+			wantEvents:       []string{`Normal SubscriptionCreated Created PubSub subscription "test-sub"`},
+			wantSubCondition: apis.Condition{Status: corev1.ConditionTrue},
+		},
+	}, {
+		// The fake Pub/Sub server doesn't support updating the dead letter policy, which at least
+		// shows that the policy of an existing subscription is updated.
+		testCase: testCase{
+			name: "dead letter policy update fails",
+			pre:  []reconcilertesting.PubsubAction{reconcilertesting.TopicAndSub(topic, sub)},
+			wantSubCondition: apis.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  "SubscriptionUpdateFailed",
+				Message: `Failed to update the dead letter policy of the Pub/Sub subscription: rpc error: code = InvalidArgument desc = unknown field name "dead_letter_policy"`,
+			},
+		},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr, cleanup := newTestRunner(t, tc.testCase)
+			defer cleanup()
+			r := NewReconciler(tr.client, tr.recorder)
+			su := &utilspubsubtesting.StatusUpdater{}
+			subConfig := pubsub.SubscriptionConfig{Topic: tr.client.Topic(topic), DeadLetterPolicy: dlp}
+			res, err := r.ReconcileSubscription(context.Background(), sub, subConfig, obj, su)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ReconcileSubscription() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			tr.verify(t, tc.testCase, su, nil)
+			if res != nil {
+				gotConfig, err := res.Config(context.Background())
+				if err != nil {
+					t.Fatalf("Failed to get config: %v", err)
+				}
+				if !reflect.DeepEqual(gotConfig.DeadLetterPolicy, dlp) {
+					t.Errorf("Unexpected dead letter policy, got: %+v, want: %+v", gotConfig.DeadLetterPolicy, dlp)
+				}
+			}
+		})
+	}
+}
+
 func TestDeleteSub(t *testing.T) {
 	tests := []testCase{
 		{
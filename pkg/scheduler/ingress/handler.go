@@ -0,0 +1,257 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ingress implements the shared scheduler ingress. The Cloud Scheduler jobs of the
+// CloudSchedulerSources with HTTP delivery call the ingress, which turns the calls into
+// events and sends them to the sinks of the sources.
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/logging"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/pubsub/adapter/converters"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
+)
+
+const (
+	healthCheckPath = "/healthz"
+
+	// scheduleTimeHeader is the header of the scheduled time of the execution, which Cloud
+	// Scheduler adds to the calls of HTTP jobs.
+	scheduleTimeHeader = "X-CloudScheduler-ScheduleTime"
+
+	// maxBodySize is the maximum size of the data of an execution.
+	maxBodySize = 1 << 20
+)
+
+var (
+	errUnauthenticated  = errors.New("unauthenticated")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// TokenVerifier verifies bearer tokens.
+type TokenVerifier interface {
+	// Verify verifies the token and returns the identity of the caller.
+	Verify(ctx context.Context, token string) (string, error)
+}
+
+// Handler receives the calls of the Cloud Scheduler jobs of CloudSchedulerSources with HTTP
+// delivery and sends their events to the sinks of the sources.
+type Handler struct {
+	// schedulerLister is used to look up the source of a call.
+	schedulerLister listers.CloudSchedulerSourceLister
+	// verifier verifies the OIDC tokens of the jobs.
+	verifier TokenVerifier
+	// allowedIdentities are the identities allowed to call the ingress.
+	allowedIdentities map[string]bool
+	// client sends the events to the sinks.
+	client *nethttp.Client
+
+	logger *zap.Logger
+}
+
+// NewHandler creates a Handler which accepts the calls authenticated with a token of one of the
+// allowed identities, and sends the events with the client.
+func NewHandler(ctx context.Context, schedulerLister listers.CloudSchedulerSourceLister, verifier TokenVerifier, allowedIdentities []string, client *nethttp.Client) *Handler {
+	allowed := make(map[string]bool, len(allowedIdentities))
+	for _, identity := range allowedIdentities {
+		allowed[identity] = true
+	}
+	return &Handler{
+		schedulerLister:   schedulerLister,
+		verifier:          verifier,
+		allowedIdentities: allowed,
+		client:            client,
+		logger:            logging.FromContext(ctx).Desugar(),
+	}
+}
+
+// ServeHTTP implements net/http Handler interface method.
+//  1. Authenticates the caller of the request.
+//  2. Looks up the CloudSchedulerSource of the request path.
+//  3. Converts the request to the event of the execution of the job of the source.
+//  4. Sends the event to the sink of the source.
+//
+// It responds with an error status if the event is not delivered, so that Cloud Scheduler
+// retries the execution according to the retry config of the job.
+func (h *Handler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	if request.URL.Path == healthCheckPath {
+		response.WriteHeader(nethttp.StatusOK)
+		return
+	}
+	if request.Method != nethttp.MethodPost {
+		response.WriteHeader(nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := request.Context()
+	// Path should be in the form of "/<ns>/<scheduler>".
+	pieces := strings.Split(request.URL.Path, "/")
+	if len(pieces) != 3 || pieces[1] == "" || pieces[2] == "" {
+		msg := fmt.Sprintf("Malformed request path. want: '/<ns>/<scheduler>'; got: %v", request.URL.Path)
+		h.logger.Debug(msg)
+		nethttp.Error(response, msg, nethttp.StatusNotFound)
+		return
+	}
+	namespace, name := pieces[1], pieces[2]
+
+	if err := h.authenticate(ctx, request); err != nil {
+		h.logger.Debug("Failed to authenticate request", zap.String("namespace", namespace), zap.String("scheduler", name), zap.Error(err))
+		switch {
+		case errors.Is(err, errUnauthenticated):
+			nethttp.Error(response, err.Error(), nethttp.StatusUnauthorized)
+		case errors.Is(err, errPermissionDenied):
+			nethttp.Error(response, err.Error(), nethttp.StatusForbidden)
+		default:
+			nethttp.Error(response, err.Error(), nethttp.StatusInternalServerError)
+		}
+		return
+	}
+
+	scheduler, err := h.schedulerLister.CloudSchedulerSources(namespace).Get(name)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			nethttp.Error(response, fmt.Sprintf("CloudSchedulerSource %s/%s not found", namespace, name), nethttp.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get CloudSchedulerSource", zap.String("namespace", namespace), zap.String("scheduler", name), zap.Error(err))
+		nethttp.Error(response, err.Error(), nethttp.StatusInternalServerError)
+		return
+	}
+	if !scheduler.Spec.IsHTTPDelivery() {
+		nethttp.Error(response, fmt.Sprintf("CloudSchedulerSource %s/%s does not use HTTP delivery", namespace, name), nethttp.StatusNotFound)
+		return
+	}
+	if scheduler.Status.JobName == "" || scheduler.Status.SinkURI == nil {
+		nethttp.Error(response, fmt.Sprintf("CloudSchedulerSource %s/%s is not ready", namespace, name), nethttp.StatusServiceUnavailable)
+		return
+	}
+
+	event, err := h.toEvent(request, scheduler)
+	if err != nil {
+		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+
+	if err := h.send(ctx, scheduler.Status.SinkURI.String(), event); err != nil {
+		h.logger.Error("Failed to deliver event", zap.String("namespace", namespace), zap.String("scheduler", name), zap.Error(err))
+		nethttp.Error(response, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+	response.WriteHeader(nethttp.StatusOK)
+}
+
+// authenticate checks that the request has a valid bearer token of an allowed identity.
+func (h *Handler) authenticate(ctx context.Context, request *nethttp.Request) error {
+	token, ok := bearerToken(request)
+	if !ok {
+		return fmt.Errorf("missing bearer token: %w", errUnauthenticated)
+	}
+	identity, err := h.verifier.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return fmt.Errorf("%v: %w", err, errUnauthenticated)
+		}
+		return err
+	}
+	if !h.allowedIdentities[identity] {
+		return fmt.Errorf("%q is not allowed to call the scheduler ingress: %w", identity, errPermissionDenied)
+	}
+	return nil
+}
+
+// toEvent converts the request to the event of the execution of the job of the scheduler.
+func (h *Handler) toEvent(request *nethttp.Request, scheduler *v1beta1.CloudSchedulerSource) (*cev2.Event, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(request.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(data) > maxBodySize {
+		return nil, fmt.Errorf("request body is larger than %d bytes", maxBodySize)
+	}
+	event, err := converters.NewCloudSchedulerEvent(uuid.New().String(), scheduleTime(request), scheduler.Status.JobName, scheduler.Name, data)
+	if err != nil {
+		return nil, err
+	}
+	if overrides := scheduler.Spec.CloudEventOverrides; overrides != nil {
+		for k, v := range overrides.Extensions {
+			event.SetExtension(k, v)
+		}
+	}
+	return event, nil
+}
+
+// send sends the event to the sink and checks that it is accepted.
+func (h *Handler) send(ctx context.Context, sink string, event *cev2.Event) error {
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, sink, nil)
+	if err != nil {
+		return err
+	}
+	if err := cehttp.WriteRequest(ctx, binding.ToMessage(event), req); err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		if err := resp.Body.Close(); err != nil {
+			h.logger.Warn("Failed to close response body", zap.Error(err))
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("sink responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// scheduleTime returns the scheduled time of the execution of the request, or the current time
+// if the request doesn't have it, e.g. when the job is forced to run.
+func scheduleTime(request *nethttp.Request) time.Time {
+	if t, err := time.Parse(time.RFC3339, request.Header.Get(scheduleTimeHeader)); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// bearerToken returns the token of the bearer authorization header of the request.
+func bearerToken(request *nethttp.Request) (string, bool) {
+	const prefix = "bearer "
+	h := request.Header.Get("Authorization")
+	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(h[len(prefix):]), true
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtest "knative.dev/pkg/logging/testing"
+
+	"github.com/google/knative-gcp/pkg/apis/events/v1beta1"
+	listers "github.com/google/knative-gcp/pkg/client/listers/events/v1beta1"
+	"github.com/google/knative-gcp/pkg/utils/oidc"
+)
+
+const (
+	testJobName      = "projects/project/locations/us-central1/jobs/cre-scheduler-uid"
+	testIdentity     = "scheduler@project.iam.gserviceaccount.com"
+	testScheduleTime = "2020-09-01T10:00:00Z"
+)
+
+// fakeVerifier maps tokens to identities.
+type fakeVerifier map[string]string
+
+func (v fakeVerifier) Verify(_ context.Context, token string) (string, error) {
+	if token == "unavailable" {
+		return "", errors.New("issuer unavailable")
+	}
+	identity, ok := v[token]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown token", oidc.ErrInvalidToken)
+	}
+	return identity, nil
+}
+
+func newScheduler(name string, sinkURI *apis.URL, opts ...func(*v1beta1.CloudSchedulerSource)) *v1beta1.CloudSchedulerSource {
+	s := &v1beta1.CloudSchedulerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		Spec: v1beta1.CloudSchedulerSourceSpec{
+			Delivery: v1beta1.SchedulerDeliveryHTTP,
+		},
+		Status: v1beta1.CloudSchedulerSourceStatus{
+			JobName: testJobName,
+		},
+	}
+	s.Status.SinkURI = sinkURI
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// sink records the events it receives and responds with its status code.
+type sink struct {
+	statusCode int
+	events     []*cev2.Event
+}
+
+func (s *sink) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
+	event, err := binding.ToEvent(request.Context(), cehttp.NewMessageFromHttpRequest(request))
+	if err != nil {
+		nethttp.Error(response, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+	s.events = append(s.events, event)
+	response.WriteHeader(s.statusCode)
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		sinkStatusCode int
+		wantStatusCode int
+		wantEvent      bool
+	}{{
+		name:           "health check",
+		method:         nethttp.MethodGet,
+		path:           "/healthz",
+		wantStatusCode: nethttp.StatusOK,
+	}, {
+		name:           "delivered",
+		path:           "/ns/scheduler",
+		token:          "google",
+		wantStatusCode: nethttp.StatusOK,
+		wantEvent:      true,
+	}, {
+		name:           "method not allowed",
+		method:         nethttp.MethodGet,
+		path:           "/ns/scheduler",
+		token:          "google",
+		wantStatusCode: nethttp.StatusMethodNotAllowed,
+	}, {
+		name:           "malformed path",
+		path:           "/ns/scheduler/extra",
+		token:          "google",
+		wantStatusCode: nethttp.StatusNotFound,
+	}, {
+		name:           "missing token",
+		path:           "/ns/scheduler",
+		wantStatusCode: nethttp.StatusUnauthorized,
+	}, {
+		name:           "invalid token",
+		path:           "/ns/scheduler",
+		token:          "forged",
+		wantStatusCode: nethttp.StatusUnauthorized,
+	}, {
+		name:           "identity not allowed",
+		path:           "/ns/scheduler",
+		token:          "outsider",
+		wantStatusCode: nethttp.StatusForbidden,
+	}, {
+		name:           "verifier unavailable",
+		path:           "/ns/scheduler",
+		token:          "unavailable",
+		wantStatusCode: nethttp.StatusInternalServerError,
+	}, {
+		name:           "scheduler not found",
+		path:           "/ns/other",
+		token:          "google",
+		wantStatusCode: nethttp.StatusNotFound,
+	}, {
+		name:           "scheduler with pubsub delivery",
+		path:           "/ns/pubsub",
+		token:          "google",
+		wantStatusCode: nethttp.StatusNotFound,
+	}, {
+		name:           "scheduler not ready",
+		path:           "/ns/notready",
+		token:          "google",
+		wantStatusCode: nethttp.StatusServiceUnavailable,
+	}, {
+		name:           "sink rejects event",
+		path:           "/ns/scheduler",
+		token:          "google",
+		sinkStatusCode: nethttp.StatusInternalServerError,
+		wantStatusCode: nethttp.StatusBadGateway,
+		wantEvent:      true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logtest.TestContextWithLogger(t)
+			s := &sink{statusCode: nethttp.StatusAccepted}
+			if tc.sinkStatusCode != 0 {
+				s.statusCode = tc.sinkStatusCode
+			}
+			sinkServer := httptest.NewServer(s)
+			defer sinkServer.Close()
+			sinkURI, _ := apis.ParseURL(sinkServer.URL)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, scheduler := range []*v1beta1.CloudSchedulerSource{
+				newScheduler("scheduler", sinkURI, func(s *v1beta1.CloudSchedulerSource) {
+					s.Spec.CloudEventOverrides = &duckv1.CloudEventOverrides{
+						Extensions: map[string]string{"team": "billing"},
+					}
+				}),
+				newScheduler("pubsub", sinkURI, func(s *v1beta1.CloudSchedulerSource) {
+					s.Spec.Delivery = ""
+				}),
+				newScheduler("notready", nil),
+			} {
+				if err := indexer.Add(scheduler); err != nil {
+					t.Fatalf("Failed to add scheduler: %v", err)
+				}
+			}
+			verifier := fakeVerifier{
+				"google":   testIdentity,
+				"outsider": "outsider@example.com",
+			}
+			h := NewHandler(ctx, listers.NewCloudSchedulerSourceLister(indexer), verifier, []string{testIdentity}, sinkServer.Client())
+
+			method := tc.method
+			if method == "" {
+				method = nethttp.MethodPost
+			}
+			request := httptest.NewRequest(method, "http://scheduler-ingress"+tc.path, strings.NewReader(`{"key":"value"}`))
+			request.Header.Set(scheduleTimeHeader, testScheduleTime)
+			if tc.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.wantStatusCode {
+				t.Errorf("Status code got %d, want %d: %s", recorder.Code, tc.wantStatusCode, recorder.Body.String())
+			}
+			if !tc.wantEvent {
+				if len(s.events) != 0 {
+					t.Errorf("Sink got %d events, want none", len(s.events))
+				}
+				return
+			}
+			if len(s.events) != 1 {
+				t.Fatalf("Sink got %d events, want 1", len(s.events))
+			}
+			event := s.events[0]
+			if event.Type() != v1beta1.CloudSchedulerSourceExecute {
+				t.Errorf("Type %q != %q", event.Type(), v1beta1.CloudSchedulerSourceExecute)
+			}
+			if want := v1beta1.CloudSchedulerSourceEventSource("projects/project/locations/us-central1", "scheduler"); event.Source() != want {
+				t.Errorf("Source %q != %q", event.Source(), want)
+			}
+			if want := "jobs/cre-scheduler-uid"; event.Subject() != want {
+				t.Errorf("Subject %q != %q", event.Subject(), want)
+			}
+			if want, _ := time.Parse(time.RFC3339, testScheduleTime); !event.Time().Equal(want) {
+				t.Errorf("Time %v != %v", event.Time(), want)
+			}
+			if event.ID() == "" {
+				t.Error("Event has no ID")
+			}
+			if got := event.Extensions()["team"]; got != "billing" {
+				t.Errorf("Extension team %v != billing", got)
+			}
+			if want := `{"key":"value"}`; string(event.Data()) != want {
+				t.Errorf("Data %s != %s", event.Data(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batch implements the batched content mode of the CloudEvents HTTP
+// protocol binding, where a request carries a JSON array of structured events.
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	nethttp "net/http"
+	"sync"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+)
+
+const (
+	// MaxEvents is the maximum number of events of a batch.
+	MaxEvents = 1000
+	// MaxBytes is the maximum size of the body of a batch request, the maximum
+	// size of a Pub/Sub publish request.
+	MaxBytes = 10 << 20
+	// MaxWorkers is the maximum number of events of a batch sent concurrently.
+	MaxWorkers = 16
+)
+
+// Result is the result of sending an event of a batch.
+type Result struct {
+	// ID is the ID of the event.
+	ID string `json:"id"`
+	// Status is the HTTP status code of the event, e.g. 202 if the event is accepted.
+	Status int `json:"status"`
+	// Error describes why the event is not accepted.
+	Error string `json:"error,omitempty"`
+}
+
+// response is the body of the response to a batch request.
+type response struct {
+	Results []Result `json:"results"`
+}
+
+// IsBatch returns true if the request is in the batched content mode.
+func IsBatch(request *nethttp.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return err == nil && mediaType == cev2.ApplicationCloudEventsBatchJSON
+}
+
+// ReadEvents reads the events of a batch request. It returns an error if the
+// body is not a JSON array of at most MaxEvents valid events, or if it is
+// larger than MaxBytes. The events are decoded one at a time so that a large
+// batch is rejected without reading it whole.
+func ReadEvents(response nethttp.ResponseWriter, request *nethttp.Request) ([]*cev2.Event, error) {
+	d := json.NewDecoder(nethttp.MaxBytesReader(response, request.Body, MaxBytes))
+	if t, err := d.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode batch: %w", err)
+	} else if t != json.Delim('[') {
+		return nil, fmt.Errorf("failed to decode batch: got %v, want a JSON array", t)
+	}
+	events := make([]*cev2.Event, 0)
+	for i := 0; d.More(); i++ {
+		if i == MaxEvents {
+			return nil, fmt.Errorf("batch has more than %d events", MaxEvents)
+		}
+		event := cev2.NewEvent()
+		if err := d.Decode(&event); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d: %w", i, err)
+		}
+		if err := event.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+		events = append(events, &event)
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode batch: %w", err)
+	}
+	return events, nil
+}
+
+// SendAll sends the events with at most MaxWorkers concurrent sends and returns
+// the result of each event in the same order. send returns the HTTP status code
+// of the event and an error if the event is not accepted. orderingKey returns
+// the ordering key of an event, or an empty string if the event isn't ordered;
+// it may be nil if no event is ordered. Events with the same ordering key are
+// sent one at a time in the order of the batch, and once one of them isn't
+// accepted the later ones aren't sent, so that a client retrying the batch
+// doesn't get them out of order.
+func SendAll(events []*cev2.Event, orderingKey func(*cev2.Event) string, send func(*cev2.Event) (int, error)) []Result {
+	// Group the indices of the events so that each group is sent sequentially.
+	var groups [][]int
+	keyGroups := make(map[string]int)
+	for i, event := range events {
+		key := ""
+		if orderingKey != nil {
+			key = orderingKey(event)
+		}
+		if key == "" {
+			groups = append(groups, []int{i})
+			continue
+		}
+		if g, ok := keyGroups[key]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+		keyGroups[key] = len(groups)
+		groups = append(groups, []int{i})
+	}
+
+	results := make([]Result, len(events))
+	sendGroup := func(group []int) {
+		var failed *Result
+		for _, i := range group {
+			results[i] = Result{ID: events[i].ID()}
+			if failed != nil {
+				results[i].Status = failed.Status
+				results[i].Error = fmt.Sprintf("not sent, event %q with the same ordering key is not accepted", failed.ID)
+				continue
+			}
+			status, err := send(events[i])
+			results[i].Status = status
+			if err != nil {
+				results[i].Error = err.Error()
+				failed = &results[i]
+			}
+		}
+	}
+
+	workers := MaxWorkers
+	if len(groups) < workers {
+		workers = len(groups)
+	}
+	work := make(chan []int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for group := range work {
+				sendGroup(group)
+			}
+		}()
+	}
+	for _, group := range groups {
+		work <- group
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
+// WriteResults writes the results as the response to a batch request. The
+// status code of the response is 202 if all events are accepted, otherwise it's
+// the status code of the first event that isn't accepted, so that clients which
+// don't check the per event results still retry the batch.
+func WriteResults(w nethttp.ResponseWriter, results []Result) error {
+	statusCode := nethttp.StatusAccepted
+	for _, r := range results {
+		if r.Status/100 != 2 {
+			statusCode = r.Status
+			break
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(response{Results: results})
+}
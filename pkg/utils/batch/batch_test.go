@@ -0,0 +1,259 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsBatch(t *testing.T) {
+	cases := []struct {
+		contentType string
+		want        bool
+	}{
+		{contentType: "application/cloudevents-batch+json", want: true},
+		{contentType: "application/cloudevents-batch+json; charset=utf-8", want: true},
+		{contentType: "application/cloudevents+json", want: false},
+		{contentType: "application/json", want: false},
+		{contentType: "", want: false},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(nethttp.MethodPost, "/", nil)
+		req.Header.Set("Content-Type", tc.contentType)
+		if got := IsBatch(req); got != tc.want {
+			t.Errorf("IsBatch(%q) got=%v, want=%v", tc.contentType, got, tc.want)
+		}
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	tooMany := "[" + strings.Repeat(`{"specversion":"1.0","id":"1","source":"s","type":"t"},`, MaxEvents) + `{"specversion":"1.0","id":"1","source":"s","type":"t"}]`
+	cases := []struct {
+		name    string
+		body    string
+		wantIDs []string
+		wantErr bool
+	}{{
+		name:    "valid batch",
+		body:    `[{"specversion":"1.0","id":"1","source":"s","type":"t"},{"specversion":"1.0","id":"2","source":"s","type":"t","time":"2020-01-01T00:00:00Z","data":{"a":"b"}}]`,
+		wantIDs: []string{"1", "2"},
+	}, {
+		name:    "empty batch",
+		body:    `[]`,
+		wantIDs: []string{},
+	}, {
+		name:    "not an array",
+		body:    `{"specversion":"1.0","id":"1","source":"s","type":"t"}`,
+		wantErr: true,
+	}, {
+		name:    "invalid json",
+		body:    `[{`,
+		wantErr: true,
+	}, {
+		name:    "invalid event",
+		body:    `[{"specversion":"1.0","id":"1","source":"s","type":"t"},{"specversion":"1.0","id":"2","source":"s"}]`,
+		wantErr: true,
+	}, {
+		name:    "too many events",
+		body:    tooMany,
+		wantErr: true,
+	}, {
+		name:    "too large",
+		body:    `[{"specversion":"1.0","id":"1","source":"s","type":"t","data":"` + strings.Repeat("a", MaxBytes) + `"}]`,
+		wantErr: true,
+	}, {
+		name:    "unterminated array",
+		body:    `[{"specversion":"1.0","id":"1","source":"s","type":"t"}`,
+		wantErr: true,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(tc.body))
+			events, err := ReadEvents(httptest.NewRecorder(), req)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReadEvents got error=%v, want error=%v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			gotIDs := make([]string, 0, len(events))
+			for _, e := range events {
+				gotIDs = append(gotIDs, e.ID())
+				// The events are published as they are sent, like single events.
+				if e.ID() == "1" && !e.Time().IsZero() {
+					t.Errorf("event %s got time %v, want no time", e.ID(), e.Time())
+				}
+			}
+			if diff := cmp.Diff(tc.wantIDs, gotIDs); diff != "" {
+				t.Errorf("ReadEvents unexpected event IDs (-want,+got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSendAllAndWriteResults(t *testing.T) {
+	var events []*cev2.Event
+	for _, id := range []string{"1", "2", "3"} {
+		e := cev2.NewEvent()
+		e.SetID(id)
+		events = append(events, &e)
+	}
+
+	cases := []struct {
+		name        string
+		send        func(*cev2.Event) (int, error)
+		wantCode    int
+		wantResults []Result
+	}{{
+		name: "all accepted",
+		send: func(*cev2.Event) (int, error) {
+			return nethttp.StatusAccepted, nil
+		},
+		wantCode: nethttp.StatusAccepted,
+		wantResults: []Result{
+			{ID: "1", Status: nethttp.StatusAccepted},
+			{ID: "2", Status: nethttp.StatusAccepted},
+			{ID: "3", Status: nethttp.StatusAccepted},
+		},
+	}, {
+		name: "some failed",
+		send: func(e *cev2.Event) (int, error) {
+			switch e.ID() {
+			case "2":
+				return nethttp.StatusServiceUnavailable, errors.New("not ready")
+			case "3":
+				return nethttp.StatusInternalServerError, errors.New("failed")
+			}
+			return nethttp.StatusAccepted, nil
+		},
+		wantCode: nethttp.StatusServiceUnavailable,
+		wantResults: []Result{
+			{ID: "1", Status: nethttp.StatusAccepted},
+			{ID: "2", Status: nethttp.StatusServiceUnavailable, Error: "not ready"},
+			{ID: "3", Status: nethttp.StatusInternalServerError, Error: "failed"},
+		},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := WriteResults(w, SendAll(events, nil, tc.send)); err != nil {
+				t.Fatalf("WriteResults failed: %v", err)
+			}
+			if w.Code != tc.wantCode {
+				t.Errorf("status code got=%d, want=%d", w.Code, tc.wantCode)
+			}
+			var got response
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResults, got.Results); diff != "" {
+				t.Errorf("unexpected results (-want,+got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSendAllOrdering(t *testing.T) {
+	var events []*cev2.Event
+	for i := 0; i < 100; i++ {
+		e := cev2.NewEvent()
+		e.SetID(strconv.Itoa(i))
+		// Every third event is not ordered, the others have one of three keys.
+		if i%3 != 0 {
+			e.SetExtension("partitionkey", strconv.Itoa(i%4))
+		}
+		events = append(events, &e)
+	}
+	orderingKey := func(e *cev2.Event) string {
+		key, _ := e.Extensions()["partitionkey"].(string)
+		return key
+	}
+
+	var (
+		mu            sync.Mutex
+		inFlight      int
+		maxInFlight   int
+		inFlightByKey = make(map[string]int)
+		sent          = make(map[string][]int)
+	)
+	results := SendAll(events, orderingKey, func(e *cev2.Event) (int, error) {
+		key := orderingKey(e)
+		id, _ := strconv.Atoi(e.ID())
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		if key != "" {
+			inFlightByKey[key]++
+			if inFlightByKey[key] > 1 {
+				t.Errorf("events with ordering key %q sent concurrently", key)
+			}
+			sent[key] = append(sent[key], id)
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		if key != "" {
+			inFlightByKey[key]--
+		}
+		mu.Unlock()
+		if id == 50 {
+			return nethttp.StatusInternalServerError, errors.New("failed")
+		}
+		return nethttp.StatusAccepted, nil
+	})
+
+	if maxInFlight > MaxWorkers {
+		t.Errorf("got %d concurrent sends, want at most %d", maxInFlight, MaxWorkers)
+	}
+	for key, ids := range sent {
+		if !sort.IntsAreSorted(ids) {
+			t.Errorf("events with ordering key %q sent out of order: %v", key, ids)
+		}
+	}
+	// Event 50 has ordering key "2", the later events with the same key are not sent.
+	for i, r := range results {
+		if r.ID != strconv.Itoa(i) {
+			t.Fatalf("result %d got ID %q", i, r.ID)
+		}
+		failed := i == 50 || (i > 50 && i%3 != 0 && i%4 == 2)
+		if failed != (r.Status != nethttp.StatusAccepted) {
+			t.Errorf("result of event %d got status %d, want failed=%v", i, r.Status, failed)
+		}
+	}
+	for _, id := range sent["2"] {
+		if id > 50 {
+			t.Errorf("event %d sent after event 50 with the same ordering key failed", id)
+		}
+	}
+}
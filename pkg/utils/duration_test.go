@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	testCases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "PT1S", want: time.Second},
+		{in: "PT0.5S", want: 500 * time.Millisecond},
+		{in: "PT2M", want: 2 * time.Minute},
+		{in: "PT1H30M", want: 90 * time.Minute},
+		{in: "P1D", want: 24 * time.Hour},
+		{in: "P1DT1H1M1.25S", want: 25*time.Hour + time.Minute + 1250*time.Millisecond},
+		{in: "PT0S", want: 0},
+		{in: "", wantErr: true},
+		{in: "P", wantErr: true},
+		{in: "PT", wantErr: true},
+		{in: "P1DT", wantErr: true},
+		{in: "1s", wantErr: true},
+		{in: "P1M", wantErr: true},
+		{in: "PT-1S", wantErr: true},
+		{in: "PT1S1M", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseISO8601Duration(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseISO8601Duration(%q) error got=%v, wantErr=%v", tc.in, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseISO8601Duration(%q) got=%v, want=%v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// clientTimeout is the timeout of the requests to discover the issuers and fetch their keys.
+const clientTimeout = 10 * time.Second
+
+// InClusterClient returns the client to fetch the keys of the issuers from a pod. The discovery
+// endpoints of the Kubernetes API server, the issuer of the service account tokens, are served
+// with the cluster CA and require authentication unless anonymous discovery is enabled, so the
+// requests to the API server trust the cluster CA and send the service account token of the
+// pod. The requests to other issuers, e.g. "https://accounts.google.com", are sent without
+// credentials.
+func InClusterClient() (*http.Client, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	apiServer, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API server address %q: %w", config.Host, err)
+	}
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &apiServerTransport{
+			hosts: map[string]bool{
+				apiServer.Hostname():                   true,
+				"kubernetes.default.svc":               true,
+				"kubernetes.default.svc.cluster.local": true,
+			},
+			apiServer: transport,
+			other:     http.DefaultTransport,
+		},
+		Timeout: clientTimeout,
+	}, nil
+}
+
+// apiServerTransport sends the requests to the Kubernetes API server with the apiServer
+// transport, and the other requests with the other transport.
+type apiServerTransport struct {
+	hosts     map[string]bool
+	apiServer http.RoundTripper
+	other     http.RoundTripper
+}
+
+func (t *apiServerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.hosts[req.URL.Hostname()] {
+		return t.apiServer.RoundTrip(req)
+	}
+	return t.other.RoundTrip(req)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"net/http"
+	"testing"
+)
+
+type recordingTransport struct {
+	name string
+	got  *string
+}
+
+func (t recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*t.got = t.name
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestAPIServerTransport(t *testing.T) {
+	var got string
+	transport := &apiServerTransport{
+		hosts:     map[string]bool{"10.0.0.1": true, "kubernetes.default.svc": true},
+		apiServer: recordingTransport{name: "apiServer", got: &got},
+		other:     recordingTransport{name: "other", got: &got},
+	}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://10.0.0.1:443/.well-known/openid-configuration", want: "apiServer"},
+		{url: "https://kubernetes.default.svc/openid/v1/jwks", want: "apiServer"},
+		{url: "https://accounts.google.com/.well-known/openid-configuration", want: "other"},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(http.MethodGet, test.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("request to %s sent with the %s transport, want %s", test.url, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileRefreshInterval is how often the token is read again from the file. The kubelet rotates
+// projected service account tokens well before they expire, after at least 80% of their
+// lifetime of at least 10 minutes.
+const fileRefreshInterval = time.Minute
+
+// FileTokenSource provides the token in a file, such as a Kubernetes projected service account
+// token. It is safe for concurrent use.
+type FileTokenSource struct {
+	path string
+	now  func() time.Time
+
+	mu    sync.Mutex
+	token string
+	read  time.Time
+}
+
+// NewFileTokenSource creates a FileTokenSource which reads the token from the file at path.
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path, now: time.Now}
+}
+
+// Token returns the token in the file. The token is cached and the file is read again after
+// fileRefreshInterval to pick up the rotated token.
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.token != "" && now.Sub(s.read) < fileRefreshInterval {
+		return s.token, nil
+	}
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %q is empty", s.path)
+	}
+	s.token, s.read = token, now
+	return s.token, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	now := time.Now()
+	s := NewFileTokenSource(path)
+	s.now = func() time.Time { return now }
+
+	if _, err := s.Token(context.Background()); err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+
+	write := func(token string) {
+		if err := ioutil.WriteFile(path, []byte(token), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("token1\n")
+	if got, err := s.Token(context.Background()); err != nil || got != "token1" {
+		t.Errorf("Token got=(%q, %v), want=%q", got, err, "token1")
+	}
+
+	// The rotated token is read after the refresh interval.
+	write("token2")
+	if got, _ := s.Token(context.Background()); got != "token1" {
+		t.Errorf("Token before refresh got=%q, want=%q", got, "token1")
+	}
+	now = now.Add(fileRefreshInterval)
+	if got, _ := s.Token(context.Background()); got != "token2" {
+		t.Errorf("Token after refresh got=%q, want=%q", got, "token2")
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oidc verifies OpenID Connect ID tokens, such as Kubernetes projected
+// service account tokens and Google ID tokens, with github.com/coreos/go-oidc.
+// The signing keys of each issuer are discovered from
+// "<issuer>/.well-known/openid-configuration" and cached.
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc"
+)
+
+// ErrInvalidToken is the error when a token is malformed, expired or can't be verified.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Verifier verifies ID tokens issued by a set of trusted issuers for an audience.
+// It is safe for concurrent use.
+type Verifier struct {
+	audience string
+	client   *http.Client
+	issuers  map[string]*issuer
+}
+
+// issuer discovers the keys of an issuer on first use, so that an issuer which is temporarily
+// unavailable doesn't prevent the Verifier from being created.
+type issuer struct {
+	url string
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewVerifier creates a Verifier which trusts tokens from the given issuers with the given
+// audience. The client is used to discover the issuers and fetch their keys, see InClusterClient.
+func NewVerifier(issuers []string, audience string, client *http.Client) (*Verifier, error) {
+	if len(issuers) == 0 {
+		return nil, errors.New("at least one issuer is required")
+	}
+	if audience == "" {
+		return nil, errors.New("audience is required")
+	}
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	v := &Verifier{
+		audience: audience,
+		client:   client,
+		issuers:  make(map[string]*issuer, len(issuers)),
+	}
+	for _, iss := range issuers {
+		v.issuers[iss] = &issuer{url: iss}
+	}
+	return v, nil
+}
+
+// Verify verifies the token and returns the identity of its subject. The identity is the
+// verified email of the subject if the token has one, e.g. for Google service accounts,
+// and the subject otherwise, e.g. "system:serviceaccount:<namespace>:<name>" for
+// Kubernetes service accounts.
+func (v *Verifier) Verify(ctx context.Context, token string) (string, error) {
+	iss, err := unverifiedIssuer(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	is, ok := v.issuers[iss]
+	if !ok {
+		return "", fmt.Errorf("%w: untrusted issuer %q", ErrInvalidToken, iss)
+	}
+	verifier, err := v.verifier(is)
+	if err != nil {
+		return "", err
+	}
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Email != "" && claims.EmailVerified {
+		return claims.Email, nil
+	}
+	if idToken.Subject == "" {
+		return "", fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return idToken.Subject, nil
+}
+
+// verifier returns the ID token verifier of the issuer, discovering the issuer if it hasn't been
+// discovered yet.
+func (v *Verifier) verifier(is *issuer) (*oidc.IDTokenVerifier, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	if is.verifier != nil {
+		return is.verifier, nil
+	}
+	// The provider keeps the context to fetch the rotated keys of the issuer, so it must not
+	// be the context of a request.
+	provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), v.client), is.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover issuer %q: %w", is.url, err)
+	}
+	is.verifier = provider.Verifier(&oidc.Config{ClientID: v.audience})
+	return is.verifier, nil
+}
+
+// unverifiedIssuer returns the issuer claim of the token without verifying the token, to find
+// the keys to verify it with.
+func unverifiedIssuer(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("malformed claims: %v", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("malformed claims: %v", err)
+	}
+	return claims.Issuer, nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testAudience = "http://default-brokercell-ingress.cloud-run-events.svc.cluster.local"
+
+func TestVerify(t *testing.T) {
+	issuer := newTestIssuer(t)
+	now := time.Now()
+	valid := map[string]interface{}{
+		"iss": issuer.URL,
+		"sub": "system:serviceaccount:ns:sa",
+		"aud": []string{testAudience},
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+	}
+	with := func(overrides map[string]interface{}) map[string]interface{} {
+		c := make(map[string]interface{}, len(valid))
+		for k, v := range valid {
+			c[k] = v
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{{
+		name:  "kubernetes service account",
+		token: issuer.Sign(t, valid),
+		want:  "system:serviceaccount:ns:sa",
+	}, {
+		name:  "string audience",
+		token: issuer.Sign(t, with(map[string]interface{}{"aud": testAudience})),
+		want:  "system:serviceaccount:ns:sa",
+	}, {
+		name: "verified email",
+		token: issuer.Sign(t, with(map[string]interface{}{
+			"sub":            "1234567890",
+			"email":          "publisher@project.iam.gserviceaccount.com",
+			"email_verified": true,
+		})),
+		want: "publisher@project.iam.gserviceaccount.com",
+	}, {
+		name: "unverified email",
+		token: issuer.Sign(t, with(map[string]interface{}{
+			"sub":   "1234567890",
+			"email": "publisher@project.iam.gserviceaccount.com",
+		})),
+		want: "1234567890",
+	}, {
+		name:    "wrong audience",
+		token:   issuer.Sign(t, with(map[string]interface{}{"aud": "other"})),
+		wantErr: true,
+	}, {
+		name:    "untrusted issuer",
+		token:   issuer.Sign(t, with(map[string]interface{}{"iss": "https://example.com"})),
+		wantErr: true,
+	}, {
+		name:    "expired",
+		token:   issuer.Sign(t, with(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})),
+		wantErr: true,
+	}, {
+		name:    "no expiry",
+		token:   issuer.Sign(t, with(map[string]interface{}{"exp": nil})),
+		wantErr: true,
+	}, {
+		name:    "not valid yet",
+		token:   issuer.Sign(t, with(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})),
+		wantErr: true,
+	}, {
+		name:    "no subject",
+		token:   issuer.Sign(t, with(map[string]interface{}{"sub": nil})),
+		wantErr: true,
+	}, {
+		name:    "signed by another key",
+		token:   newTestIssuer(t).Sign(t, valid),
+		wantErr: true,
+	}, {
+		name:    "tampered",
+		token:   issuer.Sign(t, valid) + "x",
+		wantErr: true,
+	}, {
+		name:    "malformed",
+		token:   "not.a-token",
+		wantErr: true,
+	}}
+	v, err := NewVerifier([]string{issuer.URL}, testAudience, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := v.Verify(context.Background(), test.token)
+			if test.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("Verify got error %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Verify got identity %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestVerifyKeyRotation(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v, err := NewVerifier([]string{issuer.URL}, testAudience, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]interface{}{
+		"iss": issuer.URL,
+		"sub": "system:serviceaccount:ns:sa",
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	if _, err := v.Verify(context.Background(), issuer.Sign(t, claims)); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&issuer.fetches); got != 1 {
+		t.Errorf("keys fetched %d times, want 1", got)
+	}
+
+	if _, err := v.Verify(context.Background(), issuer.Sign(t, claims)); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&issuer.fetches); got != 1 {
+		t.Errorf("keys fetched %d times, want the cached keys to be used", got)
+	}
+
+	issuer.Rotate(t)
+	if _, err := v.Verify(context.Background(), issuer.Sign(t, claims)); err != nil {
+		t.Errorf("Verify with a rotated key failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&issuer.fetches); got != 2 {
+		t.Errorf("keys fetched %d times, want 2", got)
+	}
+}
+
+func TestVerifyIssuerUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	v, err := NewVerifier([]string{srv.URL}, testAudience, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := (&testIssuer{URL: srv.URL, key: newTestIssuer(t).key, kid: "1"}).Sign(t, map[string]interface{}{
+		"iss": srv.URL,
+		"sub": "system:serviceaccount:ns:sa",
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	if _, err := v.Verify(context.Background(), token); err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify got error %v, want an error fetching the keys", err)
+	}
+}
+
+func TestNewVerifierError(t *testing.T) {
+	if _, err := NewVerifier(nil, testAudience, http.DefaultClient); err == nil {
+		t.Error("NewVerifier without issuers got nil error")
+	}
+	if _, err := NewVerifier([]string{"https://example.com"}, "", http.DefaultClient); err == nil {
+		t.Error("NewVerifier without audience got nil error")
+	}
+	if _, err := NewVerifier([]string{"https://example.com"}, testAudience, nil); err == nil {
+		t.Error("NewVerifier without client got nil error")
+	}
+}
+
+// testIssuer is an OIDC issuer serving its discovery document and keys.
+type testIssuer struct {
+	URL     string
+	fetches int32
+
+	mu  sync.Mutex
+	key *rsa.PrivateKey
+	kid string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	iss := &testIssuer{}
+	iss.Rotate(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   iss.URL,
+			"jwks_uri": iss.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&iss.fetches, 1)
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": iss.kid,
+				"n":   base64.RawURLEncoding.EncodeToString(iss.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(iss.key.E)).Bytes()),
+			}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	iss.URL = srv.URL
+	return iss
+}
+
+// Rotate replaces the signing key of the issuer.
+func (iss *testIssuer) Rotate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.key = key
+	n, _ := strconv.Atoi(iss.kid)
+	iss.kid = strconv.Itoa(n + 1)
+}
+
+// Sign signs the claims with the current key of the issuer. The issuers created by
+// newTestIssuer use the same key IDs, so a token signed by another issuer has a known key
+// ID but an invalid signature.
+func (iss *testIssuer) Sign(t *testing.T, claims map[string]interface{}) string {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	h, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": iss.kid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	hashed := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, iss.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}